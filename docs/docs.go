@@ -15,54 +15,63 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/book": {
+        "/account/addresses": {
             "get": {
-                "description": "Возвращает список всех книг",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml",
+                    "text/csv",
+                    "application/msgpack"
                 ],
                 "tags": [
-                    "books"
+                    "account"
                 ],
-                "summary": "Получить все книги",
+                "summary": "Адресная книга",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.BookDTO"
+                                "$ref": "#/definitions/dto.AddressDTO"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "internal server error",
-                        "schema": {
-                            "type": "string"
-                        }
                     }
                 }
             },
             "post": {
-                "description": "Создает новую книгу",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
                 ],
                 "tags": [
-                    "books"
+                    "account"
                 ],
-                "summary": "Создать книгу",
+                "summary": "Добавить адрес",
                 "parameters": [
                     {
-                        "description": "Book data",
-                        "name": "book",
+                        "description": "Address",
+                        "name": "address",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.BookRequest"
+                            "$ref": "#/definitions/dto.AddressRequest"
                         }
                     }
                 ],
@@ -73,146 +82,280 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "invalid request body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "422": {
                         "description": "validation error",
                         "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "internal server error",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDTO"
                         }
                     }
                 }
             }
         },
-        "/book/{id}": {
-            "get": {
-                "description": "Возвращает книгу по идентификатору",
-                "produces": [
-                    "application/json"
+        "/account/addresses/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
                 ],
                 "tags": [
-                    "books"
+                    "account"
                 ],
-                "summary": "Получить книгу по ID",
+                "summary": "Обновить адрес",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Book ID",
+                        "description": "Address ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Address",
+                        "name": "address",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddressRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "no content",
                         "schema": {
-                            "$ref": "#/definitions/dto.BookDTO"
+                            "type": "string"
                         }
                     },
                     "404": {
                         "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Удалить адрес",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Address ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "no content",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "404": {
+                        "description": "not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDTO"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Обновляет данные существующей книги",
+            }
+        },
+        "/account/login": {
+            "post": {
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
                 ],
                 "tags": [
-                    "books"
+                    "account"
                 ],
-                "summary": "Обновить книгу",
+                "summary": "Вход покупателя",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Book ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessTokenDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
                     },
+                    "403": {
+                        "description": "email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/password/forgot": {
+            "post": {
+                "description": "Отправляет письмо со ссылкой для сброса, если аккаунт существует",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Запросить сброс пароля",
+                "parameters": [
                     {
-                        "description": "Book data",
-                        "name": "book",
+                        "description": "Account email",
+                        "name": "email",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.BookRequest"
+                            "$ref": "#/definitions/dto.EmailRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "ok",
+                    "202": {
+                        "description": "accepted",
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "400": {
-                        "description": "invalid request body",
+                    }
+                }
+            }
+        },
+        "/account/password/reset": {
+            "post": {
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Сбросить пароль",
+                "parameters": [
+                    {
+                        "description": "Token and new password",
+                        "name": "reset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "no content",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "not found",
+                    "400": {
+                        "description": "invalid or expired token",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDTO"
                         }
                     },
                     "422": {
                         "description": "validation error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Профиль покупателя",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProfileDTO"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
+                    "401": {
+                        "description": "authorization required",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDTO"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Удаляет книгу по ID",
-                "produces": [
-                    "application/json"
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
                 ],
                 "tags": [
-                    "books"
+                    "account"
                 ],
-                "summary": "Удалить книгу",
+                "summary": "Обновить профиль",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Book ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Profile data",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProfileRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -222,82 +365,3828 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "not found",
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/register": {
+            "post": {
+                "description": "Создает аккаунт и отправляет письмо для подтверждения email; на занятый email отвечает так же, а владельцу шлет письмо о попытке регистрации",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Регистрация покупателя",
+                "parameters": [
+                    {
+                        "description": "Account data",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "accepted",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
                     "500": {
                         "description": "internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ProblemDTO"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.BookDTO": {
-            "type": "object",
-            "properties": {
-                "author": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
+        },
+        "/account/verify-email": {
+            "post": {
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Подтвердить email",
+                "parameters": [
+                    {
+                        "description": "Token from the email",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "no content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/verify-email/resend": {
+            "post": {
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Отправить письмо подтверждения повторно",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "accepted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "text/csv",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Список API-ключей",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.APIKeyDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выпускает ключ для интеграции. Ключ в открытом виде возвращается только в этом ответе",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Создать API-ключ",
+                "parameters": [
+                    {
+                        "description": "Key data",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.IssuedAPIKeyDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Отозвать API-ключ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "no content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid uuid format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/api-keys/{id}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выпускает новый ключ с теми же параметрами, старый продолжает работать в течение grace-периода",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Ротация API-ключа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.IssuedAPIKeyDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid uuid format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "api key is revoked or expired",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit-events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "События изменения данных от новых к старым, с фильтрами и курсорной пагинацией",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Журнал аудита",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Actor subject",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity type, e.g. book",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "create, update, delete or merge",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339, inclusive",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339, exclusive",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor from next_cursor",
+                        "name": "before_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, max 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuditEventListDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit-events/verify": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Пересчитывает хэши всех событий и возвращает id первой испорченной записи",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Проверить цепочку аудита",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuditVerificationDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Пары похожих книг из последнего фонового прохода по каталогу, от самых похожих.\nОценка складывается из совпадения ISBN-13, сходства названий по триграммам и совпадения автора.\nКниги пары читаются заново; пары с уже удалёнными книгами пропускаются.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "duplicates"
+                ],
+                "summary": "Кандидаты в дубликаты",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Minimum score from 0 to 1, default 0.6",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max candidates, default 50, max 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DuplicateReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/duplicates/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Оставляет каноничную книгу без изменений и удаляет дубликат. В журнал аудита пишется событие merge\nна дубликат, дельта-выгрузки сообщают о нём как об удалённом.",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "duplicates"
+                ],
+                "summary": "Слить дубликаты",
+                "parameters": [
+                    {
+                        "description": "Book to keep and book to remove",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MergeBooksRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "book not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported media type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "text/csv",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Список подписок на вебхуки",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Секрет для проверки подписи X-Webhook-Signature возвращается только в этом ответе. Если secret не передан, он генерируется",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Создать подписку на вебхуки",
+                "parameters": [
+                    {
+                        "description": "Subscription data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatedWebhookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/deliveries/{id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ставит в очередь новую доставку того же события, в том числе после исчерпания попыток",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Повторить доставку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid uuid format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Получить подписку на вебхуки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid uuid format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Включение подписки (active=true) сбрасывает счётчик неудачных доставок. Без secret остаётся прежний",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Обновить подписку на вебхуки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "no content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Удалить подписку на вебхуки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "no content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid uuid format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Последние доставки, новые первыми",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "text/csv",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Журнал доставок подписки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, max 200",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookDeliveryDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "Обменивает code на ID token и выдаёт access-токен с ролями по группам IdP",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "staff-auth"
+                ],
+                "summary": "Callback OIDC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessTokenDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "unknown or expired login state, or state from another browser",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "identity has no staff role",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "502": {
+                        "description": "identity provider error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Перенаправляет на страницу авторизации IdP (authorization code + PKCE)",
+                "tags": [
+                    "staff-auth"
+                ],
+                "summary": "Вход сотрудника через корпоративный IdP",
+                "responses": {
+                    "302": {
+                        "description": "redirect to identity provider, state is set in the oidc_state cookie",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "identity provider error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book": {
+            "get": {
+                "description": "Возвращает список всех книг; формат ответа выбирается по Accept.\nfields оставляет в ответе только перечисленные поля (id — всегда), include встраивает связи: authors — автор и число его книг.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "text/csv",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Получить все книги",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields: id,title,author,description,price,isbn,created_at,updated_at",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated relations: authors",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BookDTO"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "unknown field or relation",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Создает новую книгу",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Создать книгу",
+                "parameters": [
+                    {
+                        "description": "Book data",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error, errors lists every invalid field",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Создаёт, обновляет и удаляет до 500 книг за один запрос. В режиме atomic любая ошибка\nоткатывает весь пакет (422 с результатами по элементам), в режиме best_effort\nвалидные элементы записываются, а ошибочные возвращаются со статусом invalid или not_found.",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Пакетные изменения книг",
+                "parameters": [
+                    {
+                        "description": "Operations",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "atomic batch rolled back; invalid envelope is reported as dto.ProblemDTO",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookBatchResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book/labels": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "PDF на листах A4 под этикетки 3×8 (70×36 мм): название, автор, цена, штрихкод EAN-13 и QR-код ссылки на магазин.\nЭтикетки идут в порядке ids, повтор id печатает несколько этикеток одной книги. Книге без ISBN Bookland штрихкод не печатается.",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Лист этикеток",
+                "parameters": [
+                    {
+                        "description": "Book ids, at most 240",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LabelSheetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "label sheet",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported media type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book/{id}": {
+            "get": {
+                "description": "Возвращает книгу по идентификатору; fields и include — как у списка книг",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Получить книгу по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields: id,title,author,description,price,isbn,created_at,updated_at",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated relations: authors",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "unknown field or relation",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет данные существующей книги",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Обновить книгу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Book data",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error, errors lists every invalid field",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет книгу по ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Удалить книгу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "no content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book/{id}/barcode": {
+            "get": {
+                "description": "EAN-13 из ISBN-13 книги (префикс Bookland 978/979) с подписью «ISBN …» и добавочным EAN-5 с ценой.\nПервая цифра добавочного кода — валюта из LABEL_PRICE_ADDON_CURRENCY, без неё пишется 90000.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Штрихкод книги",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "png (default) or svg",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pixels per module, 1-20 (default 3)",
+                        "name": "scale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "barcode image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "book has no bookland isbn",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book/{id}/cover": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Принимает JPEG, PNG или WebP от 100×100 до 10000×10000 пикселей; тип проверяется по содержимому файла.\nИзображение поворачивается по EXIF, метаданные удаляются. Миниатюры small (160), medium (400) и large (800 px\nпо ширине) сохраняются в JPEG и WebP под новой версией, прежняя обложка удаляется.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Загрузка обложки книги",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Cover image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CoverImageDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "413": {
+                        "description": "file too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported image type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "502": {
+                        "description": "blob storage error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Удаление обложки книги",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "invalid uuid format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book/{id}/marc": {
+            "get": {
+                "description": "Отдаёт библиографическую запись книги в MARCXML или в ISO 2709, как в выгрузке format=marc|marcxml.",
+                "produces": [
+                    "application/marcxml+xml",
+                    "application/marc"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Запись MARC 21 книги",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "marcxml (default) or marc",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MARC record",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book/{id}/qr": {
+            "get": {
+                "description": "Ссылка на страницу книги в магазине по шаблону LABEL_STOREFRONT_URL.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "QR-код книги",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "png (default) or svg",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pixels per module, 1-20 (default 8)",
+                        "name": "scale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book/{id}/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Сравнивает книгу с метаданными её ISBN. Предлагаются только отличающиеся поля title, author, description\nи cover (только книге без обложки); книга не меняется.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "enrichment"
+                ],
+                "summary": "Предложения по полям книги",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookEnrichmentDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid uuid format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "book or metadata not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "book has no valid isbn",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "502": {
+                        "description": "metadata source error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/book/{id}/suggestions/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Переносит в книгу только перечисленные поля; остальные не меняются. Для cover обложка скачивается\nу источника и загружается как обычная. Поле без актуального предложения — ошибка валидации.",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "enrichment"
+                ],
+                "summary": "Принять предложения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to accept: title, author, description, cover",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AcceptSuggestionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "book or metadata not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "book was changed concurrently",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported media type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "502": {
+                        "description": "metadata source or blob storage error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/covers/{id}/{version}/{file}": {
+            "get": {
+                "description": "Путь берётся из поля cover книги. Ответ кэшируется на год (immutable): новая обложка получает новый URL.",
+                "produces": [
+                    "image/jpeg",
+                    "image/webp"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Миниатюра обложки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cover version",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "{small|medium|large}.{jpg|webp}",
+                        "name": "file",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "cover image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "502": {
+                        "description": "blob storage error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/stream": {
+            "get": {
+                "description": "Server-Sent Events: события BookCreated, BookUpdated, BookDeleted, PriceChanged. Поле data — конверт события {id, type, occurred_at, data}.\nДоставка at-least-once: повторы дедуплицируются по data.id. При переподключении заголовок Last-Event-ID (или параметр last_event_id) дочитывает пропущенное.\nЕсли пропущенные события уже не хранятся, приходит событие reset — каталог нужно перечитать. Раз в несколько секунд приходит комментарий-heartbeat.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Лента изменений каталога (SSE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event types, comma separated",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this event id (alternative to Last-Event-ID header)",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this event id",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "streaming unsupported",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Потоково отдаёт книги из курсора Postgres в CSV, NDJSON или JSON-массиве, память не растёт с размером каталога.\nПри Accept-Encoding: gzip ответ сжимается. Если выгрузка оборвалась после начала, соединение закрывается без завершающего чанка.\nformat=onix отдаёт сообщение ONIX 3.0 со всеми полями: книги без валидного ISBN пропускаются с XML-комментарием о причине.\nС updated_since выгрузка ONIX становится дельтой и в конце содержит удаления (NotificationType 05) за этот период.\nformat=marc отдаёт записи MARC 21 в ISO 2709 (.mrc), format=marcxml — те же записи в MARCXML.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/json",
+                    "application/xml",
+                    "application/marc",
+                    "application/marcxml+xml"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Выгрузка каталога",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default), ndjson, json, onix, marc or marcxml",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns: id,title,author,description,isbn,price,created_at,updated_at; ignored for onix, marc and marcxml",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated book ids",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exact author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exact ISBN",
+                        "name": "isbn",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimal price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximal price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 timestamp",
+                        "name": "updated_since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "catalog dump",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Книги с авторами за один запрос. Схема доступна через интроспекцию.\nГлубина и сложность запроса ограничены: стоимость вложенных полей умножается на first. Ошибки приходят в errors с extensions.code и статусом 200.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "GraphQL-запрос к каталогу",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GraphQLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GraphQLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Ставит файл в очередь и сразу возвращает задачу; прогресс и ошибки по строкам — в GET /import/{job}.\nСтроки проверяются как при создании книги. mapping — JSON-объект {\"поле книги\": \"заголовок колонки\"},\nполя без сопоставления ищутся по своему имени (title, author, description, isbn, price).\nONIX 3.0 (полные или короткие теги) всегда импортируется с upsert по ISBN, NotificationType 05 удаляет книгу;\nошибки продукта содержат его RecordReference.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Импорт каталога из CSV, XLSX или ONIX",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV, XLSX or ONIX XML file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv, xlsx or onix, by default from the file extension (.xml is onix)",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Column mapping as JSON object",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CSV delimiter, comma by default",
+                        "name": "delimiter",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "XLSX sheet, the first one by default",
+                        "name": "sheet",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Update books with the same ISBN instead of creating new ones",
+                        "name": "upsert",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate rows, write nothing",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportJobDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "413": {
+                        "description": "file too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/import/{job}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Прогресс задачи и ошибки по строкам с номерами строк файла; для ONIX — строка начала Product и RecordReference",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Статус импорта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "job",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportJobDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid uuid format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/metadata/{isbn}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Сведения о книге из внешнего библиографического источника, например для заполнения формы новой книги.\nОтветы источника, включая «не найдено», кэшируются.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "enrichment"
+                ],
+                "summary": "Метаданные по ISBN",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBN-10 or ISBN-13",
+                        "name": "isbn",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookMetadataDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "authorization required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "metadata not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid isbn",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "502": {
+                        "description": "metadata source error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/quote": {
+            "post": {
+                "description": "Считает net, tax и gross по строкам корзины или заказа для страны покупателя",
+                "consumes": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Рассчитать налог",
+                "parameters": [
+                    {
+                        "description": "Quote lines",
+                        "name": "quote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaxQuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaxQuoteDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "unsupported Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.APIKeyDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.AcceptSuggestionsRequest": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.AccessTokenDTO": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AddressDTO": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "line1": {
+                    "type": "string"
+                },
+                "line2": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AddressRequest": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "line1": {
+                    "type": "string"
+                },
+                "line2": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuditEventDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "actor_type": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "prev_hash": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuditEventListDTO": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditEventDTO"
+                    }
+                },
+                "next_cursor": {
+                    "description": "NextCursor передаётся в before_id для следующей страницы",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AuditVerificationDTO": {
+            "type": "object",
+            "properties": {
+                "broken_at": {
+                    "type": "integer"
+                },
+                "checked": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.BookBatchItemDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldErrorDTO"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "deleted",
+                        "invalid",
+                        "not_found",
+                        "rolled_back"
+                    ]
+                }
+            }
+        },
+        "dto.BookBatchOperation": {
+            "type": "object",
+            "properties": {
+                "book": {
+                    "$ref": "#/definitions/dto.BookRequest"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ]
+                }
+            }
+        },
+        "dto.BookBatchRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "atomic",
+                        "best_effort"
+                    ]
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BookBatchOperation"
+                    }
+                }
+            }
+        },
+        "dto.BookBatchResponse": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BookBatchItemDTO"
+                    }
+                }
+            }
+        },
+        "dto.BookDTO": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "cover": {
+                    "description": "Cover — миниатюры обложки, у книги без обложки поля нет",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CoverImageDTO"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BookEnrichmentDTO": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/dto.BookMetadataDTO"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldSuggestionDTO"
+                    }
+                }
+            }
+        },
+        "dto.BookMetadataDTO": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "cover_url": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "page_count": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BookRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CoverImageDTO": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateAPIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreatedWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DuplicateCandidateDTO": {
+            "type": "object",
+            "properties": {
+                "author_similarity": {
+                    "type": "number"
+                },
+                "books": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BookDTO"
+                    }
+                },
+                "isbn_match": {
+                    "type": "boolean"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "title_similarity": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.DuplicateReportDTO": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DuplicateCandidateDTO"
+                    }
+                },
+                "scanned_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EmailRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FieldErrorDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FieldSuggestionDTO": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string",
+                    "enum": [
+                        "title",
+                        "author",
+                        "description",
+                        "cover"
+                    ]
+                },
+                "suggested": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GraphQLError": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "dto.GraphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "dto.GraphQLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GraphQLError"
+                    }
+                }
+            }
+        },
+        "dto.ImportJobDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "options": {
+                    "$ref": "#/definitions/dto.ImportOptionsDTO"
+                },
+                "progress": {
+                    "$ref": "#/definitions/dto.ImportProgressDTO"
+                },
+                "row_errors": {
+                    "description": "RowErrors — первые 1000 ошибок, полное число — в progress.failed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportRowErrorDTO"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "running",
+                        "completed",
+                        "failed"
+                    ]
+                }
+            }
+        },
+        "dto.ImportOptionsDTO": {
+            "type": "object",
+            "properties": {
+                "delimiter": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "sheet": {
+                    "type": "string"
+                },
+                "upsert_by_isbn": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ImportProgressDTO": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportRowErrorDTO": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldErrorDTO"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "record": {
+                    "description": "Record — RecordReference продукта, только для ONIX",
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.IssuedAPIKeyDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "isbn": {
+                "key": {
                     "type": "string"
                 },
-                "price": {
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.LabelSheetRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MergeBooksRequest": {
+            "type": "object",
+            "properties": {
+                "canonical_id": {
+                    "type": "string"
+                },
+                "duplicate_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProblemDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldErrorDTO"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "integer"
                 },
                 "title": {
                     "type": "string"
                 },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProfileDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verified": {
+                    "type": "boolean"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProfileRequest": {
+            "type": "object",
+            "properties": {
+                "full_name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TaxLineDTO": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "gross": {
+                    "type": "integer"
+                },
+                "net": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "rate_bp": {
+                    "type": "integer"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TaxLineRequest": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TaxQuoteDTO": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "gross": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TaxLineDTO"
+                    }
+                },
+                "net": {
+                    "type": "integer"
+                },
+                "prices_include_tax": {
+                    "type": "boolean"
+                },
+                "tax": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TaxQuoteRequest": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TaxLineRequest"
+                    }
+                }
+            }
+        },
+        "dto.TokenRequest": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WebhookDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "dto.BookRequest": {
+        "dto.WebhookDeliveryDTO": {
             "type": "object",
             "properties": {
-                "author": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "isbn": {
+                "last_attempt_at": {
                     "type": "string"
                 },
-                "price": {
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
                     "type": "integer"
                 },
-                "title": {
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "redelivery_of": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "APIKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "0.0.0.0:8080",
+	Host:             "book-store-api:8080",
 	BasePath:         "/api/v1/",
 	Schemes:          []string{"http"},
 	Title:            "Book API",
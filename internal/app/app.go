@@ -5,22 +5,71 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"strings"
+	"time"
 
+	"book-store-api/internal/auth"
 	"book-store-api/internal/cache"
 	"book-store-api/internal/config"
+	"book-store-api/internal/dedup"
+	"book-store-api/internal/delivery/graphqlv1"
+	"book-store-api/internal/delivery/grpcv1"
 	"book-store-api/internal/delivery/httpv1"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/feed"
+	"book-store-api/internal/importer"
+	"book-store-api/internal/infrastructure/blob"
 	"book-store-api/internal/infrastructure/db"
+	"book-store-api/internal/infrastructure/googlebooks"
+	"book-store-api/internal/infrastructure/mailer"
+	"book-store-api/internal/infrastructure/oidc"
+	"book-store-api/internal/infrastructure/password"
+	"book-store-api/internal/label"
+	"book-store-api/internal/marc"
+	"book-store-api/internal/models"
+	"book-store-api/internal/onix"
+	"book-store-api/internal/outbox"
+	"book-store-api/internal/ratelimit"
 	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase/apikey"
+	"book-store-api/internal/usecase/audit"
 	"book-store-api/internal/usecase/book"
+	"book-store-api/internal/usecase/cover"
+	coverInterfaces "book-store-api/internal/usecase/cover/interfaces"
+	"book-store-api/internal/usecase/customer"
+	customerInterfaces "book-store-api/internal/usecase/customer/interfaces"
+	"book-store-api/internal/usecase/duplicate"
+	"book-store-api/internal/usecase/enrichment"
+	"book-store-api/internal/usecase/importjob"
+	"book-store-api/internal/usecase/staff"
+	"book-store-api/internal/usecase/tax"
+	webhookUsecase "book-store-api/internal/usecase/webhook"
+	"book-store-api/internal/webhook"
 
+	"github.com/gorilla/mux"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 )
 
 type App struct {
 	httpServer *http.Server
+	grpcServer *grpc.Server
+	grpcHealth *health.Server
+	grpcAddr   string
 	db         *pgxpool.Pool
 	usecase    *book.Service
+	relay      *outbox.Relay
+	relayDone  chan struct{}
+	webhooks   *webhook.Worker
+	hooksDone  chan struct{}
+	imports    *importer.Worker
+	importDone chan struct{}
+	dedup      *dedup.Worker
+	dedupDone  chan struct{}
+	feed       *feed.Hub
 	logger     *slog.Logger
 }
 
@@ -38,13 +87,150 @@ func BuildApp(cfg *config.Config) (*App, error) {
 	redisCache := buildCache(cfg.Redis)
 	repo := buildRepo(pool)
 
-	usecase := buildUseCase(logger, repo, redisCache)
-	httpServer := buildHTTP(cfg.HTTP, logger, usecase)
+	blobStorage, err := buildBlobStorage(cfg.Blob)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+	usecase := buildUseCase(logger, repo, redisCache, blobStorage)
+	taxUsecase := buildTaxUseCase(logger, cfg.Tax, pool, repo)
+
+	mail, err := buildMailer(cfg.Mailer)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+	issuer := auth.NewIssuer([]byte(cfg.Auth.JWTSecret), cfg.Auth.Issuer, cfg.Auth.Audience, time.Duration(cfg.Auth.TokenTTL)*time.Minute)
+	verifier, err := buildVerifier(cfg.Auth, cfg.OIDC)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+	accountUsecase := buildAccountUseCase(logger, cfg.Account, pool, mail, issuer)
+	apiKeyUsecase := buildAPIKeyUseCase(logger, cfg.APIKey, pool)
+
+	auditUsecase := audit.NewService(logger, repository.NewAuditRepository(pool))
+	auditedBooks := audit.NewBookAuditor(usecase)
+	onixCfg := buildONIXConfig(cfg.ONIX, cfg.Tax)
+	marcCfg := marc.Config{
+		OrgCode:          cfg.MARC.OrgCode,
+		Currency:         onixCfg.Currency,
+		PriceDecimals:    onixCfg.PriceDecimals,
+		PricesIncludeTax: onixCfg.PricesIncludeTax,
+	}
+
+	coverUsecase := audit.NewCoverAuditor(cover.NewService(logger, repo, blobStorage, redisCache))
+
+	registrars := []httpv1.RouteRegistrar{
+		httpv1.NewBookHandler(auditedBooks, usecase, logger),
+		httpv1.NewBookBatchHandler(auditedBooks, logger),
+		httpv1.NewExportHandler(usecase, auditUsecase, onixCfg, marcCfg, logger),
+		httpv1.NewMARCHandler(auditedBooks, marcCfg, logger),
+		httpv1.NewCoverHandler(coverUsecase, cfg.Cover.MaxFileSize<<20, logger),
+		httpv1.NewLabelHandler(auditedBooks, usecase, label.Config{
+			StorefrontURL: cfg.Label.StorefrontURL,
+			AddOnCurrency: cfg.Label.PriceAddOnCurrency,
+			Currency:      onixCfg.Currency,
+			PriceDecimals: onixCfg.PriceDecimals,
+		}, logger),
+		httpv1.NewTaxHandler(taxUsecase, logger),
+		httpv1.NewAccountHandler(accountUsecase, logger),
+		httpv1.NewAPIKeyHandler(apiKeyUsecase, logger),
+		httpv1.NewAuditHandler(auditUsecase, logger),
+	}
+	if cfg.GraphQL.Enabled {
+		graphqlHandler, err := graphqlv1.NewHandler(auditedBooks, usecase, graphqlv1.Limits{
+			MaxDepth:      cfg.GraphQL.MaxDepth,
+			MaxComplexity: cfg.GraphQL.MaxComplexity,
+		}, logger)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+		registrars = append(registrars, graphqlHandler)
+	}
+	publishers := outbox.MultiPublisher{outbox.NewLogPublisher(logger)}
+	var webhookWorker *webhook.Worker
+	if cfg.Webhook.Enabled {
+		webhookRepo := repository.NewWebhookRepository(pool)
+		registrars = append(registrars, httpv1.NewWebhookHandler(webhookUsecase.NewService(logger, webhookRepo), logger))
+		publishers = append(publishers, webhook.NewDispatcher(webhookRepo))
+		webhookWorker = buildWebhookWorker(logger, cfg.Webhook, webhookRepo)
+	}
+	var importWorker *importer.Worker
+	if cfg.Import.Enabled {
+		importRepo := repository.NewImportJobRepository(pool)
+		registrars = append(registrars, httpv1.NewImportHandler(importjob.NewService(logger, importRepo), cfg.Import.MaxFileSize<<20, logger))
+		importWorker = buildImportWorker(logger, cfg.Import, onixCfg, importRepo, auditedBooks, usecase)
+	}
+	var dedupWorker *dedup.Worker
+	if cfg.Dedup.Enabled {
+		dedupStore := cache.NewDuplicateStore(redisCache)
+		registrars = append(registrars, httpv1.NewDuplicateHandler(duplicate.NewService(logger, dedupStore, usecase, auditedBooks), logger))
+		dedupWorker = dedup.NewWorker(usecase, dedupStore, dedup.WorkerConfig{
+			Interval: time.Duration(cfg.Dedup.Interval) * time.Minute,
+			MinScore: cfg.Dedup.MinScore,
+		}, logger)
+	}
+	var hub *feed.Hub
+	if cfg.Feed.Enabled {
+		feedLog := feed.NewRedisLog(redisCache.Client(), "feed:catalog", cfg.Feed.Retention)
+		hub = feed.NewHub(feedLog, cfg.Feed.ClientBuffer, logger)
+		// последним: ошибка предыдущих публикаторов не должна плодить дубли в ленте
+		publishers = append(publishers, feedLog)
+		registrars = append(registrars, httpv1.NewEventsHandler(hub, time.Duration(cfg.Feed.Heartbeat)*time.Second, logger))
+	}
+	if cfg.Enrich.Enabled {
+		enrichUsecase := buildEnrichmentUseCase(logger, cfg.Enrich, redisCache, auditedBooks, coverUsecase, cfg.Cover.MaxFileSize<<20)
+		registrars = append(registrars, httpv1.NewEnrichmentHandler(enrichUsecase, logger))
+	}
+	if cfg.OIDC.Enabled() {
+		staffUsecase := buildStaffUseCase(logger, cfg.OIDC, redisCache, issuer)
+		registrars = append(registrars, httpv1.NewStaffAuthHandler(staffUsecase, logger))
+	}
+	var preAuth, rateLimit []mux.MiddlewareFunc
+	var grpcLimits *grpcv1.RateLimitOptions
+	if cfg.Limits.Enabled {
+		preAuth, rateLimit, grpcLimits, err = buildRateLimit(logger, cfg.Limits, redisCache)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+	}
+	// IP-лимит до аутентификации: неверные токены и ключи тоже упираются в 429
+	middlewares := append(preAuth,
+		middleware.JWTMiddleware(verifier),
+		middleware.APIKeyMiddleware(apiKeyUsecase),
+	)
+	middlewares = append(middlewares, rateLimit...)
+
+	httpServer := buildHTTP(cfg.HTTP, logger, middlewares, registrars)
+	var grpcServer *grpc.Server
+	var grpcHealth *health.Server
+	if cfg.GRPC.Enabled {
+		grpcServer, grpcHealth = grpcv1.InitServer(auditedBooks, verifier, apiKeyUsecase, grpcLimits, cfg.GRPC.Reflection, logger)
+	}
+	relay := buildRelay(logger, cfg.Outbox, pool, publishers)
+	if hub != nil {
+		httpServer.RegisterOnShutdown(hub.Close)
+	}
 
 	return &App{
 		httpServer: httpServer,
+		grpcServer: grpcServer,
+		grpcHealth: grpcHealth,
+		grpcAddr:   net.JoinHostPort(cfg.GRPC.Host, cfg.GRPC.Port),
 		db:         pool,
 		usecase:    usecase,
+		relay:      relay,
+		relayDone:  make(chan struct{}),
+		webhooks:   webhookWorker,
+		hooksDone:  make(chan struct{}),
+		imports:    importWorker,
+		importDone: make(chan struct{}),
+		dedup:      dedupWorker,
+		dedupDone:  make(chan struct{}),
+		feed:       hub,
 		logger:     logger,
 	}, nil
 }
@@ -72,16 +258,176 @@ func buildCache(cfg config.RedisConfig) *cache.Cache {
 	return cache.NewCache(cfg)
 }
 
-func buildUseCase(logger *slog.Logger, db *repository.BookRepository, cache *cache.Cache) *book.Service {
-	return book.NewService(logger, db, cache)
+func buildUseCase(logger *slog.Logger, db *repository.BookRepository, cache *cache.Cache, storage coverInterfaces.BlobStorage) *book.Service {
+	return book.NewService(logger, db, cache, storage)
 }
 
-func buildHTTP(cfg config.HTTPConfig, logger *slog.Logger, service *book.Service) *http.Server {
-	handler := httpv1.NewBookHandler(service, logger)
-	return httpv1.InitServer(cfg, logger, handler)
+func buildTaxUseCase(logger *slog.Logger, cfg config.TaxConfig, pool *pgxpool.Pool, books *repository.BookRepository) *tax.Service {
+	return tax.NewService(logger, repository.NewTaxRateRepository(pool), books, cfg.PricesIncludeTax)
+}
+
+func buildVerifier(cfg config.AuthConfig, oidcCfg config.OIDCConfig) (*auth.Verifier, error) {
+	opts := auth.VerifierOptions{
+		Secret:   []byte(cfg.JWTSecret),
+		Issuer:   cfg.Issuer,
+		Audience: cfg.Audience,
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := auth.LoadJWKSFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.External = []auth.ExternalIssuer{{
+			Issuer:      cfg.JWKSIssuer,
+			Keys:        keys,
+			GroupsClaim: oidcCfg.GroupsClaim,
+			RoleMapping: oidcCfg.RoleMapping,
+		}}
+	}
+
+	return auth.NewVerifier(opts), nil
+}
+
+func buildMailer(cfg config.MailerConfig) (customerInterfaces.Mailer, error) {
+	if cfg.Driver == "smtp" {
+		return mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword, cfg.From), nil
+	}
+	return mailer.NewFileMailer(cfg.Dir, cfg.From)
+}
+
+func buildBlobStorage(cfg config.BlobConfig) (coverInterfaces.BlobStorage, error) {
+	if cfg.Driver == "s3" {
+		return blob.NewS3Storage(blob.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		}, nil), nil
+	}
+	return blob.NewFileStorage(cfg.Dir)
+}
+
+func buildAccountUseCase(logger *slog.Logger, cfg config.AccountConfig, pool *pgxpool.Pool, mail customerInterfaces.Mailer, issuer *auth.Issuer) *customer.Service {
+	repo := repository.NewCustomerRepository(pool)
+	hasher := password.NewBcryptHasher(cfg.BcryptCost)
+	return customer.NewService(logger, repo, mail, hasher, issuer, cfg.PublicURL)
+}
+
+func buildAPIKeyUseCase(logger *slog.Logger, cfg config.APIKeyConfig, pool *pgxpool.Pool) *apikey.Service {
+	return apikey.NewService(logger, repository.NewAPIKeyRepository(pool), time.Duration(cfg.RotationGrace)*time.Minute)
+}
+
+func buildStaffUseCase(logger *slog.Logger, cfg config.OIDCConfig, redisCache *cache.Cache, issuer *auth.Issuer) *staff.Service {
+	provider := oidc.NewClient(oidc.Config{
+		IssuerURL:    cfg.IssuerURL,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+		GroupsClaim:  cfg.GroupsClaim,
+	}, nil)
+	return staff.NewService(logger, provider, cache.NewLoginStateStore(redisCache), issuer, cfg.RoleMapping)
+}
+
+func buildEnrichmentUseCase(logger *slog.Logger, cfg config.EnrichmentConfig, redisCache *cache.Cache, books *audit.BookAuditor, covers *audit.CoverAuditor, maxImageSize int64) *enrichment.Service {
+	source := googlebooks.NewClient(googlebooks.Config{BaseURL: cfg.BaseURL, APIKey: cfg.APIKey},
+		&http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second})
+	return enrichment.NewService(logger, source, cache.NewMetadataStore(redisCache), books, covers, enrichment.Config{
+		CacheTTL:     time.Duration(cfg.CacheTTL) * time.Minute,
+		MissTTL:      time.Duration(cfg.MissTTL) * time.Minute,
+		MaxImageSize: maxImageSize,
+	})
+}
+
+// buildRateLimit возвращает middleware до аутентификации и после неё и те же лимиты для gRPC:
+// полный каталог отдаёт и ListAll, его нельзя оставлять без счётчика клиента.
+func buildRateLimit(logger *slog.Logger, cfg config.RateLimitConfig, redisCache *cache.Cache) (preAuth, rateLimit []mux.MiddlewareFunc, grpcLimits *grpcv1.RateLimitOptions, err error) {
+	rules, err := ratelimit.ParseRules(cfg.Rules)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	limiter := ratelimit.NewFallbackLimiter(
+		ratelimit.NewRedisLimiter(redisCache.Client(), "ratelimit:"),
+		ratelimit.NewMemoryLimiter(),
+		logger,
+	)
+	grpcLimits = &grpcv1.RateLimitOptions{Limiter: limiter, Rules: rules}
+	if cfg.PreAuth != "" {
+		limit, err := ratelimit.ParseLimit(cfg.PreAuth)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: RATE_LIMIT_PREAUTH: %v", ratelimit.ErrInvalidRule, err)
+		}
+		preAuth = append(preAuth, middleware.PreAuthRateLimit(limiter, limit, logger))
+		grpcLimits.PreAuth = limit
+	}
+	rateLimit = append(rateLimit, middleware.RateLimit(limiter, middleware.RateLimitOptions{
+		Rules:       rules,
+		RoutePrefix: httpv1.APIPrefix,
+	}, logger))
+	return preAuth, rateLimit, grpcLimits, nil
+}
+
+func buildRelay(logger *slog.Logger, cfg config.OutboxConfig, pool *pgxpool.Pool, publisher outbox.Publisher) *outbox.Relay {
+	return outbox.NewRelay(repository.NewOutboxRepository(pool), publisher, outbox.RelayConfig{
+		PollInterval: time.Duration(cfg.PollInterval) * time.Millisecond,
+		BatchSize:    cfg.BatchSize,
+		Retry: models.RetryPolicy{
+			MaxAttempts: cfg.MaxAttempts,
+			BaseDelay:   time.Duration(cfg.RetryBase) * time.Second,
+			MaxDelay:    time.Duration(cfg.RetryMax) * time.Second,
+		},
+	}, logger)
+}
+
+func buildWebhookWorker(logger *slog.Logger, cfg config.WebhookConfig, repo *repository.WebhookRepository) *webhook.Worker {
+	return webhook.NewWorker(repo, nil, webhook.WorkerConfig{
+		PollInterval: time.Duration(cfg.PollInterval) * time.Millisecond,
+		BatchSize:    cfg.BatchSize,
+		Concurrency:  cfg.Concurrency,
+		Timeout:      time.Duration(cfg.Timeout) * time.Second,
+		Retry: models.RetryPolicy{
+			MaxAttempts: cfg.MaxAttempts,
+			BaseDelay:   time.Duration(cfg.RetryBase) * time.Second,
+			MaxDelay:    time.Duration(cfg.RetryMax) * time.Second,
+		},
+		DisableAfter: cfg.DisableAfter,
+	}, logger)
+}
+
+func buildImportWorker(logger *slog.Logger, cfg config.ImportConfig, onixCfg onix.Config, repo *repository.ImportJobRepository, books importer.Books, catalog importer.Catalog) *importer.Worker {
+	return importer.NewWorker(repo, books, catalog, importer.WorkerConfig{
+		PollInterval: time.Duration(cfg.PollInterval) * time.Millisecond,
+		ChunkSize:    cfg.ChunkSize,
+		Lease:        time.Duration(cfg.Lease) * time.Second,
+		ONIX:         onixCfg,
+	}, logger)
+}
+
+func buildONIXConfig(cfg config.ONIXConfig, tax config.TaxConfig) onix.Config {
+	return onix.Config{
+		SenderName:       cfg.SenderName,
+		RecordPrefix:     cfg.RecordPrefix,
+		Currency:         strings.ToUpper(cfg.Currency),
+		PriceDecimals:    cfg.PriceDecimals,
+		PricesIncludeTax: tax.PricesIncludeTax,
+	}
+}
+
+func buildHTTP(cfg config.HTTPConfig, logger *slog.Logger, middlewares []mux.MiddlewareFunc, registrars []httpv1.RouteRegistrar) *http.Server {
+	return httpv1.InitServer(cfg, logger, middlewares, registrars...)
 }
 
 func (a *App) Run(ctx context.Context, cacheConfig config.CacheConfig) error {
+	// слушаем порт gRPC до запуска горутин, чтобы занятый порт был ошибкой старта
+	var grpcListener net.Listener
+	if a.grpcServer != nil {
+		lis, err := net.Listen("tcp", a.grpcAddr)
+		if err != nil {
+			return fmt.Errorf("grpc listen: %w", err)
+		}
+		grpcListener = lis
+	}
 
 	go func() {
 		if err := a.usecase.LoadCache(ctx, cacheConfig.Limit); err != nil {
@@ -93,6 +439,51 @@ func (a *App) Run(ctx context.Context, cacheConfig config.CacheConfig) error {
 
 	}()
 
+	go func() {
+		defer close(a.relayDone)
+		a.relay.Run(ctx)
+		a.logger.Info("outbox relay stopped")
+	}()
+
+	if a.feed != nil {
+		go a.feed.Run(ctx)
+	}
+
+	go func() {
+		defer close(a.hooksDone)
+		if a.webhooks == nil {
+			return
+		}
+		a.webhooks.Run(ctx)
+		a.logger.Info("webhook worker stopped")
+	}()
+
+	go func() {
+		defer close(a.importDone)
+		if a.imports == nil {
+			return
+		}
+		a.imports.Run(ctx)
+		a.logger.Info("import worker stopped")
+	}()
+
+	go func() {
+		defer close(a.dedupDone)
+		if a.dedup == nil {
+			return
+		}
+		a.dedup.Run(ctx)
+		a.logger.Info("duplicate scan worker stopped")
+	}()
+
+	if grpcListener != nil {
+		go func() {
+			if err := a.grpcServer.Serve(grpcListener); err != nil {
+				a.logger.Error("grpc server error", "err", err)
+			}
+		}()
+	}
+
 	go func() {
 		if err := a.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 
@@ -111,6 +502,35 @@ func (a *App) Shutdown(ctx context.Context) error {
 	}
 	a.logger.Info("httpv1 server shutdown")
 
+	if a.grpcServer != nil {
+		if err := a.shutdownGRPC(ctx); err != nil {
+			errList = append(errList, err)
+		}
+		a.logger.Info("grpc server shutdown")
+	}
+
+	// relay останавливается по отмене ctx из Run, ждём текущую пачку до закрытия пула
+	select {
+	case <-a.relayDone:
+	case <-ctx.Done():
+		errList = append(errList, fmt.Errorf("outbox relay: %w", ctx.Err()))
+	}
+	select {
+	case <-a.hooksDone:
+	case <-ctx.Done():
+		errList = append(errList, fmt.Errorf("webhook worker: %w", ctx.Err()))
+	}
+	select {
+	case <-a.importDone:
+	case <-ctx.Done():
+		errList = append(errList, fmt.Errorf("import worker: %w", ctx.Err()))
+	}
+	select {
+	case <-a.dedupDone:
+	case <-ctx.Done():
+		errList = append(errList, fmt.Errorf("duplicate scan worker: %w", ctx.Err()))
+	}
+
 	a.db.Close()
 	a.logger.Info("db shutdown")
 
@@ -120,3 +540,23 @@ func (a *App) Shutdown(ctx context.Context) error {
 
 	return nil
 }
+
+// shutdownGRPC переводит health в NOT_SERVING, чтобы балансировщик снял трафик,
+// и ждёт завершения текущих вызовов; по истечении ctx обрывает их.
+func (a *App) shutdownGRPC(ctx context.Context) error {
+	a.grpcHealth.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		a.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		a.grpcServer.Stop()
+		return fmt.Errorf("grpc server: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJWKS(t *testing.T) (*rsa.PrivateKey, ed25519.PrivateKey, KeySet) {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	doc := fmt.Sprintf(`{"keys":[
+		{"kty":"RSA","kid":"rsa-1","use":"sig","alg":"RS256","n":%q,"e":%q},
+		{"kty":"OKP","kid":"ed-1","crv":"Ed25519","x":%q},
+		{"kty":"RSA","kid":"enc-1","use":"enc","n":"AQAB","e":"AQAB"}
+	]}`,
+		base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString(edPub),
+	)
+	keys, err := ParseJWKS([]byte(doc))
+	require.NoError(t, err)
+	return rsaKey, edKey, keys
+}
+
+// idpClaims — токен внешнего IdP: роли сервиса в нём не учитываются, только группы.
+type idpClaims struct {
+	Groups []string `json:"groups,omitempty"`
+	Claims
+}
+
+func signed(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	raw, err := token.SignedString(key)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestVerifier_JWKS(t *testing.T) {
+	t.Parallel()
+	rsaKey, edKey, keys := testJWKS(t)
+	assert.Len(t, keys, 2, "encryption keys must be skipped")
+
+	secret := []byte("secret")
+	verifier := NewVerifier(VerifierOptions{
+		Secret: secret,
+		Issuer: "book-store-api",
+		External: []ExternalIssuer{{
+			Issuer:      "idp",
+			Keys:        keys,
+			GroupsClaim: "groups",
+			RoleMapping: map[string]string{"catalog-editors": RoleCatalogEditor},
+		}},
+		Audience: "book-store-api",
+	})
+	claims := idpClaims{
+		Groups: []string{"catalog-editors", "everyone"},
+		Claims: Claims{
+			Roles: []string{RoleAdmin},
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "idp",
+				Subject:   "staff-1",
+				Audience:  jwt.ClaimStrings{"book-store-api"},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		},
+	}
+
+	t.Run("rs256", func(t *testing.T) {
+		t.Parallel()
+		p, err := verifier.Verify(signed(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims))
+		assert.NoError(t, err)
+		assert.Equal(t, "staff-1", p.Subject)
+		assert.Equal(t, []string{RoleCatalogEditor}, p.Roles, "roles come from mapped groups, not from the roles claim")
+	})
+
+	t.Run("unmapped groups", func(t *testing.T) {
+		t.Parallel()
+		other := claims
+		other.Groups = []string{"everyone"}
+		p, err := verifier.Verify(signed(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, other))
+		assert.NoError(t, err)
+		assert.Empty(t, p.Roles)
+	})
+
+	t.Run("idp key cannot sign own issuer tokens", func(t *testing.T) {
+		t.Parallel()
+		other := claims
+		other.Issuer = "book-store-api"
+		_, err := verifier.Verify(signed(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, other))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("idp key with unknown issuer", func(t *testing.T) {
+		t.Parallel()
+		other := claims
+		other.Issuer = "other-idp"
+		_, err := verifier.Verify(signed(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, other))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("hs256 with idp issuer", func(t *testing.T) {
+		t.Parallel()
+		_, err := verifier.Verify(signed(t, jwt.SigningMethodHS256, "", secret, claims))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("own token keeps roles", func(t *testing.T) {
+		t.Parallel()
+		own := claims
+		own.Issuer = "book-store-api"
+		p, err := verifier.Verify(signed(t, jwt.SigningMethodHS256, "", secret, own))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{RoleAdmin}, p.Roles)
+	})
+
+	t.Run("eddsa", func(t *testing.T) {
+		t.Parallel()
+		_, err := verifier.Verify(signed(t, jwt.SigningMethodEdDSA, "ed-1", edKey, claims))
+		assert.NoError(t, err)
+	})
+
+	t.Run("key of another type", func(t *testing.T) {
+		t.Parallel()
+		_, err := verifier.Verify(signed(t, jwt.SigningMethodEdDSA, "rsa-1", edKey, claims))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("unknown kid", func(t *testing.T) {
+		t.Parallel()
+		_, err := verifier.Verify(signed(t, jwt.SigningMethodRS256, "rsa-2", rsaKey, claims))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("hs256 without secret", func(t *testing.T) {
+		t.Parallel()
+		own := claims
+		own.Issuer = "book-store-api"
+		noSecret := NewVerifier(VerifierOptions{Issuer: "book-store-api", Audience: "book-store-api"})
+		_, err := noSecret.Verify(signed(t, jwt.SigningMethodHS256, "", secret, own))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		t.Parallel()
+		other := claims
+		other.Audience = jwt.ClaimStrings{"other-api"}
+		_, err := verifier.Verify(signed(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, other))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
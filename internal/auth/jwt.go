@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type Claims struct {
+	Roles []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Issuer выпускает access-токены HS256 для собственных пользователей сервиса.
+// Если задан audience, он пишется в aud: Verifier с тем же AUTH_JWT_AUDIENCE требует его.
+type Issuer struct {
+	secret   []byte
+	issuer   string
+	audience string
+	ttl      time.Duration
+}
+
+func NewIssuer(secret []byte, issuer, audience string, ttl time.Duration) *Issuer {
+	return &Issuer{secret: secret, issuer: issuer, audience: audience, ttl: ttl}
+}
+
+func (i *Issuer) Issue(subject string, roles []string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(i.ttl)
+	claims := Claims{
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.issuer,
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	if i.audience != "" {
+		claims.Audience = jwt.ClaimStrings{i.audience}
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+type VerifierOptions struct {
+	// Secret проверяет HS256-токены самого сервиса с iss = Issuer; только им доверяется claim roles
+	Secret   []byte
+	Issuer   string
+	External []ExternalIssuer
+	Audience string
+}
+
+// ExternalIssuer — внешний издатель с ключами RS256/EdDSA. Ключ принимается только
+// в токенах этого издателя, а роли берутся не из roles, а из групп через RoleMapping —
+// так же, как при входе сотрудников через OIDC.
+type ExternalIssuer struct {
+	Issuer      string
+	Keys        KeySet
+	GroupsClaim string
+	RoleMapping map[string]string
+}
+
+type Verifier struct {
+	opts VerifierOptions
+}
+
+func NewVerifier(opts VerifierOptions) *Verifier {
+	return &Verifier{opts: opts}
+}
+
+func (v *Verifier) Verify(raw string) (Principal, error) {
+	claims := jwt.MapClaims{}
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{
+			jwt.SigningMethodHS256.Alg(),
+			jwt.SigningMethodRS256.Alg(),
+			jwt.SigningMethodEdDSA.Alg(),
+		}),
+		jwt.WithExpirationRequired(),
+	}
+	if v.opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(v.opts.Audience))
+	}
+
+	_, err := jwt.ParseWithClaims(raw, claims, v.keyFunc, parserOpts...)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return Principal{}, fmt.Errorf("%w: subject is required", ErrInvalidToken)
+	}
+
+	// keyFunc уже проверил, что ключ принадлежит издателю из iss
+	iss, _ := claims.GetIssuer()
+	if iss == v.opts.Issuer {
+		return Principal{Type: PrincipalUser, Subject: subject, Roles: StringList(claims["roles"])}, nil
+	}
+	ext, _ := v.external(iss)
+	roles := MapRoles(StringList(claims[ext.GroupsClaim]), ext.RoleMapping)
+	return Principal{Type: PrincipalUser, Subject: subject, Roles: roles}, nil
+}
+
+// keyFunc выбирает ключ по издателю: HS256 — только собственный, RS256 и EdDSA — только
+// ключ из JWKS того внешнего издателя, что указан в iss. Ключ не своего типа не подходит,
+// чтобы исключить подмену алгоритма.
+func (v *Verifier) keyFunc(t *jwt.Token) (any, error) {
+	iss, _ := t.Claims.GetIssuer()
+	if t.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+		if len(v.opts.Secret) == 0 {
+			return nil, errors.New("hs256 is not configured")
+		}
+		if iss != v.opts.Issuer {
+			return nil, fmt.Errorf("untrusted issuer %q", iss)
+		}
+		return v.opts.Secret, nil
+	}
+
+	ext, ok := v.external(iss)
+	if !ok {
+		return nil, fmt.Errorf("untrusted issuer %q", iss)
+	}
+	kid, _ := t.Header["kid"].(string)
+	key, ok := ext.Keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q for issuer %q", kid, iss)
+	}
+
+	switch t.Method.Alg() {
+	case jwt.SigningMethodRS256.Alg():
+		if rsaKey, ok := key.(*rsa.PublicKey); ok {
+			return rsaKey, nil
+		}
+	case jwt.SigningMethodEdDSA.Alg():
+		if edKey, ok := key.(ed25519.PublicKey); ok {
+			return edKey, nil
+		}
+	}
+	return nil, fmt.Errorf("key %q does not match %s", kid, t.Method.Alg())
+}
+
+func (v *Verifier) external(iss string) (ExternalIssuer, bool) {
+	for _, ext := range v.opts.External {
+		if ext.Issuer == iss && iss != v.opts.Issuer {
+			return ext, true
+		}
+	}
+	return ExternalIssuer{}, false
+}
+
+// MapRoles переводит группы IdP в роли сервиса; группы без сопоставления не дают ролей.
+func MapRoles(groups []string, mapping map[string]string) []string {
+	var roles []string
+	for _, group := range groups {
+		role, ok := mapping[group]
+		if ok && !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	slices.Sort(roles)
+	return roles
+}
+
+// StringList принимает claim со списком как массив или как одну строку.
+func StringList(v any) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []any:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
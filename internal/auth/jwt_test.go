@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuerVerifier(t *testing.T) {
+	t.Parallel()
+	secret := []byte("test-secret")
+	issuer := NewIssuer(secret, "book-store-api", "", time.Hour)
+
+	token, expiresAt, err := issuer.Issue("customer-1", []string{RoleCustomer})
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+	t.Run("valid token", func(t *testing.T) {
+		t.Parallel()
+		p, err := NewVerifier(VerifierOptions{Secret: secret, Issuer: "book-store-api"}).Verify(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "customer-1", p.Subject)
+		assert.True(t, p.HasRole(RoleCustomer))
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewVerifier(VerifierOptions{Secret: []byte("other"), Issuer: "book-store-api"}).Verify(token)
+		assert.True(t, errors.Is(err, ErrInvalidToken))
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewVerifier(VerifierOptions{Secret: secret, Issuer: "someone-else"}).Verify(token)
+		assert.True(t, errors.Is(err, ErrInvalidToken))
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		t.Parallel()
+		expired, _, err := NewIssuer(secret, "book-store-api", "", -time.Minute).Issue("customer-1", nil)
+		assert.NoError(t, err)
+		_, err = NewVerifier(VerifierOptions{Secret: secret, Issuer: "book-store-api"}).Verify(expired)
+		assert.True(t, errors.Is(err, ErrInvalidToken))
+	})
+}
+
+func TestIssuerVerifier_Audience(t *testing.T) {
+	t.Parallel()
+	secret := []byte("test-secret")
+	verifier := NewVerifier(VerifierOptions{Secret: secret, Issuer: "book-store-api", Audience: "book-store"})
+
+	token, _, err := NewIssuer(secret, "book-store-api", "book-store", time.Hour).Issue("customer-1", []string{RoleCustomer})
+	assert.NoError(t, err)
+	p, err := verifier.Verify(token)
+	assert.NoError(t, err, "own tokens pass the audience check")
+	assert.Equal(t, "customer-1", p.Subject)
+
+	withoutAud, _, err := NewIssuer(secret, "book-store-api", "", time.Hour).Issue("customer-1", nil)
+	assert.NoError(t, err)
+	_, err = verifier.Verify(withoutAud)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
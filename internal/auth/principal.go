@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+const (
+	RoleAdmin         = "admin"
+	RoleCatalogEditor = "catalog-editor"
+	RoleCustomer      = "customer"
+)
+
+type PrincipalType string
+
+const (
+	PrincipalUser   PrincipalType = "user"
+	PrincipalAPIKey PrincipalType = "api_key"
+)
+
+type contextKey string
+
+const principalKey contextKey = "principal"
+
+// Principal описывает аутентифицированного вызывающего.
+type Principal struct {
+	Type    PrincipalType
+	Subject string
+	Roles   []string
+	Scopes  []string
+}
+
+// Policy разрешает доступ по любой из ролей пользователя или любому из scope ключа.
+type Policy struct {
+	Roles  []string
+	Scopes []string
+}
+
+func (p Policy) Allows(principal Principal) bool {
+	return principal.HasAnyRole(p.Roles...) || principal.HasAnyScope(p.Scopes...)
+}
+
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+func (p Principal) HasAnyRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Principal) HasAnyScope(scopes ...string) bool {
+	for _, scope := range scopes {
+		if slices.Contains(p.Scopes, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey).(Principal)
+	return p, ok
+}
@@ -0,0 +1,40 @@
+// Package barcode строит штрихкоды Bookland EAN-13 и QR-коды как раскладку из тёмных
+// прямоугольников и подписей в модулях. PNG, SVG и PDF только масштабируют её,
+// поэтому штрихкод на экране и на этикетке одинаков.
+package barcode
+
+// Rect — тёмный прямоугольник в модулях.
+type Rect struct {
+	X, Y, W, H float64
+}
+
+// Text — подпись: X — центр строки, Y — базовая линия, Size — высота шрифта, всё в модулях.
+type Text struct {
+	X, Y, Size float64
+	Value      string
+}
+
+// Symbol — штрихкод вместе с полями тишины; начало координат в левом верхнем углу.
+type Symbol struct {
+	Width, Height float64
+	Bars          []Rect
+	Texts         []Text
+}
+
+// bars склеивает подряд идущие тёмные модули в полосы от x0 на высоте y..y+h.
+func bars(modules []bool, x0, y, h float64) []Rect {
+	var out []Rect
+	for i := 0; i < len(modules); {
+		if !modules[i] {
+			i++
+			continue
+		}
+		j := i
+		for j < len(modules) && modules[j] {
+			j++
+		}
+		out = append(out, Rect{X: x0 + float64(i), Y: y, W: float64(j - i), H: h})
+		i = j
+	}
+	return out
+}
@@ -0,0 +1,184 @@
+package barcode
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/boombuler/barcode/ean"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+)
+
+const testISBN = "978-5-17-118366-0"
+
+// modulesAt восстанавливает модули штрихкода из полос в строке y.
+func modulesAt(s Symbol, x0, n int, y float64) []bool {
+	out := make([]bool, n)
+	for _, r := range s.Bars {
+		if r.Y > y || r.Y+r.H <= y {
+			continue
+		}
+		for x := int(r.X); x < int(r.X+r.W); x++ {
+			if x-x0 >= 0 && x-x0 < n {
+				out[x-x0] = true
+			}
+		}
+	}
+	return out
+}
+
+func TestBookland_MatchesReferenceEncoder(t *testing.T) {
+	for _, isbn := range []string{testISBN, "0-306-40615-2", "979-10-90636-07-1"} {
+		code, err := models.ISBN13(isbn)
+		require.NoError(t, err)
+		ref, err := ean.Encode(code)
+		require.NoError(t, err)
+
+		s, err := Bookland(isbn, "")
+		require.NoError(t, err)
+		got := modulesAt(s, quietLeft, symbolWidth, barTop+1)
+		require.Equal(t, symbolWidth, ref.Bounds().Dx())
+		for x := 0; x < symbolWidth; x++ {
+			assert.Equal(t, ref.At(x, 0) == color.Black, got[x], "%s module %d", isbn, x)
+		}
+		assert.Equal(t, "ISBN "+code, s.Texts[0].Value)
+		assert.Equal(t, float64(quietLeft+symbolWidth+quietRight), s.Width)
+	}
+}
+
+func TestBookland_Guards(t *testing.T) {
+	s, err := Bookland(testISBN, "")
+	require.NoError(t, err)
+	var long []float64
+	for _, r := range s.Bars {
+		if r.H > barHeight {
+			long = append(long, r.X-quietLeft)
+		}
+	}
+	assert.Equal(t, []float64{0, 2, 46, 48, 92, 94}, long)
+}
+
+func TestBookland_AddOn(t *testing.T) {
+	s, err := Bookland(testISBN, "52495")
+	require.NoError(t, err)
+	x0 := quietLeft + symbolWidth + addOnGap
+	assert.Equal(t, float64(x0+addOnWidth+addOnQuiet), s.Width)
+
+	modules := modulesAt(s, x0, addOnWidth, addOnTop+1)
+	assert.Equal(t, []bool{true, false, true, true}, modules[:4], "start guard")
+	// 3*(5+4+5) + 9*(2+9) = 141, контрольная 1 задаёт чётность GLGLL
+	var parity strings.Builder
+	for i := 0; i < 5; i++ {
+		start := 4 + 9*i
+		digit := patternOf(modules[start : start+7])
+		switch digit {
+		case eanL[int("52495"[i]-'0')]:
+			parity.WriteByte('L')
+		case eanG[int("52495"[i]-'0')]:
+			parity.WriteByte('G')
+		default:
+			t.Fatalf("digit %d encoded as %s", i, digit)
+		}
+		if i < 4 {
+			assert.Equal(t, "01", patternOf(modules[start+7:start+9]), "separator")
+		}
+	}
+	assert.Equal(t, "GLGLL", parity.String())
+	assert.Equal(t, "5", s.Texts[len(s.Texts)-5].Value)
+}
+
+func patternOf(modules []bool) string {
+	var b strings.Builder
+	for _, m := range modules {
+		if m {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	return b.String()
+}
+
+func TestBookland_Rejects(t *testing.T) {
+	_, err := Bookland("978-5-17-118366-1", "")
+	assert.ErrorIs(t, err, models.ErrInvalidISBN)
+	_, err = Bookland("4006381333931", "")
+	assert.ErrorIs(t, err, ErrNotBookland)
+	_, err = Bookland(testISBN, "5249")
+	assert.ErrorIs(t, err, ErrInvalidAddOn)
+}
+
+func TestPriceAddOn(t *testing.T) {
+	assert.Equal(t, "51995", PriceAddOn(1995, 2, "5"))
+	assert.Equal(t, "50700", PriceAddOn(7, 0, "5"))
+	assert.Equal(t, "61250", PriceAddOn(12499, 3, "6"), "rounded to cents")
+	assert.Equal(t, "59999", PriceAddOn(125000, 2, "5"), "100 and above")
+	assert.Equal(t, NoPriceAddOn, PriceAddOn(1995, 2, ""))
+	assert.Equal(t, NoPriceAddOn, PriceAddOn(0, 2, "5"))
+}
+
+func TestQR(t *testing.T) {
+	s, err := QR("https://shop.example/books/1")
+	require.NoError(t, err)
+	size := int(s.Width) - 2*qrQuiet
+	assert.Equal(t, s.Width, s.Height)
+	assert.Zero(t, (size-21)%4, "QR side is 21+4k modules")
+
+	// искатель в левом верхнем углу: сплошная строка из 7 модулей, затем светлый модуль
+	top := modulesAt(s, qrQuiet, 8, qrQuiet)
+	assert.Equal(t, []bool{true, true, true, true, true, true, true, false}, top)
+	inner := modulesAt(s, qrQuiet, 7, qrQuiet+1)
+	assert.Equal(t, []bool{true, false, false, false, false, false, true}, inner)
+}
+
+func TestWritePNG(t *testing.T) {
+	s, err := Bookland(testISBN, "51995")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, WritePNG(&buf, s, 3))
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, int(s.Width)*3, int(s.Height)*3), img.Bounds())
+	gray := func(x, y int) uint8 { return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y }
+	assert.Equal(t, uint8(0), gray(quietLeft*3+1, (barTop+10)*3), "start guard")
+	assert.Equal(t, uint8(0xFF), gray(quietLeft*3+4, (barTop+10)*3), "guard gap")
+	assert.Equal(t, uint8(0xFF), gray(2, 2), "quiet zone")
+
+	var dark int
+	for y := (digitsBase - 5) * 3; y < digitsBase*3; y++ {
+		if gray(quietLeft*3+4*3*7/2, y) < 0x80 {
+			dark++
+		}
+	}
+	assert.Positive(t, dark, "digits are drawn under the bars")
+}
+
+func TestWriteSVG(t *testing.T) {
+	s, err := Bookland(testISBN, "")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, WriteSVG(&buf, s, 2))
+
+	var doc struct {
+		Width  string `xml:"width,attr"`
+		Height string `xml:"height,attr"`
+		Path   struct {
+			D string `xml:"d,attr"`
+		} `xml:"path"`
+		Texts []string `xml:"text"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "226", doc.Width)
+	assert.Equal(t, "140", doc.Height)
+	assert.Equal(t, len(s.Bars), strings.Count(doc.Path.D, "M"))
+	assert.Equal(t, "ISBN 9785171183660", doc.Texts[0])
+	assert.Len(t, doc.Texts, 14)
+}
@@ -0,0 +1,177 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"book-store-api/internal/models"
+)
+
+// ErrNotBookland — ISBN-13 не из префиксов 978/979, книжный EAN из него не строится.
+var ErrNotBookland = errors.New("isbn is not a bookland ean")
+
+var ErrInvalidAddOn = errors.New("add-on must be 5 digits")
+
+// NoPriceAddOn — добавочный код «цена не указана».
+const NoPriceAddOn = "90000"
+
+// Кодовые наборы EAN: L — нечётная чётность, G — чётная, R — правая половина.
+var (
+	eanL = [10]string{"0001101", "0011001", "0010011", "0111101", "0100011", "0110001", "0101111", "0111011", "0110111", "0001011"}
+	eanG = [10]string{"0100111", "0110011", "0011011", "0100001", "0011101", "0111001", "0000101", "0010001", "0001001", "0010111"}
+	eanR = [10]string{"1110010", "1100110", "1101100", "1000010", "1011100", "1001110", "1010000", "1000100", "1001000", "1110100"}
+
+	// первая цифра EAN-13 не кодируется штрихами, а задаёт чётность левых шести
+	ean13Parity = [10]string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG", "LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+	// чётность пяти цифр добавочного кода задаёт его контрольная сумма
+	ean5Parity = [10]string{"GGLLL", "GLGLL", "GLLGL", "GLLLG", "LGGLL", "LLGGL", "LLLGG", "LGLGL", "LGLLG", "LLGLG"}
+)
+
+// Геометрия в модулях. Высота штрихов ниже номинальных 69 модулей —
+// такие укороченные коды обычны для этикеток и читаются сканерами.
+const (
+	quietLeft   = 11
+	quietRight  = 7
+	addOnGap    = 9
+	addOnQuiet  = 5
+	textSize    = 8
+	barTop      = 11
+	barHeight   = 50
+	guardExtra  = 5
+	digitsBase  = barTop + barHeight + 7
+	addOnTop    = barTop + 9
+	addOnBase   = barTop + 7
+	symbolWidth = 95
+	addOnWidth  = 47
+)
+
+// Bookland строит EAN-13 книги из ISBN (ISBN-10 переводится в 978) с подписью
+// «ISBN …» над штрихами и добавочным EAN-5 справа; пустой addOn — без него.
+func Bookland(isbn, addOn string) (Symbol, error) {
+	code, err := models.ISBN13(isbn)
+	if err != nil {
+		return Symbol{}, err
+	}
+	if !strings.HasPrefix(code, "978") && !strings.HasPrefix(code, "979") {
+		return Symbol{}, ErrNotBookland
+	}
+	if addOn != "" && !isDigits(addOn, 5) {
+		return Symbol{}, ErrInvalidAddOn
+	}
+
+	s := Symbol{Width: quietLeft + symbolWidth + quietRight, Height: digitsBase + 2}
+	modules := ean13Modules(code)
+	for _, r := range bars(modules, quietLeft, barTop, barHeight) {
+		// крайние и центральный ограничители длиннее остальных штрихов
+		if guardModule(int(r.X) - quietLeft) {
+			r.H += guardExtra
+		}
+		s.Bars = append(s.Bars, r)
+	}
+	s.Texts = append(s.Texts,
+		Text{X: quietLeft + symbolWidth/2.0, Y: barTop - 3, Size: textSize, Value: "ISBN " + code},
+		Text{X: quietLeft - 4, Y: digitsBase, Size: textSize, Value: code[:1]},
+	)
+	for i := 0; i < 6; i++ {
+		s.Texts = append(s.Texts,
+			Text{X: quietLeft + 3 + 7*float64(i) + 3.5, Y: digitsBase, Size: textSize, Value: code[1+i : 2+i]},
+			Text{X: quietLeft + 50 + 7*float64(i) + 3.5, Y: digitsBase, Size: textSize, Value: code[7+i : 8+i]},
+		)
+	}
+
+	if addOn == "" {
+		return s, nil
+	}
+	x0 := float64(quietLeft + symbolWidth + addOnGap)
+	s.Width = x0 + addOnWidth + addOnQuiet
+	s.Bars = append(s.Bars, bars(ean5Modules(addOn), x0, addOnTop, barTop+barHeight+guardExtra-addOnTop)...)
+	for i := 0; i < 5; i++ {
+		s.Texts = append(s.Texts, Text{X: x0 + 4 + 9*float64(i) + 3.5, Y: addOnBase, Size: textSize, Value: addOn[i : i+1]})
+	}
+	return s, nil
+}
+
+// PriceAddOn — добавочный код цены: цифра валюты (5 — доллар США, 6 — канадский доллар и т. д.)
+// и четыре цифры цены с двумя знаками после запятой; цена от 100 единиц кодируется как 9999.
+// Пустая валюта или неположительная цена дают 90000.
+func PriceAddOn(price, decimals int, currency string) string {
+	if currency == "" || price <= 0 {
+		return NoPriceAddOn
+	}
+	for ; decimals > 2; decimals-- {
+		price = (price + 5) / 10
+	}
+	for ; decimals < 2; decimals++ {
+		price *= 10
+	}
+	return fmt.Sprintf("%s%04d", currency, min(price, 9999))
+}
+
+func ean13Modules(code string) []bool {
+	var b strings.Builder
+	b.WriteString("101")
+	parity := ean13Parity[code[0]-'0']
+	for i := 1; i <= 6; i++ {
+		b.WriteString(encodeDigit(code[i], parity[i-1]))
+	}
+	b.WriteString("01010")
+	for i := 7; i <= 12; i++ {
+		b.WriteString(eanR[code[i]-'0'])
+	}
+	b.WriteString("101")
+	return modulesOf(b.String())
+}
+
+func ean5Modules(code string) []bool {
+	sum := 0
+	for i := 0; i < 5; i++ {
+		w := 3
+		if i%2 == 1 {
+			w = 9
+		}
+		sum += w * int(code[i]-'0')
+	}
+	parity := ean5Parity[sum%10]
+
+	var b strings.Builder
+	b.WriteString("1011")
+	for i := 0; i < 5; i++ {
+		if i > 0 {
+			b.WriteString("01")
+		}
+		b.WriteString(encodeDigit(code[i], parity[i]))
+	}
+	return modulesOf(b.String())
+}
+
+func encodeDigit(d, parity byte) string {
+	if parity == 'G' {
+		return eanG[d-'0']
+	}
+	return eanL[d-'0']
+}
+
+func guardModule(i int) bool {
+	return i < 3 || (i >= 45 && i < 50) || i >= 92
+}
+
+func modulesOf(pattern string) []bool {
+	out := make([]bool, len(pattern))
+	for i := range pattern {
+		out[i] = pattern[i] == '1'
+	}
+	return out
+}
+
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
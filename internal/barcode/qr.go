@@ -0,0 +1,28 @@
+package barcode
+
+import (
+	"image/color"
+
+	"github.com/boombuler/barcode/qr"
+)
+
+// qrQuiet — поле тишины QR-кода в модулях по ISO/IEC 18004.
+const qrQuiet = 4
+
+// QR кодирует content с уровнем коррекции M: ссылка остаётся читаемой на потёртой этикетке.
+func QR(content string) (Symbol, error) {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return Symbol{}, err
+	}
+	size := code.Bounds().Dx()
+	s := Symbol{Width: float64(size + 2*qrQuiet), Height: float64(size + 2*qrQuiet)}
+	row := make([]bool, size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			row[x] = code.At(x, y) == color.Black
+		}
+		s.Bars = append(s.Bars, bars(row, qrQuiet, float64(y+qrQuiet), 1)...)
+	}
+	return s, nil
+}
@@ -0,0 +1,104 @@
+package barcode
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// MaxScale ограничивает размер модуля: крупнее штрихкод не нужен даже для печати.
+const MaxScale = 20
+
+var (
+	monoOnce sync.Once
+	monoFont *opentype.Font
+	monoErr  error
+)
+
+// WritePNG рисует штрихкод в оттенках серого, scale пикселей на модуль.
+// Края штрихов выравниваются по пикселям, чтобы не было полутонов.
+func WritePNG(w io.Writer, s Symbol, scale int) error {
+	k := float64(scale)
+	img := image.NewGray(image.Rect(0, 0, int(math.Ceil(s.Width*k)), int(math.Ceil(s.Height*k))))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	for _, r := range s.Bars {
+		rect := image.Rect(int(math.Round(r.X*k)), int(math.Round(r.Y*k)), int(math.Round((r.X+r.W)*k)), int(math.Round((r.Y+r.H)*k)))
+		draw.Draw(img, rect, image.Black, image.Point{}, draw.Src)
+	}
+	if len(s.Texts) > 0 {
+		if err := drawTexts(img, s.Texts, k); err != nil {
+			return err
+		}
+	}
+	return png.Encode(w, img)
+}
+
+func drawTexts(img draw.Image, texts []Text, k float64) error {
+	monoOnce.Do(func() { monoFont, monoErr = opentype.Parse(gomono.TTF) })
+	if monoErr != nil {
+		return monoErr
+	}
+	faces := map[float64]font.Face{}
+	defer func() {
+		for _, f := range faces {
+			f.Close()
+		}
+	}()
+	for _, t := range texts {
+		face, ok := faces[t.Size]
+		if !ok {
+			var err error
+			face, err = opentype.NewFace(monoFont, &opentype.FaceOptions{Size: t.Size * k, DPI: 72, Hinting: font.HintingFull})
+			if err != nil {
+				return err
+			}
+			faces[t.Size] = face
+		}
+		d := font.Drawer{Dst: img, Src: image.NewUniform(color.Black), Face: face}
+		width := d.MeasureString(t.Value)
+		d.Dot = fixed.Point26_6{
+			X: fixed.Int26_6(t.X*k*64) - width/2,
+			Y: fixed.Int26_6(t.Y * k * 64),
+		}
+		d.DrawString(t.Value)
+	}
+	return nil
+}
+
+// WriteSVG пишет штрихкод в координатах модулей; scale задаёт размер в пикселях по умолчанию.
+func WriteSVG(w io.Writer, s Symbol, scale int) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" shape-rendering="crispEdges">`,
+		num(s.Width*float64(scale)), num(s.Height*float64(scale)), num(s.Width), num(s.Height))
+	fmt.Fprintf(bw, `<rect width="%s" height="%s" fill="#fff"/>`, num(s.Width), num(s.Height))
+	bw.WriteString(`<path fill="#000" d="`)
+	for _, r := range s.Bars {
+		fmt.Fprintf(bw, "M%s %sh%sv%sh-%sz", num(r.X), num(r.Y), num(r.W), num(r.H), num(r.W))
+	}
+	bw.WriteString(`"/>`)
+	for _, t := range s.Texts {
+		fmt.Fprintf(bw, `<text x="%s" y="%s" font-size="%s" font-family="OCR-B, 'Go Mono', monospace" text-anchor="middle">`,
+			num(t.X), num(t.Y), num(t.Size))
+		xml.EscapeText(bw, []byte(t.Value))
+		bw.WriteString("</text>")
+	}
+	bw.WriteString("</svg>")
+	return bw.Flush()
+}
+
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"book-store-api/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const duplicateScanKey = "dedup:scan"
+
+// DuplicateStore хранит последний проход поиска дубликатов, общий для всех экземпляров.
+type DuplicateStore struct {
+	client *redis.Client
+}
+
+func NewDuplicateStore(c *Cache) *DuplicateStore {
+	return &DuplicateStore{client: c.client}
+}
+
+func (s *DuplicateStore) Save(ctx context.Context, scan models.DuplicateScan) error {
+	data, err := json.Marshal(scan)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, duplicateScanKey, data, 0).Err()
+}
+
+// Latest возвращает nil, если прохода ещё не было.
+func (s *DuplicateStore) Latest(ctx context.Context) (*models.DuplicateScan, error) {
+	data, err := s.client.Get(ctx, duplicateScanKey).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var scan models.DuplicateScan
+	if err := json.Unmarshal(data, &scan); err != nil {
+		return nil, err
+	}
+	return &scan, nil
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const loginStatePrefix = "oidc:state:"
+
+// LoginStateStore хранит состояние OIDC-логина в Redis, чтобы callback мог прийти на любой инстанс.
+type LoginStateStore struct {
+	client *redis.Client
+}
+
+func NewLoginStateStore(c *Cache) *LoginStateStore {
+	return &LoginStateStore{client: c.client}
+}
+
+func (s *LoginStateStore) Save(ctx context.Context, state string, ls models.StaffLoginState, ttl time.Duration) error {
+	data, err := json.Marshal(ls)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, loginStatePrefix+state, data, ttl).Err()
+}
+
+func (s *LoginStateStore) Take(ctx context.Context, state string) (models.StaffLoginState, error) {
+	data, err := s.client.GetDel(ctx, loginStatePrefix+state).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return models.StaffLoginState{}, usecase.ErrInvalidLoginState
+		}
+		return models.StaffLoginState{}, err
+	}
+
+	var ls models.StaffLoginState
+	if err := json.Unmarshal(data, &ls); err != nil {
+		return models.StaffLoginState{}, err
+	}
+	return ls, nil
+}
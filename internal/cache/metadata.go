@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"book-store-api/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const metadataPrefix = "enrich:isbn:"
+
+// MetadataStore кэширует ответы библиографического источника по ISBN-13.
+type MetadataStore struct {
+	client *redis.Client
+}
+
+func NewMetadataStore(c *Cache) *MetadataStore {
+	return &MetadataStore{client: c.client}
+}
+
+// Get возвращает nil, если ответа в кэше нет.
+func (s *MetadataStore) Get(ctx context.Context, isbn string) (*models.MetadataLookup, error) {
+	data, err := s.client.Get(ctx, metadataPrefix+isbn).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var lookup models.MetadataLookup
+	if err := json.Unmarshal(data, &lookup); err != nil {
+		return nil, err
+	}
+	return &lookup, nil
+}
+
+func (s *MetadataStore) Set(ctx context.Context, isbn string, lookup models.MetadataLookup, ttl time.Duration) error {
+	data, err := json.Marshal(lookup)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, metadataPrefix+isbn, data, ttl).Err()
+}
@@ -25,6 +25,11 @@ func NewCache(cfg config.RedisConfig) *Cache {
 	return &Cache{client: rdb, ttl: time.Duration(cfg.TTL) * time.Second}
 }
 
+// Client отдаёт общий клиент Redis для компонентов, которым нужны не только get/set.
+func (c *Cache) Client() *redis.Client {
+	return c.client
+}
+
 func (c *Cache) Set(ctx context.Context, key string, value interface{}) error {
 	data, err := json.Marshal(value)
 	if err != nil {
@@ -53,3 +58,22 @@ func (c *Cache) Get(ctx context.Context, key string) (interface{}, error) {
 func (c *Cache) Delete(ctx context.Context, key string) error {
 	return c.client.Del(ctx, key).Err()
 }
+
+// SetMany пишет значения одним pipeline вместо запроса на ключ.
+func (c *Cache) SetMany(ctx context.Context, values map[string]any) error {
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			pipe.Set(ctx, key, data, c.ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+func (c *Cache) DeleteMany(ctx context.Context, keys []string) error {
+	return c.client.Del(ctx, keys...).Err()
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"book-store-api/internal/auth"
+
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 )
@@ -12,11 +14,31 @@ import (
 var ErrCfgInvalid = errors.New("invalid configuration")
 
 type Config struct {
-	Env   string `env:"APP_ENV"`
-	DB    DBConfig
-	HTTP  HTTPConfig
-	Cache CacheConfig
-	Redis RedisConfig
+	Env     string `env:"APP_ENV"`
+	DB      DBConfig
+	HTTP    HTTPConfig
+	GRPC    GRPCConfig
+	GraphQL GraphQLConfig
+	Cache   CacheConfig
+	Redis   RedisConfig
+	Tax     TaxConfig
+	Auth    AuthConfig
+	Account AccountConfig
+	Mailer  MailerConfig
+	APIKey  APIKeyConfig
+	OIDC    OIDCConfig
+	Limits  RateLimitConfig
+	Outbox  OutboxConfig
+	Webhook WebhookConfig
+	Feed    FeedConfig
+	Import  ImportConfig
+	ONIX    ONIXConfig
+	MARC    MARCConfig
+	Cover   CoverConfig
+	Blob    BlobConfig
+	Label   LabelConfig
+	Enrich  EnrichmentConfig
+	Dedup   DedupConfig
 }
 
 type DBConfig struct {
@@ -38,6 +60,21 @@ type HTTPConfig struct {
 	ReadTimeout  int    `env:"HTTP_READ_TIMEOUT" env-default:"5"`
 	WriteTimeout int    `env:"HTTP_WRITE_TIMEOUT" env-default:"10"`
 	IdleTimeout  int    `env:"HTTP_IDLE_TIMEOUT" env-default:"120"`
+	TrustProxy   bool   `env:"HTTP_TRUST_PROXY" env-default:"false"`
+}
+
+type GRPCConfig struct {
+	Enabled    bool   `env:"GRPC_ENABLED" env-default:"true"`
+	Host       string `env:"GRPC_HOST"`
+	Port       string `env:"GRPC_PORT" env-default:"9090"`
+	Reflection bool   `env:"GRPC_REFLECTION" env-default:"true"`
+}
+
+// GraphQLConfig: глубина по умолчанию пропускает стандартный запрос интроспекции.
+type GraphQLConfig struct {
+	Enabled       bool `env:"GRAPHQL_ENABLED" env-default:"true"`
+	MaxDepth      int  `env:"GRAPHQL_MAX_DEPTH" env-default:"12"`
+	MaxComplexity int  `env:"GRAPHQL_MAX_COMPLEXITY" env-default:"1000"`
 }
 
 type CacheConfig struct {
@@ -51,6 +88,161 @@ type RedisConfig struct {
 	TTL      int    `env:"REDIS_TTL"`
 }
 
+type TaxConfig struct {
+	PricesIncludeTax bool `env:"TAX_PRICES_INCLUDE_TAX" env-default:"true"`
+}
+
+// AuthConfig.JWKSFile — ключи внешнего издателя AUTH_JWKS_ISSUER. Роли его токенам
+// назначаются только по группам через OIDC_GROUPS_CLAIM и OIDC_ROLE_MAPPING.
+type AuthConfig struct {
+	JWTSecret  string `env:"AUTH_JWT_SECRET"`
+	Issuer     string `env:"AUTH_JWT_ISSUER" env-default:"book-store-api"`
+	TokenTTL   int    `env:"AUTH_TOKEN_TTL" env-default:"60"`
+	JWKSFile   string `env:"AUTH_JWKS_FILE"`
+	JWKSIssuer string `env:"AUTH_JWKS_ISSUER"`
+	Audience   string `env:"AUTH_JWT_AUDIENCE"`
+}
+
+type AccountConfig struct {
+	PublicURL  string `env:"ACCOUNT_PUBLIC_URL" env-default:"http://localhost:8080"`
+	BcryptCost int    `env:"ACCOUNT_BCRYPT_COST" env-default:"12"`
+}
+
+type APIKeyConfig struct {
+	RotationGrace int `env:"API_KEY_ROTATION_GRACE" env-default:"1440"`
+}
+
+// OIDCConfig включает вход сотрудников через IdP, если задан OIDC_ISSUER_URL.
+// OIDC_ROLE_MAPPING задаётся как group:role через запятую.
+type OIDCConfig struct {
+	IssuerURL    string            `env:"OIDC_ISSUER_URL"`
+	ClientID     string            `env:"OIDC_CLIENT_ID"`
+	ClientSecret string            `env:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string            `env:"OIDC_REDIRECT_URL"`
+	Scopes       []string          `env:"OIDC_SCOPES" env-separator:"," env-default:"openid,profile,email"`
+	GroupsClaim  string            `env:"OIDC_GROUPS_CLAIM" env-default:"groups"`
+	RoleMapping  map[string]string `env:"OIDC_ROLE_MAPPING" env-separator:","`
+}
+
+func (oc OIDCConfig) Enabled() bool {
+	return oc.IssuerURL != ""
+}
+
+// RateLimitConfig.Rules — правила вида "<METHOD /path|*> <ip|user|api_key>=<N>/<окно>" через ";".
+// PreAuth — общий лимит на IP до аутентификации; пустое значение его отключает.
+type RateLimitConfig struct {
+	Enabled bool   `env:"RATE_LIMIT_ENABLED" env-default:"true"`
+	Rules   string `env:"RATE_LIMIT_RULES" env-default:"* ip=120/1m; * user=600/1m; * api_key=3000/1m; GET /book ip=30/1m"`
+	PreAuth string `env:"RATE_LIMIT_PREAUTH" env-default:"3000/1m"`
+}
+
+type OutboxConfig struct {
+	PollInterval int `env:"OUTBOX_POLL_INTERVAL_MS" env-default:"1000"`
+	BatchSize    int `env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+	MaxAttempts  int `env:"OUTBOX_MAX_ATTEMPTS" env-default:"10"`
+	RetryBase    int `env:"OUTBOX_RETRY_BASE" env-default:"1"`
+	RetryMax     int `env:"OUTBOX_RETRY_MAX" env-default:"300"`
+}
+
+// WebhookConfig: таймаут запроса к получателю в секундах, RetryBase и RetryMax тоже в секундах.
+type WebhookConfig struct {
+	Enabled      bool `env:"WEBHOOK_ENABLED" env-default:"true"`
+	PollInterval int  `env:"WEBHOOK_POLL_INTERVAL_MS" env-default:"1000"`
+	BatchSize    int  `env:"WEBHOOK_BATCH_SIZE" env-default:"50"`
+	Concurrency  int  `env:"WEBHOOK_CONCURRENCY" env-default:"8"`
+	Timeout      int  `env:"WEBHOOK_TIMEOUT" env-default:"10"`
+	MaxAttempts  int  `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+	RetryBase    int  `env:"WEBHOOK_RETRY_BASE" env-default:"30"`
+	RetryMax     int  `env:"WEBHOOK_RETRY_MAX" env-default:"21600"`
+	DisableAfter int  `env:"WEBHOOK_DISABLE_AFTER" env-default:"50"`
+}
+
+// FeedConfig: Retention — сколько последних событий хранится для Last-Event-ID,
+// Heartbeat в секундах, ClientBuffer — сколько событий ждёт медленного клиента до отключения.
+type FeedConfig struct {
+	Enabled      bool  `env:"FEED_ENABLED" env-default:"true"`
+	Retention    int64 `env:"FEED_RETENTION" env-default:"10000"`
+	Heartbeat    int   `env:"FEED_HEARTBEAT" env-default:"15"`
+	ClientBuffer int   `env:"FEED_CLIENT_BUFFER" env-default:"256"`
+}
+
+// ImportConfig: Lease в секундах — через сколько задачу упавшего воркера подхватит другой,
+// MaxFileSize в мегабайтах.
+type ImportConfig struct {
+	Enabled      bool  `env:"IMPORT_ENABLED" env-default:"true"`
+	PollInterval int   `env:"IMPORT_POLL_INTERVAL_MS" env-default:"2000"`
+	ChunkSize    int   `env:"IMPORT_CHUNK_SIZE" env-default:"200"`
+	Lease        int   `env:"IMPORT_LEASE" env-default:"300"`
+	MaxFileSize  int64 `env:"IMPORT_MAX_FILE_SIZE" env-default:"20"`
+}
+
+// ONIXConfig: PriceDecimals — сколько последних разрядов цены книги являются дробной частью
+// (2 — цены хранятся в копейках). Учёт налога в цене берётся из TAX_PRICES_INCLUDE_TAX.
+type ONIXConfig struct {
+	SenderName    string `env:"ONIX_SENDER_NAME" env-default:"Book Store"`
+	RecordPrefix  string `env:"ONIX_RECORD_PREFIX"`
+	Currency      string `env:"ONIX_CURRENCY" env-default:"RUB"`
+	PriceDecimals int    `env:"ONIX_PRICE_DECIMALS" env-default:"2"`
+}
+
+// MARCConfig: валюта и разрядность цены в 365 берутся из ONIX_CURRENCY и ONIX_PRICE_DECIMALS.
+type MARCConfig struct {
+	OrgCode string `env:"MARC_ORG_CODE"`
+}
+
+// CoverConfig: MaxFileSize в мегабайтах.
+type CoverConfig struct {
+	MaxFileSize int64 `env:"COVER_MAX_FILE_SIZE" env-default:"10"`
+}
+
+// BlobConfig — хранилище миниатюр обложек: каталог BLOB_FS_DIR (fs) или бакет
+// S3-совместимого сервиса (s3), адресуемый путём {endpoint}/{bucket}.
+type BlobConfig struct {
+	Driver      string `env:"BLOB_DRIVER" env-default:"fs"`
+	Dir         string `env:"BLOB_FS_DIR" env-default:"blobs"`
+	S3Endpoint  string `env:"BLOB_S3_ENDPOINT"`
+	S3Region    string `env:"BLOB_S3_REGION" env-default:"us-east-1"`
+	S3Bucket    string `env:"BLOB_S3_BUCKET"`
+	S3AccessKey string `env:"BLOB_S3_ACCESS_KEY"`
+	S3SecretKey string `env:"BLOB_S3_SECRET_KEY"`
+}
+
+// LabelConfig: StorefrontURL — шаблон ссылки QR-кода с подстановками {id} и {isbn},
+// PriceAddOnCurrency — цифра валюты в добавочном коде цены EAN-5 (5 — USD), пусто — 90000.
+type LabelConfig struct {
+	StorefrontURL      string `env:"LABEL_STOREFRONT_URL" env-default:"http://localhost:3000/books/{id}"`
+	PriceAddOnCurrency string `env:"LABEL_PRICE_ADDON_CURRENCY"`
+}
+
+// EnrichmentConfig — источник метаданных с API томов Google Books по адресу BaseURL.
+// Timeout в секундах, CacheTTL и MissTTL (для ответов «не найдено») в минутах.
+type EnrichmentConfig struct {
+	Enabled  bool   `env:"ENRICH_ENABLED" env-default:"true"`
+	BaseURL  string `env:"ENRICH_BASE_URL" env-default:"https://www.googleapis.com/books/v1"`
+	APIKey   string `env:"ENRICH_API_KEY"`
+	Timeout  int    `env:"ENRICH_TIMEOUT" env-default:"10"`
+	CacheTTL int    `env:"ENRICH_CACHE_TTL" env-default:"1440"`
+	MissTTL  int    `env:"ENRICH_MISS_TTL" env-default:"60"`
+}
+
+// DedupConfig: Interval между проходами поиска дубликатов в минутах. MinScore — порог оценки
+// пары, больше 0.4: столько даёт одно совпадение автора, а такие пары поиск не перебирает.
+type DedupConfig struct {
+	Enabled  bool    `env:"DEDUP_ENABLED" env-default:"true"`
+	Interval int     `env:"DEDUP_INTERVAL" env-default:"60"`
+	MinScore float64 `env:"DEDUP_MIN_SCORE" env-default:"0.6"`
+}
+
+type MailerConfig struct {
+	Driver       string `env:"MAILER_DRIVER" env-default:"file"`
+	Dir          string `env:"MAILER_DIR" env-default:"mail"`
+	From         string `env:"MAILER_FROM" env-default:"no-reply@book-store.local"`
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     int    `env:"SMTP_PORT" env-default:"25"`
+	SMTPUser     string `env:"SMTP_USER"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+}
+
 func (dc *DBConfig) DSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -76,6 +268,47 @@ func mapStructs() (*Config, error) {
 	if err := cleanenv.ReadEnv(&cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
+
+func (c *Config) validate() error {
+	if c.Auth.JWTSecret == "" {
+		return fmt.Errorf("AUTH_JWT_SECRET is required: %w", ErrCfgInvalid)
+	}
+	switch c.Mailer.Driver {
+	case "file", "smtp":
+	default:
+		return fmt.Errorf("%s is invalid mailer driver %w", c.Mailer.Driver, ErrCfgInvalid)
+	}
+	switch c.Blob.Driver {
+	case "fs":
+	case "s3":
+		if c.Blob.S3Endpoint == "" || c.Blob.S3Bucket == "" {
+			return fmt.Errorf("BLOB_S3_ENDPOINT and BLOB_S3_BUCKET are required: %w", ErrCfgInvalid)
+		}
+	default:
+		return fmt.Errorf("%s is invalid blob driver %w", c.Blob.Driver, ErrCfgInvalid)
+	}
+	if cur := c.Label.PriceAddOnCurrency; cur != "" && (len(cur) != 1 || cur[0] < '0' || cur[0] > '9') {
+		return fmt.Errorf("LABEL_PRICE_ADDON_CURRENCY must be a single digit: %w", ErrCfgInvalid)
+	}
+	if c.Dedup.MinScore <= 0.4 || c.Dedup.MinScore > 1 {
+		return fmt.Errorf("DEDUP_MIN_SCORE must be above 0.4 and at most 1: %w", ErrCfgInvalid)
+	}
+	if c.Auth.JWKSFile != "" && (c.Auth.JWKSIssuer == "" || c.Auth.JWKSIssuer == c.Auth.Issuer) {
+		return fmt.Errorf("AUTH_JWKS_ISSUER is required and must differ from AUTH_JWT_ISSUER: %w", ErrCfgInvalid)
+	}
+	if c.OIDC.Enabled() && (c.OIDC.ClientID == "" || c.OIDC.RedirectURL == "") {
+		return fmt.Errorf("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required: %w", ErrCfgInvalid)
+	}
+	for group, role := range c.OIDC.RoleMapping {
+		if role != auth.RoleAdmin && role != auth.RoleCatalogEditor {
+			return fmt.Errorf("group %q mapped to non-staff role %q: %w", group, role, ErrCfgInvalid)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+)
+
+func ToAPIKeyParams(req dto.CreateAPIKeyRequest) models.APIKeyParams {
+	return models.APIKeyParams{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+}
+
+func ToAPIKeyResponse(k models.APIKey) dto.APIKeyDTO {
+	return dto.APIKeyDTO{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+func ToAPIKeyResponseList(keys []models.APIKey) []dto.APIKeyDTO {
+	resp := make([]dto.APIKeyDTO, 0, len(keys))
+	for _, k := range keys {
+		resp = append(resp, ToAPIKeyResponse(k))
+	}
+	return resp
+}
+
+func ToIssuedAPIKeyResponse(k models.IssuedAPIKey) dto.IssuedAPIKeyDTO {
+	return dto.IssuedAPIKeyDTO{
+		APIKeyDTO: ToAPIKeyResponse(k.Key),
+		Key:       k.Secret,
+	}
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+)
+
+func ToAuditEventListResponse(events []models.AuditEvent, limit int) dto.AuditEventListDTO {
+	resp := dto.AuditEventListDTO{Events: make([]dto.AuditEventDTO, 0, len(events))}
+	for _, e := range events {
+		resp.Events = append(resp.Events, dto.AuditEventDTO{
+			ID:         e.ID,
+			Actor:      e.Actor,
+			ActorType:  e.ActorType,
+			RequestID:  e.RequestID,
+			IP:         e.IP,
+			Entity:     e.Entity,
+			EntityID:   e.EntityID,
+			Action:     e.Action,
+			Before:     e.Before,
+			After:      e.After,
+			OccurredAt: e.OccurredAt,
+			PrevHash:   e.PrevHash,
+			Hash:       e.Hash,
+		})
+	}
+	if limit > 0 && len(events) == limit {
+		resp.NextCursor = events[len(events)-1].ID
+	}
+	return resp
+}
+
+func ToAuditVerificationResponse(v models.AuditVerification) dto.AuditVerificationDTO {
+	return dto.AuditVerificationDTO{
+		Valid:    v.Valid,
+		Checked:  v.Checked,
+		BrokenAt: v.BrokenAt,
+	}
+}
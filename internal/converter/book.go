@@ -15,6 +15,7 @@ func ToBookResponse(b models.Book) dto.BookDTO {
 		Price:       b.Price,
 		CreatedAt:   b.CreatedAt,
 		UpdatedAt:   b.UpdatedAt,
+		Cover:       ToCoverImages(b.ID, b.Cover),
 	}
 }
 
@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"errors"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func ToBookBatchOps(req dto.BookBatchRequest) (models.BookBatchMode, []models.BookBatchOp) {
+	ops := make([]models.BookBatchOp, 0, len(req.Operations))
+	for _, o := range req.Operations {
+		op := models.BookBatchOp{Op: models.BookBatchOpType(o.Op), ID: o.ID}
+		if o.Book != nil {
+			op.Book = ToBookParams(*o.Book)
+		}
+		ops = append(ops, op)
+	}
+	return models.BookBatchMode(req.Mode), ops
+}
+
+func ToBookBatchResponse(res models.BookBatchResult) dto.BookBatchResponse {
+	results := make([]dto.BookBatchItemDTO, 0, len(res.Items))
+	for _, item := range res.Items {
+		d := dto.BookBatchItemDTO{
+			Index:  item.Index,
+			Op:     string(item.Op),
+			Status: string(item.Status),
+		}
+		if item.ID != uuid.Nil {
+			d.ID = item.ID.String()
+		}
+		if item.Err != nil {
+			d.Error = item.Err.Error()
+			var verr *models.ValidationError
+			if errors.As(item.Err, &verr) {
+				for _, f := range verr.Fields {
+					d.Errors = append(d.Errors, dto.FieldErrorDTO{Field: f.Field, Code: f.Code, Message: f.Message})
+				}
+			}
+		}
+		results = append(results, d)
+	}
+	return dto.BookBatchResponse{Committed: res.Committed, Results: results}
+}
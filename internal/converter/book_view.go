@@ -0,0 +1,79 @@
+package converter
+
+import (
+	"reflect"
+	"strings"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+)
+
+// bookView — структура только с выбранными полями BookDTO и встроенными связями.
+// Тип собирается через reflect, поэтому JSON, XML, MessagePack и CSV видят
+// одно и то же подмножество полей в порядке BookDTO.
+type bookView struct {
+	typ     reflect.Type
+	src     []int
+	authors int
+}
+
+func newBookView(sel models.BookSelection) bookView {
+	dtoType := reflect.TypeFor[dto.BookDTO]()
+	v := bookView{authors: -1}
+	var fields []reflect.StructField
+	for i := 0; i < dtoType.NumField(); i++ {
+		f := dtoType.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if sel.HasField(name) {
+			fields = append(fields, reflect.StructField{Name: f.Name, Type: f.Type, Tag: f.Tag})
+			v.src = append(v.src, i)
+		}
+	}
+	if sel.Includes(models.IncludeAuthors) {
+		v.authors = len(fields)
+		fields = append(fields, reflect.StructField{
+			Name: "Authors",
+			Type: reflect.TypeFor[[]dto.BookAuthorDTO](),
+			Tag:  `json:"authors"`,
+		})
+	}
+	v.typ = reflect.StructOf(fields)
+	return v
+}
+
+func (v bookView) value(b models.Book, rel models.BookRelations) reflect.Value {
+	out := reflect.New(v.typ).Elem()
+	full := reflect.ValueOf(ToBookResponse(b))
+	for i, j := range v.src {
+		out.Field(i).Set(full.Field(j))
+	}
+	if v.authors >= 0 {
+		authors := []dto.BookAuthorDTO{}
+		if a, ok := rel.Authors[b.Author]; ok {
+			authors = append(authors, dto.BookAuthorDTO{Name: a.Name, BookCount: a.BookCount})
+		}
+		out.Field(v.authors).Set(reflect.ValueOf(authors))
+	}
+	return out
+}
+
+// ToBookView — книга с полями и связями sel; без выбора — обычный dto.BookDTO.
+func ToBookView(b models.Book, sel models.BookSelection, rel models.BookRelations) any {
+	if !sel.Partial() {
+		return ToBookResponse(b)
+	}
+	return newBookView(sel).value(b, rel).Interface()
+}
+
+// ToBookViewList возвращает срез структур, а не []any, чтобы CSV видел колонки.
+func ToBookViewList(books []models.Book, sel models.BookSelection, rel models.BookRelations) any {
+	if !sel.Partial() {
+		return ToBookResponseList(books)
+	}
+	v := newBookView(sel)
+	list := reflect.MakeSlice(reflect.SliceOf(v.typ), 0, len(books))
+	for _, b := range books {
+		list = reflect.Append(list, v.value(b, rel))
+	}
+	return list.Interface()
+}
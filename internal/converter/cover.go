@@ -0,0 +1,29 @@
+package converter
+
+import (
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// coverURLPrefix — миниатюры раздаёт сам API (httpv1.APIPrefix) по пути из models.CoverPath.
+const coverURLPrefix = "/api/v1/"
+
+func ToCoverImages(id uuid.UUID, cover models.BookCover) []dto.CoverImageDTO {
+	images := cover.Images(id)
+	if len(images) == 0 {
+		return nil
+	}
+	resp := make([]dto.CoverImageDTO, 0, len(images))
+	for _, img := range images {
+		resp = append(resp, dto.CoverImageDTO{
+			Size:   img.Size,
+			Format: img.Format,
+			Width:  img.Width,
+			Height: img.Height,
+			URL:    coverURLPrefix + img.Path,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,76 @@
+package converter
+
+import (
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func ToRegistrationParams(req dto.RegisterRequest) models.RegistrationParams {
+	return models.RegistrationParams{
+		Email:    req.Email,
+		Password: req.Password,
+		FullName: req.FullName,
+		Phone:    req.Phone,
+	}
+}
+
+func ToAccessTokenResponse(t models.AccessToken) dto.AccessTokenDTO {
+	return dto.AccessTokenDTO{
+		AccessToken: t.Token,
+		TokenType:   "Bearer",
+		ExpiresAt:   t.ExpiresAt,
+	}
+}
+
+func ToProfileResponse(c models.Customer) dto.ProfileDTO {
+	return dto.ProfileDTO{
+		ID:            c.ID,
+		Email:         c.Email,
+		FullName:      c.FullName,
+		Phone:         c.Phone,
+		EmailVerified: c.EmailVerified,
+		CreatedAt:     c.CreatedAt,
+	}
+}
+
+func ToProfileParams(id uuid.UUID, req dto.ProfileRequest) models.ProfileParams {
+	return models.ProfileParams{
+		ID:       id,
+		FullName: req.FullName,
+		Phone:    req.Phone,
+	}
+}
+
+func ToAddressParams(customerID uuid.UUID, req dto.AddressRequest) models.AddressParams {
+	return models.AddressParams{
+		CustomerID: customerID,
+		Label:      req.Label,
+		Recipient:  req.Recipient,
+		Line1:      req.Line1,
+		Line2:      req.Line2,
+		City:       req.City,
+		PostalCode: req.PostalCode,
+		Country:    req.Country,
+		IsDefault:  req.IsDefault,
+	}
+}
+
+func ToAddressResponseList(addresses []models.Address) []dto.AddressDTO {
+	resp := make([]dto.AddressDTO, 0, len(addresses))
+	for _, a := range addresses {
+		resp = append(resp, dto.AddressDTO{
+			ID:         a.ID,
+			Label:      a.Label,
+			Recipient:  a.Recipient,
+			Line1:      a.Line1,
+			Line2:      a.Line2,
+			City:       a.City,
+			PostalCode: a.PostalCode,
+			Country:    a.Country,
+			IsDefault:  a.IsDefault,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,29 @@
+package converter
+
+import (
+	"math"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+)
+
+func ToDuplicateReportResponse(r models.DuplicateReport) dto.DuplicateReportDTO {
+	res := dto.DuplicateReportDTO{Candidates: make([]dto.DuplicateCandidateDTO, 0, len(r.Candidates))}
+	if !r.ScannedAt.IsZero() {
+		res.ScannedAt = &r.ScannedAt
+	}
+	for _, c := range r.Candidates {
+		res.Candidates = append(res.Candidates, dto.DuplicateCandidateDTO{
+			Score:            round3(c.Pair.Score),
+			ISBNMatch:        c.Pair.ISBNMatch,
+			TitleSimilarity:  round3(c.Pair.TitleSimilarity),
+			AuthorSimilarity: round3(c.Pair.AuthorSimilarity),
+			Books:            ToBookResponseList(c.Books[:]),
+		})
+	}
+	return res
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
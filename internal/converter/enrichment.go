@@ -0,0 +1,30 @@
+package converter
+
+import (
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+)
+
+func ToBookMetadataResponse(m models.BookMetadata) dto.BookMetadataDTO {
+	return dto.BookMetadataDTO{
+		Source:      m.Source,
+		ISBN:        m.ISBN,
+		Title:       m.Title,
+		Author:      m.Author,
+		Description: m.Description,
+		PageCount:   m.PageCount,
+		CoverURL:    m.CoverURL,
+	}
+}
+
+func ToBookEnrichmentResponse(e models.BookEnrichment) dto.BookEnrichmentDTO {
+	suggestions := make([]dto.FieldSuggestionDTO, 0, len(e.Suggestions))
+	for _, s := range e.Suggestions {
+		suggestions = append(suggestions, dto.FieldSuggestionDTO{Field: s.Field, Current: s.Current, Suggested: s.Suggested})
+	}
+	return dto.BookEnrichmentDTO{
+		BookID:      e.BookID,
+		Metadata:    ToBookMetadataResponse(e.Metadata),
+		Suggestions: suggestions,
+	}
+}
@@ -0,0 +1,44 @@
+package converter
+
+import (
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+)
+
+func ToImportJobResponse(j models.ImportJob) dto.ImportJobDTO {
+	rowErrors := make([]dto.ImportRowErrorDTO, 0, len(j.RowErrors))
+	for _, e := range j.RowErrors {
+		rowErr := dto.ImportRowErrorDTO{Row: e.Row, Record: e.Record, Message: e.Message}
+		for _, f := range e.Fields {
+			rowErr.Errors = append(rowErr.Errors, dto.FieldErrorDTO{Field: f.Field, Code: f.Code, Message: f.Message})
+		}
+		rowErrors = append(rowErrors, rowErr)
+	}
+
+	return dto.ImportJobDTO{
+		ID:       j.ID,
+		Status:   string(j.Status),
+		Format:   string(j.Format),
+		FileName: j.FileName,
+		Options: dto.ImportOptionsDTO{
+			Mapping:      j.Options.Mapping,
+			Delimiter:    j.Options.Delimiter,
+			Sheet:        j.Options.Sheet,
+			UpsertByISBN: j.Options.UpsertByISBN,
+			DryRun:       j.Options.DryRun,
+		},
+		Progress: dto.ImportProgressDTO{
+			TotalRows:     j.TotalRows,
+			ProcessedRows: j.ProcessedRows,
+			Created:       j.Created,
+			Updated:       j.Updated,
+			Deleted:       j.Deleted,
+			Failed:        j.Failed,
+		},
+		RowErrors:  rowErrors,
+		Error:      j.Error,
+		CreatedAt:  j.CreatedAt,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package converter
+
+import (
+	"strings"
+	"time"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+)
+
+func ToTaxQuoteParams(req dto.TaxQuoteRequest, date time.Time) models.TaxQuoteParams {
+	lines := make([]models.TaxLineParams, 0, len(req.Lines))
+	for _, l := range req.Lines {
+		format := models.ProductFormat(strings.ToLower(l.Format))
+		if format == "" {
+			format = models.FormatPrint
+		}
+		lines = append(lines, models.TaxLineParams{
+			BookID:   l.BookID,
+			Format:   format,
+			Quantity: l.Quantity,
+		})
+	}
+
+	return models.TaxQuoteParams{
+		Country: strings.ToUpper(req.Country),
+		Date:    date,
+		Lines:   lines,
+	}
+}
+
+func ToTaxQuoteResponse(q models.TaxQuote) dto.TaxQuoteDTO {
+	lines := make([]dto.TaxLineDTO, 0, len(q.Lines))
+	for _, l := range q.Lines {
+		lines = append(lines, dto.TaxLineDTO{
+			BookID:    l.BookID,
+			Format:    string(l.Format),
+			Quantity:  l.Quantity,
+			UnitPrice: l.UnitPrice,
+			RateBP:    l.RateBP,
+			Net:       l.Net,
+			Tax:       l.Tax,
+			Gross:     l.Gross,
+		})
+	}
+
+	return dto.TaxQuoteDTO{
+		Country:          q.Country,
+		Date:             q.Date.Format(time.DateOnly),
+		PricesIncludeTax: q.PricesIncludeTax,
+		Lines:            lines,
+		Net:              q.Net,
+		Tax:              q.Tax,
+		Gross:            q.Gross,
+	}
+}
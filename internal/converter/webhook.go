@@ -0,0 +1,80 @@
+package converter
+
+import (
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func ToWebhookSubscriptionParams(id uuid.UUID, req dto.WebhookRequest) models.WebhookSubscriptionParams {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+	return models.WebhookSubscriptionParams{
+		ID:         id,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+		Active:     active,
+	}
+}
+
+func ToWebhookResponse(s models.WebhookSubscription) dto.WebhookDTO {
+	return dto.WebhookDTO{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+		Active:     s.Active,
+		Failures:   s.Failures,
+		DisabledAt: s.DisabledAt,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+func ToWebhookResponseList(subs []models.WebhookSubscription) []dto.WebhookDTO {
+	resp := make([]dto.WebhookDTO, 0, len(subs))
+	for _, s := range subs {
+		resp = append(resp, ToWebhookResponse(s))
+	}
+	return resp
+}
+
+func ToCreatedWebhookResponse(s models.WebhookSubscription) dto.CreatedWebhookDTO {
+	return dto.CreatedWebhookDTO{
+		WebhookDTO: ToWebhookResponse(s),
+		Secret:     s.Secret,
+	}
+}
+
+func ToWebhookDeliveryResponse(d models.WebhookDelivery) dto.WebhookDeliveryDTO {
+	resp := dto.WebhookDeliveryDTO{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		LastAttemptAt:  d.LastAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		RedeliveryOf:   d.RedeliveryOf,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == models.DeliveryPending && !d.NextAttemptAt.IsZero() {
+		resp.NextAttemptAt = &d.NextAttemptAt
+	}
+	return resp
+}
+
+func ToWebhookDeliveryResponseList(deliveries []models.WebhookDelivery) []dto.WebhookDeliveryDTO {
+	resp := make([]dto.WebhookDeliveryDTO, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, ToWebhookDeliveryResponse(d))
+	}
+	return resp
+}
@@ -0,0 +1,81 @@
+package dedup
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"book-store-api/internal/models"
+)
+
+// scanColumns — всё, что нужно для оценки пары; остальное не читается.
+var scanColumns = []string{"id", "title", "author", "isbn"}
+
+// Catalog читает весь каталог потоком, как выгрузки.
+type Catalog interface {
+	Export(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error
+}
+
+// Store хранит результат последнего прохода; новый проход заменяет старый целиком.
+type Store interface {
+	Save(ctx context.Context, scan models.DuplicateScan) error
+}
+
+type WorkerConfig struct {
+	Interval time.Duration
+	MinScore float64
+}
+
+// Worker периодически ищет дубликаты во всём каталоге. Проход идемпотентен:
+// несколько экземпляров приложения просто перезапишут одинаковый результат.
+type Worker struct {
+	catalog Catalog
+	store   Store
+	cfg     WorkerConfig
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+func NewWorker(catalog Catalog, store Store, cfg WorkerConfig, logger *slog.Logger) *Worker {
+	return &Worker{catalog: catalog, store: store, cfg: cfg, logger: logger, now: time.Now}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Scan(ctx); err != nil && ctx.Err() == nil {
+			w.logger.Error("duplicate scan error", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) Scan(ctx context.Context) error {
+	started := w.now()
+	var books []models.Book
+	err := w.catalog.Export(ctx, models.BookExport{Columns: scanColumns}, func(b models.Book) error {
+		books = append(books, b)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	scan := models.DuplicateScan{
+		ScannedAt: started.UTC(),
+		Books:     len(books),
+		Pairs:     models.FindDuplicates(books, w.cfg.MinScore),
+	}
+	if err := w.store.Save(ctx, scan); err != nil {
+		return err
+	}
+	w.logger.Info("duplicate scan finished", "books", scan.Books, "pairs", len(scan.Pairs), "took", w.now().Sub(started))
+	return nil
+}
@@ -0,0 +1,52 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+)
+
+func TestWorker_Scan(t *testing.T) {
+	books := []models.Book{
+		{ID: uuid.New(), Title: "Солярис", Author: "Станислав Лем", ISBN: "978-5-17-118366-0"},
+		{ID: uuid.New(), Title: "Непобедимый", Author: "Станислав Лем"},
+		{ID: uuid.New(), Title: "Солярис", Author: "Лем С.", ISBN: "5-17-118366-X"},
+	}
+	catalog := &CatalogMock{ExportFunc: func(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error {
+		for _, b := range books {
+			if err := emit(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+	store := &StoreMock{SaveFunc: func(ctx context.Context, scan models.DuplicateScan) error { return nil }}
+	w := NewWorker(catalog, store, WorkerConfig{Interval: time.Hour, MinScore: models.DefaultDuplicateScore},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	w.now = func() time.Time { return time.Date(2025, 11, 3, 4, 0, 0, 0, time.UTC) }
+
+	require.NoError(t, w.Scan(context.Background()))
+	assert.Equal(t, []string{"id", "title", "author", "isbn"}, catalog.ExportCalls()[0].Exp.Columns)
+	require.Len(t, store.SaveCalls(), 1)
+	scan := store.SaveCalls()[0].Scan
+	assert.Equal(t, w.now(), scan.ScannedAt)
+	assert.Equal(t, 3, scan.Books)
+	require.Len(t, scan.Pairs, 1)
+	assert.Equal(t, [2]uuid.UUID{books[0].ID, books[2].ID}, scan.Pairs[0].BookIDs)
+	assert.True(t, scan.Pairs[0].ISBNMatch)
+
+	catalog.ExportFunc = func(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error {
+		return errors.New("db error")
+	}
+	assert.Error(t, w.Scan(context.Background()))
+	assert.Len(t, store.SaveCalls(), 1, "failed scan does not replace the previous result")
+}
@@ -0,0 +1,78 @@
+package graphqlv1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// complexityLimit оценивает запрос до выполнения: каждое поле стоит 1, а стоимость
+// вложенных полей умножается на аргумент first — столько элементов вернёт список.
+type complexityLimit struct {
+	schema *ast.Schema
+	max    int
+}
+
+func newComplexityLimit(sdl string, max int) (*complexityLimit, error) {
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: sdl})
+	if err != nil {
+		return nil, err
+	}
+	return &complexityLimit{schema: schema, max: max}, nil
+}
+
+// check отклоняет запрос, который не удалось разобрать и оценить: иначе запрос, на котором
+// gqlparser и graphql-go расходятся, исполнился бы без ограничения сложности.
+func (c *complexityLimit) check(query, operationName string, variables map[string]any) *resolverError {
+	if c.max <= 0 {
+		return nil
+	}
+	doc, errs := gqlparser.LoadQuery(c.schema, query)
+	if len(errs) > 0 {
+		return &resolverError{message: errs[0].Message, code: codeValidationFailed}
+	}
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		if operationName == "" {
+			return &resolverError{message: "operation name is required when the document has several operations", code: codeValidationFailed}
+		}
+		return &resolverError{message: fmt.Sprintf("unknown operation %q", operationName), code: codeValidationFailed}
+	}
+
+	if cost := selectionCost(op.SelectionSet, variables); cost > c.max {
+		return &resolverError{message: fmt.Sprintf("query complexity %d exceeds limit %d", cost, c.max), code: codeQueryTooComplex}
+	}
+	return nil
+}
+
+func selectionCost(set ast.SelectionSet, variables map[string]any) int {
+	cost := 0
+	for _, sel := range set {
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			cost += 1 + listSize(s, variables)*selectionCost(s.SelectionSet, variables)
+		case *ast.InlineFragment:
+			cost += selectionCost(s.SelectionSet, variables)
+		case *ast.FragmentSpread:
+			cost += selectionCost(s.Definition.SelectionSet, variables)
+		}
+	}
+	return cost
+}
+
+// listSize берёт first с учётом значения по умолчанию из схемы.
+func listSize(f *ast.Field, variables map[string]any) int {
+	var n int64
+	switch v := f.ArgumentMap(variables)["first"].(type) {
+	case int64:
+		n = v
+	case float64:
+		n = int64(v)
+	}
+	return int(max(n, 1))
+}
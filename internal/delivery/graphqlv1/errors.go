@@ -0,0 +1,54 @@
+package graphqlv1
+
+import (
+	"errors"
+	"log/slog"
+
+	"book-store-api/internal/models"
+)
+
+const (
+	codeBadUserInput    = "BAD_USER_INPUT"
+	codeQueryTooComplex = "QUERY_TOO_COMPLEX"
+	// codeValidationFailed — запрос не разобран или не прошёл проверку по схеме
+	codeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	codeInternal         = "INTERNAL_SERVER_ERROR"
+)
+
+// resolverError попадает в ответ как message и extensions.code.
+type resolverError struct {
+	message string
+	code    string
+	fields  []models.FieldError
+}
+
+func (e resolverError) Error() string {
+	return e.message
+}
+
+func (e resolverError) Extensions() map[string]any {
+	ext := map[string]any{"code": e.code}
+	if len(e.fields) > 0 {
+		fields := make([]map[string]string, 0, len(e.fields))
+		for _, f := range e.fields {
+			fields = append(fields, map[string]string{"field": f.Field, "code": f.Code, "message": f.Message})
+		}
+		ext["fields"] = fields
+	}
+	return ext
+}
+
+// toGraphQLError повторяет отображение ошибок из httpv1.Handler: текст ошибки
+// валидации отдаётся клиенту, остальное логируется и скрывается.
+func toGraphQLError(logger *slog.Logger, err error) error {
+	if errors.Is(err, models.ErrDomainValidation) {
+		rerr := resolverError{message: err.Error(), code: codeBadUserInput}
+		var verr *models.ValidationError
+		if errors.As(err, &verr) {
+			rerr.fields = verr.Fields
+		}
+		return rerr
+	}
+	logger.Error("graphql resolver failed", "err", err)
+	return resolverError{message: "internal server error", code: codeInternal}
+}
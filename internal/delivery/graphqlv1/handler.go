@@ -0,0 +1,98 @@
+package graphqlv1
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/dto"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// запрос с телом больше этого размера отклоняется до разбора
+const maxRequestBody = 1 << 20
+
+type Handler struct {
+	schema     *graphql.Schema
+	complexity *complexityLimit
+	authors    delivery.AuthorUsecase
+	logger     *slog.Logger
+}
+
+func NewHandler(u delivery.Usecase, authors delivery.AuthorUsecase, limits Limits, logger *slog.Logger) (*Handler, error) {
+	schema, err := newSchema(u, limits, logger)
+	if err != nil {
+		return nil, err
+	}
+	complexity, err := newComplexityLimit(schemaSDL, limits.MaxComplexity)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{schema: schema, complexity: complexity, authors: authors, logger: logger}, nil
+}
+
+func (h *Handler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/graphql", h.Query).Methods("GET", "POST")
+}
+
+// @Summary GraphQL-запрос к каталогу
+// @Description Книги с авторами за один запрос. Схема доступна через интроспекцию.
+// @Description Глубина и сложность запроса ограничены: стоимость вложенных полей умножается на first. Ошибки приходят в errors с extensions.code и статусом 200.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body dto.GraphQLRequest true "GraphQL request"
+// @Success 200 {object} dto.GraphQLResponse
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Router /graphql [post]
+func (h *Handler) Query(w http.ResponseWriter, r *http.Request) {
+	var req dto.GraphQLRequest
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if vars := r.URL.Query().Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				problem.Write(w, r, problem.InvalidRequest, "invalid variables")
+				return
+			}
+		}
+	} else {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody)).Decode(&req); err != nil {
+			problem.Write(w, r, problem.InvalidRequest, "invalid request body")
+			return
+		}
+	}
+	if req.Query == "" {
+		problem.Write(w, r, problem.InvalidRequest, "query is required")
+		return
+	}
+
+	var resp *graphql.Response
+	if rerr := h.complexity.check(req.Query, req.OperationName, req.Variables); rerr != nil {
+		resp = &graphql.Response{Errors: []*gqlerrors.QueryError{{
+			Message:    rerr.message,
+			Extensions: rerr.Extensions(),
+		}}}
+	} else {
+		ctx := withLoaders(r.Context(), newLoaders(h.authors))
+		resp = h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+		h.logger.Error("graphql response encode", "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		return
+	}
+}
@@ -0,0 +1,259 @@
+package graphqlv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+)
+
+type gqlResult struct {
+	Data   json.RawMessage    `json:"data"`
+	Errors []dto.GraphQLError `json:"errors"`
+}
+
+func newTestRouter(t *testing.T, u *UsecaseMock, a *AuthorUsecaseMock, limits Limits) *mux.Router {
+	t.Helper()
+	h, err := NewHandler(u, a, limits, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	router := mux.NewRouter()
+	h.RegisterRoutes(router)
+	return router
+}
+
+func execQuery(t *testing.T, router http.Handler, query string, variables map[string]any) gqlResult {
+	t.Helper()
+	body, err := json.Marshal(dto.GraphQLRequest{Query: query, Variables: variables})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res gqlResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res
+}
+
+func testBook(author string, createdAt time.Time) models.Book {
+	return models.Book{ID: uuid.New(), Title: "Title by " + author, Author: author, CreatedAt: createdAt, UpdatedAt: createdAt}
+}
+
+func TestHandler_BatchesAuthorLoads(t *testing.T) {
+	now := time.Now().UTC()
+	books := []models.Book{
+		testBook("Le Guin", now),
+		testBook("Lem", now.Add(time.Second)),
+		testBook("Le Guin", now.Add(2*time.Second)),
+	}
+
+	u := &UsecaseMock{
+		ListFunc: func(ctx context.Context, after *models.BookCursor, size int) (models.BookPage, error) {
+			return models.BookPage{Books: books}, nil
+		},
+	}
+	var mu sync.Mutex
+	var booksCalls, countCalls [][]string
+	a := &AuthorUsecaseMock{
+		BooksByAuthorsFunc: func(ctx context.Context, authors []string, limit int) (map[string][]models.Book, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			booksCalls = append(booksCalls, authors)
+			assert.Equal(t, 2, limit)
+			return map[string][]models.Book{
+				"Le Guin": {books[0], books[2]},
+				"Lem":     {books[1]},
+			}, nil
+		},
+		CountByAuthorsFunc: func(ctx context.Context, authors []string) (map[string]int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			countCalls = append(countCalls, authors)
+			return map[string]int{"Le Guin": 2, "Lem": 1}, nil
+		},
+	}
+	router := newTestRouter(t, u, a, Limits{MaxDepth: 12, MaxComplexity: 1000})
+
+	res := execQuery(t, router, `{
+		books(first: 3) { edges { node { title author { name bookCount books(first: 2) { id } } } } }
+	}`, nil)
+	require.Empty(t, res.Errors)
+
+	var data struct {
+		Books struct {
+			Edges []struct {
+				Node struct {
+					Author struct {
+						Name      string
+						BookCount int
+						Books     []struct{ ID string }
+					}
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(res.Data, &data))
+	require.Len(t, data.Books.Edges, 3)
+	assert.Equal(t, 2, data.Books.Edges[0].Node.Author.BookCount)
+	assert.Len(t, data.Books.Edges[0].Node.Author.Books, 2)
+	assert.Equal(t, books[1].ID.String(), data.Books.Edges[1].Node.Author.Books[0].ID)
+
+	// три книги — но один запрос за книгами авторов и один за счётчиками
+	require.Len(t, booksCalls, 1)
+	require.Len(t, countCalls, 1)
+	sort.Strings(booksCalls[0])
+	sort.Strings(countCalls[0])
+	assert.Equal(t, []string{"Le Guin", "Lem"}, booksCalls[0])
+	assert.Equal(t, []string{"Le Guin", "Lem"}, countCalls[0])
+}
+
+func TestHandler_Pagination(t *testing.T) {
+	now := time.Now().UTC()
+	books := []models.Book{testBook("Lem", now), testBook("Lem", now.Add(time.Second))}
+	next := models.CursorOf(books[1])
+
+	var gotAfter *models.BookCursor
+	u := &UsecaseMock{
+		ListFunc: func(ctx context.Context, after *models.BookCursor, size int) (models.BookPage, error) {
+			gotAfter = after
+			assert.Equal(t, 2, size)
+			return models.BookPage{Books: books, Next: &next}, nil
+		},
+	}
+	router := newTestRouter(t, u, &AuthorUsecaseMock{}, Limits{MaxDepth: 12, MaxComplexity: 1000})
+
+	t.Run("next page", func(t *testing.T) {
+		after := models.CursorOf(testBook("Lem", now.Add(-time.Hour)))
+		res := execQuery(t, router, `query($after: String) {
+			books(first: 2, after: $after) { edges { cursor } pageInfo { hasNextPage endCursor } }
+		}`, map[string]any{"after": after.String()})
+		require.Empty(t, res.Errors)
+
+		var data struct {
+			Books struct {
+				Edges    []struct{ Cursor string }
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(res.Data, &data))
+		require.NotNil(t, gotAfter)
+		assert.Equal(t, after.ID, gotAfter.ID)
+		assert.True(t, data.Books.PageInfo.HasNextPage)
+		assert.Equal(t, next.String(), data.Books.PageInfo.EndCursor)
+		assert.Equal(t, models.CursorOf(books[0]).String(), data.Books.Edges[0].Cursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		res := execQuery(t, router, `{ books(first: 2, after: "%%%") { edges { cursor } } }`, nil)
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, codeBadUserInput, res.Errors[0].Extensions["code"])
+	})
+}
+
+func TestHandler_Book(t *testing.T) {
+	id := uuid.New()
+	u := &UsecaseMock{}
+	router := newTestRouter(t, u, &AuthorUsecaseMock{}, Limits{MaxDepth: 12, MaxComplexity: 1000})
+
+	t.Run("not found is null", func(t *testing.T) {
+		u.GetByIDFunc = func(ctx context.Context, _ string) (*models.Book, error) {
+			return nil, repository.ErrNotFound
+		}
+		res := execQuery(t, router, `query($id: ID!) { book(id: $id) { title } }`, map[string]any{"id": id.String()})
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"book": null}`, string(res.Data))
+	})
+
+	t.Run("internal error is hidden", func(t *testing.T) {
+		u.GetByIDFunc = func(ctx context.Context, _ string) (*models.Book, error) {
+			return nil, errors.New("connection refused")
+		}
+		res := execQuery(t, router, `query($id: ID!) { book(id: $id) { title } }`, map[string]any{"id": id.String()})
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "internal server error", res.Errors[0].Message)
+		assert.Equal(t, codeInternal, res.Errors[0].Extensions["code"])
+	})
+
+	t.Run("price beyond Int is an error", func(t *testing.T) {
+		u.GetByIDFunc = func(ctx context.Context, _ string) (*models.Book, error) {
+			return &models.Book{ID: id, Title: "Дорогая", Price: math.MaxInt32 + 1}, nil
+		}
+		res := execQuery(t, router, `query($id: ID!) { book(id: $id) { title price } }`, map[string]any{"id": id.String()})
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "book price does not fit into Int", res.Errors[0].Message)
+		assert.Equal(t, []any{"book", "price"}, res.Errors[0].Path)
+		assert.JSONEq(t, `{"book": null}`, string(res.Data), "price is non-null, so the book is nulled instead of wrapping")
+
+		u.GetByIDFunc = func(ctx context.Context, _ string) (*models.Book, error) {
+			return &models.Book{ID: id, Title: "Обычная", Price: math.MaxInt32}, nil
+		}
+		res = execQuery(t, router, `query($id: ID!) { book(id: $id) { price } }`, map[string]any{"id": id.String()})
+		require.Empty(t, res.Errors)
+		assert.JSONEq(t, `{"book": {"price": 2147483647}}`, string(res.Data))
+	})
+}
+
+func TestHandler_Limits(t *testing.T) {
+	u := &UsecaseMock{}
+	router := newTestRouter(t, u, &AuthorUsecaseMock{}, Limits{MaxDepth: 6, MaxComplexity: 500})
+
+	t.Run("depth", func(t *testing.T) {
+		res := execQuery(t, router, `{
+			books { edges { node { author { books { author { name } } } } } }
+		}`, nil)
+		require.NotEmpty(t, res.Errors)
+		assert.Contains(t, res.Errors[0].Message, "exceeds max depth")
+	})
+
+	t.Run("complexity", func(t *testing.T) {
+		res := execQuery(t, router, `query($n: Int) {
+			books(first: $n) { edges { node { author { books(first: 50) { title } } } } }
+		}`, map[string]any{"n": 100})
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, codeQueryTooComplex, res.Errors[0].Extensions["code"])
+	})
+
+	t.Run("unparsable query is rejected", func(t *testing.T) {
+		for _, query := range []string{
+			`{ books { edges { node { nope } } } }`,
+			`{ books(first: 1000000) { edges { node { title } } `,
+			`query A { books { pageInfo { hasNextPage } } } query B { author(name: "Лем") { name } }`,
+		} {
+			res := execQuery(t, router, query, nil)
+			require.Len(t, res.Errors, 1, query)
+			assert.Equal(t, codeValidationFailed, res.Errors[0].Extensions["code"], query)
+		}
+	})
+
+	t.Run("default first counts", func(t *testing.T) {
+		// books(first = 20) × author.books(first = 10) укладывается в лимит
+		u.ListFunc = func(ctx context.Context, after *models.BookCursor, size int) (models.BookPage, error) {
+			return models.BookPage{}, nil
+		}
+		res := execQuery(t, router, `{ books { edges { node { author { books { title } } } } } }`, nil)
+		assert.Empty(t, res.Errors)
+	})
+
+	assert.Len(t, u.ListCalls(), 1)
+}
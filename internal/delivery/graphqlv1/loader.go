@@ -0,0 +1,92 @@
+package graphqlv1
+
+import (
+	"context"
+	"time"
+
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/models"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// сколько загрузчик ждёт остальные ключи перед походом в базу; резолверы
+// соседних полей graphql-go вызывает параллельно
+const loaderWait = 2 * time.Millisecond
+
+type authorBooksKey struct {
+	name  string
+	first int
+}
+
+// loaders живут один запрос: кэш dataloader не должен отдавать данные другому клиенту.
+type loaders struct {
+	authorBooks *dataloader.Loader[authorBooksKey, []models.Book]
+	bookCount   *dataloader.Loader[string, int]
+}
+
+type loadersKey struct{}
+
+func newLoaders(authors delivery.AuthorUsecase) *loaders {
+	return &loaders{
+		authorBooks: dataloader.NewBatchedLoader(batchAuthorBooks(authors),
+			dataloader.WithWait[authorBooksKey, []models.Book](loaderWait)),
+		bookCount: dataloader.NewBatchedLoader(batchBookCount(authors),
+			dataloader.WithWait[string, int](loaderWait)),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// batchAuthorBooks делает по одному запросу на каждое встреченное значение first —
+// на практике оно в запросе одно.
+func batchAuthorBooks(authors delivery.AuthorUsecase) dataloader.BatchFunc[authorBooksKey, []models.Book] {
+	return func(ctx context.Context, keys []authorBooksKey) []*dataloader.Result[[]models.Book] {
+		names := make(map[int][]string)
+		for _, k := range keys {
+			names[k.first] = append(names[k.first], k.name)
+		}
+
+		byFirst := make(map[int]map[string][]models.Book, len(names))
+		errs := make(map[int]error)
+		for first, authorNames := range names {
+			books, err := authors.BooksByAuthors(ctx, authorNames, first)
+			if err != nil {
+				errs[first] = err
+				continue
+			}
+			byFirst[first] = books
+		}
+
+		results := make([]*dataloader.Result[[]models.Book], len(keys))
+		for i, k := range keys {
+			if err := errs[k.first]; err != nil {
+				results[i] = &dataloader.Result[[]models.Book]{Error: err}
+				continue
+			}
+			results[i] = &dataloader.Result[[]models.Book]{Data: byFirst[k.first][k.name]}
+		}
+		return results
+	}
+}
+
+func batchBookCount(authors delivery.AuthorUsecase) dataloader.BatchFunc[string, int] {
+	return func(ctx context.Context, keys []string) []*dataloader.Result[int] {
+		results := make([]*dataloader.Result[int], len(keys))
+		counts, err := authors.CountByAuthors(ctx, keys)
+		for i, k := range keys {
+			if err != nil {
+				results[i] = &dataloader.Result[int]{Error: err}
+				continue
+			}
+			results[i] = &dataloader.Result[int]{Data: counts[k]}
+		}
+		return results
+	}
+}
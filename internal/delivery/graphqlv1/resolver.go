@@ -0,0 +1,191 @@
+package graphqlv1
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+)
+
+type queryResolver struct {
+	usecase delivery.Usecase
+	logger  *slog.Logger
+}
+
+func (q *queryResolver) Book(ctx context.Context, args struct{ ID graphql.ID }) (*bookResolver, error) {
+	if _, err := uuid.Parse(string(args.ID)); err != nil {
+		return nil, resolverError{message: "invalid uuid format", code: codeBadUserInput}
+	}
+
+	book, err := q.usecase.GetByID(ctx, string(args.ID))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, toGraphQLError(q.logger, err)
+	}
+	return &bookResolver{book: *book, logger: q.logger}, nil
+}
+
+func (q *queryResolver) Books(ctx context.Context, args struct {
+	First int32
+	After *string
+}) (*bookConnectionResolver, error) {
+	var after *models.BookCursor
+	if args.After != nil && *args.After != "" {
+		cursor, err := models.ParseBookCursor(*args.After)
+		if err != nil {
+			return nil, toGraphQLError(q.logger, err)
+		}
+		after = &cursor
+	}
+
+	page, err := q.usecase.List(ctx, after, int(args.First))
+	if err != nil {
+		return nil, toGraphQLError(q.logger, err)
+	}
+	return &bookConnectionResolver{page: page, logger: q.logger}, nil
+}
+
+// Author возвращает null, если у автора нет книг в каталоге.
+func (q *queryResolver) Author(ctx context.Context, args struct{ Name string }) (*authorResolver, error) {
+	count, err := loadersFrom(ctx).bookCount.Load(ctx, args.Name)()
+	if err != nil {
+		return nil, toGraphQLError(q.logger, err)
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return &authorResolver{name: args.Name, logger: q.logger}, nil
+}
+
+type bookResolver struct {
+	book   models.Book
+	logger *slog.Logger
+}
+
+func (b *bookResolver) ID() graphql.ID {
+	return graphql.ID(b.book.ID.String())
+}
+
+func (b *bookResolver) Title() string {
+	return b.book.Title
+}
+
+func (b *bookResolver) Description() string {
+	return b.book.Description
+}
+
+func (b *bookResolver) ISBN() string {
+	return b.book.ISBN
+}
+
+// Price — Int в GraphQL 32-битный: цену, которая в него не помещается, отдаём ошибкой,
+// а не отрицательным числом после переполнения.
+func (b *bookResolver) Price() (int32, error) {
+	if b.book.Price > math.MaxInt32 || b.book.Price < math.MinInt32 {
+		b.logger.Error("graphql price out of range", "book", b.book.ID, "price", b.book.Price)
+		return 0, resolverError{message: "book price does not fit into Int", code: codeInternal}
+	}
+	return int32(b.book.Price), nil
+}
+
+func (b *bookResolver) Author() *authorResolver {
+	return &authorResolver{name: b.book.Author, logger: b.logger}
+}
+
+func (b *bookResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: b.book.CreatedAt}
+}
+
+func (b *bookResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: b.book.UpdatedAt}
+}
+
+type authorResolver struct {
+	name   string
+	logger *slog.Logger
+}
+
+func (a *authorResolver) Name() string {
+	return a.name
+}
+
+func (a *authorResolver) BookCount(ctx context.Context) (int32, error) {
+	count, err := loadersFrom(ctx).bookCount.Load(ctx, a.name)()
+	if err != nil {
+		return 0, toGraphQLError(a.logger, err)
+	}
+	return int32(count), nil
+}
+
+func (a *authorResolver) Books(ctx context.Context, args struct{ First int32 }) ([]*bookResolver, error) {
+	key := authorBooksKey{name: a.name, first: models.NormalizeBookPageSize(int(args.First))}
+	books, err := loadersFrom(ctx).authorBooks.Load(ctx, key)()
+	if err != nil {
+		return nil, toGraphQLError(a.logger, err)
+	}
+	return newBookResolvers(books, a.logger), nil
+}
+
+type bookConnectionResolver struct {
+	page   models.BookPage
+	logger *slog.Logger
+}
+
+func (c *bookConnectionResolver) Edges() []*bookEdgeResolver {
+	edges := make([]*bookEdgeResolver, 0, len(c.page.Books))
+	for _, b := range c.page.Books {
+		edges = append(edges, &bookEdgeResolver{node: &bookResolver{book: b, logger: c.logger}})
+	}
+	return edges
+}
+
+func (c *bookConnectionResolver) PageInfo() *pageInfoResolver {
+	info := &pageInfoResolver{hasNextPage: c.page.Next != nil}
+	if n := len(c.page.Books); n > 0 {
+		cursor := models.CursorOf(c.page.Books[n-1]).String()
+		info.endCursor = &cursor
+	}
+	return info
+}
+
+type bookEdgeResolver struct {
+	node *bookResolver
+}
+
+func (e *bookEdgeResolver) Cursor() string {
+	return models.CursorOf(e.node.book).String()
+}
+
+func (e *bookEdgeResolver) Node() *bookResolver {
+	return e.node
+}
+
+type pageInfoResolver struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func (p *pageInfoResolver) HasNextPage() bool {
+	return p.hasNextPage
+}
+
+func (p *pageInfoResolver) EndCursor() *string {
+	return p.endCursor
+}
+
+func newBookResolvers(books []models.Book, logger *slog.Logger) []*bookResolver {
+	resolvers := make([]*bookResolver, 0, len(books))
+	for _, b := range books {
+		resolvers = append(resolvers, &bookResolver{book: b, logger: logger})
+	}
+	return resolvers
+}
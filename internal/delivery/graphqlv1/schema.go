@@ -0,0 +1,31 @@
+package graphqlv1
+
+import (
+	_ "embed"
+	"log/slog"
+
+	"book-store-api/internal/delivery"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// резолверы с context graphql-go выполняет параллельно не больше этого числа;
+// загрузчик собирает в пачку только ожидающие одновременно, поэтому лимит
+// равен максимальному размеру страницы — иначе страница грузится кусками по 10
+const maxParallelism = 500
+
+//go:embed schema.graphql
+var schemaSDL string
+
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+func newSchema(u delivery.Usecase, limits Limits, logger *slog.Logger) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schemaSDL, &queryResolver{usecase: u, logger: logger},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(limits.MaxDepth),
+		graphql.MaxParallelism(maxParallelism),
+	)
+}
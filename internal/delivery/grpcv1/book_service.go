@@ -0,0 +1,117 @@
+package grpcv1
+
+import (
+	"context"
+	"log/slog"
+
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/grpcv1/bookv1"
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListAll читает каталог страницами максимального размера, а не через GetAll,
+// чтобы память не росла вместе с каталогом.
+const listAllPageSize = 500
+
+type BookService struct {
+	bookv1.UnimplementedBookServiceServer
+	usecase delivery.Usecase
+	logger  *slog.Logger
+}
+
+func NewBookService(u delivery.Usecase, logger *slog.Logger) *BookService {
+	return &BookService{usecase: u, logger: logger}
+}
+
+func (s *BookService) Get(ctx context.Context, req *bookv1.GetRequest) (*bookv1.Book, error) {
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid uuid format")
+	}
+
+	book, err := s.usecase.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+	return toProtoBook(*book), nil
+}
+
+func (s *BookService) List(ctx context.Context, req *bookv1.ListRequest) (*bookv1.ListResponse, error) {
+	var after *models.BookCursor
+	if req.GetPageToken() != "" {
+		cursor, err := models.ParseBookCursor(req.GetPageToken())
+		if err != nil {
+			return nil, s.statusError(err)
+		}
+		after = &cursor
+	}
+
+	page, err := s.usecase.List(ctx, after, int(req.GetPageSize()))
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+
+	resp := &bookv1.ListResponse{Books: make([]*bookv1.Book, 0, len(page.Books))}
+	for _, b := range page.Books {
+		resp.Books = append(resp.Books, toProtoBook(b))
+	}
+	if page.Next != nil {
+		resp.NextPageToken = page.Next.String()
+	}
+	return resp, nil
+}
+
+func (s *BookService) Create(ctx context.Context, req *bookv1.CreateRequest) (*bookv1.CreateResponse, error) {
+	id, err := s.usecase.Create(ctx, toBookParams(uuid.Nil, req.GetBook()))
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+	return &bookv1.CreateResponse{Id: id}, nil
+}
+
+func (s *BookService) Update(ctx context.Context, req *bookv1.UpdateRequest) (*emptypb.Empty, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid uuid format")
+	}
+
+	if err := s.usecase.Update(ctx, toBookParams(id, req.GetBook())); err != nil {
+		return nil, s.statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BookService) Delete(ctx context.Context, req *bookv1.DeleteRequest) (*emptypb.Empty, error) {
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid uuid format")
+	}
+
+	if err := s.usecase.DeleteBook(ctx, req.GetId()); err != nil {
+		return nil, s.statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *BookService) ListAll(_ *bookv1.ListAllRequest, stream bookv1.BookService_ListAllServer) error {
+	ctx := stream.Context()
+	var after *models.BookCursor
+	for {
+		page, err := s.usecase.List(ctx, after, listAllPageSize)
+		if err != nil {
+			return s.statusError(err)
+		}
+		for _, b := range page.Books {
+			if err := stream.Send(toProtoBook(b)); err != nil {
+				return err
+			}
+		}
+		if page.Next == nil {
+			return nil
+		}
+		after = page.Next
+	}
+}
@@ -0,0 +1,287 @@
+package grpcv1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/delivery/grpcv1/bookv1"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/reqctx"
+	"book-store-api/internal/usecase"
+)
+
+const (
+	editorToken = "editor-token"
+	readerToken = "reader-token"
+	erpKey      = "bsk_erp_secret"
+)
+
+func startServer(t *testing.T, u *UsecaseMock) *grpc.ClientConn {
+	t.Helper()
+	return startLimitedServer(t, u, nil)
+}
+
+func startLimitedServer(t *testing.T, u *UsecaseMock, limits *RateLimitOptions) *grpc.ClientConn {
+	t.Helper()
+	verifier := &TokenVerifierMock{
+		VerifyFunc: func(token string) (auth.Principal, error) {
+			switch token {
+			case editorToken:
+				return auth.Principal{Type: auth.PrincipalUser, Subject: "editor", Roles: []string{auth.RoleCatalogEditor}}, nil
+			case readerToken:
+				return auth.Principal{Type: auth.PrincipalUser, Subject: "reader", Roles: []string{auth.RoleCustomer}}, nil
+			}
+			return auth.Principal{}, auth.ErrInvalidToken
+		},
+	}
+	apiKeys := &APIKeyAuthenticatorMock{
+		AuthenticateFunc: func(ctx context.Context, key string) (auth.Principal, error) {
+			if key != erpKey {
+				return auth.Principal{}, usecase.ErrInvalidAPIKey
+			}
+			return auth.Principal{Type: auth.PrincipalAPIKey, Subject: "erp", Scopes: []string{models.ScopeCatalogWrite}}, nil
+		},
+	}
+
+	server, healthServer := InitServer(u, verifier, apiKeys, limits, true, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(func() {
+		healthServer.Shutdown()
+		server.GracefulStop()
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func withMD(kv ...string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), kv...)
+}
+
+func TestBookService_Get(t *testing.T) {
+	book := models.Book{ID: uuid.New(), Title: "Dune", Author: "Herbert", ISBN: "9780441013593", Price: 900, CreatedAt: time.Now().UTC()}
+	u := &UsecaseMock{
+		GetByIDFunc: func(ctx context.Context, id string) (*models.Book, error) {
+			if id != book.ID.String() {
+				return nil, repository.ErrNotFound
+			}
+			return &book, nil
+		},
+	}
+	client := bookv1.NewBookServiceClient(startServer(t, u))
+
+	var header metadata.MD
+	got, err := client.Get(withMD("x-request-id", "req-42"), &bookv1.GetRequest{Id: book.ID.String()}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, book.Title, got.GetTitle())
+	assert.Equal(t, int64(900), got.GetPrice())
+	assert.True(t, book.CreatedAt.Equal(got.GetCreatedAt().AsTime()))
+	assert.Equal(t, []string{"req-42"}, header.Get(requestIDKey))
+
+	_, err = client.Get(context.Background(), &bookv1.GetRequest{Id: uuid.NewString()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.Get(context.Background(), &bookv1.GetRequest{Id: "42"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestBookService_ErrorMapping(t *testing.T) {
+	u := &UsecaseMock{
+		CreateFunc: func(ctx context.Context, params models.BookParams) (string, error) {
+			return "", fmt.Errorf("%w: title is required", models.ErrDomainValidation)
+		},
+		DeleteBookFunc: func(ctx context.Context, id string) error {
+			return usecase.ErrDbInfrastructure
+		},
+	}
+	client := bookv1.NewBookServiceClient(startServer(t, u))
+	ctx := withMD("authorization", "Bearer "+editorToken)
+
+	_, err := client.Create(ctx, &bookv1.CreateRequest{Book: &bookv1.BookInput{}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "title is required")
+
+	_, err = client.Delete(ctx, &bookv1.DeleteRequest{Id: uuid.NewString()})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message(), "infrastructure details are not leaked")
+
+	u.UpdateFunc = func(ctx context.Context, params models.BookParams) error {
+		var verr models.ValidationError
+		verr.Add("title", models.FieldRequired, "book title is required")
+		verr.Add("price", models.FieldNegative, "book price is negative")
+		return verr.Err()
+	}
+	_, err = client.Update(ctx, &bookv1.UpdateRequest{Id: uuid.NewString(), Book: &bookv1.BookInput{}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 2)
+	assert.Equal(t, "title", badRequest.GetFieldViolations()[0].GetField())
+	assert.Equal(t, models.FieldNegative, badRequest.GetFieldViolations()[1].GetReason())
+}
+
+func TestBookService_WriteAuthorization(t *testing.T) {
+	var principal auth.Principal
+	var requestID string
+	u := &UsecaseMock{
+		CreateFunc: func(ctx context.Context, params models.BookParams) (string, error) {
+			principal, _ = auth.PrincipalFromContext(ctx)
+			requestID = reqctx.RequestID(ctx)
+			return "new-id", nil
+		},
+	}
+	client := bookv1.NewBookServiceClient(startServer(t, u))
+	req := &bookv1.CreateRequest{Book: &bookv1.BookInput{Title: "T", Author: "A", Isbn: "1", Price: 1}}
+
+	_, err := client.Create(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Create(withMD("authorization", "Bearer "+readerToken), req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.Create(withMD("authorization", "Bearer forged"), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Create(withMD("x-api-key", "bsk_unknown"), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	resp, err := client.Create(withMD("authorization", "Bearer "+editorToken), req)
+	require.NoError(t, err)
+	assert.Equal(t, "new-id", resp.GetId())
+	assert.Equal(t, "editor", principal.Subject)
+	assert.NotEmpty(t, requestID)
+
+	_, err = client.Create(withMD("x-api-key", erpKey), req)
+	require.NoError(t, err)
+	assert.Equal(t, auth.PrincipalAPIKey, principal.Type)
+}
+
+func TestBookService_ListPagination(t *testing.T) {
+	books := make([]models.Book, 5)
+	base := time.Date(2025, 10, 26, 9, 0, 0, 0, time.UTC)
+	for i := range books {
+		books[i] = models.Book{ID: uuid.New(), Title: fmt.Sprintf("Book %d", i), CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+	}
+	u := &UsecaseMock{ListFunc: pagedList(books)}
+	client := bookv1.NewBookServiceClient(startServer(t, u))
+
+	var titles []string
+	token := ""
+	for {
+		resp, err := client.List(context.Background(), &bookv1.ListRequest{PageSize: 2, PageToken: token})
+		require.NoError(t, err)
+		for _, b := range resp.GetBooks() {
+			titles = append(titles, b.GetTitle())
+		}
+		token = resp.GetNextPageToken()
+		if token == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"Book 0", "Book 1", "Book 2", "Book 3", "Book 4"}, titles)
+
+	_, err := client.List(context.Background(), &bookv1.ListRequest{PageToken: "garbage"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestBookService_ListAllStreamsEveryPage(t *testing.T) {
+	books := make([]models.Book, listAllPageSize+3)
+	base := time.Date(2025, 10, 26, 9, 0, 0, 0, time.UTC)
+	for i := range books {
+		books[i] = models.Book{ID: uuid.New(), CreatedAt: base.Add(time.Duration(i) * time.Second)}
+	}
+	u := &UsecaseMock{ListFunc: pagedList(books)}
+	client := bookv1.NewBookServiceClient(startServer(t, u))
+
+	stream, err := client.ListAll(context.Background(), &bookv1.ListAllRequest{})
+	require.NoError(t, err)
+	var got int
+	for {
+		b, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, books[got].ID.String(), b.GetId())
+		got++
+	}
+	assert.Equal(t, len(books), got)
+	assert.Len(t, u.ListCalls(), 2)
+	for _, c := range u.ListCalls() {
+		assert.Equal(t, listAllPageSize, c.Size)
+	}
+}
+
+func TestHealthAndReflection(t *testing.T) {
+	conn := startServer(t, &UsecaseMock{})
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{
+		Service: bookv1.BookService_ServiceDesc.ServiceName,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	reply, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, s := range reply.GetListServicesResponse().GetService() {
+		services = append(services, s.GetName())
+	}
+	assert.Contains(t, services, bookv1.BookService_ServiceDesc.ServiceName)
+}
+
+// pagedList эмулирует keyset-пагинацию usecase поверх среза, упорядоченного по created_at.
+func pagedList(books []models.Book) func(ctx context.Context, after *models.BookCursor, size int) (models.BookPage, error) {
+	return func(ctx context.Context, after *models.BookCursor, size int) (models.BookPage, error) {
+		start := 0
+		if after != nil {
+			for i, b := range books {
+				if b.ID == after.ID {
+					start = i + 1
+				}
+			}
+		}
+		size = models.NormalizeBookPageSize(size)
+		end := min(start+size, len(books))
+		page := models.BookPage{Books: books[start:end]}
+		if end < len(books) {
+			next := models.CursorOf(books[end-1])
+			page.Next = &next
+		}
+		return page, nil
+	}
+}
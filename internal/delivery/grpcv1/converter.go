@@ -0,0 +1,33 @@
+package grpcv1
+
+import (
+	"book-store-api/internal/delivery/grpcv1/bookv1"
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoBook(b models.Book) *bookv1.Book {
+	return &bookv1.Book{
+		Id:          b.ID.String(),
+		Title:       b.Title,
+		Description: b.Description,
+		Author:      b.Author,
+		Isbn:        b.ISBN,
+		Price:       int64(b.Price),
+		CreatedAt:   timestamppb.New(b.CreatedAt),
+		UpdatedAt:   timestamppb.New(b.UpdatedAt),
+	}
+}
+
+func toBookParams(id uuid.UUID, in *bookv1.BookInput) models.BookParams {
+	return models.BookParams{
+		ID:          id,
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+		Author:      in.GetAuthor(),
+		ISBN:        in.GetIsbn(),
+		Price:       int(in.GetPrice()),
+	}
+}
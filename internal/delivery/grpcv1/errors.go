@@ -0,0 +1,48 @@
+package grpcv1
+
+import (
+	"errors"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError повторяет отображение ошибок из httpv1.Handler:
+// 422 -> InvalidArgument, 404 -> NotFound, остальное -> Internal без деталей.
+func (s *BookService) statusError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrDomainValidation):
+		return validationStatus(err)
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		s.logger.Error("grpc book request failed", "err", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+// validationStatus прикладывает нарушенные поля как google.rpc.BadRequest.
+func validationStatus(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	var verr *models.ValidationError
+	if !errors.As(err, &verr) {
+		return st.Err()
+	}
+
+	details := &errdetails.BadRequest{}
+	for _, f := range verr.Fields {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Message,
+			Reason:      f.Code,
+		})
+	}
+	if withDetails, detailsErr := st.WithDetails(details); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
@@ -0,0 +1,183 @@
+package grpcv1
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/delivery/grpcv1/bookv1"
+	"book-store-api/internal/models"
+	"book-store-api/internal/reqctx"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDKey = "x-request-id"
+
+type TokenVerifier interface {
+	Verify(token string) (auth.Principal, error)
+}
+
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (auth.Principal, error)
+}
+
+// catalogWrite — та же политика, что у POST/PUT/DELETE /book в httpv1.
+var catalogWrite = auth.Policy{
+	Roles:  []string{auth.RoleAdmin, auth.RoleCatalogEditor},
+	Scopes: []string{models.ScopeCatalogWrite},
+}
+
+// methodPolicies — методы, закрытые авторизацией; чтение каталога публичное, как в HTTP.
+var methodPolicies = map[string]auth.Policy{
+	bookv1.BookService_Create_FullMethodName: catalogWrite,
+	bookv1.BookService_Update_FullMethodName: catalogWrite,
+	bookv1.BookService_Delete_FullMethodName: catalogWrite,
+}
+
+// interceptor собирает контекст запроса так же, как цепочка middleware в httpv1:
+// request id, IP клиента, лимиты, principal, проверка прав и лог.
+type interceptor struct {
+	verifier TokenVerifier
+	apiKeys  APIKeyAuthenticator
+	// limits == nil — лимиты выключены
+	limits *RateLimitOptions
+	logger *slog.Logger
+}
+
+func (i *interceptor) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	ctx, err = i.prepare(ctx, info.FullMethod)
+	if err == nil {
+		defer i.recover(ctx, info.FullMethod, &err)
+		resp, err = handler(ctx, req)
+	}
+	i.log(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+func (i *interceptor) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	ctx, err := i.prepare(ss.Context(), info.FullMethod)
+	if err == nil {
+		defer i.recover(ctx, info.FullMethod, &err)
+		err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+	i.log(ctx, info.FullMethod, start, err)
+	return err
+}
+
+func (i *interceptor) prepare(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	reqID := first(md, requestIDKey)
+	if reqID == "" {
+		reqID = uuid.New().String()
+	}
+	ctx = reqctx.WithRequestID(ctx, reqID)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, reqID))
+
+	if p, ok := peer.FromContext(ctx); ok {
+		ip := p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		ctx = reqctx.WithClientIP(ctx, ip)
+	}
+
+	if err := i.allowPreAuth(ctx); err != nil {
+		return ctx, err
+	}
+	ctx, err := i.authenticate(ctx, md)
+	if err != nil {
+		return ctx, err
+	}
+	if err := i.allowMethod(ctx, method); err != nil {
+		return ctx, err
+	}
+
+	policy, ok := methodPolicies[method]
+	if !ok {
+		return ctx, nil
+	}
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return ctx, status.Error(codes.Unauthenticated, "authorization required")
+	}
+	if !policy.Allows(principal) {
+		return ctx, status.Error(codes.PermissionDenied, "forbidden")
+	}
+	return ctx, nil
+}
+
+// authenticate принимает Bearer-токен из authorization или ключ из x-api-key;
+// при наличии обоих побеждает токен, как в JWTMiddleware/APIKeyMiddleware.
+func (i *interceptor) authenticate(ctx context.Context, md metadata.MD) (context.Context, error) {
+	if header := first(md, "authorization"); header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return ctx, status.Error(codes.Unauthenticated, "invalid authorization metadata")
+		}
+		principal, err := i.verifier.Verify(strings.TrimSpace(token))
+		if err != nil {
+			return ctx, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return auth.WithPrincipal(ctx, principal), nil
+	}
+
+	if key := first(md, "x-api-key"); key != "" {
+		principal, err := i.apiKeys.Authenticate(ctx, key)
+		if err != nil {
+			if errors.Is(err, usecase.ErrInvalidAPIKey) {
+				return ctx, status.Error(codes.Unauthenticated, "invalid api key")
+			}
+			return ctx, status.Error(codes.Internal, "internal server error")
+		}
+		return auth.WithPrincipal(ctx, principal), nil
+	}
+	return ctx, nil
+}
+
+func (i *interceptor) recover(ctx context.Context, method string, err *error) {
+	if r := recover(); r != nil {
+		i.logger.Error("grpc panic", "method", method, "panic", r, "request_id", reqctx.RequestID(ctx), "stack", string(debug.Stack()))
+		*err = status.Error(codes.Internal, "internal server error")
+	}
+}
+
+func (i *interceptor) log(ctx context.Context, method string, start time.Time, err error) {
+	i.logger.Info("grpc request finished",
+		"method", method,
+		"code", status.Code(err).String(),
+		"duration", time.Since(start),
+		"request_id", reqctx.RequestID(ctx),
+	)
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// serverStream подменяет контекст потока на обогащённый интерсептором.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
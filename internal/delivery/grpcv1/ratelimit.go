@@ -0,0 +1,77 @@
+package grpcv1
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/ratelimit"
+	"book-store-api/internal/reqctx"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimitOptions — те же хранилище и правила, что у middleware.RateLimit в httpv1.
+// Маршрут правила — полное имя метода, например "/book.v1.BookService/ListAll"; методы
+// без своего правила считаются по "*" на общем с HTTP счётчике клиента.
+type RateLimitOptions struct {
+	Limiter ratelimit.Limiter
+	Rules   ratelimit.Rules
+	// PreAuth — лимит по IP до аутентификации, как PreAuthRateLimit; нулевой отключает его
+	PreAuth ratelimit.Limit
+}
+
+// allowPreAuth ограничивает перебор токенов и ключей по IP до их проверки.
+func (i *interceptor) allowPreAuth(ctx context.Context) error {
+	if i.limits == nil || i.limits.PreAuth.Requests == 0 {
+		return nil
+	}
+	return i.allow(ctx, "preauth:"+string(ratelimit.KindIP)+":"+reqctx.ClientIP(ctx), i.limits.PreAuth)
+}
+
+// allowMethod ограничивает вызов по методу и клиенту: API-ключу, пользователю или IP.
+func (i *interceptor) allowMethod(ctx context.Context, method string) error {
+	if i.limits == nil {
+		return nil
+	}
+	kind, client := clientKey(ctx)
+	limit, matched, ok := i.limits.Rules.Lookup(method, kind)
+	if !ok && kind != ratelimit.KindIP {
+		// для типа клиента нет своего правила — ограничиваем как анонимного
+		kind, client = ratelimit.KindIP, reqctx.ClientIP(ctx)
+		limit, matched, ok = i.limits.Rules.Lookup(method, kind)
+	}
+	if !ok {
+		return nil
+	}
+	return i.allow(ctx, matched+":"+string(kind)+":"+client, limit)
+}
+
+// allow списывает вызов со счётчика key; при превышении возвращает ResourceExhausted
+// с retry-after в заголовках ответа. Ошибка хранилища лимитов вызов не блокирует.
+func (i *interceptor) allow(ctx context.Context, key string, limit ratelimit.Limit) error {
+	res, err := i.limits.Limiter.Allow(ctx, key, limit)
+	if err != nil {
+		i.logger.Error("rate limiter error", "err", err)
+		return nil
+	}
+	if !res.Allowed {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(res.Reset.Seconds())))))
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return nil
+}
+
+func clientKey(ctx context.Context) (ratelimit.Kind, string) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		if principal.Type == auth.PrincipalAPIKey {
+			return ratelimit.KindAPIKey, principal.Subject
+		}
+		return ratelimit.KindUser, principal.Subject
+	}
+	return ratelimit.KindIP, reqctx.ClientIP(ctx)
+}
@@ -0,0 +1,87 @@
+package grpcv1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"book-store-api/internal/delivery/grpcv1/bookv1"
+	"book-store-api/internal/models"
+	"book-store-api/internal/ratelimit"
+)
+
+func limitedClient(t *testing.T, rules string, preAuth ratelimit.Limit) bookv1.BookServiceClient {
+	t.Helper()
+	parsed, err := ratelimit.ParseRules(rules)
+	require.NoError(t, err)
+	book := models.Book{ID: uuid.New(), Title: "Солярис"}
+	u := &UsecaseMock{
+		GetByIDFunc: func(ctx context.Context, id string) (*models.Book, error) { return &book, nil },
+		ListFunc:    pagedList([]models.Book{book}),
+	}
+	limits := &RateLimitOptions{Limiter: ratelimit.NewMemoryLimiter(), Rules: parsed, PreAuth: preAuth}
+	return bookv1.NewBookServiceClient(startLimitedServer(t, u, limits))
+}
+
+func listAll(ctx context.Context, client bookv1.BookServiceClient) error {
+	stream, err := client.ListAll(ctx, &bookv1.ListAllRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	client := limitedClient(t, "* ip=3/1m; /book.v1.BookService/ListAll ip=1/1m; * user=100/1m",
+		ratelimit.Limit{Requests: 100, Window: time.Minute})
+
+	require.NoError(t, listAll(context.Background(), client))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(listAll(context.Background(), client)),
+		"streaming the whole catalog is limited like any other read")
+
+	get := &bookv1.GetRequest{Id: uuid.NewString()}
+	for range 3 {
+		_, err := client.Get(context.Background(), get)
+		require.NoError(t, err, "method without its own rule has a separate counter")
+	}
+	var header metadata.MD
+	_, err := client.Get(context.Background(), get, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"60"}, header.Get("retry-after"))
+
+	_, err = client.Get(withMD("authorization", "Bearer "+readerToken), get)
+	assert.NoError(t, err, "users are counted by subject, not by ip")
+	assert.NoError(t, listAll(withMD("authorization", "Bearer "+readerToken), client),
+		"ListAll has only an ip rule: users get the * user rule")
+}
+
+func TestPreAuthRateLimit(t *testing.T) {
+	client := limitedClient(t, "* user=100/1m", ratelimit.Limit{Requests: 2, Window: 30 * time.Second})
+	get := &bookv1.GetRequest{Id: uuid.NewString()}
+
+	for range 2 {
+		_, err := client.Get(withMD("authorization", "Bearer forged"), get)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+	_, err := client.Get(withMD("authorization", "Bearer forged"), get)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "bad credentials are throttled before authentication")
+	_, err = client.Get(withMD("authorization", "Bearer "+editorToken), get)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,35 @@
+package grpcv1
+
+import (
+	"log/slog"
+
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/grpcv1/bookv1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// InitServer собирает gRPC-сервер поверх того же delivery.Usecase, что и HTTP.
+// Возвращаемый health.Server нужно перевести в NOT_SERVING перед остановкой.
+// limits == nil отключает ограничение частоты вызовов.
+func InitServer(u delivery.Usecase, verifier TokenVerifier, apiKeys APIKeyAuthenticator, limits *RateLimitOptions, enableReflection bool, logger *slog.Logger) (*grpc.Server, *health.Server) {
+	i := &interceptor{verifier: verifier, apiKeys: apiKeys, limits: limits, logger: logger}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(i.unary),
+		grpc.ChainStreamInterceptor(i.stream),
+	)
+
+	bookv1.RegisterBookServiceServer(server, NewBookService(u, logger))
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	healthServer.SetServingStatus(bookv1.BookService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	if enableReflection {
+		reflection.Register(server)
+	}
+	return server, healthServer
+}
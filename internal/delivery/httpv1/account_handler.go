@@ -0,0 +1,392 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type AccountHandler struct {
+	usecase delivery.AccountUsecase
+	logger  *slog.Logger
+}
+
+func NewAccountHandler(u delivery.AccountUsecase, logger *slog.Logger) *AccountHandler {
+	return &AccountHandler{usecase: u, logger: logger}
+}
+
+func (h *AccountHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/account/register", h.Register).Methods("POST")
+	router.HandleFunc("/account/verify-email", h.VerifyEmail).Methods("POST")
+	router.HandleFunc("/account/verify-email/resend", h.ResendVerification).Methods("POST")
+	router.HandleFunc("/account/login", h.Login).Methods("POST")
+	router.HandleFunc("/account/password/forgot", h.ForgotPassword).Methods("POST")
+	router.HandleFunc("/account/password/reset", h.ResetPassword).Methods("POST")
+
+	private := router.PathPrefix("/account").Subrouter()
+	private.Use(middleware.RequireRoles(auth.RoleCustomer))
+	private.HandleFunc("/profile", h.GetProfile).Methods("GET")
+	private.HandleFunc("/profile", h.UpdateProfile).Methods("PUT")
+	private.HandleFunc("/addresses", h.ListAddresses).Methods("GET")
+	private.HandleFunc("/addresses", h.AddAddress).Methods("POST")
+	private.HandleFunc("/addresses/{id}", h.UpdateAddress).Methods("PUT")
+	private.HandleFunc("/addresses/{id}", h.DeleteAddress).Methods("DELETE")
+}
+
+// @Summary Регистрация покупателя
+// @Description Создает аккаунт и отправляет письмо для подтверждения email; на занятый email отвечает так же, а владельцу шлет письмо о попытке регистрации
+// @Tags account
+// @Accept json,xml,application/msgpack
+// @Param account body dto.RegisterRequest true "Account data"
+// @Success 202 {string} string "accepted"
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Failure 415 {object} dto.ProblemDTO "unsupported Content-Type"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /account/register [post]
+func (h *AccountHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req dto.RegisterRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	if err := h.usecase.Register(r.Context(), converter.ToRegistrationParams(req)); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// @Summary Подтвердить email
+// @Tags account
+// @Accept json,xml,application/msgpack
+// @Param token body dto.TokenRequest true "Token from the email"
+// @Success 204 {string} string "no content"
+// @Failure 400 {object} dto.ProblemDTO "invalid or expired token"
+// @Router /account/verify-email [post]
+func (h *AccountHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req dto.TokenRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	if err := h.usecase.VerifyEmail(r.Context(), req.Token); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Отправить письмо подтверждения повторно
+// @Tags account
+// @Accept json,xml,application/msgpack
+// @Param email body dto.EmailRequest true "Account email"
+// @Success 202 {string} string "accepted"
+// @Router /account/verify-email/resend [post]
+func (h *AccountHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req dto.EmailRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	if err := h.usecase.ResendVerification(r.Context(), req.Email); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// @Summary Вход покупателя
+// @Tags account
+// @Accept json,xml,application/msgpack
+// @Produce json,xml,application/msgpack
+// @Param credentials body dto.LoginRequest true "Credentials"
+// @Success 200 {object} dto.AccessTokenDTO
+// @Failure 401 {object} dto.ProblemDTO "invalid email or password"
+// @Failure 403 {object} dto.ProblemDTO "email is not verified"
+// @Router /account/login [post]
+func (h *AccountHandler) Login(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.LoginRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	token, err := h.usecase.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToAccessTokenResponse(token))
+}
+
+// @Summary Запросить сброс пароля
+// @Description Отправляет письмо со ссылкой для сброса, если аккаунт существует
+// @Tags account
+// @Accept json,xml,application/msgpack
+// @Param email body dto.EmailRequest true "Account email"
+// @Success 202 {string} string "accepted"
+// @Router /account/password/forgot [post]
+func (h *AccountHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.EmailRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	if err := h.usecase.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// @Summary Сбросить пароль
+// @Tags account
+// @Accept json,xml,application/msgpack
+// @Param reset body dto.ResetPasswordRequest true "Token and new password"
+// @Success 204 {string} string "no content"
+// @Failure 400 {object} dto.ProblemDTO "invalid or expired token"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Router /account/password/reset [post]
+func (h *AccountHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ResetPasswordRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	if err := h.usecase.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Профиль покупателя
+// @Tags account
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Success 200 {object} dto.ProfileDTO
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Router /account/profile [get]
+func (h *AccountHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := customerID(r)
+	if !ok {
+		problem.Write(w, r, problem.Forbidden, "forbidden")
+		return
+	}
+
+	customer, err := h.usecase.GetProfile(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToProfileResponse(customer))
+}
+
+// @Summary Обновить профиль
+// @Tags account
+// @Accept json,xml,application/msgpack
+// @Security BearerAuth
+// @Param profile body dto.ProfileRequest true "Profile data"
+// @Success 204 {string} string "no content"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Router /account/profile [put]
+func (h *AccountHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	id, ok := customerID(r)
+	if !ok {
+		problem.Write(w, r, problem.Forbidden, "forbidden")
+		return
+	}
+
+	var req dto.ProfileRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	if err := h.usecase.UpdateProfile(r.Context(), converter.ToProfileParams(id, req)); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Адресная книга
+// @Tags account
+// @Produce json,xml,text/csv,application/msgpack
+// @Security BearerAuth
+// @Success 200 {array} dto.AddressDTO
+// @Router /account/addresses [get]
+func (h *AccountHandler) ListAddresses(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.NegotiateList(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := customerID(r)
+	if !ok {
+		problem.Write(w, r, problem.Forbidden, "forbidden")
+		return
+	}
+
+	addresses, err := h.usecase.ListAddresses(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToAddressResponseList(addresses))
+}
+
+// @Summary Добавить адрес
+// @Tags account
+// @Accept json,xml,application/msgpack
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Param address body dto.AddressRequest true "Address"
+// @Success 201 {string} string "created id"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Router /account/addresses [post]
+func (h *AccountHandler) AddAddress(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	customer, ok := customerID(r)
+	if !ok {
+		problem.Write(w, r, problem.Forbidden, "forbidden")
+		return
+	}
+
+	var req dto.AddressRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	id, err := h.usecase.AddAddress(r.Context(), converter.ToAddressParams(customer, req))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusCreated, map[string]string{"id": id})
+}
+
+// @Summary Обновить адрес
+// @Tags account
+// @Accept json,xml,application/msgpack
+// @Security BearerAuth
+// @Param id path string true "Address ID"
+// @Param address body dto.AddressRequest true "Address"
+// @Success 204 {string} string "no content"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Router /account/addresses/{id} [put]
+func (h *AccountHandler) UpdateAddress(w http.ResponseWriter, r *http.Request) {
+	customer, ok := customerID(r)
+	if !ok {
+		problem.Write(w, r, problem.Forbidden, "forbidden")
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	var req dto.AddressRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	params := converter.ToAddressParams(customer, req)
+	params.ID = id
+	if err := h.usecase.UpdateAddress(r.Context(), params); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Удалить адрес
+// @Tags account
+// @Security BearerAuth
+// @Param id path string true "Address ID"
+// @Success 204 {string} string "no content"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Router /account/addresses/{id} [delete]
+func (h *AccountHandler) DeleteAddress(w http.ResponseWriter, r *http.Request) {
+	customer, ok := customerID(r)
+	if !ok {
+		problem.Write(w, r, problem.Forbidden, "forbidden")
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	if err := h.usecase.DeleteAddress(r.Context(), customer, id); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AccountHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, models.ErrDomainValidation):
+		problem.Validation(w, r, err)
+	case errors.Is(err, repository.ErrNotFound):
+		problem.Write(w, r, problem.NotFound, err.Error())
+	case errors.Is(err, usecase.ErrInvalidCredentials):
+		problem.Write(w, r, problem.InvalidCredentials, err.Error())
+	case errors.Is(err, usecase.ErrEmailNotVerified):
+		problem.Write(w, r, problem.EmailNotVerified, err.Error())
+	case errors.Is(err, usecase.ErrInvalidToken):
+		problem.Write(w, r, problem.ExpiredToken, err.Error())
+	default:
+		h.logger.Error("account request failed", "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+	}
+}
+
+// customerID достаёт id покупателя из principal, выпущенного при входе.
+func customerID(r *http.Request) (uuid.UUID, bool) {
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok || !principal.HasRole(auth.RoleCustomer) {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(principal.Subject)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
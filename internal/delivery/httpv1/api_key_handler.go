@@ -0,0 +1,166 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type APIKeyHandler struct {
+	usecase delivery.APIKeyUsecase
+	logger  *slog.Logger
+}
+
+func NewAPIKeyHandler(u delivery.APIKeyUsecase, logger *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{usecase: u, logger: logger}
+}
+
+func (h *APIKeyHandler) RegisterRoutes(router *mux.Router) {
+	admin := router.PathPrefix("/admin/api-keys").Subrouter()
+	admin.Use(middleware.RequireRoles(auth.RoleAdmin))
+	admin.HandleFunc("", h.CreateAPIKey).Methods("POST")
+	admin.HandleFunc("", h.ListAPIKeys).Methods("GET")
+	admin.HandleFunc("/{id}", h.RevokeAPIKey).Methods("DELETE")
+	admin.HandleFunc("/{id}/rotate", h.RotateAPIKey).Methods("POST")
+}
+
+// @Summary Создать API-ключ
+// @Description Выпускает ключ для интеграции. Ключ в открытом виде возвращается только в этом ответе
+// @Tags api-keys
+// @Accept json,xml,application/msgpack
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Param key body dto.CreateAPIKeyRequest true "Key data"
+// @Success 201 {object} dto.IssuedAPIKeyDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Failure 415 {object} dto.ProblemDTO "unsupported Content-Type"
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /admin/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	issued, err := h.usecase.Create(r.Context(), converter.ToAPIKeyParams(req))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusCreated, converter.ToIssuedAPIKeyResponse(issued))
+}
+
+// @Summary Список API-ключей
+// @Tags api-keys
+// @Produce json,xml,text/csv,application/msgpack
+// @Security BearerAuth
+// @Success 200 {array} dto.APIKeyDTO
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /admin/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.NegotiateList(w, r)
+	if !ok {
+		return
+	}
+
+	keys, err := h.usecase.List(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToAPIKeyResponseList(keys))
+}
+
+// @Summary Отозвать API-ключ
+// @Tags api-keys
+// @Security BearerAuth
+// @Param id path string true "Key ID"
+// @Success 204 {string} string "no content"
+// @Failure 400 {object} dto.ProblemDTO "invalid uuid format"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Router /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	if err := h.usecase.Revoke(r.Context(), id); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Ротация API-ключа
+// @Description Выпускает новый ключ с теми же параметрами, старый продолжает работать в течение grace-периода
+// @Tags api-keys
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Param id path string true "Key ID"
+// @Success 201 {object} dto.IssuedAPIKeyDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid uuid format"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 409 {object} dto.ProblemDTO "api key is revoked or expired"
+// @Router /admin/api-keys/{id}/rotate [post]
+func (h *APIKeyHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	issued, err := h.usecase.Rotate(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusCreated, converter.ToIssuedAPIKeyResponse(issued))
+}
+
+func (h *APIKeyHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, models.ErrDomainValidation):
+		problem.Validation(w, r, err)
+	case errors.Is(err, repository.ErrNotFound):
+		problem.Write(w, r, problem.NotFound, err.Error())
+	case errors.Is(err, usecase.ErrAPIKeyInactive):
+		problem.Write(w, r, problem.Conflict, err.Error())
+	default:
+		h.logger.Error("api key request failed", "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+	}
+}
@@ -0,0 +1,139 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+type AuditHandler struct {
+	usecase delivery.AuditUsecase
+	logger  *slog.Logger
+}
+
+func NewAuditHandler(u delivery.AuditUsecase, logger *slog.Logger) *AuditHandler {
+	return &AuditHandler{usecase: u, logger: logger}
+}
+
+func (h *AuditHandler) RegisterRoutes(router *mux.Router) {
+	admin := router.PathPrefix("/admin/audit-events").Subrouter()
+	admin.Use(middleware.RequireRoles(auth.RoleAdmin))
+	admin.HandleFunc("", h.ListEvents).Methods("GET")
+	admin.HandleFunc("/verify", h.VerifyChain).Methods("GET")
+}
+
+// @Summary Журнал аудита
+// @Description События изменения данных от новых к старым, с фильтрами и курсорной пагинацией
+// @Tags audit
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Param actor query string false "Actor subject"
+// @Param entity query string false "Entity type, e.g. book"
+// @Param entity_id query string false "Entity ID"
+// @Param action query string false "create, update, delete or merge"
+// @Param from query string false "RFC3339, inclusive"
+// @Param to query string false "RFC3339, exclusive"
+// @Param before_id query int false "Cursor from next_cursor"
+// @Param limit query int false "Page size, max 500"
+// @Success 200 {object} dto.AuditEventListDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid query"
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /admin/audit-events [get]
+func (h *AuditHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid query: "+err.Error())
+		return
+	}
+
+	events, err := h.usecase.List(r.Context(), filter)
+	if err != nil {
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToAuditEventListResponse(events, filter.Limit))
+}
+
+// @Summary Проверить цепочку аудита
+// @Description Пересчитывает хэши всех событий и возвращает id первой испорченной записи
+// @Tags audit
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Success 200 {object} dto.AuditVerificationDTO
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /admin/audit-events/verify [get]
+func (h *AuditHandler) VerifyChain(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := h.usecase.Verify(r.Context())
+	if err != nil {
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+	if !res.Valid {
+		h.logger.Error("audit chain is broken", "broken_at", res.BrokenAt)
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToAuditVerificationResponse(res))
+}
+
+func parseAuditFilter(r *http.Request) (models.AuditFilter, error) {
+	q := r.URL.Query()
+	f := models.AuditFilter{
+		Actor:    q.Get("actor"),
+		Entity:   q.Get("entity"),
+		EntityID: q.Get("entity_id"),
+		Action:   q.Get("action"),
+	}
+
+	for name, dst := range map[string]**time.Time{"from": &f.From, "to": &f.To} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return models.AuditFilter{}, err
+			}
+			*dst = &t
+		}
+	}
+	if v := q.Get("before_id"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return models.AuditFilter{}, errors.New("before_id must be a positive integer")
+		}
+		f.BeforeID = n
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return models.AuditFilter{}, errors.New("limit must be a positive integer")
+		}
+		f.Limit = n
+	}
+	f.Limit = models.NormalizeAuditLimit(f.Limit)
+	return f, nil
+}
@@ -0,0 +1,95 @@
+package httpv1
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Маршруты книг с настоящими JWT- и API-key-middleware: чтение открыто, запись — только
+// редакторам каталога и ключам со scope catalog:write.
+func TestBookRoutes_Authorization(t *testing.T) {
+	secret := []byte("test-secret")
+	issuer := auth.NewIssuer(secret, "book-store-api", "", time.Hour)
+	verifier := auth.NewVerifier(auth.VerifierOptions{Secret: secret, Issuer: "book-store-api"})
+	keys := &APIKeyAuthenticatorMock{AuthenticateFunc: func(ctx context.Context, key string) (auth.Principal, error) {
+		switch key {
+		case "writer":
+			return auth.Principal{Type: auth.PrincipalAPIKey, Subject: key, Scopes: []string{models.ScopeCatalogWrite}}, nil
+		case "reader":
+			return auth.Principal{Type: auth.PrincipalAPIKey, Subject: key, Scopes: []string{models.ScopeCatalogRead}}, nil
+		}
+		return auth.Principal{}, usecase.ErrInvalidAPIKey
+	}}
+
+	book := exportBooks(1)[0]
+	u := &UsecaseMock{
+		GetAllFunc:     func(ctx context.Context) ([]models.Book, error) { return []models.Book{book}, nil },
+		GetByIDFunc:    func(ctx context.Context, id string) (*models.Book, error) { return &book, nil },
+		CreateFunc:     func(ctx context.Context, bookInfo models.BookParams) (string, error) { return uuid.NewString(), nil },
+		UpdateFunc:     func(ctx context.Context, bookInfo models.BookParams) error { return nil },
+		DeleteBookFunc: func(ctx context.Context, id string) error { return nil },
+	}
+	router := mux.NewRouter()
+	router.Use(middleware.JWTMiddleware(verifier), middleware.APIKeyMiddleware(keys))
+	NewBookHandler(u, &BookQueryUsecaseMock{}, slog.New(slog.NewTextHandler(io.Discard, nil))).RegisterRoutes(router)
+
+	bearer := func(roles ...string) http.Header {
+		token, _, err := issuer.Issue("user-1", roles)
+		require.NoError(t, err)
+		return http.Header{"Authorization": {"Bearer " + token}}
+	}
+	serve := func(method, target string, header http.Header) int {
+		body := ""
+		if method == http.MethodPost || method == http.MethodPut {
+			body = `{"title":"Солярис","author":"Лем","price":1250,"isbn":"9785171183660"}`
+		}
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	item := "/book/" + book.ID.String()
+	writes := []struct{ method, target string }{
+		{http.MethodPost, "/book"},
+		{http.MethodPut, item},
+		{http.MethodDelete, item},
+	}
+	for _, w := range writes {
+		name := w.method + " " + w.target
+		assert.Equal(t, http.StatusUnauthorized, serve(w.method, w.target, nil), "anonymous %s", name)
+		assert.Equal(t, http.StatusUnauthorized, serve(w.method, w.target, http.Header{"Authorization": {"Bearer forged"}}), "forged token %s", name)
+		assert.Equal(t, http.StatusForbidden, serve(w.method, w.target, bearer(auth.RoleCustomer)), "customer %s", name)
+		assert.Equal(t, http.StatusForbidden, serve(w.method, w.target, http.Header{"X-Api-Key": {"reader"}}), "read-only key %s", name)
+		assert.Less(t, serve(w.method, w.target, bearer(auth.RoleCatalogEditor)), 300, "editor %s", name)
+		assert.Less(t, serve(w.method, w.target, bearer(auth.RoleAdmin)), 300, "admin %s", name)
+		assert.Less(t, serve(w.method, w.target, http.Header{"X-Api-Key": {"writer"}}), 300, "writer key %s", name)
+	}
+	assert.Len(t, u.CreateCalls(), 3)
+	assert.Len(t, u.UpdateCalls(), 3)
+	assert.Len(t, u.DeleteBookCalls(), 3, "rejected requests never reach the usecase")
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/book", nil))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, item, nil))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/book", bearer(auth.RoleCustomer)))
+}
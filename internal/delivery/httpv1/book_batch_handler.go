@@ -0,0 +1,85 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+type BookBatchHandler struct {
+	usecase delivery.BookBatchUsecase
+	logger  *slog.Logger
+}
+
+func NewBookBatchHandler(u delivery.BookBatchUsecase, logger *slog.Logger) *BookBatchHandler {
+	return &BookBatchHandler{usecase: u, logger: logger}
+}
+
+func (h *BookBatchHandler) RegisterRoutes(router *mux.Router) {
+	catalogWrite := middleware.Authorize(auth.Policy{
+		Roles:  []string{auth.RoleAdmin, auth.RoleCatalogEditor},
+		Scopes: []string{models.ScopeCatalogWrite},
+	})
+	router.Handle("/book/batch", catalogWrite(http.HandlerFunc(h.Batch))).Methods("POST")
+}
+
+// @Summary Пакетные изменения книг
+// @Description Создаёт, обновляет и удаляет до 500 книг за один запрос. В режиме atomic любая ошибка
+// @Description откатывает весь пакет (422 с результатами по элементам), в режиме best_effort
+// @Description валидные элементы записываются, а ошибочные возвращаются со статусом invalid или not_found.
+// @Tags books
+// @Accept json,xml,application/msgpack
+// @Produce json,xml,application/msgpack
+// @Param batch body dto.BookBatchRequest true "Operations"
+// @Success 200 {object} dto.BookBatchResponse
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Failure 415 {object} dto.ProblemDTO "unsupported Content-Type"
+// @Failure 422 {object} dto.BookBatchResponse "atomic batch rolled back; invalid envelope is reported as dto.ProblemDTO"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /book/batch [post]
+func (h *BookBatchHandler) Batch(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+
+	var req dto.BookBatchRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	mode, ops := converter.ToBookBatchOps(req)
+	res, err := h.usecase.Batch(ctx, mode, ops)
+	if err != nil {
+		if errors.Is(err, models.ErrDomainValidation) {
+			problem.Validation(w, r, err)
+			return
+		}
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+
+	status := http.StatusOK
+	if !res.Committed {
+		status = http.StatusUnprocessableEntity
+	}
+	if err := enc.Respond(w, status, converter.ToBookBatchResponse(res)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
@@ -0,0 +1,240 @@
+package httpv1
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// coverCacheControl: URL миниатюры меняется с каждой загрузкой, поэтому содержимое по нему неизменно.
+const coverCacheControl = "public, max-age=31536000, immutable"
+
+type CoverHandler struct {
+	usecase delivery.CoverUsecase
+	// maxFileSize — предел размера загружаемого изображения в байтах
+	maxFileSize int64
+	logger      *slog.Logger
+}
+
+func NewCoverHandler(u delivery.CoverUsecase, maxFileSize int64, logger *slog.Logger) *CoverHandler {
+	return &CoverHandler{usecase: u, maxFileSize: maxFileSize, logger: logger}
+}
+
+func (h *CoverHandler) RegisterRoutes(router *mux.Router) {
+	catalogWrite := middleware.Authorize(auth.Policy{
+		Roles:  []string{auth.RoleAdmin, auth.RoleCatalogEditor},
+		Scopes: []string{models.ScopeCatalogWrite},
+	})
+	router.Handle("/book/{id}/cover", catalogWrite(http.HandlerFunc(h.UploadCover))).Methods("POST")
+	router.Handle("/book/{id}/cover", catalogWrite(http.HandlerFunc(h.DeleteCover))).Methods("DELETE")
+	router.HandleFunc("/covers/{id}/{version}/{file}", h.GetCover).Methods("GET", "HEAD")
+}
+
+// @Summary Загрузка обложки книги
+// @Description Принимает JPEG, PNG или WebP от 100×100 до 10000×10000 пикселей; тип проверяется по содержимому файла.
+// @Description Изображение поворачивается по EXIF, метаданные удаляются. Миниатюры small (160), medium (400) и large (800 px
+// @Description по ширине) сохраняются в JPEG и WebP под новой версией, прежняя обложка удаляется.
+// @Tags books
+// @Accept mpfd
+// @Produce json,xml,application/msgpack
+// @Param id path string true "Book ID"
+// @Param file formData file true "Cover image"
+// @Success 200 {array} dto.CoverImageDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid request"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 413 {object} dto.ProblemDTO "file too large"
+// @Failure 415 {object} dto.ProblemDTO "unsupported image type"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Failure 502 {object} dto.ProblemDTO "blob storage error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /book/{id}/cover [post]
+func (h *CoverHandler) UploadCover(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+	idParam := mux.Vars(r)["id"]
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	// запас на заголовки частей формы
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxFileSize+64<<10)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			problem.Write(w, r, problem.PayloadTooLarge, "file is too large")
+			return
+		}
+		problem.Write(w, r, problem.InvalidRequest, "invalid multipart form")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "file is required")
+		return
+	}
+	defer file.Close()
+	if !acceptedCoverType(header.Header.Get("Content-Type")) {
+		problem.Write(w, r, problem.UnsupportedMedia, "cover must be "+strings.Join(models.CoverContentTypes, ", "))
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(file, h.maxFileSize+1))
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "failed to read file")
+		return
+	}
+	if int64(len(data)) > h.maxFileSize {
+		problem.Write(w, r, problem.PayloadTooLarge, "file is too large")
+		return
+	}
+
+	cover, err := h.usecase.Upload(r.Context(), idParam, data)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := enc.Respond(w, http.StatusOK, converter.ToCoverImages(id, cover)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
+
+// acceptedCoverType: тип части формы проверяется, только если клиент его указал —
+// application/octet-stream означает «не знаю», и решает проверка содержимого.
+func acceptedCoverType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/octet-stream" || slices.Contains(models.CoverContentTypes, mediaType)
+}
+
+// @Summary Удаление обложки книги
+// @Tags books
+// @Param id path string true "Book ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ProblemDTO "invalid uuid format"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /book/{id}/cover [delete]
+func (h *CoverHandler) DeleteCover(w http.ResponseWriter, r *http.Request) {
+	idParam := mux.Vars(r)["id"]
+	if _, err := uuid.Parse(idParam); err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+	if err := h.usecase.Remove(r.Context(), idParam); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Миниатюра обложки
+// @Description Путь берётся из поля cover книги. Ответ кэшируется на год (immutable): новая обложка получает новый URL.
+// @Tags books
+// @Produce image/jpeg,image/webp
+// @Param id path string true "Book ID"
+// @Param version path string true "Cover version"
+// @Param file path string true "{small|medium|large}.{jpg|webp}"
+// @Success 200 {file} file "cover image"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Failure 502 {object} dto.ProblemDTO "blob storage error"
+// @Router /covers/{id}/{version}/{file} [get]
+func (h *CoverHandler) GetCover(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	// версия входит в путь, так что пара версия+файл однозначно задаёт содержимое
+	etag := `"` + vars["version"] + "-" + vars["file"] + `"`
+	if _, ok := models.ParseCoverPath(vars["id"], vars["version"], vars["file"]); ok && etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", coverCacheControl)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	blob, err := h.usecase.Open(r.Context(), vars["id"], vars["version"], vars["file"])
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	defer blob.Body.Close()
+
+	w.Header().Set("Content-Type", blob.ContentType)
+	if blob.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(blob.Size, 10))
+	}
+	if !blob.ModTime.IsZero() {
+		w.Header().Set("Last-Modified", blob.ModTime.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", coverCacheControl)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, blob.Body); err != nil {
+		h.logger.Error("failed to write cover", "err", err)
+	}
+}
+
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *CoverHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		problem.Write(w, r, problem.NotFound, "not found")
+	case errors.Is(err, usecase.ErrUnsupportedImage):
+		problem.Write(w, r, problem.UnsupportedMedia, "cover must be "+strings.Join(models.CoverContentTypes, ", "))
+	case errors.Is(err, models.ErrDomainValidation):
+		problem.Validation(w, r, err)
+	case errors.Is(err, usecase.ErrBlobStorage):
+		problem.Write(w, r, problem.UpstreamUnavailable, "blob storage error")
+	default:
+		problem.Write(w, r, problem.Internal, "internal server error")
+	}
+}
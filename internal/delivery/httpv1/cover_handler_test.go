@@ -0,0 +1,149 @@
+package httpv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+const testCoverVersion = "00112233aabbccdd"
+
+// uploadCover вызывает обработчик напрямую, минуя авторизацию маршрута.
+func uploadCover(u *CoverUsecaseMock, maxFileSize int64, id, contentType string, data []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="cover"`)
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	part, _ := mw.CreatePart(header)
+	part.Write(data)
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/book/"+id+"/cover", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req = mux.SetURLVars(req, map[string]string{"id": id})
+	rec := httptest.NewRecorder()
+	NewCoverHandler(u, maxFileSize, slog.New(slog.NewTextHandler(io.Discard, nil))).UploadCover(rec, req)
+	return rec
+}
+
+func TestUploadCover(t *testing.T) {
+	id := uuid.New()
+	u := &CoverUsecaseMock{UploadFunc: func(ctx context.Context, bookID string, data []byte) (models.BookCover, error) {
+		return models.BookCover{Version: testCoverVersion, Width: 300, Height: 450}, nil
+	}}
+
+	rec := uploadCover(u, 1<<10, id.String(), "image/png", []byte("png bytes"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var images []dto.CoverImageDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &images))
+	require.Len(t, images, 6)
+	assert.Equal(t, dto.CoverImageDTO{
+		Size: "small", Format: "jpeg", Width: 160, Height: 240,
+		URL: "/api/v1/covers/" + id.String() + "/" + testCoverVersion + "/small.jpg",
+	}, images[0])
+	assert.Equal(t, 300, images[5].Width, "narrow covers are not upscaled")
+	assert.Equal(t, "png bytes", string(u.UploadCalls()[0].Data))
+
+	for name, tc := range map[string]struct {
+		contentType string
+		size        int
+		err         error
+		want        int
+	}{
+		"declared type is not an image": {"text/plain", 10, nil, http.StatusUnsupportedMediaType},
+		"file over the limit":           {"image/jpeg", 2 << 10, nil, http.StatusRequestEntityTooLarge},
+		"content is not an image":       {"application/octet-stream", 10, usecase.ErrUnsupportedImage, http.StatusUnsupportedMediaType},
+		"image too small":               {"", 10, models.ValidateCoverSize(50, 50), http.StatusUnprocessableEntity},
+		"storage unavailable":           {"image/webp", 10, usecase.ErrBlobStorage, http.StatusBadGateway},
+	} {
+		u := &CoverUsecaseMock{UploadFunc: func(ctx context.Context, bookID string, data []byte) (models.BookCover, error) {
+			return models.BookCover{}, tc.err
+		}}
+		rec := uploadCover(u, 1<<10, id.String(), tc.contentType, bytes.Repeat([]byte("x"), tc.size))
+		assert.Equal(t, tc.want, rec.Code, name)
+		if tc.err == nil {
+			assert.Empty(t, u.UploadCalls(), name)
+		}
+	}
+}
+
+func TestGetCover(t *testing.T) {
+	id := uuid.NewString()
+	modTime := time.Date(2025, 10, 30, 9, 0, 0, 0, time.UTC)
+	u := &CoverUsecaseMock{OpenFunc: func(ctx context.Context, bookID, version, file string) (models.Blob, error) {
+		if file != "medium.webp" {
+			return models.Blob{}, repository.ErrNotFound
+		}
+		return models.Blob{Body: io.NopCloser(strings.NewReader("RIFF")), Size: 4, ContentType: "image/webp", ModTime: modTime}, nil
+	}}
+	router := mux.NewRouter()
+	NewCoverHandler(u, 1<<20, slog.New(slog.NewTextHandler(io.Discard, nil))).RegisterRoutes(router)
+	get := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	path := "/covers/" + id + "/" + testCoverVersion + "/medium.webp"
+
+	rec := get(path, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "RIFF", rec.Body.String())
+	assert.Equal(t, "image/webp", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "4", rec.Header().Get("Content-Length"))
+	assert.Equal(t, "public, max-age=31536000, immutable", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "Thu, 30 Oct 2025 09:00:00 GMT", rec.Header().Get("Last-Modified"))
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	rec = get(path, http.Header{"If-None-Match": {`"other", ` + etag}})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Len(t, u.OpenCalls(), 1, "revalidation does not touch the storage")
+
+	rec = get("/covers/"+id+"/"+testCoverVersion+"/small.jpg", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestBooks_CoverURLs(t *testing.T) {
+	books := exportBooks(1)
+	books[0].Cover = models.BookCover{Version: testCoverVersion, Width: 1000, Height: 1500}
+	u := &UsecaseMock{GetByIDFunc: func(ctx context.Context, id string) (*models.Book, error) { return &books[0], nil }}
+
+	rec := serveBooks(t, u, "/book/"+books[0].ID.String(), nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got dto.BookDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got.Cover, 6)
+	assert.Equal(t, "/api/v1/covers/"+books[0].ID.String()+"/"+testCoverVersion+"/large.webp", got.Cover[5].URL)
+	assert.Equal(t, 1200, got.Cover[5].Height)
+
+	books[0].Cover = models.BookCover{}
+	rec = serveBooks(t, u, "/book/"+books[0].ID.String(), nil)
+	assert.NotContains(t, rec.Body.String(), `"cover"`)
+}
@@ -0,0 +1,152 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type DuplicateHandler struct {
+	usecase delivery.DuplicateUsecase
+	logger  *slog.Logger
+}
+
+func NewDuplicateHandler(u delivery.DuplicateUsecase, logger *slog.Logger) *DuplicateHandler {
+	return &DuplicateHandler{usecase: u, logger: logger}
+}
+
+func (h *DuplicateHandler) RegisterRoutes(router *mux.Router) {
+	admin := router.PathPrefix("/admin/duplicates").Subrouter()
+	admin.Use(middleware.RequireRoles(auth.RoleAdmin))
+	admin.HandleFunc("", h.ListCandidates).Methods("GET")
+	admin.HandleFunc("/merge", h.Merge).Methods("POST")
+}
+
+// @Summary Кандидаты в дубликаты
+// @Description Пары похожих книг из последнего фонового прохода по каталогу, от самых похожих.
+// @Description Оценка складывается из совпадения ISBN-13, сходства названий по триграммам и совпадения автора.
+// @Description Книги пары читаются заново; пары с уже удалёнными книгами пропускаются.
+// @Tags duplicates
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Param min_score query number false "Minimum score from 0 to 1, default 0.6"
+// @Param limit query int false "Max candidates, default 50, max 500"
+// @Success 200 {object} dto.DuplicateReportDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid query"
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /admin/duplicates [get]
+func (h *DuplicateHandler) ListCandidates(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	minScore, limit, err := parseDuplicateQuery(r)
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid query: "+err.Error())
+		return
+	}
+
+	report, err := h.usecase.Candidates(r.Context(), minScore, limit)
+	if err != nil {
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+
+	if err := enc.Respond(w, http.StatusOK, converter.ToDuplicateReportResponse(report)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
+
+// @Summary Слить дубликаты
+// @Description Оставляет каноничную книгу без изменений и удаляет дубликат. В журнал аудита пишется событие merge
+// @Description на дубликат, дельта-выгрузки сообщают о нём как об удалённом.
+// @Tags duplicates
+// @Accept json,xml,application/msgpack
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Param request body dto.MergeBooksRequest true "Book to keep and book to remove"
+// @Success 200 {object} dto.BookDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid request"
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Failure 404 {object} dto.ProblemDTO "book not found"
+// @Failure 415 {object} dto.ProblemDTO "unsupported media type"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /admin/duplicates/merge [post]
+func (h *DuplicateHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.MergeBooksRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+	verr := &models.ValidationError{}
+	if _, err := uuid.Parse(req.CanonicalID); err != nil {
+		verr.Add("canonical_id", models.FieldInvalid, "invalid uuid format")
+	}
+	if _, err := uuid.Parse(req.DuplicateID); err != nil {
+		verr.Add("duplicate_id", models.FieldInvalid, "invalid uuid format")
+	}
+	if err := verr.Err(); err != nil {
+		problem.Validation(w, r, err)
+		return
+	}
+
+	book, err := h.usecase.Merge(r.Context(), req.CanonicalID, req.DuplicateID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			problem.Write(w, r, problem.NotFound, "book not found")
+		case errors.Is(err, models.ErrDomainValidation):
+			problem.Validation(w, r, err)
+		default:
+			problem.Write(w, r, problem.Internal, "internal server error")
+		}
+		return
+	}
+
+	if err := enc.Respond(w, http.StatusOK, converter.ToBookResponse(book)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
+
+func parseDuplicateQuery(r *http.Request) (float64, int, error) {
+	q := r.URL.Query()
+	minScore, limit := models.DefaultDuplicateScore, 0
+	if v := q.Get("min_score"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f > 1 {
+			return 0, 0, errors.New("min_score must be a number from 0 to 1")
+		}
+		minScore = f
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return 0, 0, errors.New("limit must be a positive integer")
+		}
+		limit = n
+	}
+	return minScore, limit, nil
+}
@@ -0,0 +1,90 @@
+package httpv1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+)
+
+func TestListDuplicateCandidates(t *testing.T) {
+	books := exportBooks(2)
+	scannedAt := time.Date(2025, 11, 3, 4, 0, 0, 0, time.UTC)
+	u := &DuplicateUsecaseMock{CandidatesFunc: func(ctx context.Context, minScore float64, limit int) (models.DuplicateReport, error) {
+		return models.DuplicateReport{ScannedAt: scannedAt, Candidates: []models.DuplicateCandidate{{
+			Pair:  models.DuplicatePair{BookIDs: [2]uuid.UUID{books[0].ID, books[1].ID}, Score: 2.0 / 3, TitleSimilarity: 1},
+			Books: [2]models.Book{books[0], books[1]},
+		}}}, nil
+	}}
+	h := NewDuplicateHandler(u, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	list := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ListCandidates(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := list("/admin/duplicates?min_score=0.65&limit=10")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got dto.DuplicateReportDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, scannedAt, *got.ScannedAt)
+	require.Len(t, got.Candidates, 1)
+	assert.Equal(t, 0.667, got.Candidates[0].Score)
+	assert.Equal(t, books[1].ID, got.Candidates[0].Books[1].ID)
+	assert.Equal(t, 0.65, u.CandidatesCalls()[0].MinScore)
+	assert.Equal(t, 10, u.CandidatesCalls()[0].Limit)
+
+	list("/admin/duplicates")
+	assert.Equal(t, models.DefaultDuplicateScore, u.CandidatesCalls()[1].MinScore)
+
+	assert.Equal(t, http.StatusBadRequest, list("/admin/duplicates?min_score=1.5").Code)
+	assert.Equal(t, http.StatusBadRequest, list("/admin/duplicates?limit=-1").Code)
+	assert.Len(t, u.CandidatesCalls(), 2)
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	canonical := exportBooks(1)[0]
+	u := &DuplicateUsecaseMock{MergeFunc: func(ctx context.Context, canonicalID, duplicateID string) (models.Book, error) {
+		if canonicalID != canonical.ID.String() {
+			return models.Book{}, repository.ErrNotFound
+		}
+		return canonical, nil
+	}}
+	h := NewDuplicateHandler(u, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	merge := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/admin/duplicates/merge", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.Merge(rec, req)
+		return rec
+	}
+
+	duplicate := uuid.NewString()
+	rec := merge(`{"canonical_id": "` + canonical.ID.String() + `", "duplicate_id": "` + duplicate + `"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got dto.BookDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, canonical.ID, got.ID)
+	assert.Equal(t, duplicate, u.MergeCalls()[0].DuplicateID)
+
+	rec = merge(`{"canonical_id": "` + duplicate + `", "duplicate_id": "` + canonical.ID.String() + `"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = merge(`{"canonical_id": "` + canonical.ID.String() + `"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "duplicate_id")
+	assert.Len(t, u.MergeCalls(), 2)
+}
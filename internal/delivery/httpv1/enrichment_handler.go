@@ -0,0 +1,175 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type EnrichmentHandler struct {
+	usecase delivery.EnrichmentUsecase
+	logger  *slog.Logger
+}
+
+func NewEnrichmentHandler(u delivery.EnrichmentUsecase, logger *slog.Logger) *EnrichmentHandler {
+	return &EnrichmentHandler{usecase: u, logger: logger}
+}
+
+func (h *EnrichmentHandler) RegisterRoutes(router *mux.Router) {
+	catalogWrite := middleware.Authorize(auth.Policy{
+		Roles:  []string{auth.RoleAdmin, auth.RoleCatalogEditor},
+		Scopes: []string{models.ScopeCatalogWrite},
+	})
+	router.Handle("/metadata/{isbn}", catalogWrite(http.HandlerFunc(h.Lookup))).Methods("GET")
+	router.Handle("/book/{id}/suggestions", catalogWrite(http.HandlerFunc(h.Suggest))).Methods("GET")
+	router.Handle("/book/{id}/suggestions/accept", catalogWrite(http.HandlerFunc(h.Accept))).Methods("POST")
+}
+
+// @Summary Метаданные по ISBN
+// @Description Сведения о книге из внешнего библиографического источника, например для заполнения формы новой книги.
+// @Description Ответы источника, включая «не найдено», кэшируются.
+// @Tags enrichment
+// @Produce json,xml,application/msgpack
+// @Param isbn path string true "ISBN-10 or ISBN-13"
+// @Success 200 {object} dto.BookMetadataDTO
+// @Failure 404 {object} dto.ProblemDTO "metadata not found"
+// @Failure 422 {object} dto.ProblemDTO "invalid isbn"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Failure 502 {object} dto.ProblemDTO "metadata source error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /metadata/{isbn} [get]
+func (h *EnrichmentHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+	meta, err := h.usecase.Lookup(r.Context(), mux.Vars(r)["isbn"])
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if err := enc.Respond(w, http.StatusOK, converter.ToBookMetadataResponse(meta)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
+
+// @Summary Предложения по полям книги
+// @Description Сравнивает книгу с метаданными её ISBN. Предлагаются только отличающиеся поля title, author, description
+// @Description и cover (только книге без обложки); книга не меняется.
+// @Tags enrichment
+// @Produce json,xml,application/msgpack
+// @Param id path string true "Book ID"
+// @Success 200 {object} dto.BookEnrichmentDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid uuid format"
+// @Failure 404 {object} dto.ProblemDTO "book or metadata not found"
+// @Failure 422 {object} dto.ProblemDTO "book has no valid isbn"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Failure 502 {object} dto.ProblemDTO "metadata source error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /book/{id}/suggestions [get]
+func (h *EnrichmentHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+	idParam := mux.Vars(r)["id"]
+	if _, err := uuid.Parse(idParam); err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+	enrichment, err := h.usecase.Suggest(r.Context(), idParam)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if err := enc.Respond(w, http.StatusOK, converter.ToBookEnrichmentResponse(enrichment)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
+
+// @Summary Принять предложения
+// @Description Переносит в книгу только перечисленные поля; остальные не меняются. Для cover обложка скачивается
+// @Description у источника и загружается как обычная. Поле без актуального предложения — ошибка валидации.
+// @Tags enrichment
+// @Accept json,xml,application/msgpack
+// @Produce json,xml,application/msgpack
+// @Param id path string true "Book ID"
+// @Param request body dto.AcceptSuggestionsRequest true "Fields to accept: title, author, description, cover"
+// @Success 200 {object} dto.BookDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid request"
+// @Failure 404 {object} dto.ProblemDTO "book or metadata not found"
+// @Failure 409 {object} dto.ProblemDTO "book was changed concurrently"
+// @Failure 415 {object} dto.ProblemDTO "unsupported media type"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Failure 502 {object} dto.ProblemDTO "metadata source or blob storage error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /book/{id}/suggestions/accept [post]
+func (h *EnrichmentHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+	idParam := mux.Vars(r)["id"]
+	if _, err := uuid.Parse(idParam); err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+	var req dto.AcceptSuggestionsRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+	book, err := h.usecase.Accept(r.Context(), idParam, req.Fields)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if err := enc.Respond(w, http.StatusOK, converter.ToBookResponse(book)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
+
+func (h *EnrichmentHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		problem.Write(w, r, problem.NotFound, "not found")
+	case errors.Is(err, models.ErrMetadataNotFound):
+		problem.Write(w, r, problem.NotFound, err.Error())
+	case errors.Is(err, repository.ErrConflict):
+		problem.Write(w, r, problem.Conflict, "book was changed, review the suggestions again")
+	case errors.Is(err, models.ErrDomainValidation):
+		problem.Validation(w, r, err)
+	case errors.Is(err, usecase.ErrMetadataSource):
+		problem.Write(w, r, problem.UpstreamUnavailable, err.Error())
+	case errors.Is(err, usecase.ErrUnsupportedImage):
+		// обложку прислал источник, клиент тут ни при чём
+		problem.Write(w, r, problem.UpstreamUnavailable, "metadata source returned an unsupported cover image")
+	case errors.Is(err, usecase.ErrBlobStorage):
+		problem.Write(w, r, problem.UpstreamUnavailable, "blob storage error")
+	default:
+		problem.Write(w, r, problem.Internal, "internal server error")
+	}
+}
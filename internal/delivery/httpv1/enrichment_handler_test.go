@@ -0,0 +1,151 @@
+package httpv1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+var testEnrichmentMetadata = models.BookMetadata{
+	Source:    "googlebooks",
+	ISBN:      "9785171183660",
+	Title:     "Пикник на обочине",
+	Author:    "Аркадий Стругацкий, Борис Стругацкий",
+	PageCount: 256,
+	CoverURL:  "https://covers.example/9785171183660.jpg",
+}
+
+// serveEnrichment вызывает обработчики напрямую, минуя авторизацию маршрута.
+func serveEnrichment(u *EnrichmentUsecaseMock, method, path string, vars map[string]string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req = mux.SetURLVars(req, vars)
+	rec := httptest.NewRecorder()
+	h := NewEnrichmentHandler(u, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	switch {
+	case strings.HasPrefix(path, "/metadata/"):
+		h.Lookup(rec, req)
+	case method == http.MethodGet:
+		h.Suggest(rec, req)
+	default:
+		h.Accept(rec, req)
+	}
+	return rec
+}
+
+func TestEnrichment_Lookup(t *testing.T) {
+	u := &EnrichmentUsecaseMock{LookupFunc: func(ctx context.Context, isbn string) (models.BookMetadata, error) {
+		switch isbn {
+		case "5-17-118366-X":
+			return testEnrichmentMetadata, nil
+		case "9780306406157":
+			return models.BookMetadata{}, models.ErrMetadataNotFound
+		case "9780306406164":
+			return models.BookMetadata{}, usecase.ErrMetadataSource
+		}
+		verr := &models.ValidationError{}
+		verr.Add("isbn", models.FieldInvalid, "invalid isbn")
+		return models.BookMetadata{}, verr.Err()
+	}}
+	lookup := func(isbn string) *httptest.ResponseRecorder {
+		return serveEnrichment(u, http.MethodGet, "/metadata/"+isbn, map[string]string{"isbn": isbn}, "")
+	}
+
+	rec := lookup("5-17-118366-X")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got dto.BookMetadataDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, "Пикник на обочине", got.Title)
+	assert.Equal(t, 256, got.PageCount)
+	assert.Equal(t, testEnrichmentMetadata.CoverURL, got.CoverURL)
+
+	assert.Equal(t, http.StatusNotFound, lookup("9780306406157").Code)
+	assert.Equal(t, http.StatusBadGateway, lookup("9780306406164").Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, lookup("n-a").Code)
+}
+
+func TestEnrichment_Suggest(t *testing.T) {
+	id := uuid.New()
+	u := &EnrichmentUsecaseMock{SuggestFunc: func(ctx context.Context, bookID string) (models.BookEnrichment, error) {
+		if bookID != id.String() {
+			return models.BookEnrichment{}, repository.ErrNotFound
+		}
+		return models.BookEnrichment{
+			BookID:   id,
+			Metadata: testEnrichmentMetadata,
+			Suggestions: []models.FieldSuggestion{
+				{Field: models.SuggestAuthor, Current: "Стругацкие", Suggested: testEnrichmentMetadata.Author},
+				{Field: models.SuggestCover, Suggested: testEnrichmentMetadata.CoverURL},
+			},
+		}, nil
+	}}
+	suggest := func(id string) *httptest.ResponseRecorder {
+		return serveEnrichment(u, http.MethodGet, "/book/"+id+"/suggestions", map[string]string{"id": id}, "")
+	}
+
+	rec := suggest(id.String())
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got dto.BookEnrichmentDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, id, got.BookID)
+	require.Len(t, got.Suggestions, 2)
+	assert.Equal(t, dto.FieldSuggestionDTO{Field: "author", Current: "Стругацкие", Suggested: testEnrichmentMetadata.Author}, got.Suggestions[0])
+
+	assert.Equal(t, http.StatusNotFound, suggest(uuid.NewString()).Code)
+	assert.Equal(t, http.StatusBadRequest, suggest("not-a-uuid").Code)
+	assert.Len(t, u.SuggestCalls(), 2)
+}
+
+func TestEnrichment_Accept(t *testing.T) {
+	id := uuid.New()
+	u := &EnrichmentUsecaseMock{AcceptFunc: func(ctx context.Context, bookID string, fields []string) (models.Book, error) {
+		return models.Book{ID: id, Title: testEnrichmentMetadata.Title, Author: "Стругацкие", ISBN: testEnrichmentMetadata.ISBN, Price: 500}, nil
+	}}
+	accept := func(body string) *httptest.ResponseRecorder {
+		return serveEnrichment(u, http.MethodPost, "/book/"+id.String()+"/suggestions/accept", map[string]string{"id": id.String()}, body)
+	}
+
+	rec := accept(`{"fields": ["title", "cover"]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got dto.BookDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, testEnrichmentMetadata.Title, got.Title)
+	assert.Equal(t, []string{"title", "cover"}, u.AcceptCalls()[0].Fields)
+
+	assert.Equal(t, http.StatusBadRequest, accept(`{"fields": "title"`).Code)
+
+	for name, tc := range map[string]struct {
+		err  error
+		want int
+	}{
+		"book not found":        {repository.ErrNotFound, http.StatusNotFound},
+		"metadata not found":    {models.ErrMetadataNotFound, http.StatusNotFound},
+		"changed concurrently":  {repository.ErrConflict, http.StatusConflict},
+		"source unavailable":    {usecase.ErrMetadataSource, http.StatusBadGateway},
+		"source cover is bogus": {usecase.ErrUnsupportedImage, http.StatusBadGateway},
+		"storage unavailable":   {usecase.ErrBlobStorage, http.StatusBadGateway},
+		"db error":              {usecase.ErrDbInfrastructure, http.StatusInternalServerError},
+	} {
+		u.AcceptFunc = func(ctx context.Context, bookID string, fields []string) (models.Book, error) {
+			return models.Book{}, tc.err
+		}
+		assert.Equal(t, tc.want, accept(`{"fields": ["cover"]}`).Code, name)
+	}
+}
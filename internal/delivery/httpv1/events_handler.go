@@ -0,0 +1,157 @@
+package httpv1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/feed"
+	"book-store-api/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// задержка переподключения, которую EventSource берёт из поля retry
+const sseRetry = 3 * time.Second
+
+type EventsHandler struct {
+	feed      delivery.EventFeedUsecase
+	heartbeat time.Duration
+	logger    *slog.Logger
+}
+
+func NewEventsHandler(f delivery.EventFeedUsecase, heartbeat time.Duration, logger *slog.Logger) *EventsHandler {
+	return &EventsHandler{feed: f, heartbeat: heartbeat, logger: logger}
+}
+
+func (h *EventsHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/events/stream", h.Stream).Methods("GET")
+}
+
+// @Summary Лента изменений каталога (SSE)
+// @Description Server-Sent Events: события BookCreated, BookUpdated, BookDeleted, PriceChanged. Поле data — конверт события {id, type, occurred_at, data}.
+// @Description Доставка at-least-once: повторы дедуплицируются по data.id. При переподключении заголовок Last-Event-ID (или параметр last_event_id) дочитывает пропущенное.
+// @Description Если пропущенные события уже не хранятся, приходит событие reset — каталог нужно перечитать. Раз в несколько секунд приходит комментарий-heartbeat.
+// @Tags events
+// @Produce text/event-stream
+// @Param types query string false "Event types, comma separated"
+// @Param last_event_id query string false "Resume after this event id (alternative to Last-Event-ID header)"
+// @Param Last-Event-ID header string false "Resume after this event id"
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} dto.ProblemDTO "invalid query"
+// @Failure 500 {object} dto.ProblemDTO "streaming unsupported"
+// @Router /events/stream [get]
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	types, err := parseEventTypes(r.URL.Query().Get("types"))
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, err.Error())
+		return
+	}
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("last_event_id")
+	}
+	if lastID != "" && !models.ValidFeedID(lastID) {
+		problem.Write(w, r, problem.InvalidRequest, "invalid last event id")
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// WriteTimeout сервера рассчитан на обычные запросы и оборвал бы поток
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Error("sse write deadline", "err", err)
+	}
+
+	// подписываемся до чтения истории, чтобы не потерять события между ними
+	events, unsubscribe := h.feed.Subscribe(types)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds()); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		h.logger.Error("sse flush unsupported", "err", err)
+		return
+	}
+
+	ctx := r.Context()
+	if lastID != "" {
+		err := h.feed.Replay(ctx, lastID, types, func(e models.FeedEvent) error {
+			lastID = e.ID
+			return writeEvent(w, e)
+		})
+		switch {
+		case errors.Is(err, feed.ErrHistoryGap):
+			if _, err := io.WriteString(w, "event: reset\ndata: {}\n\n"); err != nil {
+				return
+			}
+			lastID = ""
+		case err != nil:
+			if ctx.Err() == nil {
+				h.logger.Error("sse replay failed", "err", err)
+			}
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				// отстали или сервер останавливается — клиент переподключится с Last-Event-ID
+				return
+			}
+			if lastID != "" && models.CompareFeedIDs(e.ID, lastID) <= 0 {
+				continue
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w io.Writer, e models.FeedEvent) error {
+	_, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+	return err
+}
+
+func parseEventTypes(raw string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var types []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		if !models.IsEventType(t) {
+			return nil, fmt.Errorf("unknown event type %q", t)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
@@ -0,0 +1,264 @@
+package httpv1
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/marc"
+	"book-store-api/internal/models"
+	"book-store-api/internal/onix"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// exportFlushRows — через сколько строк буферы сбрасываются клиенту
+	exportFlushRows = 1000
+	// exportWriteWindow продлевается после каждого сброса: выгрузка длится дольше
+	// HTTP_WRITE_TIMEOUT, но зависший клиент всё равно отваливается
+	exportWriteWindow = 30 * time.Second
+)
+
+var exportExtensions = map[models.ExportFormat]string{
+	models.ExportCSV:     "csv",
+	models.ExportNDJSON:  "ndjson",
+	models.ExportJSON:    "json",
+	models.ExportONIX:    "xml",
+	models.ExportMARC:    "mrc",
+	models.ExportMARCXML: "xml",
+}
+
+type ExportHandler struct {
+	usecase delivery.BookExportUsecase
+	deleted delivery.DeletedBooksUsecase
+	onix    onix.Config
+	marc    marc.Config
+	logger  *slog.Logger
+}
+
+func NewExportHandler(u delivery.BookExportUsecase, deleted delivery.DeletedBooksUsecase, onixCfg onix.Config, marcCfg marc.Config, logger *slog.Logger) *ExportHandler {
+	return &ExportHandler{usecase: u, deleted: deleted, onix: onixCfg, marc: marcCfg, logger: logger}
+}
+
+func (h *ExportHandler) RegisterRoutes(router *mux.Router) {
+	catalogRead := middleware.Authorize(auth.Policy{
+		Roles:  []string{auth.RoleAdmin, auth.RoleCatalogEditor},
+		Scopes: []string{models.ScopeCatalogRead, models.ScopeCatalogWrite},
+	})
+	router.Handle("/export", catalogRead(http.HandlerFunc(h.Export))).Methods("GET")
+}
+
+// @Summary Выгрузка каталога
+// @Description Потоково отдаёт книги из курсора Postgres в CSV, NDJSON или JSON-массиве, память не растёт с размером каталога.
+// @Description При Accept-Encoding: gzip ответ сжимается. Если выгрузка оборвалась после начала, соединение закрывается без завершающего чанка.
+// @Description format=onix отдаёт сообщение ONIX 3.0 со всеми полями: книги без валидного ISBN пропускаются с XML-комментарием о причине.
+// @Description С updated_since выгрузка ONIX становится дельтой и в конце содержит удаления (NotificationType 05) за этот период.
+// @Description format=marc отдаёт записи MARC 21 в ISO 2709 (.mrc), format=marcxml — те же записи в MARCXML.
+// @Tags export
+// @Produce text/csv,application/x-ndjson,json,application/xml,application/marc,application/marcxml+xml
+// @Param format query string false "csv (default), ndjson, json, onix, marc or marcxml"
+// @Param columns query string false "Comma separated columns: id,title,author,description,isbn,price,created_at,updated_at; ignored for onix, marc and marcxml"
+// @Param ids query string false "Comma separated book ids"
+// @Param author query string false "Exact author"
+// @Param isbn query string false "Exact ISBN"
+// @Param min_price query int false "Minimal price"
+// @Param max_price query int false "Maximal price"
+// @Param updated_since query string false "RFC 3339 timestamp"
+// @Success 200 {file} file "catalog dump"
+// @Failure 400 {object} dto.ProblemDTO "invalid query parameter"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /export [get]
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	params, err := exportParams(r)
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, err.Error())
+		return
+	}
+	exp, err := models.NewBookExport(params)
+	if err != nil {
+		problem.Validation(w, r, err)
+		return
+	}
+
+	var deleted []models.DeletedBook
+	if exp.Format == models.ExportONIX && exp.Filter.UpdatedSince != nil {
+		if deleted, err = h.deleted.DeletedBooks(r.Context(), *exp.Filter.UpdatedSince); err != nil {
+			problem.Write(w, r, problem.Internal, "internal server error")
+			return
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	extendDeadline := func() {
+		if err := rc.SetWriteDeadline(time.Now().Add(exportWriteWindow)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			h.logger.Warn("failed to extend write deadline", "err", err)
+		}
+	}
+	extendDeadline()
+
+	var (
+		out        io.Writer = w
+		gz         *gzip.Writer
+		writer     exportWriter
+		onixWriter *onixExportWriter
+		started    bool
+		rows       int
+	)
+	// заголовки и первые байты пишутся с первой строкой: ошибку открытия курсора
+	// ещё можно вернуть как problem+json
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", exportContentTypes[exp.Format])
+		w.Header().Set("Content-Disposition", `attachment; filename="books-`+time.Now().UTC().Format("20060102")+"."+exportExtensions[exp.Format]+`"`)
+		w.Header().Add("Vary", "Accept-Encoding")
+		if acceptsGzip(r) {
+			w.Header().Set("Content-Encoding", "gzip")
+			gz = gzip.NewWriter(w)
+			out = gz
+		}
+		w.WriteHeader(http.StatusOK)
+		switch exp.Format {
+		case models.ExportONIX:
+			onixWriter = newOnixExportWriter(out, h.onix, deleted)
+			writer = onixWriter
+		case models.ExportMARC:
+			writer = &marcExportWriter{w: out, cfg: h.marc}
+		case models.ExportMARCXML:
+			writer = &marcXMLExportWriter{w: marc.NewXMLWriter(out), cfg: h.marc}
+		default:
+			writer = newExportWriter(exp.Format, out, exp.Columns)
+		}
+		return writer.Begin()
+	}
+	flush := func() error {
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		if gz != nil {
+			if err := gz.Flush(); err != nil {
+				return err
+			}
+		}
+		extendDeadline()
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	}
+
+	err = h.usecase.Export(r.Context(), exp, func(b models.Book) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := writer.Write(b); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushRows == 0 {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = writer.End()
+	}
+	if err == nil && gz != nil {
+		err = gz.Close()
+	}
+
+	if err != nil {
+		if !started {
+			problem.Write(w, r, problem.Internal, "internal server error")
+			return
+		}
+		h.logger.Error("export aborted", "rows", rows, "err", err)
+		// обрываем соединение: иначе клиент примет усечённую выгрузку за полную
+		panic(http.ErrAbortHandler)
+	}
+	if onixWriter != nil && onixWriter.skipped > 0 {
+		h.logger.Warn("onix export skipped invalid books", "skipped", onixWriter.skipped, "rows", rows)
+	}
+}
+
+func exportParams(r *http.Request) (models.BookExportParams, error) {
+	q := r.URL.Query()
+	params := models.BookExportParams{
+		Format:  models.ExportFormat(strings.ToLower(q.Get("format"))),
+		Columns: q.Get("columns"),
+		Filter: models.BookFilter{
+			Author: q.Get("author"),
+			ISBN:   q.Get("isbn"),
+		},
+	}
+
+	if raw := q.Get("ids"); raw != "" {
+		for _, s := range strings.Split(raw, ",") {
+			id, err := uuid.Parse(strings.TrimSpace(s))
+			if err != nil {
+				return models.BookExportParams{}, errors.New("ids must be comma separated UUIDs")
+			}
+			params.Filter.IDs = append(params.Filter.IDs, id)
+		}
+	}
+
+	var err error
+	if params.Filter.MinPrice, err = queryInt(q.Get("min_price"), "min_price"); err != nil {
+		return models.BookExportParams{}, err
+	}
+	if params.Filter.MaxPrice, err = queryInt(q.Get("max_price"), "max_price"); err != nil {
+		return models.BookExportParams{}, err
+	}
+	if raw := q.Get("updated_since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return models.BookExportParams{}, errors.New("updated_since must be an RFC 3339 timestamp")
+		}
+		params.Filter.UpdatedSince = &since
+	}
+	return params, nil
+}
+
+func queryInt(raw, name string) (*int, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, errors.New(name + " must be an integer")
+	}
+	return &v, nil
+}
+
+// acceptsGzip учитывает q=0, которым клиент явно отказывается от кодировки.
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		q := strings.ReplaceAll(strings.TrimSpace(params), " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
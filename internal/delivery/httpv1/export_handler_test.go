@@ -0,0 +1,223 @@
+package httpv1
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/marc"
+	"book-store-api/internal/models"
+	"book-store-api/internal/onix"
+	"book-store-api/internal/usecase"
+)
+
+func exportBooks(n int) []models.Book {
+	base := time.Date(2025, 10, 28, 9, 0, 0, 0, time.UTC)
+	books := make([]models.Book, n)
+	for i := range books {
+		books[i] = models.Book{ID: uuid.New(), Title: "Book, \"quoted\"", Author: "Lem", Price: i, CreatedAt: base, UpdatedAt: base}
+	}
+	return books
+}
+
+func streamOf(books []models.Book) func(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error {
+	return func(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error {
+		for _, b := range books {
+			if err := emit(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+var testONIXConfig = onix.Config{SenderName: "Book Store", RecordPrefix: "local.bookstore", Currency: "RUB", PriceDecimals: 2, PricesIncludeTax: true}
+
+var testMARCConfig = marc.Config{OrgCode: "BkStr", Currency: "RUB", PriceDecimals: 2, PricesIncludeTax: true}
+
+func serveExport(t *testing.T, u *BookExportUsecaseMock, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	return serveExportWith(t, u, &DeletedBooksUsecaseMock{}, target, header)
+}
+
+func serveExportWith(t *testing.T, u *BookExportUsecaseMock, deleted *DeletedBooksUsecaseMock, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	h := NewExportHandler(u, deleted, testONIXConfig, testMARCConfig, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.Export(rec, req)
+	return rec
+}
+
+func TestExport_CSVWithColumns(t *testing.T) {
+	books := exportBooks(exportFlushRows + 5)
+	u := &BookExportUsecaseMock{ExportFunc: streamOf(books)}
+
+	rec := serveExport(t, u, "/export?columns=title,price&author=Lem&min_price=0", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), ".csv")
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(books)+1)
+	assert.Equal(t, []string{"title", "price"}, records[0])
+	assert.Equal(t, []string{`Book, "quoted"`, "3"}, records[4])
+
+	exp := u.ExportCalls()[0].Exp
+	assert.Equal(t, []string{"title", "price"}, exp.Columns)
+	assert.Equal(t, "Lem", exp.Filter.Author)
+	require.NotNil(t, exp.Filter.MinPrice)
+}
+
+func TestExport_NDJSONGzip(t *testing.T) {
+	books := exportBooks(3)
+	u := &BookExportUsecaseMock{ExportFunc: streamOf(books)}
+
+	rec := serveExport(t, u, "/export?format=ndjson&columns=id,title", http.Header{"Accept-Encoding": {"br, gzip"}})
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	scanner := bufio.NewScanner(zr)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.Len(t, lines, 3)
+	assert.Equal(t, `{"id":"`+books[0].ID.String()+`","title":"Book, \"quoted\""}`, lines[0], "column order is preserved")
+}
+
+func TestExport_JSONArray(t *testing.T) {
+	for _, n := range []int{0, 2} {
+		u := &BookExportUsecaseMock{ExportFunc: streamOf(exportBooks(n))}
+		rec := serveExport(t, u, "/export?format=json", http.Header{"Accept-Encoding": {"gzip;q=0"}})
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		var got []map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Len(t, got, n)
+		if n > 0 {
+			assert.Equal(t, "2025-10-28T09:00:00Z", got[0]["created_at"])
+		}
+	}
+}
+
+func TestExport_ONIXDelta(t *testing.T) {
+	books := exportBooks(2)
+	books[0].ISBN, books[0].Price, books[0].Description = "5-17-118366-X", 125050, "Роман"
+	books[1].ISBN = "not an isbn"
+	gone := models.DeletedBook{ID: uuid.New(), ISBN: "9780306406157"}
+	u := &BookExportUsecaseMock{ExportFunc: streamOf(books)}
+	deleted := &DeletedBooksUsecaseMock{DeletedBooksFunc: func(ctx context.Context, since time.Time) ([]models.DeletedBook, error) {
+		return []models.DeletedBook{gone}, nil
+	}}
+
+	rec := serveExportWith(t, u, deleted, "/export?format=onix&columns=title&updated_since=2025-10-01T00:00:00Z&ids="+books[0].ID.String()+","+books[1].ID.String(), nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "book "+books[1].ID.String()+" skipped", "invalid product is reported in place")
+	var msg onix.Message
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &msg))
+	assert.Equal(t, onix.Release, msg.Release)
+	require.Len(t, msg.Products, 2)
+
+	p := msg.Products[0]
+	assert.Equal(t, "local.bookstore."+books[0].ID.String(), p.RecordReference)
+	assert.Equal(t, onix.NotificationConfirm, p.NotificationType)
+	assert.Equal(t, "9785171183660", p.Identifiers[1].IDValue, "ISBN-10 is exported as ISBN-13")
+	assert.Equal(t, "Роман", p.CollateralDetail.TextContents[0].Text.Value)
+	price := p.ProductSupply[0].SupplyDetails[0].Prices[0]
+	assert.Equal(t, onix.Price{PriceType: onix.PriceRRPIncludingTax, PriceAmount: "1250.50", CurrencyCode: "RUB"}, price)
+
+	assert.Equal(t, onix.NotificationDelete, msg.Products[1].NotificationType)
+	assert.Equal(t, "9780306406157", msg.Products[1].Identifiers[1].IDValue)
+
+	exp := u.ExportCalls()[0].Exp
+	assert.Equal(t, models.BookColumns, exp.Columns, "onix always exports every column")
+	assert.Len(t, exp.Filter.IDs, 2)
+	assert.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), deleted.DeletedBooksCalls()[0].Since)
+
+	rec = serveExport(t, u, "/export?format=onix", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "<NotificationType>05</NotificationType>", "full export has no deletions")
+}
+
+func TestExport_MARC(t *testing.T) {
+	books := exportBooks(3)
+	u := &BookExportUsecaseMock{ExportFunc: streamOf(books)}
+
+	rec := serveExport(t, u, "/export?format=marc&columns=title", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/marc", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), ".mrc")
+	for _, b := range books {
+		r, err := marc.ReadBinary(rec.Body)
+		require.NoError(t, err)
+		assert.Equal(t, b.ID.String(), r.Get("001")[0].Value)
+		assert.Equal(t, b.Title, r.Get("245")[0].Subfield('a'))
+	}
+	_, err := marc.ReadBinary(rec.Body)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, models.BookColumns, u.ExportCalls()[0].Exp.Columns, "marc always exports every column")
+
+	rec = serveExport(t, u, "/export?format=marcxml", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/marcxml+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	records, err := marc.ReadXML(rec.Body)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "BkStr", records[2].Get("003")[0].Value)
+}
+
+func TestExport_Errors(t *testing.T) {
+	u := &BookExportUsecaseMock{ExportFunc: streamOf(nil)}
+
+	rec := serveExport(t, u, "/export?format=xml&columns=title,pages", nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, 2, strings.Count(rec.Body.String(), `"field"`))
+
+	rec = serveExport(t, u, "/export?min_price=cheap", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serveExport(t, u, "/export?ids=1,2", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	u.ExportFunc = func(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error {
+		return usecase.ErrDbInfrastructure
+	}
+	rec = serveExport(t, u, "/export", nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code, "failure before the first row is a problem response")
+	assert.Empty(t, rec.Header().Get("Content-Disposition"))
+
+	u.ExportFunc = func(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error {
+		if err := emit(exportBooks(1)[0]); err != nil {
+			return err
+		}
+		return errors.New("connection reset")
+	}
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { serveExport(t, u, "/export", nil) },
+		"failure mid-stream drops the connection instead of sending a truncated file")
+}
@@ -0,0 +1,250 @@
+package httpv1
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"book-store-api/internal/marc"
+	"book-store-api/internal/models"
+	"book-store-api/internal/onix"
+)
+
+// exportWriter пишет выгрузку построчно, не накапливая книги в памяти.
+type exportWriter interface {
+	Begin() error
+	Write(b models.Book) error
+	// Flush сбрасывает буфер формата в нижележащий writer
+	Flush() error
+	End() error
+}
+
+func newExportWriter(format models.ExportFormat, w io.Writer, columns []string) exportWriter {
+	switch format {
+	case models.ExportNDJSON:
+		return &ndjsonExportWriter{w: w, columns: columns}
+	case models.ExportJSON:
+		return &jsonExportWriter{w: w, columns: columns}
+	default:
+		return &csvExportWriter{w: csv.NewWriter(w), columns: columns}
+	}
+}
+
+var exportContentTypes = map[models.ExportFormat]string{
+	models.ExportCSV:     "text/csv; charset=utf-8",
+	models.ExportNDJSON:  "application/x-ndjson",
+	models.ExportJSON:    "application/json",
+	models.ExportONIX:    "application/xml; charset=utf-8",
+	models.ExportMARC:    "application/marc",
+	models.ExportMARCXML: "application/marcxml+xml; charset=utf-8",
+}
+
+// bookColumnValue — значение колонки в JSON-представлении, как в dto.BookDTO.
+func bookColumnValue(b models.Book, column string) any {
+	switch column {
+	case "id":
+		return b.ID.String()
+	case "title":
+		return b.Title
+	case "author":
+		return b.Author
+	case "description":
+		return b.Description
+	case "isbn":
+		return b.ISBN
+	case "price":
+		return b.Price
+	case "created_at":
+		return b.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case "updated_at":
+		return b.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return nil
+}
+
+type csvExportWriter struct {
+	w       *csv.Writer
+	columns []string
+	record  []string
+}
+
+func (e *csvExportWriter) Begin() error {
+	e.record = make([]string, len(e.columns))
+	return e.w.Write(e.columns)
+}
+
+func (e *csvExportWriter) Write(b models.Book) error {
+	for i, c := range e.columns {
+		switch v := bookColumnValue(b, c).(type) {
+		case string:
+			e.record[i] = v
+		case int:
+			e.record[i] = strconv.Itoa(v)
+		}
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvExportWriter) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) End() error {
+	return e.Flush()
+}
+
+type ndjsonExportWriter struct {
+	w       io.Writer
+	columns []string
+	buf     bytes.Buffer
+}
+
+func (e *ndjsonExportWriter) Begin() error { return nil }
+
+func (e *ndjsonExportWriter) Write(b models.Book) error {
+	e.buf.Reset()
+	if err := writeBookObject(&e.buf, b, e.columns); err != nil {
+		return err
+	}
+	e.buf.WriteByte('\n')
+	_, err := e.w.Write(e.buf.Bytes())
+	return err
+}
+
+func (e *ndjsonExportWriter) Flush() error { return nil }
+
+func (e *ndjsonExportWriter) End() error { return nil }
+
+// jsonExportWriter пишет один массив; при обрыве выгрузки он остаётся незакрытым,
+// и клиент видит невалидный JSON, а не усечённый список.
+type jsonExportWriter struct {
+	w       io.Writer
+	columns []string
+	buf     bytes.Buffer
+	n       int
+}
+
+func (e *jsonExportWriter) Begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonExportWriter) Write(b models.Book) error {
+	e.buf.Reset()
+	if e.n > 0 {
+		e.buf.WriteByte(',')
+	}
+	e.buf.WriteByte('\n')
+	if err := writeBookObject(&e.buf, b, e.columns); err != nil {
+		return err
+	}
+	e.n++
+	_, err := e.w.Write(e.buf.Bytes())
+	return err
+}
+
+func (e *jsonExportWriter) Flush() error { return nil }
+
+func (e *jsonExportWriter) End() error {
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
+
+// writeBookObject сохраняет порядок колонок из запроса, чего не даёт маршалинг map.
+func writeBookObject(buf *bytes.Buffer, b models.Book, columns []string) error {
+	buf.WriteByte('{')
+	for i, c := range columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(c)
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(bookColumnValue(b, c))
+		if err != nil {
+			return err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// onixExportWriter пропускает книги, которые нельзя выразить валидным продуктом ONIX,
+// и оставляет на их месте комментарий с причиной. Удаления пишутся в конце сообщения.
+type onixExportWriter struct {
+	w       *onix.Writer
+	deleted []models.DeletedBook
+	skipped int
+}
+
+func newOnixExportWriter(w io.Writer, cfg onix.Config, deleted []models.DeletedBook) *onixExportWriter {
+	return &onixExportWriter{w: onix.NewWriter(w, cfg), deleted: deleted}
+}
+
+func (e *onixExportWriter) Begin() error {
+	return e.w.Begin(time.Now())
+}
+
+func (e *onixExportWriter) Write(b models.Book) error {
+	err := e.w.WriteBook(b)
+	if errors.Is(err, models.ErrDomainValidation) {
+		e.skipped++
+		return e.w.Comment("book " + b.ID.String() + " skipped: " + err.Error())
+	}
+	return err
+}
+
+func (e *onixExportWriter) Flush() error {
+	return e.w.Flush()
+}
+
+func (e *onixExportWriter) End() error {
+	for _, d := range e.deleted {
+		if err := e.w.WriteDeleted(d); err != nil {
+			return err
+		}
+	}
+	return e.w.End()
+}
+
+// marcExportWriter пишет записи ISO 2709 подряд, как принято в файлах .mrc.
+type marcExportWriter struct {
+	w   io.Writer
+	cfg marc.Config
+}
+
+func (e *marcExportWriter) Begin() error { return nil }
+
+func (e *marcExportWriter) Write(b models.Book) error {
+	raw, err := marc.BookRecord(b, e.cfg).MarshalBinary()
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(raw)
+	return err
+}
+
+func (e *marcExportWriter) Flush() error { return nil }
+
+func (e *marcExportWriter) End() error { return nil }
+
+type marcXMLExportWriter struct {
+	w   *marc.XMLWriter
+	cfg marc.Config
+}
+
+func (e *marcXMLExportWriter) Begin() error { return e.w.Begin() }
+
+func (e *marcXMLExportWriter) Write(b models.Book) error {
+	return e.w.Write(marc.BookRecord(b, e.cfg))
+}
+
+func (e *marcXMLExportWriter) Flush() error { return e.w.Flush() }
+
+func (e *marcXMLExportWriter) End() error { return e.w.End() }
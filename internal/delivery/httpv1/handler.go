@@ -4,19 +4,28 @@
 // @host book-store-api:8080
 // @BasePath /api/v1/
 // @schemes http
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
 
 package httpv1
 
 import (
-	"encoding/json"
 	"errors"
 	"github.com/google/uuid"
 	"log/slog"
 	"net/http"
 
 	_ "book-store-api/docs"
+	"book-store-api/internal/auth"
 	"book-store-api/internal/converter"
 	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
 	"book-store-api/internal/dto"
 	"book-store-api/internal/models"
 	"book-store-api/internal/repository"
@@ -27,113 +36,156 @@ import (
 
 type Handler struct {
 	usecase delivery.Usecase
+	query   delivery.BookQueryUsecase
 	logger  *slog.Logger
 }
 
-func NewBookHandler(u delivery.Usecase, logger *slog.Logger) *Handler {
-	return &Handler{usecase: u, logger: logger}
+func NewBookHandler(u delivery.Usecase, query delivery.BookQueryUsecase, logger *slog.Logger) *Handler {
+	return &Handler{usecase: u, query: query, logger: logger}
 }
 
 func (h *Handler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/book", h.GetAllBooks).Methods("GET")
 	router.HandleFunc("/book/{id}", h.GetBookByID).Methods("GET")
-	router.HandleFunc("/book", h.CreateBook).Methods("POST")
-	router.HandleFunc("/book/{id}", h.UpdateBook).Methods("PUT")
-	router.HandleFunc("/book/{id}", h.DeleteBook).Methods("DELETE")
+
+	catalogWrite := middleware.Authorize(auth.Policy{
+		Roles:  []string{auth.RoleAdmin, auth.RoleCatalogEditor},
+		Scopes: []string{models.ScopeCatalogWrite},
+	})
+	router.Handle("/book", catalogWrite(http.HandlerFunc(h.CreateBook))).Methods("POST")
+	router.Handle("/book/{id}", catalogWrite(http.HandlerFunc(h.UpdateBook))).Methods("PUT")
+	router.Handle("/book/{id}", catalogWrite(http.HandlerFunc(h.DeleteBook))).Methods("DELETE")
+
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 }
 
 // @Summary Получить все книги
-// @Description Возвращает список всех книг
+// @Description Возвращает список всех книг; формат ответа выбирается по Accept.
+// @Description fields оставляет в ответе только перечисленные поля (id — всегда), include встраивает связи: authors — автор и число его книг.
 // @Tags books
-// @Produce json
+// @Produce json,xml,text/csv,application/msgpack
+// @Param fields query string false "Comma separated fields: id,title,author,description,price,isbn,created_at,updated_at"
+// @Param include query string false "Comma separated relations: authors"
 // @Success 200 {array} dto.BookDTO
-// @Failure 500 {string} string "internal server error"
+// @Failure 422 {object} dto.ProblemDTO "unknown field or relation"
+// @Failure 429 {object} dto.ProblemDTO "rate limit exceeded"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
 // @Router /book [get]
 func (h *Handler) GetAllBooks(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+	enc, ok := render.NegotiateList(w, r)
+	if !ok {
+		return
+	}
 
+	sel, err := bookSelection(r)
+	if err != nil {
+		problem.Validation(w, r, err)
+		return
+	}
 	ctx := r.Context()
 
-	books, err := h.usecase.GetAll(ctx)
+	var (
+		books []models.Book
+		rel   models.BookRelations
+	)
+	if sel.Partial() {
+		books, rel, err = h.query.Query(ctx, sel)
+	} else {
+		books, err = h.usecase.GetAll(ctx)
+	}
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			problem.Write(w, r, problem.NotFound, err.Error())
 			return
 		}
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		return
-	}
-
-	responseDTO := converter.ToBookResponseList(books)
-
-	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(responseDTO)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, r, problem.Internal, "internal server error")
 		return
 	}
 
+	enc.Respond(w, http.StatusOK, converter.ToBookViewList(books, sel, rel))
 }
 
 // @Summary Получить книгу по ID
-// @Description Возвращает книгу по идентификатору
+// @Description Возвращает книгу по идентификатору; fields и include — как у списка книг
 // @Tags books
-// @Produce json
+// @Produce json,xml,application/msgpack
 // @Param id path string true "Book ID"
+// @Param fields query string false "Comma separated fields: id,title,author,description,price,isbn,created_at,updated_at"
+// @Param include query string false "Comma separated relations: authors"
 // @Success 200 {object} dto.BookDTO
-// @Failure 404 {string} string "not found"
-// @Failure 500 {string} string "internal server error"
+// @Failure 422 {object} dto.ProblemDTO "unknown field or relation"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
 // @Router /book/{id} [get]
 func (h *Handler) GetBookByID(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
 	idParam := mux.Vars(r)["id"]
 	if _, err := uuid.Parse(idParam); err != nil {
-		http.Error(w, "invalid uuid format", http.StatusBadRequest)
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+	sel, err := bookSelection(r)
+	if err != nil {
+		problem.Validation(w, r, err)
 		return
 	}
 	ctx := r.Context()
-	book, err := h.usecase.GetByID(ctx, idParam)
+
+	var (
+		book *models.Book
+		rel  models.BookRelations
+	)
+	if sel.Partial() {
+		book, rel, err = h.query.QueryByID(ctx, idParam, sel)
+	} else {
+		book, err = h.usecase.GetByID(ctx, idParam)
+	}
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			problem.Write(w, r, problem.NotFound, err.Error())
 
 			return
 		}
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-
-		return
-	}
-	bookDTO := converter.ToBookResponse(*book)
-	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(bookDTO)
-	if err != nil {
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, problem.Internal, "internal server error")
 
 		return
 	}
+	enc.Respond(w, http.StatusOK, converter.ToBookView(*book, sel, rel))
+}
 
+func bookSelection(r *http.Request) (models.BookSelection, error) {
+	q := r.URL.Query()
+	return models.NewBookSelection(models.BookSelectionParams{Fields: q.Get("fields"), Include: q.Get("include")})
 }
 
 // @Summary Создать книгу
 // @Description Создает новую книгу
 // @Tags books
-// @Accept json
-// @Produce json
+// @Accept json,xml,application/msgpack
+// @Produce json,xml,application/msgpack
 // @Param book body dto.BookRequest true "Book data"
 // @Success 201 {string} string "created id"
-// @Failure 400 {string} string "invalid request body"
-// @Failure 422 {string} string "validation error"
-// @Failure 500 {string} string "internal server error"
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Failure 415 {object} dto.ProblemDTO "unsupported Content-Type"
+// @Failure 422 {object} dto.ProblemDTO "validation error, errors lists every invalid field"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
 // @Router /book [post]
 func (h *Handler) CreateBook(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
 	ctx := r.Context()
 
 	var bookDTO dto.BookRequest
-	if err := json.NewDecoder(r.Body).Decode(&bookDTO); err != nil {
-		h.logger.Error("invalid request body", "err", err)
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+	if !render.Decode(w, r, &bookDTO) {
 		return
 	}
 
@@ -144,51 +196,46 @@ func (h *Handler) CreateBook(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("failed to create book", "err", err)
 
 		if errors.Is(err, models.ErrDomainValidation) {
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			problem.Validation(w, r, err)
 			return
 		}
 
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusCreated)
-	err = json.NewEncoder(w).Encode(map[string]string{"id": id})
-	if err != nil {
+		problem.Write(w, r, problem.Internal, "internal server error")
 		return
 	}
 
+	enc.Respond(w, http.StatusCreated, map[string]string{"id": id})
 }
 
 // @Summary Обновить книгу
 // @Description Обновляет данные существующей книги
 // @Tags books
-// @Accept json
-// @Produce json
+// @Accept json,xml,application/msgpack
 // @Param id path string true "Book ID"
 // @Param book body dto.BookRequest true "Book data"
 // @Success 200 {string} string "ok"
-// @Failure 400 {string} string "invalid request body"
-// @Failure 404 {string} string "not found"
-// @Failure 422 {string} string "validation error"
-// @Failure 500 {string} string "internal server error"
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Failure 415 {object} dto.ProblemDTO "unsupported Content-Type"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 422 {object} dto.ProblemDTO "validation error, errors lists every invalid field"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
 // @Router /book/{id} [put]
 func (h *Handler) UpdateBook(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
 	ctx := r.Context()
 	var bookDTO dto.BookRequest
 
 	idParam := mux.Vars(r)["id"]
 	uid, err := uuid.Parse(idParam)
 	if err != nil {
-		http.Error(w, "invalid uuid format", http.StatusBadRequest)
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
 		return
 	}
 
-	err = json.NewDecoder(r.Body).Decode(&bookDTO)
-	if err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
-
+	if !render.Decode(w, r, &bookDTO) {
 		return
 	}
 
@@ -198,17 +245,18 @@ func (h *Handler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 	err = h.usecase.Update(ctx, book)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			problem.Write(w, r, problem.NotFound, err.Error())
 
 			return
 		}
 		if errors.Is(err, models.ErrDomainValidation) {
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			problem.Validation(w, r, err)
 
 			return
 		}
 
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -221,29 +269,31 @@ func (h *Handler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param id path string true "Book ID"
 // @Success 204 {string} string "no content"
-// @Failure 404 {string} string "not found"
-// @Failure 500 {string} string "internal server error"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
 // @Router /book/{id} [delete]
 func (h *Handler) DeleteBook(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
 	ctx := r.Context()
 
 	idParam := mux.Vars(r)["id"]
 	if _, err := uuid.Parse(idParam); err != nil {
-		http.Error(w, "invalid uuid format", http.StatusBadRequest)
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
 		return
 	}
 
 	err := h.usecase.DeleteBook(ctx, idParam)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			problem.Write(w, r, problem.NotFound, err.Error())
 
 			return
 		}
 
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+		problem.Write(w, r, problem.Internal, "internal server error")
 
 		return
 	}
@@ -0,0 +1,141 @@
+package httpv1
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"book-store-api/internal/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveBooks(t *testing.T, u *UsecaseMock, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	return serveBooksWith(t, u, &BookQueryUsecaseMock{}, target, header)
+}
+
+func serveBooksWith(t *testing.T, u *UsecaseMock, query *BookQueryUsecaseMock, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	router := mux.NewRouter()
+	NewBookHandler(u, query, slog.New(slog.NewTextHandler(io.Discard, nil))).RegisterRoutes(router)
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// postBook вызывает обработчик напрямую, минуя авторизацию маршрута.
+func postBook(u *UsecaseMock, body string, header http.Header) *httptest.ResponseRecorder {
+	h := NewBookHandler(u, &BookQueryUsecaseMock{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	req := httptest.NewRequest(http.MethodPost, "/book", strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.CreateBook(rec, req)
+	return rec
+}
+
+func TestBooks_ContentNegotiation(t *testing.T) {
+	books := exportBooks(2)
+	u := &UsecaseMock{
+		GetAllFunc: func(ctx context.Context) ([]models.Book, error) { return books, nil },
+		GetByIDFunc: func(ctx context.Context, id string) (*models.Book, error) {
+			return &books[0], nil
+		},
+	}
+
+	rec := serveBooks(t, u, "/book", http.Header{"Accept": {"text/csv"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "title", "author", "description", "price", "isbn", "created_at", "updated_at", "cover"}, records[0])
+	assert.Equal(t, books[1].ID.String(), records[2][0])
+
+	rec = serveBooks(t, u, "/book/"+books[0].ID.String(), http.Header{"Accept": {"application/xml"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got struct {
+		XMLName xml.Name `xml:"response"`
+		ID      string   `xml:"id"`
+		Title   string   `xml:"title"`
+	}
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, books[0].ID.String(), got.ID)
+	assert.Equal(t, books[0].Title, got.Title)
+
+	rec = serveBooks(t, u, "/book/"+books[0].ID.String(), http.Header{"Accept": {"text/csv"}})
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code, "csv is only offered for lists")
+}
+
+func TestCreateBook_DecodesByContentType(t *testing.T) {
+	var created models.BookParams
+	u := &UsecaseMock{CreateFunc: func(ctx context.Context, bookInfo models.BookParams) (string, error) {
+		created = bookInfo
+		return "new-id", nil
+	}}
+
+	body := `<book><title>Солярис</title><author>Лем</author><price>1250</price><isbn>9785171183660</isbn></book>`
+	rec := postBook(u, body, http.Header{"Content-Type": {"application/xml"}, "Accept": {"application/xml"}})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, models.BookParams{Title: "Солярис", Author: "Лем", Price: 1250, ISBN: "9785171183660"}, created)
+	assert.Contains(t, rec.Body.String(), "<id>new-id</id>")
+
+	rec = postBook(u, "title=x", http.Header{"Content-Type": {"application/x-www-form-urlencoded"}})
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+
+	rec = postBook(u, `{"title":"x"}`, http.Header{"Accept": {"text/html"}})
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	assert.Len(t, u.CreateCalls(), 1, "unacceptable requests are rejected before any change")
+}
+
+func TestBooks_SparseFieldsets(t *testing.T) {
+	books := exportBooks(2)
+	books[0].Author, books[1].Author = "Лем", ""
+	query := &BookQueryUsecaseMock{
+		QueryFunc: func(ctx context.Context, sel models.BookSelection) ([]models.Book, models.BookRelations, error) {
+			return books, models.BookRelations{Authors: map[string]models.AuthorSummary{"Лем": {Name: "Лем", BookCount: 3}}}, nil
+		},
+		QueryByIDFunc: func(ctx context.Context, id string, sel models.BookSelection) (*models.Book, models.BookRelations, error) {
+			return &books[0], models.BookRelations{}, nil
+		},
+	}
+	u := &UsecaseMock{}
+
+	rec := serveBooksWith(t, u, query, "/book?fields=title,price&include=authors", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[
+		{"id":"`+books[0].ID.String()+`","title":"Book, \"quoted\"","price":0,"authors":[{"name":"Лем","book_count":3}]},
+		{"id":"`+books[1].ID.String()+`","title":"Book, \"quoted\"","price":1,"authors":[]}
+	]`, rec.Body.String())
+	assert.Equal(t, []string{"id", "title", "price"}, query.QueryCalls()[0].Sel.Fields)
+	assert.Empty(t, u.GetAllCalls(), "sparse requests skip the full read")
+
+	rec = serveBooksWith(t, u, query, "/book?fields=title,isbn", http.Header{"Accept": {"text/csv"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "title", "isbn"}, records[0], "columns follow the BookDTO order")
+
+	rec = serveBooksWith(t, u, query, "/book/"+books[0].ID.String()+"?fields=description", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"id":"`+books[0].ID.String()+`","description":""}`, rec.Body.String())
+
+	rec = serveBooksWith(t, u, query, "/book?fields=title,pages&include=stock", nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `unknown field \"pages\"`)
+	assert.Contains(t, rec.Body.String(), `unknown relation \"stock\"`)
+}
@@ -0,0 +1,203 @@
+package httpv1
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ImportHandler struct {
+	usecase delivery.ImportUsecase
+	// maxFileSize — предел размера загружаемого файла в байтах
+	maxFileSize int64
+	logger      *slog.Logger
+}
+
+func NewImportHandler(u delivery.ImportUsecase, maxFileSize int64, logger *slog.Logger) *ImportHandler {
+	return &ImportHandler{usecase: u, maxFileSize: maxFileSize, logger: logger}
+}
+
+func (h *ImportHandler) RegisterRoutes(router *mux.Router) {
+	catalogWrite := middleware.Authorize(auth.Policy{
+		Roles:  []string{auth.RoleAdmin, auth.RoleCatalogEditor},
+		Scopes: []string{models.ScopeCatalogWrite},
+	})
+	router.Handle("/import", catalogWrite(http.HandlerFunc(h.CreateImport))).Methods("POST")
+	router.Handle("/import/{job}", catalogWrite(http.HandlerFunc(h.GetImport))).Methods("GET")
+}
+
+// @Summary Импорт каталога из CSV, XLSX или ONIX
+// @Description Ставит файл в очередь и сразу возвращает задачу; прогресс и ошибки по строкам — в GET /import/{job}.
+// @Description Строки проверяются как при создании книги. mapping — JSON-объект {"поле книги": "заголовок колонки"},
+// @Description поля без сопоставления ищутся по своему имени (title, author, description, isbn, price).
+// @Description ONIX 3.0 (полные или короткие теги) всегда импортируется с upsert по ISBN, NotificationType 05 удаляет книгу;
+// @Description ошибки продукта содержат его RecordReference.
+// @Tags import
+// @Accept mpfd
+// @Produce json,xml,application/msgpack
+// @Param file formData file true "CSV, XLSX or ONIX XML file"
+// @Param format formData string false "csv, xlsx or onix, by default from the file extension (.xml is onix)"
+// @Param mapping formData string false "Column mapping as JSON object"
+// @Param delimiter formData string false "CSV delimiter, comma by default"
+// @Param sheet formData string false "XLSX sheet, the first one by default"
+// @Param upsert formData bool false "Update books with the same ISBN instead of creating new ones"
+// @Param dry_run formData bool false "Only validate rows, write nothing"
+// @Success 202 {object} dto.ImportJobDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Failure 413 {object} dto.ProblemDTO "file too large"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /import [post]
+func (h *ImportHandler) CreateImport(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	// запас на остальные поля формы
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxFileSize+1<<20)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			problem.Write(w, r, problem.PayloadTooLarge, "file is too large")
+			return
+		}
+		problem.Write(w, r, problem.InvalidRequest, "invalid multipart form")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	params, err := h.importParams(r)
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, err.Error())
+		return
+	}
+	if int64(len(params.File)) > h.maxFileSize {
+		problem.Write(w, r, problem.PayloadTooLarge, "file is too large")
+		return
+	}
+
+	job, err := h.usecase.Create(r.Context(), params)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", APIPrefix+"/import/"+job.ID.String())
+	if err := enc.Respond(w, http.StatusAccepted, converter.ToImportJobResponse(job)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
+
+func (h *ImportHandler) importParams(r *http.Request) (models.ImportJobParams, error) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return models.ImportJobParams{}, errors.New("file is required")
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return models.ImportJobParams{}, errors.New("failed to read file")
+	}
+
+	params := models.ImportJobParams{
+		FileName: header.Filename,
+		Format:   models.ImportFormat(r.FormValue("format")),
+		File:     data,
+		Options: models.ImportOptions{
+			Delimiter: r.FormValue("delimiter"),
+			Sheet:     r.FormValue("sheet"),
+		},
+	}
+	if raw := r.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &params.Options.Mapping); err != nil {
+			return models.ImportJobParams{}, errors.New("mapping must be a JSON object of strings")
+		}
+	}
+	if params.Options.UpsertByISBN, err = formBool(r, "upsert"); err != nil {
+		return models.ImportJobParams{}, err
+	}
+	if params.Options.DryRun, err = formBool(r, "dry_run"); err != nil {
+		return models.ImportJobParams{}, err
+	}
+	return params, nil
+}
+
+func formBool(r *http.Request, name string) (bool, error) {
+	raw := r.FormValue(name)
+	if raw == "" {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New(name + " must be a boolean")
+	}
+	return v, nil
+}
+
+// @Summary Статус импорта
+// @Description Прогресс задачи и ошибки по строкам с номерами строк файла; для ONIX — строка начала Product и RecordReference
+// @Tags import
+// @Produce json,xml,application/msgpack
+// @Param job path string true "Import job ID"
+// @Success 200 {object} dto.ImportJobDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid uuid format"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /import/{job} [get]
+func (h *ImportHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["job"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	job, err := h.usecase.Get(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	if err := enc.Respond(w, http.StatusOK, converter.ToImportJobResponse(job)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
+
+func (h *ImportHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		problem.Write(w, r, problem.NotFound, "import job not found")
+	case errors.Is(err, models.ErrDomainValidation):
+		problem.Validation(w, r, err)
+	default:
+		problem.Write(w, r, problem.Internal, "internal server error")
+	}
+}
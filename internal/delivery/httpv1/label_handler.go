@@ -0,0 +1,203 @@
+package httpv1
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/barcode"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/dto"
+	"book-store-api/internal/label"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Размер модуля по умолчанию в пикселях: EAN-13 в 3 px читается с экрана, QR крупнее.
+const (
+	barcodeScale = 3
+	qrScale      = 8
+)
+
+type LabelHandler struct {
+	usecase delivery.Usecase
+	query   delivery.BookQueryUsecase
+	cfg     label.Config
+	logger  *slog.Logger
+}
+
+func NewLabelHandler(u delivery.Usecase, query delivery.BookQueryUsecase, cfg label.Config, logger *slog.Logger) *LabelHandler {
+	return &LabelHandler{usecase: u, query: query, cfg: cfg, logger: logger}
+}
+
+func (h *LabelHandler) RegisterRoutes(router *mux.Router) {
+	catalogRead := middleware.Authorize(auth.Policy{
+		Roles:  []string{auth.RoleAdmin, auth.RoleCatalogEditor},
+		Scopes: []string{models.ScopeCatalogRead, models.ScopeCatalogWrite},
+	})
+	router.HandleFunc("/book/{id}/barcode", h.GetBarcode).Methods("GET")
+	router.HandleFunc("/book/{id}/qr", h.GetQR).Methods("GET")
+	router.Handle("/book/labels", catalogRead(http.HandlerFunc(h.LabelSheet))).Methods("POST")
+}
+
+// @Summary Штрихкод книги
+// @Description EAN-13 из ISBN-13 книги (префикс Bookland 978/979) с подписью «ISBN …» и добавочным EAN-5 с ценой.
+// @Description Первая цифра добавочного кода — валюта из LABEL_PRICE_ADDON_CURRENCY, без неё пишется 90000.
+// @Tags labels
+// @Produce image/png,image/svg+xml
+// @Param id path string true "Book ID"
+// @Param format query string false "png (default) or svg"
+// @Param scale query int false "Pixels per module, 1-20 (default 3)"
+// @Success 200 {file} file "barcode image"
+// @Failure 400 {object} dto.ProblemDTO "invalid request"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 422 {object} dto.ProblemDTO "book has no bookland isbn"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /book/{id}/barcode [get]
+func (h *LabelHandler) GetBarcode(w http.ResponseWriter, r *http.Request) {
+	h.writeSymbol(w, r, barcodeScale, label.Barcode)
+}
+
+// @Summary QR-код книги
+// @Description Ссылка на страницу книги в магазине по шаблону LABEL_STOREFRONT_URL.
+// @Tags labels
+// @Produce image/png,image/svg+xml
+// @Param id path string true "Book ID"
+// @Param format query string false "png (default) or svg"
+// @Param scale query int false "Pixels per module, 1-20 (default 8)"
+// @Success 200 {file} file "QR code image"
+// @Failure 400 {object} dto.ProblemDTO "invalid request"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /book/{id}/qr [get]
+func (h *LabelHandler) GetQR(w http.ResponseWriter, r *http.Request) {
+	h.writeSymbol(w, r, qrScale, label.QR)
+}
+
+func (h *LabelHandler) writeSymbol(w http.ResponseWriter, r *http.Request, defaultScale int, build func(models.Book, label.Config) (barcode.Symbol, error)) {
+	idParam := mux.Vars(r)["id"]
+	if _, err := uuid.Parse(idParam); err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+	q := r.URL.Query()
+	format := strings.ToLower(q.Get("format"))
+	if format == "" {
+		format = "png"
+	}
+	if format != "png" && format != "svg" {
+		problem.Write(w, r, problem.InvalidRequest, `format must be "png" or "svg"`)
+		return
+	}
+	scale := defaultScale
+	if raw := q.Get("scale"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > barcode.MaxScale {
+			problem.Write(w, r, problem.InvalidRequest, "scale must be an integer from 1 to "+strconv.Itoa(barcode.MaxScale))
+			return
+		}
+		scale = v
+	}
+
+	book, err := h.usecase.GetByID(r.Context(), idParam)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.Write(w, r, problem.NotFound, err.Error())
+			return
+		}
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+	symbol, err := build(*book, h.cfg)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidISBN) || errors.Is(err, barcode.ErrNotBookland) {
+			problem.Write(w, r, problem.ValidationFailed, "book has no valid ISBN-13 with 978 or 979 prefix")
+			return
+		}
+		h.logger.Error("failed to build symbol", "id", idParam, "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "image/png"
+	if format == "svg" {
+		contentType = "image/svg+xml"
+		err = barcode.WriteSVG(&buf, symbol, scale)
+	} else {
+		err = barcode.WritePNG(&buf, symbol, scale)
+	}
+	if err != nil {
+		h.logger.Error("failed to render symbol", "id", idParam, "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		h.logger.Error("failed to write symbol", "err", err)
+	}
+}
+
+// @Summary Лист этикеток
+// @Description PDF на листах A4 под этикетки 3×8 (70×36 мм): название, автор, цена, штрихкод EAN-13 и QR-код ссылки на магазин.
+// @Description Этикетки идут в порядке ids, повтор id печатает несколько этикеток одной книги. Книге без ISBN Bookland штрихкод не печатается.
+// @Tags labels
+// @Accept json,xml,application/msgpack
+// @Produce application/pdf
+// @Param request body dto.LabelSheetRequest true "Book ids, at most 240"
+// @Success 200 {file} file "label sheet"
+// @Failure 400 {object} dto.ProblemDTO "invalid request"
+// @Failure 415 {object} dto.ProblemDTO "unsupported media type"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Router /book/labels [post]
+func (h *LabelHandler) LabelSheet(w http.ResponseWriter, r *http.Request) {
+	var req dto.LabelSheetRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+	ids, err := models.ParseLabelIDs(req.IDs)
+	if err != nil {
+		problem.Validation(w, r, err)
+		return
+	}
+	books, err := h.query.GetByIDs(r.Context(), ids)
+	if err != nil {
+		if errors.Is(err, models.ErrDomainValidation) {
+			problem.Validation(w, r, err)
+			return
+		}
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := label.WriteSheet(&buf, books, h.cfg); err != nil {
+		h.logger.Error("failed to render label sheet", "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `inline; filename="labels.pdf"`)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		h.logger.Error("failed to write label sheet", "err", err)
+	}
+}
@@ -0,0 +1,124 @@
+package httpv1
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/label"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+)
+
+var testLabelConfig = label.Config{
+	StorefrontURL: "https://shop.example/books/{id}",
+	AddOnCurrency: "5",
+	Currency:      "USD",
+	PriceDecimals: 2,
+}
+
+func newLabelHandler(u *UsecaseMock, query *BookQueryUsecaseMock) *LabelHandler {
+	return NewLabelHandler(u, query, testLabelConfig, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestGetBarcode(t *testing.T) {
+	book := exportBooks(1)[0]
+	book.ISBN = "978-5-17-118366-0"
+	u := &UsecaseMock{GetByIDFunc: func(ctx context.Context, id string) (*models.Book, error) {
+		if id != book.ID.String() {
+			return nil, repository.ErrNotFound
+		}
+		return &book, nil
+	}}
+	router := mux.NewRouter()
+	newLabelHandler(u, nil).RegisterRoutes(router)
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+	path := "/book/" + book.ID.String()
+
+	rec := get(path + "/barcode")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	img, err := png.Decode(rec.Body)
+	require.NoError(t, err)
+	small := img.Bounds().Dx()
+
+	rec = get(path + "/barcode?format=svg&scale=6")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/svg+xml", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "ISBN 9785171183660")
+	assert.Contains(t, rec.Body.String(), `width="`+strconv.Itoa(small*2)+`"`)
+
+	rec = get(path + "/qr?format=svg")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "<svg"))
+
+	for target, want := range map[string]int{
+		path + "/barcode?format=gif":             http.StatusBadRequest,
+		path + "/barcode?scale=0":                http.StatusBadRequest,
+		"/book/not-a-uuid/barcode":               http.StatusBadRequest,
+		"/book/" + uuid.NewString() + "/qr":      http.StatusNotFound,
+		"/book/" + uuid.NewString() + "/barcode": http.StatusNotFound,
+	} {
+		assert.Equal(t, want, get(target).Code, target)
+	}
+
+	book.ISBN = "4006381333931"
+	assert.Equal(t, http.StatusUnprocessableEntity, get(path+"/barcode").Code, "EAN outside bookland")
+	book.ISBN = ""
+	assert.Equal(t, http.StatusUnprocessableEntity, get(path+"/barcode").Code)
+	assert.Equal(t, http.StatusOK, get(path+"/qr").Code, "QR does not need an ISBN")
+}
+
+func TestLabelSheet(t *testing.T) {
+	books := exportBooks(2)
+	query := &BookQueryUsecaseMock{GetByIDsFunc: func(ctx context.Context, ids []uuid.UUID) ([]models.Book, error) {
+		for _, id := range ids {
+			if id != books[0].ID && id != books[1].ID {
+				verr := &models.ValidationError{}
+				verr.Addf("ids", models.FieldUnknown, "book %s not found", id)
+				return nil, verr
+			}
+		}
+		return books, nil
+	}}
+	h := newLabelHandler(&UsecaseMock{}, query)
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/book/labels", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.LabelSheet(rec, req)
+		return rec
+	}
+
+	rec := post(`{"ids":["` + books[1].ID.String() + `","` + books[0].ID.String() + `","` + books[1].ID.String() + `"]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	assert.True(t, bytes.HasPrefix(rec.Body.Bytes(), []byte("%PDF-")))
+	assert.Equal(t, []uuid.UUID{books[1].ID, books[0].ID, books[1].ID}, query.GetByIDsCalls()[0].Ids)
+
+	for body, want := range map[string]int{
+		`{"ids":[]}`:                           http.StatusUnprocessableEntity,
+		`{"ids":["nope"]}`:                     http.StatusUnprocessableEntity,
+		`{"ids":["` + uuid.NewString() + `"]}`: http.StatusUnprocessableEntity,
+		`{"ids":`:                              http.StatusBadRequest,
+	} {
+		assert.Equal(t, want, post(body).Code, body)
+	}
+	assert.Len(t, query.GetByIDsCalls(), 2, "malformed requests do not reach the usecase")
+}
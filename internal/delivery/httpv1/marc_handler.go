@@ -0,0 +1,92 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/marc"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type MARCHandler struct {
+	usecase delivery.Usecase
+	cfg     marc.Config
+	logger  *slog.Logger
+}
+
+func NewMARCHandler(u delivery.Usecase, cfg marc.Config, logger *slog.Logger) *MARCHandler {
+	return &MARCHandler{usecase: u, cfg: cfg, logger: logger}
+}
+
+func (h *MARCHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/book/{id}/marc", h.GetBookMARC).Methods("GET")
+}
+
+// @Summary Запись MARC 21 книги
+// @Description Отдаёт библиографическую запись книги в MARCXML или в ISO 2709, как в выгрузке format=marc|marcxml.
+// @Tags books
+// @Produce application/marcxml+xml,application/marc
+// @Param id path string true "Book ID"
+// @Param format query string false "marcxml (default) or marc"
+// @Success 200 {file} file "MARC record"
+// @Failure 400 {object} dto.ProblemDTO "invalid request"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /book/{id}/marc [get]
+func (h *MARCHandler) GetBookMARC(w http.ResponseWriter, r *http.Request) {
+	idParam := mux.Vars(r)["id"]
+	if _, err := uuid.Parse(idParam); err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+	format := models.ExportFormat(strings.ToLower(r.URL.Query().Get("format")))
+	if format == "" {
+		format = models.ExportMARCXML
+	}
+	if format != models.ExportMARC && format != models.ExportMARCXML {
+		problem.Write(w, r, problem.InvalidRequest, `format must be "marcxml" or "marc"`)
+		return
+	}
+
+	book, err := h.usecase.GetByID(r.Context(), idParam)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.Write(w, r, problem.NotFound, err.Error())
+			return
+		}
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+
+	rec := marc.BookRecord(*book, h.cfg)
+	raw, err := rec.MarshalBinary()
+	if err != nil {
+		h.logger.Error("failed to encode marc record", "id", idParam, "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", exportContentTypes[format])
+	w.Header().Set("Content-Disposition", `inline; filename="`+idParam+"."+exportExtensions[format]+`"`)
+	w.WriteHeader(http.StatusOK)
+	if format == models.ExportMARC {
+		_, _ = w.Write(raw)
+		return
+	}
+	xw := marc.NewXMLWriter(w)
+	if err := xw.Begin(); err != nil {
+		return
+	}
+	if err := xw.Write(rec); err != nil {
+		return
+	}
+	_ = xw.End()
+}
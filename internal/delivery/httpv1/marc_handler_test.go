@@ -0,0 +1,60 @@
+package httpv1
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"book-store-api/internal/marc"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveMARC(t *testing.T, u *UsecaseMock, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	router := mux.NewRouter()
+	NewMARCHandler(u, testMARCConfig, slog.New(slog.NewTextHandler(io.Discard, nil))).RegisterRoutes(router)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestGetBookMARC(t *testing.T) {
+	book := exportBooks(1)[0]
+	book.ISBN = "0-306-40615-2"
+	u := &UsecaseMock{GetByIDFunc: func(ctx context.Context, id string) (*models.Book, error) {
+		if id != book.ID.String() {
+			return nil, repository.ErrNotFound
+		}
+		return &book, nil
+	}}
+
+	rec := serveMARC(t, u, "/book/"+book.ID.String()+"/marc")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/marcxml+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	records, err := marc.ReadXML(rec.Body)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "9780306406157", records[0].Get("020")[0].Subfield('a'))
+
+	rec = serveMARC(t, u, "/book/"+book.ID.String()+"/marc?format=MARC")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/marc", rec.Header().Get("Content-Type"))
+	r, err := marc.ReadBinary(rec.Body)
+	require.NoError(t, err)
+	assert.Equal(t, book.Title, r.Get("245")[0].Subfield('a'))
+
+	rec = serveMARC(t, u, "/book/"+book.ID.String()+"/marc?format=onix")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serveMARC(t, u, "/book/not-a-uuid/marc")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serveMARC(t, u, "/book/00000000-0000-0000-0000-000000000001/marc")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+type TokenVerifier interface {
+	Verify(token string) (auth.Principal, error)
+}
+
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (auth.Principal, error)
+}
+
+// JWTMiddleware проверяет Bearer-токен, если он передан, и кладёт principal в контекст.
+// Запросы без токена проходят анонимно, доступ ограничивает RequireRoles.
+func JWTMiddleware(verifier TokenVerifier) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := bearerToken(r)
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_request"`)
+				problem.Write(w, r, problem.InvalidToken, "invalid authorization header")
+				return
+			}
+
+			principal, err := verifier.Verify(token)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				problem.Write(w, r, problem.InvalidToken, "invalid token")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// APIKeyMiddleware аутентифицирует интеграции по заголовку X-API-Key.
+// Если principal уже определён по Bearer-токену, заголовок не проверяется.
+func APIKeyMiddleware(authenticator APIKeyAuthenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("X-API-Key")
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if _, ok := auth.PrincipalFromContext(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := authenticator.Authenticate(r.Context(), key)
+			if err != nil {
+				if errors.Is(err, usecase.ErrInvalidAPIKey) {
+					problem.Write(w, r, problem.InvalidAPIKey, "invalid api key")
+					return
+				}
+				problem.Write(w, r, problem.Internal, "internal server error")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// RequireRoles пропускает запрос, если у principal есть хотя бы одна из ролей.
+func RequireRoles(roles ...string) func(http.Handler) http.Handler {
+	return Authorize(auth.Policy{Roles: roles})
+}
+
+// Authorize пропускает запрос, если policy разрешает доступ principal.
+func Authorize(policy auth.Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				problem.Write(w, r, problem.AuthRequired, "authorization required")
+				return
+			}
+			if !policy.Allows(principal) {
+				problem.Write(w, r, problem.Forbidden, "forbidden")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
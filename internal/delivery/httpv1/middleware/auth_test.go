@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/usecase"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	editor      = auth.Principal{Type: auth.PrincipalUser, Subject: "editor", Roles: []string{auth.RoleCatalogEditor}}
+	integration = auth.Principal{Type: auth.PrincipalAPIKey, Subject: "key-1", Scopes: []string{"catalog:write"}}
+)
+
+// authRouter отвечает 200 на GET /open без ограничений и на POST /write по политике.
+func authRouter(verifier TokenVerifier, keys APIKeyAuthenticator, policy auth.Policy) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(JWTMiddleware(verifier), APIKeyMiddleware(keys))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, _ := auth.PrincipalFromContext(r.Context())
+		w.Header().Set("X-Subject", p.Subject)
+	})
+	router.Handle("/open", ok).Methods("GET")
+	router.Handle("/write", Authorize(policy)(ok)).Methods("POST")
+	return router
+}
+
+func serveAuth(router http.Handler, method, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthMiddlewares(t *testing.T) {
+	verifier := &TokenVerifierMock{VerifyFunc: func(token string) (auth.Principal, error) {
+		switch token {
+		case "editor":
+			return editor, nil
+		case "customer":
+			return auth.Principal{Type: auth.PrincipalUser, Subject: "customer", Roles: []string{auth.RoleCustomer}}, nil
+		}
+		return auth.Principal{}, auth.ErrInvalidToken
+	}}
+	keys := &APIKeyAuthenticatorMock{AuthenticateFunc: func(ctx context.Context, key string) (auth.Principal, error) {
+		switch key {
+		case "good":
+			return integration, nil
+		case "broken":
+			return auth.Principal{}, errors.New("db is down")
+		}
+		return auth.Principal{}, usecase.ErrInvalidAPIKey
+	}}
+	router := authRouter(verifier, keys, auth.Policy{Roles: []string{auth.RoleCatalogEditor}, Scopes: []string{"catalog:write"}})
+
+	for name, tc := range map[string]struct {
+		method  string
+		target  string
+		header  http.Header
+		want    int
+		subject string
+	}{
+		"anonymous read":         {"GET", "/open", nil, http.StatusOK, ""},
+		"anonymous write":        {"POST", "/write", nil, http.StatusUnauthorized, ""},
+		"editor write":           {"POST", "/write", http.Header{"Authorization": {"Bearer editor"}}, http.StatusOK, "editor"},
+		"lowercase scheme":       {"POST", "/write", http.Header{"Authorization": {"bearer editor"}}, http.StatusOK, "editor"},
+		"customer write":         {"POST", "/write", http.Header{"Authorization": {"Bearer customer"}}, http.StatusForbidden, ""},
+		"invalid token on read":  {"GET", "/open", http.Header{"Authorization": {"Bearer forged"}}, http.StatusUnauthorized, ""},
+		"basic auth":             {"GET", "/open", http.Header{"Authorization": {"Basic dXNlcjpwYXNz"}}, http.StatusUnauthorized, ""},
+		"api key scope":          {"POST", "/write", http.Header{"X-Api-Key": {"good"}}, http.StatusOK, "key-1"},
+		"invalid api key":        {"GET", "/open", http.Header{"X-Api-Key": {"revoked"}}, http.StatusUnauthorized, ""},
+		"api key lookup failure": {"GET", "/open", http.Header{"X-Api-Key": {"broken"}}, http.StatusInternalServerError, ""},
+		"token wins over key":    {"POST", "/write", http.Header{"Authorization": {"Bearer editor"}, "X-Api-Key": {"revoked"}}, http.StatusOK, "editor"},
+	} {
+		rec := serveAuth(router, tc.method, tc.target, tc.header)
+		assert.Equal(t, tc.want, rec.Code, name)
+		assert.Equal(t, tc.subject, rec.Header().Get("X-Subject"), name)
+	}
+}
+
+func TestAuthMiddlewares_Challenges(t *testing.T) {
+	verifier := &TokenVerifierMock{VerifyFunc: func(token string) (auth.Principal, error) {
+		return auth.Principal{}, auth.ErrInvalidToken
+	}}
+	router := authRouter(verifier, &APIKeyAuthenticatorMock{}, auth.Policy{Roles: []string{auth.RoleAdmin}})
+
+	rec := serveAuth(router, "POST", "/write", nil)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+
+	rec = serveAuth(router, "POST", "/write", http.Header{"Authorization": {"Bearer"}})
+	assert.Equal(t, `Bearer error="invalid_request"`, rec.Header().Get("WWW-Authenticate"))
+	assert.Empty(t, verifier.VerifyCalls(), "malformed header is rejected before verification")
+
+	rec = serveAuth(router, "POST", "/write", http.Header{"Authorization": {"Bearer forged"}})
+	assert.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+}
@@ -1,19 +1,18 @@
 package middleware
 
 import (
-	"context"
 	"log/slog"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"book-store-api/internal/reqctx"
+
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
-type contextKey string
-
-const requestIDKey contextKey = "requestID"
-
 type loggingResponseWriter struct {
 	http.ResponseWriter
 	status int
@@ -24,21 +23,56 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap нужен http.ResponseController, чтобы потоковые ответы (SSE) могли делать Flush.
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
 func RequestIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		id := uuid.New().String()
-		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		// тот же id попадает в instance ответов об ошибках
+		w.Header().Set("X-Request-ID", id)
+		ctx := reqctx.WithRequestID(r.Context(), id)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// ClientIPMiddleware кладёт IP клиента в контекст для rate limit и аудита.
+// При trustProxy адрес берётся из X-Forwarded-For, который добавляет наш балансировщик.
+func ClientIPMiddleware(trustProxy bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := reqctx.WithClientIP(r.Context(), clientIP(r, trustProxy))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		// последний адрес в цепочке добавлен нашим прокси, предыдущие клиент может подделать
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			parts := strings.Split(xff, ",")
+			if ip := strings.TrimSpace(parts[len(parts)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func LoggerMiddleware(logger *slog.Logger) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			lrw := &loggingResponseWriter{ResponseWriter: w, status: 200}
 			start := time.Now()
 
-			reqID, _ := r.Context().Value(requestIDKey).(string)
+			reqID := reqctx.RequestID(r.Context())
 			if reqID == "" {
 				reqID = "unknown"
 			}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/ratelimit"
+	"book-store-api/internal/reqctx"
+
+	"github.com/gorilla/mux"
+)
+
+type RateLimitOptions struct {
+	Rules ratelimit.Rules
+	// RoutePrefix отрезается от шаблона пути, чтобы правила писались как в swagger: "GET /book"
+	RoutePrefix string
+}
+
+// RateLimit ограничивает запросы по маршруту и клиенту: API-ключу, пользователю или IP.
+// Должен стоять после ClientIPMiddleware, JWTMiddleware и APIKeyMiddleware, чтобы видеть principal.
+func RateLimit(limiter ratelimit.Limiter, opts RateLimitOptions, logger *slog.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := routeName(r, opts.RoutePrefix)
+			kind, client := clientKey(r)
+
+			limit, matched, ok := opts.Rules.Lookup(route, kind)
+			if !ok && kind != ratelimit.KindIP {
+				// для типа клиента нет своего правила — ограничиваем как анонимного
+				kind, client = ratelimit.KindIP, reqctx.ClientIP(r.Context())
+				limit, matched, ok = opts.Rules.Lookup(route, kind)
+			}
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !allow(w, r, limiter, matched+":"+string(kind)+":"+client, limit, logger) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// PreAuthRateLimit ограничивает все запросы с одного IP до аутентификации, чтобы перебор
+// токенов и API-ключей упирался в 429, а не в 401 и поиск ключа в базе на каждую попытку.
+// Лимит должен быть не меньше самого большого лимита клиента в RateLimit.
+func PreAuthRateLimit(limiter ratelimit.Limiter, limit ratelimit.Limit, logger *slog.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if allow(w, r, limiter, "preauth:"+string(ratelimit.KindIP)+":"+reqctx.ClientIP(r.Context()), limit, logger) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// allow списывает запрос со счётчика key и выставляет заголовки RateLimit-*; при превышении
+// отвечает 429 сам. Ошибка хранилища лимитов запрос не блокирует.
+func allow(w http.ResponseWriter, r *http.Request, limiter ratelimit.Limiter, key string, limit ratelimit.Limit, logger *slog.Logger) bool {
+	res, err := limiter.Allow(r.Context(), key, limit)
+	if err != nil {
+		logger.Error("rate limiter error", "err", err)
+		return true
+	}
+
+	reset := strconv.Itoa(ceilSeconds(res.Reset))
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	w.Header().Set("RateLimit-Reset", reset)
+	w.Header().Set("RateLimit-Policy", strconv.Itoa(limit.Requests)+";w="+strconv.Itoa(ceilSeconds(limit.Window)))
+
+	if !res.Allowed {
+		w.Header().Set("Retry-After", reset)
+		problem.Write(w, r, problem.RateLimited, "rate limit exceeded")
+		return false
+	}
+	return true
+}
+
+func routeName(r *http.Request, prefix string) string {
+	path := r.URL.Path
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			path = tpl
+		}
+	}
+	return r.Method + " " + strings.TrimPrefix(path, prefix)
+}
+
+func clientKey(r *http.Request) (ratelimit.Kind, string) {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		if principal.Type == auth.PrincipalAPIKey {
+			return ratelimit.KindAPIKey, principal.Subject
+		}
+		return ratelimit.KindUser, principal.Subject
+	}
+	return ratelimit.KindIP, reqctx.ClientIP(r.Context())
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/ratelimit"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// limitedRouter собирает цепочку как в приложении: IP, лимит до аутентификации, JWT, лимит по клиенту.
+func limitedRouter(t *testing.T, limiter ratelimit.Limiter, rules string, preAuth ratelimit.Limit) *mux.Router {
+	t.Helper()
+	parsed, err := ratelimit.ParseRules(rules)
+	require.NoError(t, err)
+	verifier := &TokenVerifierMock{VerifyFunc: func(token string) (auth.Principal, error) {
+		if token == "editor" {
+			return editor, nil
+		}
+		return auth.Principal{}, auth.ErrInvalidToken
+	}}
+
+	router := mux.NewRouter()
+	api := router.PathPrefix("/api/v1").Subrouter()
+	api.Use(
+		ClientIPMiddleware(false),
+		PreAuthRateLimit(limiter, preAuth, discardLogger),
+		JWTMiddleware(verifier),
+		RateLimit(limiter, RateLimitOptions{Rules: parsed, RoutePrefix: "/api/v1"}, discardLogger),
+	)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	api.Handle("/book", ok).Methods("GET")
+	api.Handle("/book/{id}", ok).Methods("GET")
+	return router
+}
+
+func serveFrom(router http.Handler, ip, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.RemoteAddr = ip + ":40000"
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit(t *testing.T) {
+	router := limitedRouter(t, ratelimit.NewMemoryLimiter(), "* ip=3/1m; GET /book ip=2/1m; * user=5/1m",
+		ratelimit.Limit{Requests: 100, Window: time.Minute})
+
+	rec := serveFrom(router, "10.0.0.1", "/api/v1/book", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2;w=60", rec.Header().Get("RateLimit-Policy"))
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+
+	serveFrom(router, "10.0.0.1", "/api/v1/book", nil)
+	rec = serveFrom(router, "10.0.0.1", "/api/v1/book", nil)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.Equal(t, rec.Header().Get("RateLimit-Reset"), rec.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, serveFrom(router, "10.0.0.2", "/api/v1/book", nil).Code, "limits are per ip")
+
+	rec = serveFrom(router, "10.0.0.1", "/api/v1/book/1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code, "route without its own rule has a separate counter")
+	assert.Equal(t, "3", rec.Header().Get("RateLimit-Limit"))
+
+	rec = serveFrom(router, "10.0.0.1", "/api/v1/book", http.Header{"Authorization": {"Bearer editor"}})
+	assert.Equal(t, http.StatusOK, rec.Code, "users are counted by subject, not by ip")
+	assert.Equal(t, "5", rec.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimit_IPFallback(t *testing.T) {
+	router := limitedRouter(t, ratelimit.NewMemoryLimiter(), "* ip=1/1m", ratelimit.Limit{Requests: 100, Window: time.Minute})
+
+	authorized := http.Header{"Authorization": {"Bearer editor"}}
+	rec := serveFrom(router, "10.0.0.1", "/api/v1/book", authorized)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"), "no user rule: limited as anonymous")
+
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(router, "10.0.0.1", "/api/v1/book", nil).Code,
+		"user without a rule shares the ip counter")
+}
+
+func TestPreAuthRateLimit(t *testing.T) {
+	router := limitedRouter(t, ratelimit.NewMemoryLimiter(), "* user=100/1m", ratelimit.Limit{Requests: 2, Window: 30 * time.Second})
+
+	forged := http.Header{"Authorization": {"Bearer forged"}}
+	assert.Equal(t, http.StatusUnauthorized, serveFrom(router, "10.0.0.1", "/api/v1/book", forged).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveFrom(router, "10.0.0.1", "/api/v1/book", forged).Code)
+
+	rec := serveFrom(router, "10.0.0.1", "/api/v1/book", forged)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code, "bad credentials are throttled before authentication")
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "2;w=30", rec.Header().Get("RateLimit-Policy"))
+
+	assert.Equal(t, http.StatusTooManyRequests,
+		serveFrom(router, "10.0.0.1", "/api/v1/book", http.Header{"Authorization": {"Bearer editor"}}).Code)
+	assert.Equal(t, http.StatusOK, serveFrom(router, "10.0.0.2", "/api/v1/book", nil).Code)
+}
+
+func TestRateLimit_LimiterError(t *testing.T) {
+	limiter := &LimiterMock{AllowFunc: func(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+		return ratelimit.Result{}, errors.New("redis is down")
+	}}
+	router := limitedRouter(t, limiter, "* ip=1/1m", ratelimit.Limit{Requests: 1, Window: time.Minute})
+
+	for range 3 {
+		rec := serveFrom(router, "10.0.0.1", "/api/v1/book", nil)
+		assert.Equal(t, http.StatusOK, rec.Code, "limiter failure must not block requests")
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	}
+	require.Len(t, limiter.AllowCalls(), 6)
+	assert.Equal(t, "preauth:ip:10.0.0.1", limiter.AllowCalls()[0].Key)
+	assert.Equal(t, "*:ip:10.0.0.1", limiter.AllowCalls()[1].Key)
+}
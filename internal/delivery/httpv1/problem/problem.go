@@ -0,0 +1,134 @@
+// Package problem отвечает на ошибки в формате RFC 7807 со стабильными кодами.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/reqctx"
+)
+
+const ContentType = "application/problem+json"
+
+// typeBase — префикс поля type; к нему добавляется код ошибки.
+const typeBase = "urn:book-store-api:problem:"
+
+type Code string
+
+const (
+	InvalidRequest      Code = "invalid_request"
+	PayloadTooLarge     Code = "payload_too_large"
+	ValidationFailed    Code = "validation_failed"
+	NotFound            Code = "not_found"
+	MethodNotAllowed    Code = "method_not_allowed"
+	NotAcceptable       Code = "not_acceptable"
+	UnsupportedMedia    Code = "unsupported_media_type"
+	AlreadyExists       Code = "already_exists"
+	Conflict            Code = "conflict"
+	AuthRequired        Code = "authentication_required"
+	InvalidToken        Code = "invalid_token"
+	InvalidAPIKey       Code = "invalid_api_key"
+	InvalidCredentials  Code = "invalid_credentials"
+	ExpiredToken        Code = "invalid_or_expired_token"
+	Forbidden           Code = "forbidden"
+	EmailNotVerified    Code = "email_not_verified"
+	LoginDenied         Code = "login_denied"
+	InvalidLoginState   Code = "invalid_login_state"
+	RateLimited         Code = "rate_limited"
+	UpstreamUnavailable Code = "upstream_unavailable"
+	Internal            Code = "internal_error"
+)
+
+type kind struct {
+	status int
+	title  string
+}
+
+// у каждого кода один статус и один title, чтобы клиент мог полагаться на пару type+status
+var kinds = map[Code]kind{
+	InvalidRequest:      {http.StatusBadRequest, "Invalid request"},
+	PayloadTooLarge:     {http.StatusRequestEntityTooLarge, "Payload too large"},
+	ValidationFailed:    {http.StatusUnprocessableEntity, "Validation failed"},
+	NotFound:            {http.StatusNotFound, "Resource not found"},
+	MethodNotAllowed:    {http.StatusMethodNotAllowed, "Method not allowed"},
+	NotAcceptable:       {http.StatusNotAcceptable, "Not acceptable"},
+	UnsupportedMedia:    {http.StatusUnsupportedMediaType, "Unsupported media type"},
+	AlreadyExists:       {http.StatusConflict, "Resource already exists"},
+	Conflict:            {http.StatusConflict, "Conflicting resource state"},
+	AuthRequired:        {http.StatusUnauthorized, "Authentication required"},
+	InvalidToken:        {http.StatusUnauthorized, "Invalid token"},
+	InvalidAPIKey:       {http.StatusUnauthorized, "Invalid API key"},
+	InvalidCredentials:  {http.StatusUnauthorized, "Invalid credentials"},
+	ExpiredToken:        {http.StatusBadRequest, "Invalid or expired token"},
+	Forbidden:           {http.StatusForbidden, "Forbidden"},
+	EmailNotVerified:    {http.StatusForbidden, "Email is not verified"},
+	LoginDenied:         {http.StatusUnauthorized, "Login denied"},
+	InvalidLoginState:   {http.StatusBadRequest, "Invalid login state"},
+	RateLimited:         {http.StatusTooManyRequests, "Rate limit exceeded"},
+	UpstreamUnavailable: {http.StatusBadGateway, "Upstream service error"},
+	Internal:            {http.StatusInternalServerError, "Internal server error"},
+}
+
+func (c Code) Status() int {
+	if k, ok := kinds[c]; ok {
+		return k.status
+	}
+	return http.StatusInternalServerError
+}
+
+func New(r *http.Request, code Code, detail string) dto.ProblemDTO {
+	k, ok := kinds[code]
+	if !ok {
+		code, k = Internal, kinds[Internal]
+	}
+	return dto.ProblemDTO{
+		Type:     typeBase + string(code),
+		Title:    k.title,
+		Status:   k.status,
+		Detail:   detail,
+		Instance: reqctx.RequestID(r.Context()),
+		Code:     string(code),
+	}
+}
+
+// Write заменяет http.Error: Content-Type, выставленный обработчиком заранее, перезаписывается.
+func Write(w http.ResponseWriter, r *http.Request, code Code, detail string) {
+	Render(w, New(r, code, detail))
+}
+
+// Validation отвечает 422 со списком нарушенных полей, если ошибка их содержит.
+func Validation(w http.ResponseWriter, r *http.Request, err error) {
+	p := New(r, ValidationFailed, err.Error())
+	var verr *models.ValidationError
+	if errors.As(err, &verr) {
+		for _, f := range verr.Fields {
+			p.Errors = append(p.Errors, dto.FieldErrorDTO{Field: f.Field, Code: f.Code, Message: f.Message})
+		}
+	}
+	Render(w, p)
+}
+
+func Render(w http.ResponseWriter, p dto.ProblemDTO) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		return
+	}
+}
+
+// NotFoundHandler и MethodNotAllowedHandler заменяют текстовые ответы gorilla/mux.
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, NotFound, "no route for "+r.URL.Path)
+	})
+}
+
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, MethodNotAllowed, r.Method+" is not allowed for "+r.URL.Path)
+	})
+}
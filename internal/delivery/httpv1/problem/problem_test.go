@@ -0,0 +1,86 @@
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/reqctx"
+)
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) dto.ProblemDTO {
+	t.Helper()
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	var p dto.ProblemDTO
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	return p
+}
+
+func TestWrite(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/book/1", nil)
+	r = r.WithContext(reqctx.WithRequestID(r.Context(), "req-1"))
+
+	t.Run("known code", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "application/json")
+		Write(rec, r, NotFound, "book not found")
+
+		p := decode(t, rec)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, dto.ProblemDTO{
+			Type:     "urn:book-store-api:problem:not_found",
+			Title:    "Resource not found",
+			Status:   http.StatusNotFound,
+			Detail:   "book not found",
+			Instance: "req-1",
+			Code:     "not_found",
+		}, p)
+	})
+
+	t.Run("unknown code falls back to internal", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Write(rec, r, Code("no_such_code"), "boom")
+
+		p := decode(t, rec)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, string(Internal), p.Code)
+	})
+}
+
+func TestValidation(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/book", nil)
+
+	t.Run("field errors", func(t *testing.T) {
+		var verr models.ValidationError
+		verr.Add("title", models.FieldRequired, "book title is required")
+		verr.Add("price", models.FieldNegative, "book price is negative")
+
+		rec := httptest.NewRecorder()
+		Validation(rec, r, fmt.Errorf("create book: %w", verr.Err()))
+
+		p := decode(t, rec)
+		assert.Equal(t, http.StatusUnprocessableEntity, p.Status)
+		assert.Equal(t, string(ValidationFailed), p.Code)
+		assert.Equal(t, []dto.FieldErrorDTO{
+			{Field: "title", Code: "required", Message: "book title is required"},
+			{Field: "price", Code: "negative", Message: "book price is negative"},
+		}, p.Errors)
+	})
+
+	t.Run("plain validation error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Validation(rec, r, fmt.Errorf("%w: rate is out of range", models.ErrDomainValidation))
+
+		p := decode(t, rec)
+		assert.Equal(t, http.StatusUnprocessableEntity, p.Status)
+		assert.Equal(t, "domain validation error: rate is out of range", p.Detail)
+		assert.Empty(t, p.Errors)
+	})
+}
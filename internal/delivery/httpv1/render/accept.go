@@ -0,0 +1,94 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+)
+
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// specificity: 2 — точный тип, 1 — type/*, 0 — */*
+func (m mediaRange) match(mediaType string) (int, bool) {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	switch {
+	case m.typ == "*" && m.subtype == "*":
+		return 0, true
+	case m.typ == typ && m.subtype == "*":
+		return 1, true
+	case m.typ == typ && m.subtype == subtype:
+		return 2, true
+	}
+	return 0, false
+}
+
+// parseAccept разбирает Accept по RFC 9110; диапазоны с некорректным q пропускаются.
+func parseAccept(values []string) []mediaRange {
+	var ranges []mediaRange
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			fields := strings.Split(part, ";")
+			typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(fields[0])), "/")
+			if !ok || typ == "" || subtype == "" {
+				continue
+			}
+			m := mediaRange{typ: typ, subtype: subtype, q: 1}
+			valid := true
+			for _, param := range fields[1:] {
+				name, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(strings.TrimSpace(name), "q") {
+					continue
+				}
+				q, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+				if err != nil || q < 0 || q > 1 {
+					valid = false
+					break
+				}
+				m.q = q
+			}
+			if valid {
+				ranges = append(ranges, m)
+			}
+		}
+	}
+	return ranges
+}
+
+// choose возвращает кодек с наибольшим q. Вес типа задаёт самый точный подходящий диапазон,
+// так что "application/*, application/xml;q=0" исключает XML. Если клиент назвал синоним
+// явно, он же уходит в Content-Type. Без Accept выбирается первый кодек.
+func choose(accept []string, codecs []*codec) (*codec, string) {
+	ranges := parseAccept(accept)
+	if len(ranges) == 0 {
+		if strings.TrimSpace(strings.Join(accept, "")) != "" {
+			return nil, ""
+		}
+		return codecs[0], codecs[0].mediaTypes[0]
+	}
+
+	var (
+		best     *codec
+		bestType string
+		bestQ    float64
+	)
+	for _, c := range codecs {
+		for _, mediaType := range c.mediaTypes {
+			q, specificity := 0.0, -1
+			for _, m := range ranges {
+				if s, ok := m.match(mediaType); ok && s > specificity {
+					q, specificity = m.q, s
+				}
+			}
+			if q > bestQ {
+				best, bestQ = c, q
+				bestType = c.mediaTypes[0]
+				if specificity == 2 {
+					bestType = mediaType
+				}
+			}
+		}
+	}
+	return best, bestType
+}
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+var errNotList = errors.New("csv requires a slice of structs")
+
+type column struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// jsonColumns — поля структуры под их json-именами, включая поля встроенных структур.
+func jsonColumns(t reflect.Type) []column {
+	var columns []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for _, c := range jsonColumns(f.Type) {
+				c.index = append([]int{i}, c.index...)
+				columns = append(columns, c)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		columns = append(columns, column{name: name, index: []int{i}, typ: f.Type})
+	}
+	return columns
+}
+
+// encodeCSV пишет строку заголовка из json-имён и по строке на элемент. Значения совпадают
+// с JSON-ответом: строки без кавычек, null — пустая ячейка, вложенные объекты — JSON.
+func encodeCSV(w io.Writer, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return errNotList
+	}
+	elem := rv.Type().Elem()
+	ptr := elem.Kind() == reflect.Pointer
+	if ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return errNotList
+	}
+
+	columns := jsonColumns(elem)
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i)
+		if ptr {
+			if item.IsNil() {
+				continue
+			}
+			item = item.Elem()
+		}
+		for j, c := range columns {
+			cell, err := csvCell(item.FieldByIndex(c.index).Interface())
+			if err != nil {
+				return err
+			}
+			record[j] = cell
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvCell(v any) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case string(raw) == "null":
+		return "", nil
+	case len(raw) > 0 && raw[0] == '"':
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	}
+	return string(raw), nil
+}
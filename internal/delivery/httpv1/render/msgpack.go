@@ -0,0 +1,83 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// json.RawMessage (снимки аудита, payload вебхука) кодируется как вложенная структура,
+// а не как bin с текстом JSON.
+func init() {
+	msgpack.Register(json.RawMessage(nil), encodeRawJSON, decodeRawJSON)
+}
+
+// MessagePack использует json-теги DTO. UUID кодируется 16 байтами bin,
+// время — стандартным расширением timestamp (-1).
+func encodeMsgpack(w io.Writer, v any) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	return enc.Encode(v)
+}
+
+func decodeMsgpack(r io.Reader, v any) error {
+	dec := msgpack.NewDecoder(r)
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+func encodeRawJSON(enc *msgpack.Encoder, v reflect.Value) error {
+	raw := v.Bytes()
+	if len(raw) == 0 {
+		return enc.EncodeNil()
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	return enc.Encode(jsonNumbers(value))
+}
+
+func decodeRawJSON(dec *msgpack.Decoder, v reflect.Value) error {
+	value, err := dec.DecodeInterface()
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		v.SetBytes(nil)
+		return nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	v.SetBytes(raw)
+	return nil
+}
+
+// jsonNumbers заменяет json.Number целыми, где это возможно, чтобы msgpack не писал их float.
+func jsonNumbers(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case map[string]any:
+		for k, item := range t {
+			t[k] = jsonNumbers(item)
+		}
+	case []any:
+		for i, item := range t {
+			t[i] = jsonNumbers(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,142 @@
+// Package render выбирает формат ответа по Accept и формат тела запроса по Content-Type.
+// JSON остаётся форматом по умолчанию, XML и MessagePack строятся из тех же json-тегов DTO,
+// CSV доступен только для списков. Ошибки всегда отдаются как application/problem+json.
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"book-store-api/internal/delivery/httpv1/problem"
+)
+
+// ErrUnsupported — тип тела запроса не поддерживается.
+var ErrUnsupported = errors.New("unsupported media type")
+
+type codec struct {
+	// mediaTypes: первый тип — основной, остальные — синонимы, которые клиент может запросить явно
+	mediaTypes []string
+	charset    bool
+	encode     func(w io.Writer, v any) error
+	decode     func(r io.Reader, v any) error
+}
+
+var (
+	jsonCodec = &codec{
+		mediaTypes: []string{"application/json"},
+		encode:     func(w io.Writer, v any) error { return json.NewEncoder(w).Encode(v) },
+		decode:     func(r io.Reader, v any) error { return json.NewDecoder(r).Decode(v) },
+	}
+	xmlCodec = &codec{
+		mediaTypes: []string{"application/xml", "text/xml"},
+		charset:    true,
+		encode:     encodeXML,
+		decode:     decodeXML,
+	}
+	csvCodec = &codec{
+		mediaTypes: []string{"text/csv"},
+		charset:    true,
+		encode:     encodeCSV,
+	}
+	msgpackCodec = &codec{
+		mediaTypes: []string{"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"},
+		encode:     encodeMsgpack,
+		decode:     decodeMsgpack,
+	}
+)
+
+// порядок задаёт предпочтение сервера при равных q
+var (
+	objectCodecs = []*codec{jsonCodec, xmlCodec, msgpackCodec}
+	listCodecs   = []*codec{jsonCodec, xmlCodec, csvCodec, msgpackCodec}
+)
+
+// Encoder пишет ответ в согласованном формате.
+type Encoder struct {
+	codec       *codec
+	contentType string
+}
+
+// Negotiate выбирает формат для одиночного объекта. Если Accept не допускает ни одного
+// формата, отвечает 406 и возвращает false: обработчик должен вызвать его до изменения данных.
+func Negotiate(w http.ResponseWriter, r *http.Request) (Encoder, bool) {
+	return negotiate(w, r, objectCodecs)
+}
+
+// NegotiateList — то же для списков, дополнительно допускает text/csv.
+func NegotiateList(w http.ResponseWriter, r *http.Request) (Encoder, bool) {
+	return negotiate(w, r, listCodecs)
+}
+
+func negotiate(w http.ResponseWriter, r *http.Request, codecs []*codec) (Encoder, bool) {
+	w.Header().Add("Vary", "Accept")
+	c, mediaType := choose(r.Header.Values("Accept"), codecs)
+	if c == nil {
+		problem.Write(w, r, problem.NotAcceptable, "supported response types: "+strings.Join(offered(codecs), ", "))
+		return Encoder{}, false
+	}
+	if c.charset {
+		mediaType += "; charset=utf-8"
+	}
+	return Encoder{codec: c, contentType: mediaType}, true
+}
+
+// Respond пишет статус и тело. Ошибка кодирования возвращается только для журнала:
+// заголовки уже отправлены и problem-ответом она стать не может.
+func (e Encoder) Respond(w http.ResponseWriter, status int, v any) error {
+	w.Header().Set("Content-Type", e.contentType)
+	w.WriteHeader(status)
+	return e.codec.encode(w, v)
+}
+
+// Decode читает тело по Content-Type, без заголовка тело считается JSON.
+// При ошибке отвечает 415 или 400 и возвращает false.
+func Decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := decodeBody(r, v); err != nil {
+		if errors.Is(err, ErrUnsupported) {
+			problem.Write(w, r, problem.UnsupportedMedia, err.Error())
+			return false
+		}
+		problem.Write(w, r, problem.InvalidRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+func decodeBody(r *http.Request, v any) error {
+	c := jsonCodec
+	if raw := r.Header.Get("Content-Type"); raw != "" {
+		mediaType, _, err := mime.ParseMediaType(raw)
+		if err != nil {
+			return fmt.Errorf("%w: invalid content type %q", ErrUnsupported, raw)
+		}
+		if c = byMediaType(mediaType); c == nil || c.decode == nil {
+			return fmt.Errorf("%w %s, use application/json, application/xml or application/msgpack", ErrUnsupported, mediaType)
+		}
+	}
+	return c.decode(r.Body, v)
+}
+
+func byMediaType(mediaType string) *codec {
+	for _, c := range listCodecs {
+		for _, t := range c.mediaTypes {
+			if t == mediaType {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+func offered(codecs []*codec) []string {
+	types := make([]string, 0, len(codecs))
+	for _, c := range codecs {
+		types = append(types, c.mediaTypes[0])
+	}
+	return types
+}
@@ -0,0 +1,193 @@
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"book-store-api/internal/delivery/httpv1/problem"
+)
+
+type testItem struct {
+	ID        uuid.UUID         `json:"id"`
+	Title     string            `json:"title"`
+	Price     int               `json:"price"`
+	Active    bool              `json:"active"`
+	Note      *string           `json:"note"`
+	Tags      []string          `json:"tags,omitempty"`
+	Mapping   map[string]string `json:"mapping,omitempty"`
+	Snapshot  json.RawMessage   `json:"snapshot,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	internal  string
+}
+
+func sampleItem() testItem {
+	return testItem{
+		ID:        uuid.MustParse("5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01"),
+		Title:     `Book, "quoted" & <tagged>`,
+		Price:     1250,
+		Active:    true,
+		Tags:      []string{"a", "b"},
+		Mapping:   map[string]string{"Book Title": "title"},
+		CreatedAt: time.Date(2025, 10, 28, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func negotiated(t *testing.T, accept string, list bool) (Encoder, *httptest.ResponseRecorder, bool) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	if list {
+		enc, ok := NegotiateList(rec, r)
+		return enc, rec, ok
+	}
+	enc, ok := Negotiate(rec, r)
+	return enc, rec, ok
+}
+
+func TestNegotiate(t *testing.T) {
+	for _, tc := range []struct {
+		accept string
+		list   bool
+		want   string
+	}{
+		{"", false, "application/json"},
+		{"*/*", false, "application/json"},
+		{"application/*", false, "application/json"},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", false, "application/xml; charset=utf-8"},
+		{"text/xml", false, "text/xml; charset=utf-8"},
+		{"application/x-msgpack, application/json;q=0.5", false, "application/x-msgpack"},
+		{"application/*, application/json;q=0", false, "application/xml; charset=utf-8"},
+		{"text/csv", true, "text/csv; charset=utf-8"},
+		{"TEXT/CSV;Q=0.4, application/msgpack;q=0.5", true, "application/msgpack"},
+	} {
+		enc, _, ok := negotiated(t, tc.accept, tc.list)
+		require.True(t, ok, tc.accept)
+		assert.Equal(t, tc.want, enc.contentType, tc.accept)
+	}
+
+	for _, accept := range []string{"text/csv", "text/html", "application/json;q=0", "nonsense"} {
+		_, rec, ok := negotiated(t, accept, false)
+		assert.False(t, ok, accept)
+		assert.Equal(t, http.StatusNotAcceptable, rec.Code, accept)
+		assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+	}
+}
+
+func TestRespond_CSV(t *testing.T) {
+	note := "first"
+	items := []testItem{sampleItem(), sampleItem()}
+	items[0].Note = &note
+
+	enc, rec, ok := negotiated(t, "text/csv", true)
+	require.True(t, ok)
+	enc.Respond(rec, http.StatusOK, items)
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "title", "price", "active", "note", "tags", "mapping", "snapshot", "created_at"}, records[0])
+	assert.Equal(t, []string{
+		"5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01", `Book, "quoted" & <tagged>`, "1250", "true", "first",
+		`["a","b"]`, `{"Book Title":"title"}`, "", "2025-10-28T09:00:00Z",
+	}, records[1])
+	assert.Equal(t, "", records[2][4], "null is an empty cell")
+
+	var buf bytes.Buffer
+	assert.ErrorIs(t, encodeCSV(&buf, sampleItem()), errNotList)
+}
+
+func TestXML_RoundTrip(t *testing.T) {
+	item := sampleItem()
+	item.Snapshot = json.RawMessage(`{"price":10}`)
+
+	enc, rec, ok := negotiated(t, "application/xml", false)
+	require.True(t, ok)
+	enc.Respond(rec, http.StatusCreated, item)
+
+	body := rec.Body.String()
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.True(t, strings.HasPrefix(body, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, body, `<title>Book, &#34;quoted&#34; &amp; &lt;tagged&gt;</title>`)
+	assert.Contains(t, body, `<note nil="true"></note>`)
+	assert.Contains(t, body, `<tags><item>a</item><item>b</item></tags>`)
+	assert.Contains(t, body, `<mapping><entry key="Book Title">title</entry></mapping>`)
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	var got testItem
+	require.True(t, Decode(httptest.NewRecorder(), r, &got))
+	assert.JSONEq(t, `{"price":"10"}`, string(got.Snapshot), "untyped values stay strings")
+	got.Snapshot = item.Snapshot
+	assert.Equal(t, item, got)
+}
+
+func TestMsgpack_RoundTrip(t *testing.T) {
+	item := sampleItem()
+	item.Snapshot = json.RawMessage(`{"price":10,"tags":["x"]}`)
+
+	enc, rec, ok := negotiated(t, "application/msgpack", false)
+	require.True(t, ok)
+	enc.Respond(rec, http.StatusOK, item)
+
+	var generic map[string]any
+	require.NoError(t, msgpack.Unmarshal(rec.Body.Bytes(), &generic))
+	assert.Equal(t, `Book, "quoted" & <tagged>`, generic["title"], "keys follow json tags")
+	assert.Equal(t, map[string]any{"price": int8(10), "tags": []any{"x"}}, generic["snapshot"], "raw JSON is a nested map")
+
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rec.Body.Bytes()))
+	r.Header.Set("Content-Type", "application/msgpack")
+	var got testItem
+	require.True(t, Decode(httptest.NewRecorder(), r, &got))
+	assert.Equal(t, item.ID, got.ID)
+	assert.Equal(t, item.Mapping, got.Mapping)
+	assert.JSONEq(t, string(item.Snapshot), string(got.Snapshot))
+	assert.True(t, item.CreatedAt.Equal(got.CreatedAt))
+}
+
+func TestDecode(t *testing.T) {
+	decode := func(contentType, body string) (testItem, *httptest.ResponseRecorder, bool) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		if contentType != "" {
+			r.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		var got testItem
+		ok := Decode(rec, r, &got)
+		return got, rec, ok
+	}
+
+	got, _, ok := decode("", `{"title":"no header"}`)
+	require.True(t, ok, "missing Content-Type is JSON")
+	assert.Equal(t, "no header", got.Title)
+
+	for _, contentType := range []string{"text/csv", "application/x-www-form-urlencoded", "garbage;;"} {
+		_, rec, ok := decode(contentType, `title=x`)
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code, contentType)
+	}
+
+	for _, tc := range []struct{ contentType, body string }{
+		{"application/json", `{"title":`},
+		{"text/xml", `<response><price>cheap</price></response>`},
+		{"application/xml", `<response><title>unclosed</response>`},
+	} {
+		_, rec, ok := decode(tc.contentType, tc.body)
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, tc.body)
+	}
+}
@@ -0,0 +1,237 @@
+package render
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	xmlRoot = "response"
+	xmlItem = "item"
+	// xmlEntry — элемент для ключа map, который не годится в имя XML-элемента
+	xmlEntry = "entry"
+)
+
+// encodeXML строит XML из JSON-представления, чтобы имена и значения совпадали с JSON-ответом:
+// объект — элементы по ключам, массив — элементы item, null — пустой элемент с nil="true".
+func encodeXML(w io.Writer, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	if err := writeXMLValue(enc, dec, xml.StartElement{Name: xml.Name{Local: xmlRoot}}); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+func writeXMLValue(enc *xml.Encoder, dec *json.Decoder, start xml.StartElement) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	var text string
+	switch t := tok.(type) {
+	case json.Delim:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for dec.More() {
+			child := xml.StartElement{Name: xml.Name{Local: xmlItem}}
+			if t == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child = keyElement(key.(string))
+			}
+			if err := writeXMLValue(enc, dec, child); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		return enc.EncodeToken(start.End())
+	case nil:
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "nil"}, Value: "true"})
+	case string:
+		text = t
+	case json.Number:
+		text = t.String()
+	case bool:
+		text = strconv.FormatBool(t)
+	}
+	return enc.EncodeElement(text, start)
+}
+
+func keyElement(key string) xml.StartElement {
+	if validXMLName(key) {
+		return xml.StartElement{Name: xml.Name{Local: key}}
+	}
+	return xml.StartElement{Name: xml.Name{Local: xmlEntry}, Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}}}
+}
+
+func validXMLName(s string) bool {
+	if s == "" || strings.HasPrefix(strings.ToLower(s), "xml") {
+		return false
+	}
+	for i, r := range s {
+		if unicode.IsLetter(r) || r == '_' {
+			continue
+		}
+		if i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Nodes   []xmlNode  `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+func (n xmlNode) attr(name string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (n xmlNode) key() string {
+	if n.XMLName.Local == xmlEntry {
+		if k := n.attr("key"); k != "" {
+			return k
+		}
+	}
+	return n.XMLName.Local
+}
+
+// decodeXML принимает документ в той же форме, что отдаёт encodeXML. Имя корня не проверяется,
+// типы значений берутся из v: текст переводится в JSON по типу поля и декодируется как JSON.
+func decodeXML(r io.Reader, v any) error {
+	var root xmlNode
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return err
+	}
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return fmt.Errorf("decode target must be a pointer, got %T", v)
+	}
+	value, err := xmlToJSON(root, t.Elem())
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+func xmlToJSON(n xmlNode, t reflect.Type) (any, error) {
+	if n.attr("nil") == "true" {
+		return nil, nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeFor[json.RawMessage]() {
+		return xmlToJSON(n, reflect.TypeFor[any]())
+	}
+	// uuid, time.Time и подобные типы приходят строкой, как в JSON
+	if pt := reflect.PointerTo(t); pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType) {
+		return n.Text, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := make(map[string]reflect.Type)
+		for _, c := range jsonColumns(t) {
+			fields[c.name] = c.typ
+		}
+		obj := make(map[string]any, len(n.Nodes))
+		for _, child := range n.Nodes {
+			ft, ok := fields[child.key()]
+			if !ok {
+				// неизвестные поля игнорируются, как и в JSON
+				continue
+			}
+			value, err := xmlToJSON(child, ft)
+			if err != nil {
+				return nil, err
+			}
+			obj[child.key()] = value
+		}
+		return obj, nil
+	case reflect.Map:
+		obj := make(map[string]any, len(n.Nodes))
+		for _, child := range n.Nodes {
+			value, err := xmlToJSON(child, t.Elem())
+			if err != nil {
+				return nil, err
+			}
+			obj[child.key()] = value
+		}
+		return obj, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return strings.TrimSpace(n.Text), nil
+		}
+		items := make([]any, 0, len(n.Nodes))
+		for _, child := range n.Nodes {
+			value, err := xmlToJSON(child, t.Elem())
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(n.Text))
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid boolean %q", n.XMLName.Local, n.Text)
+		}
+		return b, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		s := strings.TrimSpace(n.Text)
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("%s: invalid number %q", n.XMLName.Local, n.Text)
+		}
+		return json.Number(s), nil
+	case reflect.Interface:
+		if len(n.Nodes) > 0 {
+			return xmlToJSON(n, reflect.TypeFor[map[string]any]())
+		}
+		return n.Text, nil
+	}
+	return n.Text, nil
+}
@@ -4,16 +4,29 @@ import (
 	"log/slog"
 
 	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
 
 	"github.com/gorilla/mux"
 )
 
-func NewRouter(bookHandler *Handler, logger *slog.Logger) *mux.Router {
+const APIPrefix = "/api/v1"
+
+type RouteRegistrar interface {
+	RegisterRoutes(router *mux.Router)
+}
+
+func NewRouter(logger *slog.Logger, trustProxy bool, middlewares []mux.MiddlewareFunc, handlers ...RouteRegistrar) *mux.Router {
 	router := mux.NewRouter()
-	api := router.PathPrefix("/api/v1").Subrouter()
+	router.NotFoundHandler = problem.NotFoundHandler()
+	router.MethodNotAllowedHandler = problem.MethodNotAllowedHandler()
+	api := router.PathPrefix(APIPrefix).Subrouter()
 	api.Use(middleware.RequestIDMiddleware)
+	api.Use(middleware.ClientIPMiddleware(trustProxy))
 	api.Use(middleware.LoggerMiddleware(logger))
-	bookHandler.RegisterRoutes(api)
+	api.Use(middlewares...)
+	for _, h := range handlers {
+		h.RegisterRoutes(api)
+	}
 
 	return router
 }
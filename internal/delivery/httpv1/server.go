@@ -8,14 +8,16 @@ import (
 	"book-store-api/internal/config"
 
 	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
 )
 
-func InitServer(cfg config.HTTPConfig, logger *slog.Logger, bookHandler *Handler) *http.Server {
-	router := NewRouter(bookHandler, logger)
+func InitServer(cfg config.HTTPConfig, logger *slog.Logger, middlewares []mux.MiddlewareFunc, registrars ...RouteRegistrar) *http.Server {
+	router := NewRouter(logger, cfg.TrustProxy, middlewares, registrars...)
 	corsAllowed := handlers.CORS(
 		handlers.AllowedOrigins([]string{"*"}),
 		handlers.AllowedMethods([]string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
-		handlers.AllowedHeaders([]string{"Content-Type", "Authorization"}),
+		handlers.AllowedHeaders([]string{"Content-Type", "Authorization", "X-API-Key"}),
+		handlers.ExposedHeaders([]string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"}),
 	)
 
 	return &http.Server{
@@ -0,0 +1,126 @@
+package httpv1
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/usecase"
+
+	"github.com/gorilla/mux"
+)
+
+// loginStateCookie привязывает state к браузеру, который начал вход: без него
+// callback с чужим code и state залогинил бы жертву в аккаунт атакующего.
+const loginStateCookie = "oidc_state"
+
+type StaffAuthHandler struct {
+	usecase delivery.StaffAuthUsecase
+	logger  *slog.Logger
+}
+
+func NewStaffAuthHandler(u delivery.StaffAuthUsecase, logger *slog.Logger) *StaffAuthHandler {
+	return &StaffAuthHandler{usecase: u, logger: logger}
+}
+
+func (h *StaffAuthHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/auth/oidc/login", h.Login).Methods("GET")
+	router.HandleFunc("/auth/oidc/callback", h.Callback).Methods("GET")
+}
+
+// @Summary Вход сотрудника через корпоративный IdP
+// @Description Перенаправляет на страницу авторизации IdP (authorization code + PKCE)
+// @Tags staff-auth
+// @Success 302 {string} string "redirect to identity provider, state is set in the oidc_state cookie"
+// @Failure 502 {object} dto.ProblemDTO "identity provider error"
+// @Router /auth/oidc/login [get]
+func (h *StaffAuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	redirect, err := h.usecase.BeginLogin(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     loginStateCookie,
+		Value:    redirect.State,
+		Path:     callbackPath(r),
+		Expires:  redirect.ExpiresAt,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, redirect.AuthURL, http.StatusFound)
+}
+
+// @Summary Callback OIDC
+// @Description Обменивает code на ID token и выдаёт access-токен с ролями по группам IdP
+// @Tags staff-auth
+// @Produce json,xml,application/msgpack
+// @Param code query string true "Authorization code"
+// @Param state query string true "State"
+// @Success 200 {object} dto.AccessTokenDTO
+// @Failure 400 {object} dto.ProblemDTO "unknown or expired login state, or state from another browser"
+// @Failure 401 {object} dto.ProblemDTO "invalid or expired token"
+// @Failure 403 {object} dto.ProblemDTO "identity has no staff role"
+// @Failure 502 {object} dto.ProblemDTO "identity provider error"
+// @Router /auth/oidc/callback [get]
+func (h *StaffAuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	// state одноразовый: cookie больше не нужна при любом исходе
+	http.SetCookie(w, &http.Cookie{Name: loginStateCookie, Path: callbackPath(r), MaxAge: -1, HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode})
+
+	q := r.URL.Query()
+	if idpErr := q.Get("error"); idpErr != "" {
+		problem.Write(w, r, problem.LoginDenied, "identity provider denied login: "+idpErr)
+		return
+	}
+	if q.Get("code") == "" || q.Get("state") == "" {
+		problem.Write(w, r, problem.InvalidRequest, "code and state are required")
+		return
+	}
+	cookie, err := r.Cookie(loginStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(q.Get("state"))) != 1 {
+		problem.Write(w, r, problem.InvalidLoginState, "login was not started in this browser")
+		return
+	}
+
+	token, err := h.usecase.CompleteLogin(r.Context(), q.Get("state"), q.Get("code"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToAccessTokenResponse(token))
+}
+
+// callbackPath — каталог login и callback, чтобы cookie уходила только на callback
+// при любом префиксе роутера.
+func callbackPath(r *http.Request) string {
+	return r.URL.Path[:strings.LastIndex(r.URL.Path, "/")+1]
+}
+
+func (h *StaffAuthHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidLoginState):
+		problem.Write(w, r, problem.InvalidLoginState, err.Error())
+	case errors.Is(err, usecase.ErrInvalidToken):
+		problem.Write(w, r, problem.InvalidToken, err.Error())
+	case errors.Is(err, usecase.ErrNoStaffRole):
+		problem.Write(w, r, problem.Forbidden, err.Error())
+	case errors.Is(err, usecase.ErrIdentityProvider):
+		problem.Write(w, r, problem.UpstreamUnavailable, err.Error())
+	default:
+		h.logger.Error("staff login failed", "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+	}
+}
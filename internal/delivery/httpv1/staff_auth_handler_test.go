@@ -0,0 +1,75 @@
+package httpv1
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaffAuth_StateCookie(t *testing.T) {
+	u := &StaffAuthUsecaseMock{
+		BeginLoginFunc: func(ctx context.Context) (models.StaffLoginRedirect, error) {
+			return models.StaffLoginRedirect{
+				AuthURL:   "https://idp.example/authorize?state=victim-state",
+				State:     "victim-state",
+				ExpiresAt: time.Now().Add(10 * time.Minute),
+			}, nil
+		},
+		CompleteLoginFunc: func(ctx context.Context, state, code string) (models.AccessToken, error) {
+			if state != "victim-state" {
+				return models.AccessToken{}, usecase.ErrInvalidLoginState
+			}
+			return models.AccessToken{Token: "token", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+	}
+	router := mux.NewRouter()
+	NewStaffAuthHandler(u, slog.New(slog.NewTextHandler(io.Discard, nil))).RegisterRoutes(router.PathPrefix("/api/v1").Subrouter())
+
+	serve := func(target string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Accept", "application/json")
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/api/v1/auth/oidc/login")
+	require.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://idp.example/authorize?state=victim-state", rec.Header().Get("Location"))
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	state := cookies[0]
+	assert.Equal(t, "oidc_state", state.Name)
+	assert.Equal(t, "victim-state", state.Value)
+	assert.Equal(t, "/api/v1/auth/oidc/", state.Path, "cookie is sent to the callback only")
+	assert.True(t, state.HttpOnly)
+	assert.True(t, state.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, state.SameSite)
+	assert.False(t, state.Expires.IsZero())
+
+	callback := "/api/v1/auth/oidc/callback?code=good-code&state=victim-state"
+	rec = serve(callback)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "state without the cookie comes from another browser")
+	assert.Equal(t, http.StatusBadRequest, serve(callback, &http.Cookie{Name: "oidc_state", Value: "attacker-state"}).Code)
+	assert.Empty(t, u.CompleteLoginCalls(), "mismatched state is not consumed")
+
+	rec = serve(callback, &http.Cookie{Name: "oidc_state", Value: state.Value})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Len(t, rec.Result().Cookies(), 1)
+	assert.Equal(t, -1, rec.Result().Cookies()[0].MaxAge, "cookie is cleared after the callback")
+	assert.Equal(t, "victim-state", u.CompleteLoginCalls()[0].State)
+}
@@ -0,0 +1,86 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+
+	"github.com/gorilla/mux"
+)
+
+type TaxHandler struct {
+	usecase delivery.TaxUsecase
+	logger  *slog.Logger
+}
+
+func NewTaxHandler(u delivery.TaxUsecase, logger *slog.Logger) *TaxHandler {
+	return &TaxHandler{usecase: u, logger: logger}
+}
+
+func (h *TaxHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/tax/quote", h.Quote).Methods("POST")
+}
+
+// @Summary Рассчитать налог
+// @Description Считает net, tax и gross по строкам корзины или заказа для страны покупателя
+// @Tags tax
+// @Accept json,xml,application/msgpack
+// @Produce json,xml,application/msgpack
+// @Param quote body dto.TaxQuoteRequest true "Quote lines"
+// @Success 200 {object} dto.TaxQuoteDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Failure 415 {object} dto.ProblemDTO "unsupported Content-Type"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /tax/quote [post]
+func (h *TaxHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+
+	var req dto.TaxQuoteRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	var date time.Time
+	if req.Date != "" {
+		parsed, err := time.Parse(time.DateOnly, req.Date)
+		if err != nil {
+			problem.Write(w, r, problem.InvalidRequest, "invalid date format")
+			return
+		}
+		date = parsed
+	}
+
+	quote, err := h.usecase.Quote(ctx, converter.ToTaxQuoteParams(req, date))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.Write(w, r, problem.NotFound, err.Error())
+			return
+		}
+		if errors.Is(err, models.ErrDomainValidation) || errors.Is(err, models.ErrTaxRateNotFound) {
+			problem.Validation(w, r, err)
+			return
+		}
+		h.logger.Error("failed to quote tax", "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+		return
+	}
+
+	if err := enc.Respond(w, http.StatusOK, converter.ToTaxQuoteResponse(quote)); err != nil {
+		h.logger.Error("failed to encode response", "err", err)
+	}
+}
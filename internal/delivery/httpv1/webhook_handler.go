@@ -0,0 +1,269 @@
+package httpv1
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/converter"
+	"book-store-api/internal/delivery"
+	"book-store-api/internal/delivery/httpv1/middleware"
+	"book-store-api/internal/delivery/httpv1/problem"
+	"book-store-api/internal/delivery/httpv1/render"
+	"book-store-api/internal/dto"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type WebhookHandler struct {
+	usecase delivery.WebhookUsecase
+	logger  *slog.Logger
+}
+
+func NewWebhookHandler(u delivery.WebhookUsecase, logger *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{usecase: u, logger: logger}
+}
+
+func (h *WebhookHandler) RegisterRoutes(router *mux.Router) {
+	admin := router.PathPrefix("/admin/webhooks").Subrouter()
+	admin.Use(middleware.RequireRoles(auth.RoleAdmin))
+	admin.HandleFunc("", h.CreateWebhook).Methods("POST")
+	admin.HandleFunc("", h.ListWebhooks).Methods("GET")
+	admin.HandleFunc("/deliveries/{id}/redeliver", h.Redeliver).Methods("POST")
+	admin.HandleFunc("/{id}", h.GetWebhook).Methods("GET")
+	admin.HandleFunc("/{id}", h.UpdateWebhook).Methods("PUT")
+	admin.HandleFunc("/{id}", h.DeleteWebhook).Methods("DELETE")
+	admin.HandleFunc("/{id}/deliveries", h.ListDeliveries).Methods("GET")
+}
+
+// @Summary Создать подписку на вебхуки
+// @Description Секрет для проверки подписи X-Webhook-Signature возвращается только в этом ответе. Если secret не передан, он генерируется
+// @Tags webhooks
+// @Accept json,xml,application/msgpack
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Param webhook body dto.WebhookRequest true "Subscription data"
+// @Success 201 {object} dto.CreatedWebhookDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Failure 415 {object} dto.ProblemDTO "unsupported Content-Type"
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /admin/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.WebhookRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	sub, err := h.usecase.Create(r.Context(), converter.ToWebhookSubscriptionParams(uuid.Nil, req))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusCreated, converter.ToCreatedWebhookResponse(sub))
+}
+
+// @Summary Список подписок на вебхуки
+// @Tags webhooks
+// @Produce json,xml,text/csv,application/msgpack
+// @Security BearerAuth
+// @Success 200 {array} dto.WebhookDTO
+// @Failure 401 {object} dto.ProblemDTO "authorization required"
+// @Failure 403 {object} dto.ProblemDTO "forbidden"
+// @Failure 500 {object} dto.ProblemDTO "internal server error"
+// @Router /admin/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.NegotiateList(w, r)
+	if !ok {
+		return
+	}
+
+	subs, err := h.usecase.List(r.Context())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToWebhookResponseList(subs))
+}
+
+// @Summary Получить подписку на вебхуки
+// @Tags webhooks
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} dto.WebhookDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid uuid format"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Router /admin/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	sub, err := h.usecase.Get(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToWebhookResponse(sub))
+}
+
+// @Summary Обновить подписку на вебхуки
+// @Description Включение подписки (active=true) сбрасывает счётчик неудачных доставок. Без secret остаётся прежний
+// @Tags webhooks
+// @Accept json,xml,application/msgpack
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param webhook body dto.WebhookRequest true "Subscription data"
+// @Success 204 {string} string "no content"
+// @Failure 400 {object} dto.ProblemDTO "invalid request body"
+// @Failure 415 {object} dto.ProblemDTO "unsupported Content-Type"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Failure 422 {object} dto.ProblemDTO "validation error"
+// @Router /admin/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	var req dto.WebhookRequest
+	if !render.Decode(w, r, &req) {
+		return
+	}
+
+	if err := h.usecase.Update(r.Context(), converter.ToWebhookSubscriptionParams(id, req)); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Удалить подписку на вебхуки
+// @Tags webhooks
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Success 204 {string} string "no content"
+// @Failure 400 {object} dto.ProblemDTO "invalid uuid format"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Router /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	if err := h.usecase.Delete(r.Context(), id); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Журнал доставок подписки
+// @Description Последние доставки, новые первыми
+// @Tags webhooks
+// @Produce json,xml,text/csv,application/msgpack
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Param limit query int false "Page size, max 200"
+// @Success 200 {array} dto.WebhookDeliveryDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid query"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Router /admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.NegotiateList(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	var limit int
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			problem.Write(w, r, problem.InvalidRequest, "limit must be a positive integer")
+			return
+		}
+	}
+
+	deliveries, err := h.usecase.ListDeliveries(r.Context(), id, limit)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusOK, converter.ToWebhookDeliveryResponseList(deliveries))
+}
+
+// @Summary Повторить доставку
+// @Description Ставит в очередь новую доставку того же события, в том числе после исчерпания попыток
+// @Tags webhooks
+// @Produce json,xml,application/msgpack
+// @Security BearerAuth
+// @Param id path string true "Delivery ID"
+// @Success 202 {object} dto.WebhookDeliveryDTO
+// @Failure 400 {object} dto.ProblemDTO "invalid uuid format"
+// @Failure 404 {object} dto.ProblemDTO "not found"
+// @Router /admin/webhooks/deliveries/{id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	enc, ok := render.Negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		problem.Write(w, r, problem.InvalidRequest, "invalid uuid format")
+		return
+	}
+
+	d, err := h.usecase.Redeliver(r.Context(), id)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	enc.Respond(w, http.StatusAccepted, converter.ToWebhookDeliveryResponse(d))
+}
+
+func (h *WebhookHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, models.ErrDomainValidation):
+		problem.Validation(w, r, err)
+	case errors.Is(err, repository.ErrNotFound):
+		problem.Write(w, r, problem.NotFound, err.Error())
+	default:
+		h.logger.Error("webhook request failed", "err", err)
+		problem.Write(w, r, problem.Internal, "internal server error")
+	}
+}
@@ -2,8 +2,11 @@ package delivery
 
 import (
 	"context"
+	"time"
 
 	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
 )
 
 type Usecase interface {
@@ -12,4 +15,108 @@ type Usecase interface {
 	GetAll(ctx context.Context) ([]models.Book, error)
 	Update(ctx context.Context, bookInfo models.BookParams) error
 	GetByID(ctx context.Context, id string) (*models.Book, error)
+	List(ctx context.Context, after *models.BookCursor, size int) (models.BookPage, error)
+}
+
+// BookQueryUsecase читает только запрошенные поля книг и встраивает связи.
+type BookQueryUsecase interface {
+	Query(ctx context.Context, sel models.BookSelection) ([]models.Book, models.BookRelations, error)
+	QueryByID(ctx context.Context, id string, sel models.BookSelection) (*models.Book, models.BookRelations, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Book, error)
+}
+
+type BookBatchUsecase interface {
+	Batch(ctx context.Context, mode models.BookBatchMode, ops []models.BookBatchOp) (models.BookBatchResult, error)
+}
+
+type BookExportUsecase interface {
+	Export(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error
+}
+
+// DeletedBooksUsecase сообщает об удалениях для дельта-выгрузок.
+type DeletedBooksUsecase interface {
+	DeletedBooks(ctx context.Context, since time.Time) ([]models.DeletedBook, error)
+}
+
+type CoverUsecase interface {
+	Upload(ctx context.Context, id string, data []byte) (models.BookCover, error)
+	Remove(ctx context.Context, id string) error
+	Open(ctx context.Context, id, version, file string) (models.Blob, error)
+}
+
+// EnrichmentUsecase предлагает значения полей из внешнего источника метаданных;
+// книга меняется только по Accept и только в перечисленных полях.
+type EnrichmentUsecase interface {
+	Lookup(ctx context.Context, isbn string) (models.BookMetadata, error)
+	Suggest(ctx context.Context, id string) (models.BookEnrichment, error)
+	Accept(ctx context.Context, id string, fields []string) (models.Book, error)
+}
+
+// DuplicateUsecase отдаёт кандидатов последнего прохода поиска дубликатов и сливает пары.
+type DuplicateUsecase interface {
+	Candidates(ctx context.Context, minScore float64, limit int) (models.DuplicateReport, error)
+	Merge(ctx context.Context, canonicalID, duplicateID string) (models.Book, error)
+}
+
+type ImportUsecase interface {
+	Create(ctx context.Context, params models.ImportJobParams) (models.ImportJob, error)
+	Get(ctx context.Context, id uuid.UUID) (models.ImportJob, error)
+}
+
+type TaxUsecase interface {
+	Quote(ctx context.Context, params models.TaxQuoteParams) (models.TaxQuote, error)
+}
+
+type AccountUsecase interface {
+	Register(ctx context.Context, params models.RegistrationParams) error
+	ResendVerification(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+	Login(ctx context.Context, email, password string) (models.AccessToken, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	GetProfile(ctx context.Context, id uuid.UUID) (models.Customer, error)
+	UpdateProfile(ctx context.Context, params models.ProfileParams) error
+	ListAddresses(ctx context.Context, customerID uuid.UUID) ([]models.Address, error)
+	AddAddress(ctx context.Context, params models.AddressParams) (string, error)
+	UpdateAddress(ctx context.Context, params models.AddressParams) error
+	DeleteAddress(ctx context.Context, customerID, id uuid.UUID) error
+}
+
+type APIKeyUsecase interface {
+	Create(ctx context.Context, params models.APIKeyParams) (models.IssuedAPIKey, error)
+	List(ctx context.Context) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	Rotate(ctx context.Context, id uuid.UUID) (models.IssuedAPIKey, error)
+}
+
+type StaffAuthUsecase interface {
+	BeginLogin(ctx context.Context) (models.StaffLoginRedirect, error)
+	CompleteLogin(ctx context.Context, state, code string) (models.AccessToken, error)
+}
+
+type AuditUsecase interface {
+	List(ctx context.Context, f models.AuditFilter) ([]models.AuditEvent, error)
+	Verify(ctx context.Context) (models.AuditVerification, error)
+}
+
+type WebhookUsecase interface {
+	Create(ctx context.Context, params models.WebhookSubscriptionParams) (models.WebhookSubscription, error)
+	List(ctx context.Context) ([]models.WebhookSubscription, error)
+	Get(ctx context.Context, id uuid.UUID) (models.WebhookSubscription, error)
+	Update(ctx context.Context, params models.WebhookSubscriptionParams) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID uuid.UUID) (models.WebhookDelivery, error)
+}
+
+type EventFeedUsecase interface {
+	Subscribe(types []string) (<-chan models.FeedEvent, func())
+	Replay(ctx context.Context, afterID string, types []string, emit func(models.FeedEvent) error) error
+}
+
+// AuthorUsecase — пакетные выборки по авторам для GraphQL, чтобы список книг
+// с авторами не превращался в запрос на каждую книгу.
+type AuthorUsecase interface {
+	BooksByAuthors(ctx context.Context, authors []string, limit int) (map[string][]models.Book, error)
+	CountByAuthors(ctx context.Context, authors []string) (map[string]int, error)
 }
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEventDTO struct {
+	ID         int64           `json:"id"`
+	Actor      string          `json:"actor"`
+	ActorType  string          `json:"actor_type"`
+	RequestID  string          `json:"request_id"`
+	IP         string          `json:"ip"`
+	Entity     string          `json:"entity"`
+	EntityID   string          `json:"entity_id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	PrevHash   string          `json:"prev_hash"`
+	Hash       string          `json:"hash"`
+}
+
+type AuditEventListDTO struct {
+	Events []AuditEventDTO `json:"events"`
+	// NextCursor передаётся в before_id для следующей страницы
+	NextCursor int64 `json:"next_cursor,omitempty"`
+}
+
+type AuditVerificationDTO struct {
+	Valid    bool  `json:"valid"`
+	Checked  int   `json:"checked"`
+	BrokenAt int64 `json:"broken_at,omitempty"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/google/uuid"
+
+type BookBatchOperation struct {
+	Op   string       `json:"op" enums:"create,update,delete"`
+	ID   uuid.UUID    `json:"id" swaggertype:"string" format:"uuid"`
+	Book *BookRequest `json:"book,omitempty"`
+}
+
+type BookBatchRequest struct {
+	Mode       string               `json:"mode" enums:"atomic,best_effort"`
+	Operations []BookBatchOperation `json:"operations"`
+}
+
+type BookBatchItemDTO struct {
+	Index  int             `json:"index"`
+	Op     string          `json:"op"`
+	ID     string          `json:"id,omitempty"`
+	Status string          `json:"status" enums:"created,updated,deleted,invalid,not_found,rolled_back"`
+	Error  string          `json:"error,omitempty"`
+	Errors []FieldErrorDTO `json:"errors,omitempty"`
+}
+
+type BookBatchResponse struct {
+	Committed bool               `json:"committed"`
+	Results   []BookBatchItemDTO `json:"results"`
+}
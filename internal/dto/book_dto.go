@@ -15,6 +15,17 @@ type BookDTO struct {
 	ISBN        string    `json:"isbn"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// Cover — миниатюры обложки, у книги без обложки поля нет
+	Cover []CoverImageDTO `json:"cover,omitempty"`
+}
+
+// CoverImageDTO — миниатюра обложки; URL неизменяем и кэшируется клиентом навсегда.
+type CoverImageDTO struct {
+	Size   string `json:"size"`
+	Format string `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url"`
 }
 
 type BookRequest struct {
@@ -24,3 +35,9 @@ type BookRequest struct {
 	Price       int    `json:"price"`
 	ISBN        string `json:"isbn"`
 }
+
+// BookAuthorDTO встраивается в книгу по include=authors.
+type BookAuthorDTO struct {
+	Name      string `json:"name"`
+	BookCount int    `json:"book_count"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// DuplicateCandidateDTO — пара похожих книг; первой идёт созданная раньше.
+type DuplicateCandidateDTO struct {
+	Score            float64   `json:"score"`
+	ISBNMatch        bool      `json:"isbn_match"`
+	TitleSimilarity  float64   `json:"title_similarity"`
+	AuthorSimilarity float64   `json:"author_similarity"`
+	Books            []BookDTO `json:"books"`
+}
+
+// DuplicateReportDTO: scanned_at нет, пока поиск ни разу не прошёл.
+type DuplicateReportDTO struct {
+	ScannedAt  *time.Time              `json:"scanned_at,omitempty"`
+	Candidates []DuplicateCandidateDTO `json:"candidates"`
+}
+
+type MergeBooksRequest struct {
+	CanonicalID string `json:"canonical_id"`
+	DuplicateID string `json:"duplicate_id"`
+}
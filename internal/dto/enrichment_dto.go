@@ -0,0 +1,30 @@
+package dto
+
+import "github.com/google/uuid"
+
+// BookMetadataDTO — сведения из библиографического источника; page_count только для справки.
+type BookMetadataDTO struct {
+	Source      string `json:"source"`
+	ISBN        string `json:"isbn"`
+	Title       string `json:"title,omitempty"`
+	Author      string `json:"author,omitempty"`
+	Description string `json:"description,omitempty"`
+	PageCount   int    `json:"page_count,omitempty"`
+	CoverURL    string `json:"cover_url,omitempty"`
+}
+
+type FieldSuggestionDTO struct {
+	Field     string `json:"field" enums:"title,author,description,cover"`
+	Current   string `json:"current"`
+	Suggested string `json:"suggested"`
+}
+
+type BookEnrichmentDTO struct {
+	BookID      uuid.UUID            `json:"book_id"`
+	Metadata    BookMetadataDTO      `json:"metadata"`
+	Suggestions []FieldSuggestionDTO `json:"suggestions"`
+}
+
+type AcceptSuggestionsRequest struct {
+	Fields []string `json:"fields"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "encoding/json"
+
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLResponse — data и errors по спецификации GraphQL over HTTP.
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportOptionsDTO struct {
+	Mapping      map[string]string `json:"mapping,omitempty"`
+	Delimiter    string            `json:"delimiter,omitempty"`
+	Sheet        string            `json:"sheet,omitempty"`
+	UpsertByISBN bool              `json:"upsert_by_isbn"`
+	DryRun       bool              `json:"dry_run"`
+}
+
+type ImportProgressDTO struct {
+	TotalRows     int `json:"total_rows"`
+	ProcessedRows int `json:"processed_rows"`
+	Created       int `json:"created"`
+	Updated       int `json:"updated"`
+	Deleted       int `json:"deleted"`
+	Failed        int `json:"failed"`
+}
+
+type ImportRowErrorDTO struct {
+	Row int `json:"row"`
+	// Record — RecordReference продукта, только для ONIX
+	Record  string          `json:"record,omitempty"`
+	Message string          `json:"message"`
+	Errors  []FieldErrorDTO `json:"errors,omitempty"`
+}
+
+type ImportJobDTO struct {
+	ID       uuid.UUID         `json:"id"`
+	Status   string            `json:"status" enums:"pending,running,completed,failed"`
+	Format   string            `json:"format"`
+	FileName string            `json:"file_name"`
+	Options  ImportOptionsDTO  `json:"options"`
+	Progress ImportProgressDTO `json:"progress"`
+	// RowErrors — первые 1000 ошибок, полное число — в progress.failed
+	RowErrors  []ImportRowErrorDTO `json:"row_errors"`
+	Error      string              `json:"error,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	StartedAt  *time.Time          `json:"started_at,omitempty"`
+	FinishedAt *time.Time          `json:"finished_at,omitempty"`
+}
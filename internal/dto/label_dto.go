@@ -0,0 +1,6 @@
+package dto
+
+// LabelSheetRequest — книги для листа этикеток; повтор id печатает несколько этикеток.
+type LabelSheetRequest struct {
+	IDs []string `json:"ids"`
+}
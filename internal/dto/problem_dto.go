@@ -0,0 +1,19 @@
+package dto
+
+// ProblemDTO — тело ошибки по RFC 7807 (application/problem+json).
+// Code — стабильный машиночитаемый код, Instance — id запроса для поиска в логах.
+type ProblemDTO struct {
+	Type     string          `json:"type"`
+	Title    string          `json:"title"`
+	Status   int             `json:"status"`
+	Detail   string          `json:"detail,omitempty"`
+	Instance string          `json:"instance,omitempty"`
+	Code     string          `json:"code"`
+	Errors   []FieldErrorDTO `json:"errors,omitempty"`
+}
+
+type FieldErrorDTO struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "github.com/google/uuid"
+
+type TaxLineRequest struct {
+	BookID   uuid.UUID `json:"book_id"`
+	Format   string    `json:"format"`
+	Quantity int       `json:"quantity"`
+}
+
+type TaxQuoteRequest struct {
+	Country string           `json:"country"`
+	Date    string           `json:"date,omitempty"`
+	Lines   []TaxLineRequest `json:"lines"`
+}
+
+type TaxLineDTO struct {
+	BookID    uuid.UUID `json:"book_id"`
+	Format    string    `json:"format"`
+	Quantity  int       `json:"quantity"`
+	UnitPrice int       `json:"unit_price"`
+	RateBP    int       `json:"rate_bp"`
+	Net       int       `json:"net"`
+	Tax       int       `json:"tax"`
+	Gross     int       `json:"gross"`
+}
+
+type TaxQuoteDTO struct {
+	Country          string       `json:"country"`
+	Date             string       `json:"date"`
+	PricesIncludeTax bool         `json:"prices_include_tax"`
+	Lines            []TaxLineDTO `json:"lines"`
+	Net              int          `json:"net"`
+	Tax              int          `json:"tax"`
+	Gross            int          `json:"gross"`
+}
@@ -0,0 +1,190 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+)
+
+func newTestLog(t *testing.T, retention int64) (*RedisLog, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedisLog(client, "feed:test", retention), mr
+}
+
+func newTestHub(log Log, buffer int) *Hub {
+	return NewHub(log, buffer, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func event(eventType string) models.DomainEvent {
+	return models.DomainEvent{ID: uuid.New(), Type: eventType, Payload: []byte(`{"id":"b1"}`), OccurredAt: time.Now().UTC()}
+}
+
+func publish(t *testing.T, l *RedisLog, types ...string) []models.DomainEvent {
+	t.Helper()
+	var out []models.DomainEvent
+	for _, typ := range types {
+		e := event(typ)
+		require.NoError(t, l.Publish(context.Background(), e))
+		out = append(out, e)
+	}
+	return out
+}
+
+func collect(t *testing.T, h *Hub, afterID string, types []string) ([]models.FeedEvent, error) {
+	t.Helper()
+	var got []models.FeedEvent
+	err := h.Replay(context.Background(), afterID, types, func(e models.FeedEvent) error {
+		got = append(got, e)
+		return nil
+	})
+	return got, err
+}
+
+func TestHub_ReplayAfterLastEventID(t *testing.T) {
+	l, _ := newTestLog(t, 1000)
+	h := newTestHub(l, 8)
+	sent := publish(t, l, models.EventBookCreated, models.EventBookUpdated, models.EventPriceChanged, models.EventBookDeleted)
+
+	all, err := collect(t, h, "0-0", nil)
+	require.ErrorIs(t, err, ErrHistoryGap, "0-0 is older than the retained history")
+	assert.Empty(t, all)
+
+	first, ok, err := l.Oldest(context.Background())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	got, err := collect(t, h, first, nil)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	for i, e := range got {
+		var env models.EventEnvelope
+		require.NoError(t, json.Unmarshal(e.Data, &env))
+		assert.Equal(t, sent[i+1].ID, env.ID)
+		assert.Equal(t, sent[i+1].Type, e.Type)
+		assert.Equal(t, 1, models.CompareFeedIDs(e.ID, first))
+	}
+
+	filtered, err := collect(t, h, first, []string{models.EventBookDeleted})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, models.EventBookDeleted, filtered[0].Type)
+
+	latest, err := collect(t, h, got[2].ID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, latest)
+}
+
+func TestHub_ReplayPages(t *testing.T) {
+	l, _ := newTestLog(t, 5000)
+	h := newTestHub(l, 8)
+	types := make([]string, replayPage+10)
+	for i := range types {
+		types[i] = models.EventBookUpdated
+	}
+	publish(t, l, types...)
+
+	first, _, err := l.Oldest(context.Background())
+	require.NoError(t, err)
+	got, err := collect(t, h, first, nil)
+	require.NoError(t, err)
+	assert.Len(t, got, replayPage+9)
+}
+
+func TestHub_ReplayReportsTrimmedHistory(t *testing.T) {
+	l, _ := newTestLog(t, 2)
+	h := newTestHub(l, 8)
+	publish(t, l, models.EventBookCreated)
+	first, _, err := l.Oldest(context.Background())
+	require.NoError(t, err)
+
+	publish(t, l, models.EventBookUpdated, models.EventBookUpdated, models.EventBookUpdated, models.EventBookDeleted)
+
+	_, err = collect(t, h, first, nil)
+	assert.ErrorIs(t, err, ErrHistoryGap)
+}
+
+func TestHub_LiveFanOut(t *testing.T) {
+	l, _ := newTestLog(t, 1000)
+	h := newTestHub(l, 8)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go h.Run(ctx)
+
+	all, unsubAll := h.Subscribe(nil)
+	defer unsubAll()
+	deletes, unsubDeletes := h.Subscribe([]string{models.EventBookDeleted})
+	defer unsubDeletes()
+
+	// pub/sub не хранит сообщения, ждём, пока Listen подпишется
+	require.Eventually(t, func() bool {
+		n, _ := l.client.PubSubNumSub(ctx, l.channel).Result()
+		return n[l.channel] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	publish(t, l, models.EventBookCreated, models.EventBookDeleted)
+
+	first := receive(t, all)
+	second := receive(t, all)
+	assert.Equal(t, models.EventBookCreated, first.Type)
+	assert.Equal(t, models.EventBookDeleted, second.Type)
+	assert.Equal(t, -1, models.CompareFeedIDs(first.ID, second.ID))
+
+	onlyDelete := receive(t, deletes)
+	assert.Equal(t, second, onlyDelete)
+
+	replayed, err := collect(t, h, first.ID, nil)
+	require.NoError(t, err)
+	require.Len(t, replayed, 1)
+	assert.Equal(t, second, replayed[0], "live and replayed events share ids")
+}
+
+func TestHub_DropsSlowSubscriberAndCloses(t *testing.T) {
+	h := newTestHub(nil, 1)
+
+	slow, _ := h.Subscribe(nil)
+	fast, unsub := h.Subscribe(nil)
+	defer unsub()
+
+	h.broadcast(models.FeedEvent{ID: "1-0", Type: models.EventBookCreated})
+	<-fast
+	h.broadcast(models.FeedEvent{ID: "2-0", Type: models.EventBookCreated})
+
+	<-slow
+	_, open := <-slow
+	assert.False(t, open, "slow subscriber is disconnected")
+	assert.Equal(t, "2-0", receive(t, fast).ID)
+
+	h.Close()
+	_, open = <-fast
+	assert.False(t, open)
+
+	late, _ := h.Subscribe(nil)
+	_, open = <-late
+	assert.False(t, open, "no subscriptions after close")
+}
+
+func receive(t *testing.T, ch <-chan models.FeedEvent) models.FeedEvent {
+	t.Helper()
+	select {
+	case e, ok := <-ch:
+		require.True(t, ok)
+		return e
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+		return models.FeedEvent{}
+	}
+}
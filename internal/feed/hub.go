@@ -0,0 +1,143 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"book-store-api/internal/models"
+)
+
+// ErrHistoryGap — Last-Event-ID старше хранимой истории, часть событий потеряна
+// и клиенту нужно перечитать каталог целиком.
+var ErrHistoryGap = errors.New("events since last event id are no longer retained")
+
+const replayPage = 500
+
+type Log interface {
+	Since(ctx context.Context, afterID string, count int64) ([]models.FeedEvent, error)
+	Oldest(ctx context.Context) (string, bool, error)
+	Listen(ctx context.Context) <-chan models.FeedEvent
+}
+
+// Hub держит одну подписку на Redis на реплику и раздаёт события локальным SSE-клиентам.
+type Hub struct {
+	log    Log
+	buffer int
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	subs   map[*subscriber]struct{}
+	closed bool
+}
+
+type subscriber struct {
+	ch    chan models.FeedEvent
+	types []string
+}
+
+func NewHub(log Log, buffer int, logger *slog.Logger) *Hub {
+	return &Hub{
+		log:    log,
+		buffer: buffer,
+		logger: logger,
+		subs:   make(map[*subscriber]struct{}),
+	}
+}
+
+func (h *Hub) Run(ctx context.Context) {
+	for e := range h.log.Listen(ctx) {
+		h.broadcast(e)
+	}
+}
+
+// Subscribe возвращает канал событий указанных типов (все, если types пуст).
+// Канал закрывается при отставании клиента и при Close: клиент переподключится
+// с Last-Event-ID и дочитает пропущенное из истории.
+func (h *Hub) Subscribe(types []string) (<-chan models.FeedEvent, func()) {
+	s := &subscriber{ch: make(chan models.FeedEvent, h.buffer), types: types}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(s.ch)
+		return s.ch, func() {}
+	}
+	h.subs[s] = struct{}{}
+
+	return s.ch, func() { h.remove(s) }
+}
+
+// Replay отдаёт в emit события после afterID, постранично читая историю.
+func (h *Hub) Replay(ctx context.Context, afterID string, types []string, emit func(models.FeedEvent) error) error {
+	oldest, ok, err := h.log.Oldest(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok || models.CompareFeedIDs(afterID, oldest) < 0 {
+		return ErrHistoryGap
+	}
+
+	for {
+		events, err := h.log.Since(ctx, afterID, replayPage)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if !matches(types, e.Type) {
+				continue
+			}
+			if err := emit(e); err != nil {
+				return err
+			}
+		}
+		if len(events) < replayPage {
+			return nil
+		}
+		afterID = events[len(events)-1].ID
+	}
+}
+
+// Close отключает всех клиентов; вызывается при остановке HTTP-сервера,
+// иначе Shutdown ждал бы бесконечные SSE-ответы.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for s := range h.subs {
+		delete(h.subs, s)
+		close(s.ch)
+	}
+}
+
+func (h *Hub) broadcast(e models.FeedEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subs {
+		if !matches(s.types, e.Type) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			h.logger.Warn("feed subscriber is too slow, disconnecting", "event_id", e.ID)
+			delete(h.subs, s)
+			close(s.ch)
+		}
+	}
+}
+
+func (h *Hub) remove(s *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[s]; ok {
+		delete(h.subs, s)
+		close(s.ch)
+	}
+}
+
+func matches(types []string, t string) bool {
+	return len(types) == 0 || slices.Contains(types, t)
+}
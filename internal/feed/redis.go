@@ -0,0 +1,128 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"book-store-api/internal/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// appendScript атомарно пишет событие в поток и публикует его вместе с присвоенным id,
+// чтобы живые подписчики и повтор по Last-Event-ID видели одинаковые идентификаторы.
+// KEYS[1] — поток, KEYS[2] — канал pub/sub, ARGV: retention, type, data.
+var appendScript = redis.NewScript(`
+local id = redis.call('XADD', KEYS[1], 'MAXLEN', '~', ARGV[1], '*', 'type', ARGV[2], 'data', ARGV[3])
+redis.call('PUBLISH', KEYS[2], id .. '\n' .. ARGV[2] .. '\n' .. ARGV[3])
+return id
+`)
+
+// RedisLog хранит последние события ленты в Redis Stream и раздаёт новые через pub/sub,
+// так что клиенты любой реплики получают события, опубликованные relay на другой.
+type RedisLog struct {
+	client    *redis.Client
+	stream    string
+	channel   string
+	retention int64
+}
+
+func NewRedisLog(client *redis.Client, key string, retention int64) *RedisLog {
+	return &RedisLog{
+		client:    client,
+		stream:    key,
+		channel:   key + ":live",
+		retention: retention,
+	}
+}
+
+// Publish реализует outbox.Publisher. Повтор из outbox даст событие с новым id ленты,
+// поэтому клиенты дедуплицируют по id события в data.
+func (l *RedisLog) Publish(ctx context.Context, e models.DomainEvent) error {
+	return appendScript.Run(ctx, l.client, []string{l.stream, l.channel}, l.retention, e.Type, string(e.Envelope())).Err()
+}
+
+// Since возвращает до count событий строго после afterID.
+func (l *RedisLog) Since(ctx context.Context, afterID string, count int64) ([]models.FeedEvent, error) {
+	// XRANGE включает границу, поэтому берём на одну запись больше и пропускаем afterID
+	msgs, err := l.client.XRangeN(ctx, l.stream, afterID, "+", count+1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]models.FeedEvent, 0, len(msgs))
+	for _, m := range msgs {
+		if m.ID == afterID {
+			continue
+		}
+		events = append(events, streamEvent(m))
+	}
+	return events[:min(len(events), int(count))], nil
+}
+
+// Oldest возвращает id самой старой записи, которая ещё хранится в потоке.
+func (l *RedisLog) Oldest(ctx context.Context) (string, bool, error) {
+	msgs, err := l.client.XRangeN(ctx, l.stream, "-", "+", 1).Result()
+	if err != nil {
+		return "", false, err
+	}
+	if len(msgs) == 0 {
+		return "", false, nil
+	}
+	return msgs[0].ID, true, nil
+}
+
+// Listen подписывается на новые события. go-redis сам переподключается к pub/sub,
+// канал закрывается после отмены ctx.
+func (l *RedisLog) Listen(ctx context.Context) <-chan models.FeedEvent {
+	sub := l.client.Subscribe(ctx, l.channel)
+	out := make(chan models.FeedEvent)
+
+	go func() {
+		defer close(out)
+		defer sub.Close()
+
+		msgs := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m, ok := <-msgs:
+				if !ok {
+					return
+				}
+				e, err := parseMessage(m.Payload)
+				if err != nil {
+					continue
+				}
+				select {
+				case out <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+func streamEvent(m redis.XMessage) models.FeedEvent {
+	t, _ := m.Values["type"].(string)
+	data, _ := m.Values["data"].(string)
+	return models.FeedEvent{ID: m.ID, Type: t, Data: []byte(data)}
+}
+
+var errMalformedMessage = errors.New("malformed feed message")
+
+func parseMessage(payload string) (models.FeedEvent, error) {
+	id, rest, ok := strings.Cut(payload, "\n")
+	if !ok {
+		return models.FeedEvent{}, errMalformedMessage
+	}
+	t, data, ok := strings.Cut(rest, "\n")
+	if !ok || !models.ValidFeedID(id) {
+		return models.FeedEvent{}, errMalformedMessage
+	}
+	return models.FeedEvent{ID: id, Type: t, Data: []byte(data)}, nil
+}
@@ -0,0 +1,92 @@
+// Package imaging готовит миниатюры обложек: декодирует JPEG, PNG и WebP, поворачивает
+// по EXIF и кодирует заново. Метаданные исходника (EXIF с геометкой, модель камеры)
+// при этом не переносятся в результат.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+
+	"book-store-api/internal/models"
+
+	"github.com/HugoSmits86/nativewebp"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// decodable — форматы исходника; другие зарегистрированные в image декодеры не принимаются.
+var decodable = map[string]bool{"jpeg": true, "png": true, "webp": true}
+
+const jpegQuality = 85
+
+// DecodeConfig отдаёт размер изображения после поворота по EXIF, не декодируя пиксели,
+// чтобы проверить его до выделения памяти под всё изображение.
+func DecodeConfig(data []byte) (width, height int, err error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) || (err == nil && !decodable[format]) {
+		return 0, 0, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	if format == "jpeg" && exifOrientation(data) >= 5 {
+		return cfg.Height, cfg.Width, nil
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+func Decode(data []byte) (image.Image, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) || (err == nil && !decodable[format]) {
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if format == "jpeg" {
+		img = orient(img, exifOrientation(data))
+	}
+	return img, nil
+}
+
+// Resize масштабирует изображение до width×height фильтром Catmull-Rom.
+func Resize(img image.Image, width, height int) image.Image {
+	b := img.Bounds()
+	if b.Dx() == width && b.Dy() == height {
+		return img
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, b, xdraw.Src, nil)
+	return dst
+}
+
+// Encode пишет изображение в формате models.CoverJPEG или models.CoverWebP.
+// WebP кодируется без потерь; у JPEG нет альфа-канала, прозрачность заливается белым.
+func Encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case models.CoverJPEG:
+		return jpeg.Encode(w, flatten(img), &jpeg.Options{Quality: jpegQuality})
+	case models.CoverWebP:
+		return nativewebp.Encode(w, img, nil)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+func flatten(img image.Image) image.Image {
+	if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+		return img
+	}
+	dst := image.NewRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+	return dst
+}
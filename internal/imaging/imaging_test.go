@@ -0,0 +1,114 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+
+	"book-store-api/internal/models"
+)
+
+// halves — левая половина красная, правая синяя.
+func halves(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// withOrientation вставляет после SOI сегмент APP1 с EXIF Orientation (big-endian TIFF).
+func withOrientation(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	out := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	out = binary.BigEndian.AppendUint16(out, uint16(len(segment)+2))
+	out = append(out, segment...)
+	return append(out, buf.Bytes()[2:]...)
+}
+
+func isRed(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > 0xC000 && b < 0x4000
+}
+
+func TestDecode_AppliesOrientation(t *testing.T) {
+	data := withOrientation(t, halves(40, 20), 6)
+	require.Equal(t, 6, exifOrientation(data))
+
+	w, h, err := DecodeConfig(data)
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{20, 40}, [2]int{w, h}, "sides are swapped before decoding")
+
+	img, err := Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 20, 40), img.Bounds())
+	assert.True(t, isRed(img.At(10, 5)), "the left half is on top after turning clockwise")
+	assert.False(t, isRed(img.At(10, 35)))
+
+	var out bytes.Buffer
+	require.NoError(t, Encode(&out, img, models.CoverJPEG))
+	assert.NotContains(t, out.String(), "Exif", "metadata is not copied")
+	assert.Equal(t, 1, exifOrientation(out.Bytes()))
+}
+
+func TestOrient(t *testing.T) {
+	src := halves(4, 2)
+	for orientation, redAt := range map[int]image.Point{
+		1: {0, 0}, 2: {3, 0}, 3: {3, 1}, 4: {0, 1},
+		5: {0, 0}, 6: {0, 0}, 7: {1, 3}, 8: {0, 3},
+	} {
+		img := orient(src, orientation)
+		assert.True(t, isRed(img.At(redAt.X, redAt.Y)), "orientation %d", orientation)
+	}
+	assert.Equal(t, image.Rect(0, 0, 2, 4), orient(src, 8).Bounds())
+}
+
+func TestEncode_Thumbnails(t *testing.T) {
+	img := Resize(halves(300, 450), 160, 240)
+	assert.Equal(t, image.Rect(0, 0, 160, 240), img.Bounds())
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, img, models.CoverWebP))
+	decoded, err := webp.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, img.Bounds(), decoded.Bounds())
+	assert.True(t, isRed(decoded.At(10, 10)))
+
+	assert.ErrorIs(t, Encode(&buf, img, "bmp"), ErrUnsupportedFormat)
+}
+
+func TestDecode_RejectsOtherFormats(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, gif.Encode(&buf, halves(10, 10), nil))
+
+	_, _, err := DecodeConfig(buf.Bytes())
+	assert.ErrorIs(t, err, ErrUnsupportedFormat, "gif is registered but not accepted")
+	_, err = Decode([]byte("%PDF-1.7"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
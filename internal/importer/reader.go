@@ -0,0 +1,173 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/onix"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrUnreadableFile — файл не разбирается как CSV/XLSX/ONIX или в нём нет заголовка.
+var ErrUnreadableFile = errors.New("unreadable import file")
+
+// Row — строка файла, разобранная в параметры книги. Number — номер строки
+// в файле с единицы, заголовок — строка 1; для ONIX — строка начала Product.
+type Row struct {
+	Number int
+	// Record и Delete заполняются только для ONIX
+	Record string
+	Delete bool
+	Params models.BookParams
+	Err    error
+}
+
+// ReadRows читает файл целиком: он уже лежит в памяти, а полный список строк
+// нужен для прогресса и продолжения после сбоя. Пустые строки пропускаются.
+func ReadRows(format models.ImportFormat, file []byte, opts models.ImportOptions, onixCfg onix.Config) ([]Row, error) {
+	var (
+		records []record
+		err     error
+	)
+	switch format {
+	case models.ImportCSV:
+		records, err = readCSV(file, opts.Delimiter)
+	case models.ImportXLSX:
+		records, err = readXLSX(file, opts.Sheet)
+	case models.ImportONIX:
+		return readONIX(file, onixCfg)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrUnreadableFile, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: header row is missing", ErrUnreadableFile)
+	}
+
+	columns, err := models.ImportHeader(records[0].fields, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]Row, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		if blank(rec.fields) {
+			continue
+		}
+		params, err := rowParams(rec.fields, columns)
+		rows = append(rows, Row{Number: rec.line, Params: params, Err: err})
+	}
+	return rows, nil
+}
+
+// record — строка файла с её номером: CSV пропускает пустые строки,
+// поэтому номер не выводится из позиции в срезе.
+type record struct {
+	line   int
+	fields []string
+}
+
+func readCSV(file []byte, delimiter string) ([]record, error) {
+	r := csv.NewReader(bytes.NewReader(file))
+	if delimiter != "" {
+		r.Comma = []rune(delimiter)[0]
+	}
+	// поставщики присылают строки разной длины, недостающие колонки считаем пустыми
+	r.FieldsPerRecord = -1
+
+	var records []record
+	for {
+		fields, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnreadableFile, err)
+		}
+		line, _ := r.FieldPos(0)
+		records = append(records, record{line: line, fields: fields})
+	}
+}
+
+func readXLSX(file []byte, sheet string) ([]record, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnreadableFile, err)
+	}
+	defer f.Close()
+
+	if sheet == "" {
+		sheet = f.GetSheetName(0)
+	}
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnreadableFile, err)
+	}
+	records := make([]record, 0, len(rows))
+	for i, fields := range rows {
+		records = append(records, record{line: i + 1, fields: fields})
+	}
+	return records, nil
+}
+
+func readONIX(file []byte, cfg onix.Config) ([]Row, error) {
+	var rows []Row
+	err := onix.Decode(bytes.NewReader(file), cfg, func(rec onix.Record) error {
+		rows = append(rows, Row{Number: rec.Line, Record: rec.Reference, Delete: rec.Delete, Params: rec.Params, Err: rec.Err})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnreadableFile, err)
+	}
+	return rows, nil
+}
+
+func rowParams(fields []string, columns map[string]int) (models.BookParams, error) {
+	cell := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return fields[i]
+	}
+
+	params := models.BookParams{
+		Title:       cell("title"),
+		Author:      cell("author"),
+		Description: cell("description"),
+		ISBN:        cell("isbn"),
+	}
+
+	raw := strings.TrimSpace(cell("price"))
+	if raw == "" {
+		var verr models.ValidationError
+		verr.Add("price", models.FieldRequired, "book price is required")
+		return params, verr.Err()
+	}
+	price, err := strconv.Atoi(raw)
+	if err != nil {
+		var verr models.ValidationError
+		verr.Addf("price", models.FieldInvalid, "book price %q is not a whole number", raw)
+		return params, verr.Err()
+	}
+	params.Price = price
+	return params, nil
+}
+
+func blank(fields []string) bool {
+	for _, v := range fields {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,296 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/onix"
+	"book-store-api/internal/reqctx"
+
+	"github.com/google/uuid"
+)
+
+type Store interface {
+	// ClaimNext берёт в работу ожидающую задачу или задачу упавшего воркера
+	// с истёкшим lease; nil — задач нет.
+	ClaimNext(ctx context.Context, lease time.Duration) (*models.ImportTask, error)
+	// SaveProgress сохраняет счётчики и продлевает lease.
+	SaveProgress(ctx context.Context, job models.ImportJob, lease time.Duration) error
+	Finish(ctx context.Context, job models.ImportJob) error
+}
+
+// Books — запись книг пачками с аудитом и outbox, как у POST /book/batch.
+type Books interface {
+	Batch(ctx context.Context, mode models.BookBatchMode, ops []models.BookBatchOp) (models.BookBatchResult, error)
+}
+
+// Catalog ищет существующие книги для upsert по ISBN.
+type Catalog interface {
+	BooksByISBN(ctx context.Context, isbns []string) (map[string]models.Book, error)
+}
+
+type WorkerConfig struct {
+	PollInterval time.Duration
+	// ChunkSize — строк в одной пачке записи, не больше models.MaxBookBatchOps
+	ChunkSize int
+	Lease     time.Duration
+	ONIX      onix.Config
+}
+
+type Worker struct {
+	store   Store
+	books   Books
+	catalog Catalog
+	cfg     WorkerConfig
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+func NewWorker(store Store, books Books, catalog Catalog, cfg WorkerConfig, logger *slog.Logger) *Worker {
+	cfg.ChunkSize = min(max(cfg.ChunkSize, 1), models.MaxBookBatchOps)
+	return &Worker{store: store, books: books, catalog: catalog, cfg: cfg, logger: logger, now: time.Now}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		task, err := w.store.ClaimNext(ctx, w.cfg.Lease)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Error("import claim error", "err", err)
+			}
+			return
+		}
+		if task == nil {
+			return
+		}
+		w.process(ctx, *task)
+	}
+}
+
+// process при сбое инфраструктуры оставляет задачу в running: после lease её
+// подхватит любой воркер и продолжит с ProcessedRows.
+func (w *Worker) process(ctx context.Context, task models.ImportTask) {
+	job := task.Job
+	ctx = auth.WithPrincipal(ctx, auth.Principal{Type: auth.PrincipalType(job.CreatedByType), Subject: job.CreatedBy})
+	ctx = reqctx.WithRequestID(ctx, "import-"+job.ID.String())
+
+	rows, err := ReadRows(job.Format, task.File, job.Options, w.cfg.ONIX)
+	if err != nil {
+		job.Status, job.Error = models.ImportFailed, err.Error()
+		w.finish(ctx, job)
+		return
+	}
+	job.TotalRows = len(rows)
+
+	seen := make(map[string]int)
+	for start := 0; start < len(rows); start += w.cfg.ChunkSize {
+		end := min(start+w.cfg.ChunkSize, len(rows))
+		chunk := rows[start:end]
+		if end <= job.ProcessedRows {
+			// уже записано до сбоя, нужны только ISBN для поиска дублей в файле
+			w.dedupe(&job, chunk, seen, false)
+			continue
+		}
+
+		if err := w.processChunk(ctx, &job, chunk, seen); err != nil {
+			w.logger.Error("import chunk error", "job_id", job.ID, "row", chunk[0].Number, "err", err)
+			return
+		}
+		job.ProcessedRows = end
+		if err := w.store.SaveProgress(context.WithoutCancel(ctx), job, w.cfg.Lease); err != nil {
+			w.logger.Error("import progress error", "job_id", job.ID, "err", err)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+
+	job.Status = models.ImportCompleted
+	w.finish(ctx, job)
+}
+
+func (w *Worker) processChunk(ctx context.Context, job *models.ImportJob, chunk []Row, seen map[string]int) error {
+	valid := w.dedupe(job, chunk, seen, true)
+
+	// ONIX импортируется только с upsert, так что книги для удалений тоже найдутся здесь
+	var existing map[string]models.Book
+	if job.Options.UpsertByISBN && len(valid) > 0 {
+		isbns := make([]string, 0, len(valid))
+		for _, v := range valid {
+			isbns = append(isbns, v.book.ISBN)
+		}
+		found, err := w.catalog.BooksByISBN(ctx, isbns)
+		if err != nil {
+			return err
+		}
+		existing = found
+	}
+
+	var (
+		ops    = make([]models.BookBatchOp, 0, len(valid))
+		opRows = make([]Row, 0, len(valid))
+	)
+	for _, v := range valid {
+		old, found := existing[v.book.ISBN]
+		op := models.BookBatchOp{Op: models.BatchOpCreate, Book: v.row.Params}
+		switch {
+		case v.row.Delete && !found:
+			addRowError(job, v.row, fmt.Errorf("no book with isbn %s to delete", v.book.ISBN))
+			continue
+		case v.row.Delete:
+			op = models.BookBatchOp{Op: models.BatchOpDelete, ID: old.ID}
+		case found:
+			op.Op, op.ID = models.BatchOpUpdate, old.ID
+		}
+		ops = append(ops, op)
+		opRows = append(opRows, v.row)
+	}
+
+	if job.Options.DryRun {
+		for _, op := range ops {
+			countApplied(job, op.Op)
+		}
+		return nil
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	res, err := w.books.Batch(ctx, models.BatchBestEffort, ops)
+	if err != nil {
+		return err
+	}
+	for _, item := range res.Items {
+		if item.Failed() {
+			addRowError(job, opRows[item.Index], item.Err)
+			continue
+		}
+		countApplied(job, item.Op)
+	}
+	return nil
+}
+
+type validRow struct {
+	row  Row
+	book models.Book
+}
+
+// dedupe проверяет строки и отсеивает повторы ISBN в файле при upsert: вторая
+// строка с тем же ISBN обновила бы только что созданную книгу. report=false
+// только запоминает ISBN уже обработанных строк.
+func (w *Worker) dedupe(job *models.ImportJob, chunk []Row, seen map[string]int, report bool) []validRow {
+	valid := make([]validRow, 0, len(chunk))
+	for _, row := range chunk {
+		book, err := validate(row)
+		if err == nil && job.Options.UpsertByISBN {
+			if first, ok := seen[book.ISBN]; ok {
+				err = fmt.Errorf("%w: isbn %s already appears in row %d", models.ErrDomainValidation, book.ISBN, first)
+			} else {
+				seen[book.ISBN] = row.Number
+			}
+		}
+		if err != nil {
+			if report {
+				addRowError(job, row, err)
+			}
+			continue
+		}
+		valid = append(valid, validRow{row: row, book: book})
+	}
+	return valid
+}
+
+// validate объединяет ошибки разбора строки с проверками models.NewBook.
+// id назначается при записи, для проверки подставляется временный.
+// Для удаления достаточно ISBN, его проверил разбор.
+func validate(row Row) (models.Book, error) {
+	if row.Delete {
+		if row.Err != nil {
+			return models.Book{}, row.Err
+		}
+		return models.Book{ISBN: row.Params.ISBN}, nil
+	}
+	params := row.Params
+	params.ID = uuid.New()
+	book, err := models.NewBook(params)
+	if row.Err == nil {
+		return book, err
+	}
+
+	var verr models.ValidationError
+	var parsed *models.ValidationError
+	if errors.As(row.Err, &parsed) {
+		verr.Fields = append(verr.Fields, parsed.Fields...)
+	}
+	var checked *models.ValidationError
+	if errors.As(err, &checked) {
+		// поле, не прошедшее разбор, второй раз не сообщаем
+		for _, f := range checked.Fields {
+			if !slices.ContainsFunc(verr.Fields, func(p models.FieldError) bool { return p.Field == f.Field }) {
+				verr.Fields = append(verr.Fields, f)
+			}
+		}
+	}
+	if len(verr.Fields) == 0 {
+		return models.Book{}, row.Err
+	}
+	return models.Book{}, verr.Err()
+}
+
+func countApplied(job *models.ImportJob, op models.BookBatchOpType) {
+	switch op {
+	case models.BatchOpUpdate:
+		job.Updated++
+	case models.BatchOpDelete:
+		job.Deleted++
+	default:
+		job.Created++
+	}
+}
+
+func addRowError(job *models.ImportJob, row Row, err error) {
+	job.Failed++
+	if len(job.RowErrors) >= models.MaxImportRowErrors {
+		return
+	}
+	rowErr := models.ImportRowError{Row: row.Number, Record: row.Record, Message: err.Error()}
+	var verr *models.ValidationError
+	if errors.As(err, &verr) {
+		rowErr.Fields = verr.Fields
+	}
+	job.RowErrors = append(job.RowErrors, rowErr)
+}
+
+func (w *Worker) finish(ctx context.Context, job models.ImportJob) {
+	finished := w.now().UTC()
+	job.FinishedAt = &finished
+	// итог пишем и после отмены ctx, иначе задача повторится после lease
+	if err := w.store.Finish(context.WithoutCancel(ctx), job); err != nil {
+		w.logger.Error("import finish error", "job_id", job.ID, "err", err)
+		return
+	}
+	w.logger.Info("import finished", "job_id", job.ID, "status", job.Status,
+		"rows", job.TotalRows, "created", job.Created, "updated", job.Updated, "deleted", job.Deleted, "failed", job.Failed, "dry_run", job.Options.DryRun)
+}
@@ -0,0 +1,247 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/onix"
+)
+
+// fakeBooks применяет пакет как best_effort без базы: ISBN "missing" эмулирует
+// книгу, удалённую между поиском и записью.
+func fakeBooks() *BooksMock {
+	return &BooksMock{
+		BatchFunc: func(ctx context.Context, mode models.BookBatchMode, ops []models.BookBatchOp) (models.BookBatchResult, error) {
+			res := models.BookBatchResult{Committed: true}
+			for i, op := range ops {
+				item := models.BookBatchItem{Index: i, Op: op.Op, ID: op.ID, Status: models.BatchItemCreated}
+				switch {
+				case op.Book.ISBN == "missing":
+					item.Status, item.Err = models.BatchItemNotFound, fmt.Errorf("book %s not found", op.ID)
+				case op.Op == models.BatchOpUpdate:
+					item.Status = models.BatchItemUpdated
+				case op.Op == models.BatchOpDelete:
+					item.Status = models.BatchItemDeleted
+				}
+				res.Items = append(res.Items, item)
+			}
+			return res, nil
+		},
+	}
+}
+
+func fakeCatalog(existing ...models.Book) *CatalogMock {
+	return &CatalogMock{
+		BooksByISBNFunc: func(ctx context.Context, isbns []string) (map[string]models.Book, error) {
+			found := make(map[string]models.Book)
+			for _, b := range existing {
+				if slices.Contains(isbns, b.ISBN) {
+					found[b.ISBN] = b
+				}
+			}
+			return found, nil
+		},
+	}
+}
+
+func runTask(t *testing.T, task models.ImportTask, books *BooksMock, catalog *CatalogMock, chunk int) (models.ImportJob, *StoreMock) {
+	t.Helper()
+	var finished models.ImportJob
+	store := &StoreMock{
+		SaveProgressFunc: func(ctx context.Context, job models.ImportJob, lease time.Duration) error { return nil },
+		FinishFunc: func(ctx context.Context, job models.ImportJob) error {
+			finished = job
+			return nil
+		},
+	}
+	w := NewWorker(store, books, catalog, WorkerConfig{
+		PollInterval: time.Second, ChunkSize: chunk, Lease: time.Minute,
+		ONIX: onix.Config{Currency: "RUB", PriceDecimals: 2, PricesIncludeTax: true},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	w.process(context.Background(), task)
+	return finished, store
+}
+
+func newTask(format models.ImportFormat, file []byte, opts models.ImportOptions) models.ImportTask {
+	return models.ImportTask{
+		Job: models.ImportJob{
+			ID: uuid.New(), Status: models.ImportRunning, Format: format, Options: opts,
+			CreatedByType: string(auth.PrincipalUser), CreatedBy: "editor",
+		},
+		File: file,
+	}
+}
+
+func TestWorker_CSVWithMappingAndUpsert(t *testing.T) {
+	existing := models.Book{ID: uuid.New(), Title: "Old", ISBN: "9780441013593"}
+	csv := "Name;Writer;EAN;Cost;Notes\n" +
+		"Dune;Herbert;9780441013593;900;classic\n" +
+		";Nobody;123;abc;\n" +
+		"\n" +
+		"Emma;Austen;9780141439587;500;\n" +
+		"Emma again;Austen;9780141439587;550;\n"
+	task := newTask(models.ImportCSV, []byte(csv), models.ImportOptions{
+		Mapping:      map[string]string{"title": "name", "author": "Writer", "isbn": "EAN", "price": "Cost", "description": "Notes"},
+		Delimiter:    ";",
+		UpsertByISBN: true,
+	})
+	books := fakeBooks()
+
+	job, store := runTask(t, task, books, fakeCatalog(existing), 2)
+
+	assert.Equal(t, models.ImportCompleted, job.Status)
+	assert.Equal(t, 4, job.TotalRows, "blank line is skipped")
+	assert.Equal(t, 4, job.ProcessedRows)
+	assert.Equal(t, 1, job.Created)
+	assert.Equal(t, 1, job.Updated)
+	assert.Equal(t, 2, job.Failed)
+	require.Len(t, job.RowErrors, 2)
+
+	assert.Equal(t, 3, job.RowErrors[0].Row)
+	var fields []string
+	for _, f := range job.RowErrors[0].Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.ElementsMatch(t, []string{"price", "title"}, fields, "parse and domain errors are reported together")
+	assert.Equal(t, 6, job.RowErrors[1].Row)
+	assert.Contains(t, job.RowErrors[1].Message, "row 5")
+
+	require.Len(t, books.BatchCalls(), 2)
+	first := books.BatchCalls()[0]
+	assert.Equal(t, models.BatchBestEffort, first.Mode)
+	require.Len(t, first.Ops, 1)
+	assert.Equal(t, models.BatchOpUpdate, first.Ops[0].Op)
+	assert.Equal(t, existing.ID, first.Ops[0].ID)
+	assert.Equal(t, "classic", first.Ops[0].Book.Description)
+	assert.Len(t, store.SaveProgressCalls(), 2)
+
+	principal, ok := auth.PrincipalFromContext(first.Ctx)
+	require.True(t, ok)
+	assert.Equal(t, "editor", principal.Subject, "books are written on behalf of the uploader")
+}
+
+func TestWorker_ONIXUpsertAndDelete(t *testing.T) {
+	dune := models.Book{ID: uuid.New(), Title: "Dune", ISBN: "9780441013593"}
+	emma := models.Book{ID: uuid.New(), Title: "Emma", ISBN: "9780141439587"}
+	product := func(ref, notification, isbn, title string) string {
+		return `<Product><RecordReference>` + ref + `</RecordReference><NotificationType>` + notification + `</NotificationType>
+  <ProductIdentifier><ProductIDType>15</ProductIDType><IDValue>` + isbn + `</IDValue></ProductIdentifier>
+  <DescriptiveDetail><TitleDetail><TitleType>01</TitleType><TitleElement><TitleElementLevel>01</TitleElementLevel><TitleText>` + title + `</TitleText></TitleElement></TitleDetail>
+  <Contributor><ContributorRole>A01</ContributorRole><PersonName>Author</PersonName></Contributor></DescriptiveDetail>
+  <ProductSupply><SupplyDetail><Price><PriceType>02</PriceType><PriceAmount>9.90</PriceAmount></Price></SupplyDetail></ProductSupply>
+</Product>
+`
+	}
+	msg := `<ONIXMessage release="3.0">
+` + product("p1", "03", "978-0-441-01359-3", "Dune Messiah") +
+		product("p2", "03", "9780306406157", "Solaris") +
+		product("p3", "05", "9780141439587", "") +
+		product("p4", "05", "9785171183660", "") +
+		product("p5", "03", "9780306406158", "") + `</ONIXMessage>`
+	task := newTask(models.ImportONIX, []byte(msg), models.ImportOptions{UpsertByISBN: true})
+	books := fakeBooks()
+
+	job, _ := runTask(t, task, books, fakeCatalog(dune, emma), 10)
+
+	assert.Equal(t, models.ImportCompleted, job.Status)
+	assert.Equal(t, 5, job.TotalRows)
+	assert.Equal(t, 1, job.Created)
+	assert.Equal(t, 1, job.Updated)
+	assert.Equal(t, 1, job.Deleted)
+	assert.Equal(t, 2, job.Failed)
+
+	require.Len(t, books.BatchCalls(), 1)
+	ops := books.BatchCalls()[0].Ops
+	require.Len(t, ops, 3)
+	assert.Equal(t, models.BookBatchOp{Op: models.BatchOpUpdate, ID: dune.ID, Book: models.BookParams{
+		Title: "Dune Messiah", Author: "Author", ISBN: "9780441013593", Price: 990,
+	}}, ops[0], "ISBN with hyphens matches the stored one after normalisation")
+	assert.Equal(t, models.BatchOpCreate, ops[1].Op)
+	assert.Equal(t, models.BookBatchOp{Op: models.BatchOpDelete, ID: emma.ID}, ops[2])
+
+	require.Len(t, job.RowErrors, 2)
+	assert.Equal(t, "p5", job.RowErrors[0].Record)
+	assert.Equal(t, 26, job.RowErrors[0].Row, "row is the line of the Product element")
+	assert.Equal(t, "p4", job.RowErrors[1].Record)
+	assert.Contains(t, job.RowErrors[1].Message, "no book with isbn 9785171183660")
+	var fields []string
+	for _, f := range job.RowErrors[0].Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.Equal(t, []string{"isbn", "title"}, fields)
+}
+
+func TestWorker_DryRunDoesNotWrite(t *testing.T) {
+	existing := models.Book{ID: uuid.New(), ISBN: "1"}
+	task := newTask(models.ImportCSV, []byte("title,author,isbn,price\nA,B,1,10\nC,D,2,20\n"), models.ImportOptions{
+		UpsertByISBN: true,
+		DryRun:       true,
+	})
+	books := fakeBooks()
+
+	job, _ := runTask(t, task, books, fakeCatalog(existing), 100)
+
+	assert.Equal(t, models.ImportCompleted, job.Status)
+	assert.Equal(t, 1, job.Created)
+	assert.Equal(t, 1, job.Updated)
+	assert.Empty(t, books.BatchCalls())
+}
+
+func TestWorker_ResumesAfterProcessedRows(t *testing.T) {
+	task := newTask(models.ImportCSV, []byte("title,author,isbn,price\nA,B,1,1\nC,D,2,2\nE,F,3,3\nG,H,missing,4\n"), models.ImportOptions{})
+	task.Job.ProcessedRows, task.Job.Created = 2, 2
+	books := fakeBooks()
+
+	job, _ := runTask(t, task, books, fakeCatalog(), 2)
+
+	require.Len(t, books.BatchCalls(), 1, "already written chunk is skipped")
+	assert.Equal(t, "3", books.BatchCalls()[0].Ops[0].Book.ISBN)
+	assert.Equal(t, 3, job.Created)
+	assert.Equal(t, 1, job.Failed)
+	assert.Equal(t, 5, job.RowErrors[0].Row)
+}
+
+func TestWorker_XLSX(t *testing.T) {
+	f := excelize.NewFile()
+	rows := [][]any{
+		{"Title", "Author", "ISBN", "Price"},
+		{"Dune", "Herbert", "9780441013593", 900},
+	}
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		require.NoError(t, err)
+		require.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	buf, err := f.WriteToBuffer()
+	require.NoError(t, err)
+
+	books := fakeBooks()
+	job, _ := runTask(t, newTask(models.ImportXLSX, buf.Bytes(), models.ImportOptions{}), books, fakeCatalog(), 10)
+
+	assert.Equal(t, models.ImportCompleted, job.Status)
+	assert.Equal(t, 1, job.Created)
+	require.Len(t, books.BatchCalls(), 1)
+	assert.Equal(t, 900, books.BatchCalls()[0].Ops[0].Book.Price)
+}
+
+func TestWorker_FailsOnMissingColumns(t *testing.T) {
+	books := fakeBooks()
+	job, _ := runTask(t, newTask(models.ImportCSV, []byte("title,author\nA,B\n"), models.ImportOptions{}), books, fakeCatalog(), 10)
+
+	assert.Equal(t, models.ImportFailed, job.Status)
+	assert.Contains(t, job.Error, `column "isbn" not found`)
+	assert.NotNil(t, job.FinishedAt)
+	assert.Empty(t, books.BatchCalls())
+}
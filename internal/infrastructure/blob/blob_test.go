@@ -0,0 +1,88 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/infrastructure/blob/s3test"
+	"book-store-api/internal/models"
+)
+
+type storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (models.Blob, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// testStorage — общий контракт обеих реализаций.
+func testStorage(t *testing.T, s storage) {
+	t.Helper()
+	ctx := context.Background()
+	key := "covers/5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01/00ff00ff00ff00ff/small.webp"
+
+	_, err := s.Get(ctx, key)
+	require.ErrorIs(t, err, models.ErrBlobNotFound)
+
+	require.NoError(t, s.Put(ctx, key, []byte("RIFF....WEBP"), "image/webp"))
+	require.NoError(t, s.Put(ctx, key, []byte("RIFF..v2.WEBP"), "image/webp"), "put overwrites")
+
+	blob, err := s.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(blob.Body)
+	require.NoError(t, err)
+	require.NoError(t, blob.Body.Close())
+	assert.Equal(t, "RIFF..v2.WEBP", string(data))
+	assert.Equal(t, int64(len(data)), blob.Size)
+	assert.Equal(t, "image/webp", blob.ContentType)
+	assert.False(t, blob.ModTime.IsZero())
+
+	require.NoError(t, s.Delete(ctx, key))
+	require.NoError(t, s.Delete(ctx, key), "deleting a missing blob is not an error")
+	_, err = s.Get(ctx, key)
+	assert.ErrorIs(t, err, models.ErrBlobNotFound)
+}
+
+func TestFileStorage(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStorage(dir)
+	require.NoError(t, err)
+	testStorage(t, s)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "covers", "5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01"))
+	require.NoError(t, err)
+	assert.Empty(t, entries, "empty version directory is removed")
+
+	for _, key := range []string{"../outside", "/etc/passwd", "covers/../../x", ""} {
+		assert.Error(t, s.Put(context.Background(), key, []byte("x"), ""), key)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	srv := s3test.NewServer("covers", "minio", "minio-secret")
+	defer srv.Close()
+
+	s := NewS3Storage(S3Config{
+		Endpoint:  srv.URL + "/",
+		Bucket:    "covers",
+		AccessKey: "minio",
+		SecretKey: "minio-secret",
+	}, srv.Client())
+	testStorage(t, s)
+
+	require.NoError(t, s.Put(context.Background(), "odd key/Тест (1).jpg", []byte("x"), "image/jpeg"))
+	assert.Equal(t, []string{"odd key/Тест (1).jpg"}, srv.Keys(), "keys are escaped the same way they are signed")
+
+	wrong := NewS3Storage(S3Config{Endpoint: srv.URL, Bucket: "covers", AccessKey: "minio", SecretKey: "guess"}, srv.Client())
+	err := wrong.Put(context.Background(), "a.jpg", []byte("x"), "image/jpeg")
+	assert.ErrorContains(t, err, "SignatureDoesNotMatch")
+
+	other := NewS3Storage(S3Config{Endpoint: srv.URL, Bucket: "missing", AccessKey: "minio", SecretKey: "minio-secret"}, srv.Client())
+	err = other.Put(context.Background(), "a.jpg", []byte("x"), "image/jpeg")
+	assert.ErrorContains(t, err, "NoSuchBucket")
+}
@@ -0,0 +1,97 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+
+	"book-store-api/internal/models"
+)
+
+// FileStorage хранит объекты файлами в каталоге, ключ — относительный путь через "/".
+type FileStorage struct {
+	root string
+}
+
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileStorage{root: dir}, nil
+}
+
+// Put пишет во временный файл и переименовывает, чтобы читатель не увидел объект наполовину.
+func (s *FileStorage) Put(_ context.Context, key string, data []byte, _ string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get определяет Content-Type по расширению ключа: отдельно он не хранится.
+func (s *FileStorage) Get(_ context.Context, key string) (models.Blob, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return models.Blob{}, err
+	}
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return models.Blob{}, models.ErrBlobNotFound
+	}
+	if err != nil {
+		return models.Blob{}, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return models.Blob{}, err
+	}
+	return models.Blob{
+		Body:        f,
+		Size:        info.Size(),
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+// Delete не считает ошибкой отсутствие объекта и убирает опустевший каталог.
+func (s *FileStorage) Delete(_ context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	// удаляется, только если пуст
+	_ = os.Remove(filepath.Dir(name))
+	return nil
+}
+
+func (s *FileStorage) path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,242 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"book-store-api/internal/models"
+)
+
+// S3Config: Endpoint — адрес S3-совместимого сервиса (MinIO, Ceph, AWS), бакет
+// адресуется путём: {Endpoint}/{Bucket}/{key}.
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Storage — клиент S3 API с подписью запросов AWS Signature V4.
+type S3Storage struct {
+	cfg  S3Config
+	http *http.Client
+	now  func() time.Time
+}
+
+func NewS3Storage(cfg S3Config, httpClient *http.Client) *S3Storage {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	return &S3Storage{cfg: cfg, http: httpClient, now: time.Now}
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, data, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp, http.MethodPut, key)
+	}
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (models.Blob, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return models.Blob{}, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return models.Blob{}, models.ErrBlobNotFound
+	default:
+		defer resp.Body.Close()
+		return models.Blob{}, s3Error(resp, http.MethodGet, key)
+	}
+
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return models.Blob{
+		Body:        resp.Body,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		ModTime:     modTime,
+	}, nil
+}
+
+// Delete: S3 отвечает 204 и на отсутствующий ключ.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return s3Error(resp, http.MethodDelete, key)
+	}
+	return nil
+}
+
+func (s *S3Storage) do(ctx context.Context, method, key string, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.cfg.Endpoint+objectPath(s.cfg.Bucket, key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.ContentLength = int64(len(body))
+	signV4(req, body, s.cfg, s.now().UTC())
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s %s: %w", method, key, err)
+	}
+	return resp, nil
+}
+
+// objectPath кодирует сегменты ключа так же, как их кодирует подпись.
+func objectPath(bucket, key string) string {
+	segments := strings.Split(bucket+"/"+key, "/")
+	for i, seg := range segments {
+		segments[i] = uriEncode(seg)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func s3Error(resp *http.Response, method, key string) error {
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xml.Unmarshal(data, &body) == nil && body.Code != "" {
+		return fmt.Errorf("s3 %s %s: status %d: %s: %s", method, key, resp.StatusCode, body.Code, body.Message)
+	}
+	return fmt.Errorf("s3 %s %s: status %d", method, key, resp.StatusCode)
+}
+
+const (
+	sigAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFmt   = "20060102T150405Z"
+)
+
+// signV4 добавляет заголовки x-amz-date, x-amz-content-sha256 и Authorization.
+// Подписываются host, content-type и все x-amz-* заголовки.
+func signV4(req *http.Request, body []byte, cfg S3Config, now time.Time) {
+	payload := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(payload[:])
+	amzDate := now.Format(amzDateFmt)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	scope := now.Format("20060102") + "/" + cfg.Region + "/s3/aws4_request"
+	canonical, signed := canonicalRequest(req, payloadHash)
+	sum := sha256.Sum256([]byte(canonical))
+	stringToSign := sigAlgorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+
+	key := signingKey(cfg.SecretKey, now.Format("20060102"), cfg.Region)
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigAlgorithm, cfg.AccessKey, scope, signed, signature))
+}
+
+// canonicalRequest собирает каноническую форму запроса SigV4 и список подписанных заголовков.
+func canonicalRequest(req *http.Request, payloadHash string) (canonical, signedHeaders string) {
+	headers := map[string]string{"host": req.Host}
+	if req.Host == "" {
+		headers["host"] = req.URL.Host
+	}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders = strings.Join(names, ";")
+
+	canonical = strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	return canonical, signedHeaders
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), q[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func signingKey(secret, date, region string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// uriEncode — кодирование SigV4: всё, кроме A-Z a-z 0-9 - _ . ~, в %XX.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
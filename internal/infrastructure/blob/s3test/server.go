@@ -0,0 +1,184 @@
+// Package s3test поднимает in-process S3-совместимое хранилище для тестов — как MinIO,
+// только в памяти. Подпись SigV4 проверяется, неверная отклоняется с SignatureDoesNotMatch.
+package s3test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Object struct {
+	Data        []byte
+	ContentType string
+	ModTime     time.Time
+}
+
+type Server struct {
+	*httptest.Server
+
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	mu      sync.Mutex
+	objects map[string]Object
+}
+
+func NewServer(bucket, accessKey, secretKey string) *Server {
+	s := &Server{Bucket: bucket, AccessKey: accessKey, SecretKey: secretKey, objects: map[string]Object{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+func (s *Server) Object(key string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[key]
+	return obj, ok
+}
+
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for k := range s.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+	if code, msg := s.verify(r, body); code != "" {
+		writeError(w, http.StatusForbidden, code, msg)
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s.Bucket {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+	if key == "" {
+		writeError(w, http.StatusNotImplemented, "NotImplemented", "bucket operations are not supported")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		s.objects[key] = Object{
+			Data:        body,
+			ContentType: r.Header.Get("Content-Type"),
+			ModTime:     time.Now().UTC().Truncate(time.Second),
+		}
+		w.Header().Set("ETag", etag(body))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		obj, ok := s.objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.Header().Set("Content-Type", obj.ContentType)
+		w.Header().Set("Last-Modified", obj.ModTime.Format(http.TimeFormat))
+		w.Header().Set("ETag", etag(obj.Data))
+		w.Write(obj.Data)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method+" is not allowed")
+	}
+}
+
+// verify пересчитывает подпись по заголовкам из SignedHeaders; пустой код — подпись верна.
+func (s *Server) verify(r *http.Request, body []byte) (code, message string) {
+	auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return "AccessDenied", "missing SigV4 authorization"
+	}
+	params := map[string]string{}
+	for _, part := range strings.Split(auth, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		params[k] = v
+	}
+	credential := strings.Split(params["Credential"], "/")
+	if len(credential) != 5 || credential[3] != "s3" || credential[4] != "aws4_request" {
+		return "AuthorizationHeaderMalformed", "bad credential scope"
+	}
+	if credential[0] != s.AccessKey {
+		return "InvalidAccessKeyId", "unknown access key"
+	}
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	sum := sha256.Sum256(body)
+	if payloadHash != hex.EncodeToString(sum[:]) {
+		return "XAmzContentSHA256Mismatch", "payload hash does not match the body"
+	}
+
+	signed := strings.Split(params["SignedHeaders"], ";")
+	if !slices.Contains(signed, "host") || !slices.Contains(signed, "x-amz-date") {
+		return "AccessDenied", "host and x-amz-date must be signed"
+	}
+	var headers strings.Builder
+	for _, name := range signed {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		fmt.Fprintf(&headers, "%s:%s\n", name, strings.TrimSpace(value))
+	}
+	canonical := strings.Join([]string{
+		r.Method, r.URL.EscapedPath(), r.URL.RawQuery, headers.String(), params["SignedHeaders"], payloadHash,
+	}, "\n")
+	canonicalSum := sha256.Sum256([]byte(canonical))
+	scope := strings.Join(credential[1:], "/")
+	stringToSign := "AWS4-HMAC-SHA256\n" + r.Header.Get("X-Amz-Date") + "\n" + scope + "\n" + hex.EncodeToString(canonicalSum[:])
+
+	key := []byte("AWS4" + s.SecretKey)
+	for _, part := range credential[1:] {
+		key = mac(key, part)
+	}
+	if !hmac.Equal([]byte(hex.EncodeToString(mac(key, stringToSign))), []byte(params["Signature"])) {
+		return "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided."
+	}
+	return "", ""
+}
+
+func mac(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func etag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: message})
+}
@@ -0,0 +1,142 @@
+// Package googlebooks ищет метаданные книг по ISBN через API томов Google Books.
+// Базовый адрес настраивается, поэтому клиент работает и с локальной заглушкой
+// того же формата (googlebookstest).
+package googlebooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"book-store-api/internal/models"
+)
+
+const Source = "googlebooks"
+
+type Config struct {
+	// BaseURL — корень API, например https://www.googleapis.com/books/v1
+	BaseURL string
+	// APIKey необязателен, без него действует общая квота по IP
+	APIKey string
+}
+
+type Client struct {
+	cfg  Config
+	http *http.Client
+}
+
+func NewClient(cfg Config, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &Client{cfg: cfg, http: httpClient}
+}
+
+type volumeList struct {
+	Items []struct {
+		VolumeInfo volumeInfo `json:"volumeInfo"`
+	} `json:"items"`
+}
+
+type volumeInfo struct {
+	Title               string   `json:"title"`
+	Subtitle            string   `json:"subtitle"`
+	Authors             []string `json:"authors"`
+	Description         string   `json:"description"`
+	PageCount           int      `json:"pageCount"`
+	IndustryIdentifiers []struct {
+		Type       string `json:"type"`
+		Identifier string `json:"identifier"`
+	} `json:"industryIdentifiers"`
+	ImageLinks map[string]string `json:"imageLinks"`
+}
+
+// imageSizes — размеры imageLinks от крупного к мелкому.
+var imageSizes = []string{"extraLarge", "large", "medium", "small", "thumbnail", "smallThumbnail"}
+
+// Lookup ищет том по ISBN-13. Поиск q=isbn: бывает неточным, поэтому берётся
+// только том, среди идентификаторов которого есть этот ISBN.
+func (c *Client) Lookup(ctx context.Context, isbn string) (models.BookMetadata, error) {
+	q := url.Values{}
+	q.Set("q", "isbn:"+isbn)
+	if c.cfg.APIKey != "" {
+		q.Set("key", c.cfg.APIKey)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+"/volumes?"+q.Encode(), nil)
+	if err != nil {
+		return models.BookMetadata{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return models.BookMetadata{}, fmt.Errorf("volumes request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return models.BookMetadata{}, fmt.Errorf("volumes endpoint returned %d", resp.StatusCode)
+	}
+
+	var list volumeList
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 4<<20)).Decode(&list); err != nil {
+		return models.BookMetadata{}, fmt.Errorf("volumes response: %w", err)
+	}
+	for _, item := range list.Items {
+		if item.VolumeInfo.hasISBN(isbn) {
+			return item.VolumeInfo.metadata(isbn), nil
+		}
+	}
+	return models.BookMetadata{}, models.ErrMetadataNotFound
+}
+
+func (v volumeInfo) hasISBN(isbn string) bool {
+	for _, id := range v.IndustryIdentifiers {
+		if id.Type != "ISBN_13" && id.Type != "ISBN_10" {
+			continue
+		}
+		if code, err := models.ISBN13(id.Identifier); err == nil && code == isbn {
+			return true
+		}
+	}
+	return false
+}
+
+func (v volumeInfo) metadata(isbn string) models.BookMetadata {
+	m := models.BookMetadata{
+		Source:      Source,
+		ISBN:        isbn,
+		Title:       v.Title,
+		Author:      strings.Join(v.Authors, ", "),
+		Description: plainText(v.Description),
+		PageCount:   v.PageCount,
+	}
+	if v.Subtitle != "" {
+		m.Title += ". " + v.Subtitle
+	}
+	for _, size := range imageSizes {
+		if link := v.ImageLinks[size]; link != "" {
+			m.CoverURL = coverURL(link)
+			break
+		}
+	}
+	return m
+}
+
+// coverURL убирает загнутый уголок (edge=curl), который Google рисует на превью.
+func coverURL(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	q := u.Query()
+	if q.Has("edge") {
+		q.Del("edge")
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
@@ -0,0 +1,75 @@
+package googlebooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/infrastructure/googlebooks/googlebookstest"
+	"book-store-api/internal/models"
+)
+
+func TestClient_Lookup(t *testing.T) {
+	srv := googlebookstest.NewServer()
+	defer srv.Close()
+	srv.Add(googlebookstest.Volume{
+		ISBN:        "9785171183660",
+		Title:       "Пикник на обочине",
+		Subtitle:    "Повесть",
+		Authors:     []string{"Аркадий Стругацкий", "Борис Стругацкий"},
+		Description: "<p>Зона  посещения.</p><p>Сталкеры &amp; хабар<br>и Шар</p>",
+		PageCount:   256,
+		Cover:       []byte("jpeg"),
+	})
+	c := NewClient(Config{BaseURL: srv.URL + "/"}, srv.Client())
+	ctx := context.Background()
+
+	m, err := c.Lookup(ctx, "9785171183660")
+	require.NoError(t, err)
+	assert.Equal(t, models.BookMetadata{
+		Source:      Source,
+		ISBN:        "9785171183660",
+		Title:       "Пикник на обочине. Повесть",
+		Author:      "Аркадий Стругацкий, Борис Стругацкий",
+		Description: "Зона посещения.\nСталкеры & хабар\nи Шар",
+		PageCount:   256,
+		CoverURL:    srv.URL + "/covers/9785171183660?zoom=1",
+	}, m)
+
+	data, err := c.FetchImage(ctx, m.CoverURL, 1<<10)
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+	_, err = c.FetchImage(ctx, m.CoverURL, 2)
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+	_, err = c.FetchImage(ctx, "file:///etc/passwd", 1<<10)
+	assert.Error(t, err)
+
+	_, err = c.Lookup(ctx, "9780306406157")
+	assert.ErrorIs(t, err, models.ErrMetadataNotFound)
+
+	srv.FailWith(http.StatusTooManyRequests)
+	_, err = c.Lookup(ctx, "9785171183660")
+	assert.ErrorContains(t, err, "429")
+	assert.NotErrorIs(t, err, models.ErrMetadataNotFound)
+}
+
+func TestClient_LookupSkipsInexactMatches(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"totalItems": 2, "items": [
+			{"volumeInfo": {"title": "Другая книга", "industryIdentifiers": [{"type": "ISBN_13", "identifier": "9785171183661"}]}},
+			{"volumeInfo": {"title": "Нужная", "industryIdentifiers": [{"type": "ISBN_10", "identifier": "0-306-40615-2"}]}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	m, err := NewClient(Config{BaseURL: srv.URL, APIKey: "secret"}, srv.Client()).Lookup(context.Background(), "9780306406157")
+	require.NoError(t, err)
+	assert.Equal(t, "Нужная", m.Title, "ISBN-10 identifiers are compared as ISBN-13")
+	assert.Equal(t, "key=secret&q=isbn%3A9780306406157", query)
+}
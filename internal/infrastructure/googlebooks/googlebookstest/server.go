@@ -0,0 +1,106 @@
+// Package googlebookstest поднимает in-process заглушку API томов Google Books:
+// отвечает на /volumes?q=isbn:… добавленными томами и раздаёт их обложки.
+package googlebookstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+type Volume struct {
+	ISBN        string
+	Title       string
+	Subtitle    string
+	Authors     []string
+	Description string
+	PageCount   int
+	// Cover отдаётся по /covers/{isbn}; пусто — у тома нет imageLinks
+	Cover []byte
+}
+
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	volumes  map[string]Volume
+	lookups  int
+	failWith int
+}
+
+func NewServer() *Server {
+	s := &Server{volumes: map[string]Volume{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /volumes", s.serveVolumes)
+	mux.HandleFunc("GET /covers/{isbn}", s.serveCover)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *Server) Add(v Volume) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.volumes[v.ISBN] = v
+}
+
+// Lookups — сколько раз запрашивались тома.
+func (s *Server) Lookups() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lookups
+}
+
+// FailWith заставляет /volumes отвечать статусом status; 0 — снова отвечать нормально.
+func (s *Server) FailWith(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failWith = status
+}
+
+func (s *Server) serveVolumes(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lookups++
+	if s.failWith != 0 {
+		http.Error(w, http.StatusText(s.failWith), s.failWith)
+		return
+	}
+
+	isbn, _ := strings.CutPrefix(r.URL.Query().Get("q"), "isbn:")
+	items := []map[string]any{}
+	if v, ok := s.volumes[isbn]; ok {
+		info := map[string]any{
+			"title":       v.Title,
+			"authors":     v.Authors,
+			"description": v.Description,
+			"pageCount":   v.PageCount,
+			"industryIdentifiers": []map[string]string{
+				{"type": "ISBN_13", "identifier": v.ISBN},
+			},
+		}
+		if v.Subtitle != "" {
+			info["subtitle"] = v.Subtitle
+		}
+		if len(v.Cover) > 0 {
+			link := s.URL + "/covers/" + v.ISBN + "?zoom=1&edge=curl"
+			info["imageLinks"] = map[string]string{"smallThumbnail": link, "thumbnail": link}
+		}
+		items = append(items, map[string]any{"kind": "books#volume", "volumeInfo": info})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"kind": "books#volumes", "totalItems": len(items), "items": items})
+}
+
+func (s *Server) serveCover(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	v, ok := s.volumes[r.PathValue("isbn")]
+	s.mu.Unlock()
+	if !ok || len(v.Cover) == 0 || r.URL.Query().Has("edge") {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Write(v.Cover)
+}
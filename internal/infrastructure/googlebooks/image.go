@@ -0,0 +1,41 @@
+package googlebooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var ErrImageTooLarge = errors.New("image is too large")
+
+// FetchImage скачивает обложку по адресу из метаданных, не больше maxSize байт.
+// Адрес приходит от внешнего API, поэтому допускаются только http и https.
+func (c *Client) FetchImage(ctx context.Context, link string, maxSize int64) ([]byte, error) {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("unsupported image url %q", link)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("image request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image request returned %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("image response: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ErrImageTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,43 @@
+package googlebooks
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// plainText превращает HTML-аннотацию Google Books в текст: абзацы и <br>
+// становятся переводами строк, разметка отбрасывается.
+func plainText(s string) string {
+	if !strings.ContainsAny(s, "<&") {
+		return strings.TrimSpace(s)
+	}
+	var b strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(s))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return joinLines(b.String())
+		case html.TextToken:
+			b.Write(tokenizer.Text())
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.Br, atom.P, atom.Div, atom.Li:
+				b.WriteByte('\n')
+			}
+		}
+	}
+}
+
+// joinLines схлопывает пробелы внутри строк и пустые строки между ними.
+func joinLines(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,293 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	GroupsClaim  string
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Client реализует authorization code flow с PKCE. Discovery выполняется при первом
+// обращении, JWKS перечитывается, если ID token подписан неизвестным ключом.
+type Client struct {
+	cfg  Config
+	http *http.Client
+
+	mu   sync.Mutex
+	meta *metadata
+	keys auth.KeySet
+}
+
+func NewClient(cfg Config, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	return &Client{cfg: cfg, http: httpClient}
+}
+
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", c.cfg.ClientID)
+	q.Set("redirect_uri", c.cfg.RedirectURL)
+	q.Set("scope", strings.Join(c.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+func (c *Client) Exchange(ctx context.Context, code, codeVerifier, nonce string) (models.StaffIdentity, error) {
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return models.StaffIdentity{}, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.cfg.RedirectURL)
+	form.Set("client_id", c.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return models.StaffIdentity{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return models.StaffIdentity{}, fmt.Errorf("token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return models.StaffIdentity{}, fmt.Errorf("token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// invalid_grant означает просроченный или чужой code, а не сбой IdP
+		if body.Error == "invalid_grant" {
+			return models.StaffIdentity{}, fmt.Errorf("%w: %s", auth.ErrInvalidToken, body.ErrorDescription)
+		}
+		return models.StaffIdentity{}, fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, body.Error)
+	}
+	if body.IDToken == "" {
+		return models.StaffIdentity{}, errors.New("token response has no id_token")
+	}
+
+	return c.verifyIDToken(ctx, body.IDToken, nonce)
+}
+
+func (c *Client) verifyIDToken(ctx context.Context, raw, nonce string) (models.StaffIdentity, error) {
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return models.StaffIdentity{}, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		return c.key(ctx, t)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(c.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return models.StaffIdentity{}, fmt.Errorf("%w: %v", auth.ErrInvalidToken, err)
+	}
+
+	if got, _ := claims["nonce"].(string); got == "" || got != nonce {
+		return models.StaffIdentity{}, fmt.Errorf("%w: nonce mismatch", auth.ErrInvalidToken)
+	}
+	// При нескольких audience ID token должен быть выдан именно нам
+	if azp, ok := claims["azp"].(string); ok && azp != c.cfg.ClientID {
+		return models.StaffIdentity{}, fmt.Errorf("%w: unexpected azp %q", auth.ErrInvalidToken, azp)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return models.StaffIdentity{}, fmt.Errorf("%w: subject is required", auth.ErrInvalidToken)
+	}
+	email, _ := claims["email"].(string)
+	name, _ := claims["name"].(string)
+
+	return models.StaffIdentity{
+		Subject: subject,
+		Email:   email,
+		Name:    name,
+		Groups:  auth.StringList(claims[c.cfg.GroupsClaim]),
+	}, nil
+}
+
+// key выбирает ключ по kid и, как и auth.Verifier, не даёт подменить алгоритм.
+func (c *Client) key(ctx context.Context, t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	key, err := c.lookupKey(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t.Method.Alg() {
+	case jwt.SigningMethodRS256.Alg():
+		if rsaKey, ok := key.(*rsa.PublicKey); ok {
+			return rsaKey, nil
+		}
+	case jwt.SigningMethodEdDSA.Alg():
+		if edKey, ok := key.(ed25519.PublicKey); ok {
+			return edKey, nil
+		}
+	}
+	return nil, fmt.Errorf("key %q does not match %s", kid, t.Method.Alg())
+}
+
+func (c *Client) lookupKey(ctx context.Context, kid string) (any, error) {
+	c.mu.Lock()
+	key, ok := c.keys[kid]
+	c.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	// IdP мог сменить ключи — перечитываем JWKS один раз
+	keys, err := c.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+
+	key, ok = keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+func (c *Client) discover(ctx context.Context) (*metadata, error) {
+	c.mu.Lock()
+	meta := c.meta
+	c.mu.Unlock()
+	if meta != nil {
+		return meta, nil
+	}
+
+	wellKnown := strings.TrimSuffix(c.cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+	var doc metadata
+	if err := c.getJSON(ctx, wellKnown, &doc); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if doc.Issuer != c.cfg.IssuerURL {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match %q", doc.Issuer, c.cfg.IssuerURL)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: incomplete provider metadata")
+	}
+
+	c.mu.Lock()
+	c.meta = &doc
+	c.mu.Unlock()
+	return &doc, nil
+}
+
+func (c *Client) fetchKeys(ctx context.Context) (auth.KeySet, error) {
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	return auth.ParseJWKS(data)
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(dst)
+}
@@ -0,0 +1,129 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/infrastructure/oidc/oidctest"
+)
+
+const (
+	testClientID = "book-store-admin"
+	testVerifier = "0123456789abcdef0123456789abcdef0123456789a"
+)
+
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// authorize проходит редирект на IdP и возвращает code из callback.
+func authorize(t *testing.T, c *Client, state, nonce, verifier string) string {
+	t.Helper()
+	authURL, err := c.AuthCodeURL(context.Background(), state, nonce, challenge(verifier))
+	require.NoError(t, err)
+
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := noRedirect.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, state, location.Query().Get("state"))
+	return location.Query().Get("code")
+}
+
+func newTestClient(idp *oidctest.Server) *Client {
+	return NewClient(Config{
+		IssuerURL:   idp.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost:8080/api/v1/auth/oidc/callback",
+	}, idp.Client())
+}
+
+func TestClient_CodeFlowWithPKCE(t *testing.T) {
+	idp := oidctest.NewServer(testClientID)
+	defer idp.Close()
+	idp.Identity = oidctest.Identity{Subject: "staff-42", Email: "editor@corp.example", Groups: []string{"catalog-team"}}
+	client := newTestClient(idp)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		code := authorize(t, client, "state-1", "nonce-1", testVerifier)
+
+		identity, err := client.Exchange(ctx, code, testVerifier, "nonce-1")
+		require.NoError(t, err)
+		assert.Equal(t, "staff-42", identity.Subject)
+		assert.Equal(t, "editor@corp.example", identity.Email)
+		assert.Equal(t, []string{"catalog-team"}, identity.Groups)
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		code := authorize(t, client, "state-2", "nonce-2", testVerifier)
+
+		_, err := client.Exchange(ctx, code, testVerifier+"x", "nonce-2")
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("code is single use", func(t *testing.T) {
+		code := authorize(t, client, "state-3", "nonce-3", testVerifier)
+		_, err := client.Exchange(ctx, code, testVerifier, "nonce-3")
+		require.NoError(t, err)
+
+		_, err = client.Exchange(ctx, code, testVerifier, "nonce-3")
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		code := authorize(t, client, "state-4", "nonce-4", testVerifier)
+
+		_, err := client.Exchange(ctx, code, testVerifier, "other-nonce")
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+}
+
+func TestClient_RejectsTamperedIDToken(t *testing.T) {
+	idp := oidctest.NewServer(testClientID)
+	defer idp.Close()
+	idp.Identity = oidctest.Identity{Subject: "staff-42", Groups: []string{"admins"}}
+	client := newTestClient(idp)
+
+	cases := map[string]func(jwt.MapClaims){
+		"foreign audience": func(c jwt.MapClaims) { c["aud"] = "another-app" },
+		"foreign issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example" },
+		"expired":          func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		"foreign azp":      func(c jwt.MapClaims) { c["azp"] = "another-app" },
+	}
+	for name, tamper := range cases {
+		t.Run(name, func(t *testing.T) {
+			idp.TamperIDToken = tamper
+			code := authorize(t, client, "state", "nonce", testVerifier)
+
+			_, err := client.Exchange(context.Background(), code, testVerifier, "nonce")
+			assert.ErrorIs(t, err, auth.ErrInvalidToken)
+		})
+	}
+}
+
+func TestClient_DiscoveryIssuerMismatch(t *testing.T) {
+	idp := oidctest.NewServer(testClientID)
+	defer idp.Close()
+
+	client := NewClient(Config{IssuerURL: idp.URL + "/", ClientID: testClientID}, idp.Client())
+	_, err := client.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+// Package label печатает ценники на книги: штрихкод Bookland EAN-13 с ценой
+// в добавочном коде, QR-код со ссылкой на страницу магазина и лист этикеток в PDF.
+package label
+
+import (
+	"net/url"
+	"strings"
+
+	"book-store-api/internal/barcode"
+	"book-store-api/internal/models"
+)
+
+type Config struct {
+	// StorefrontURL — шаблон ссылки на страницу книги в магазине; {id} и {isbn}
+	// заменяются на id книги и ISBN-13 (пусто, если ISBN невалиден)
+	StorefrontURL string
+	// AddOnCurrency — первая цифра добавочного кода цены, пусто — код 90000 «без цены»
+	AddOnCurrency string
+	// Currency и PriceDecimals — валюта и число знаков после запятой в целой цене
+	Currency      string
+	PriceDecimals int
+}
+
+// BookURL подставляет книгу в шаблон ссылки магазина.
+func (c Config) BookURL(b models.Book) string {
+	isbn, _ := models.ISBN13(b.ISBN)
+	return strings.NewReplacer(
+		"{id}", b.ID.String(),
+		"{isbn}", url.PathEscape(isbn),
+	).Replace(c.StorefrontURL)
+}
+
+// Barcode — штрихкод книги с ценой в добавочном коде. Книге без ISBN-13
+// с префиксом 978/979 штрихкод не положен.
+func Barcode(b models.Book, cfg Config) (barcode.Symbol, error) {
+	return barcode.Bookland(b.ISBN, barcode.PriceAddOn(b.Price, cfg.PriceDecimals, cfg.AddOnCurrency))
+}
+
+// QR — QR-код ссылки на книгу в магазине.
+func QR(b models.Book, cfg Config) (barcode.Symbol, error) {
+	return barcode.QR(cfg.BookURL(b))
+}
+
+// Price — цена для этикетки: "1250.50 RUB".
+func (c Config) Price(b models.Book) string {
+	price := models.FormatMinorUnits(b.Price, c.PriceDecimals)
+	if c.Currency == "" {
+		return price
+	}
+	return price + " " + c.Currency
+}
@@ -0,0 +1,99 @@
+package label
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"book-store-api/internal/barcode"
+	"book-store-api/internal/models"
+)
+
+var testConfig = Config{
+	StorefrontURL: "https://shop.example/books/{id}?isbn={isbn}",
+	AddOnCurrency: "5",
+	Currency:      "USD",
+	PriceDecimals: 2,
+}
+
+func testBook() models.Book {
+	return models.Book{
+		ID:     uuid.MustParse("5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01"),
+		Title:  "Пикник на обочине",
+		Author: "Аркадий и Борис Стругацкие",
+		ISBN:   "5-17-118366-X",
+		Price:  1995,
+	}
+}
+
+func TestConfig(t *testing.T) {
+	b := testBook()
+	assert.Equal(t, "https://shop.example/books/5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01?isbn=9785171183660", testConfig.BookURL(b))
+	assert.Equal(t, "19.95 USD", testConfig.Price(b))
+
+	b.ISBN = "not an isbn"
+	assert.Equal(t, "https://shop.example/books/5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01?isbn=", testConfig.BookURL(b))
+}
+
+func TestBarcode(t *testing.T) {
+	b := testBook()
+	code, err := Barcode(b, testConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "ISBN 9785171183660", code.Texts[0].Value)
+	var addOn string
+	for _, text := range code.Texts[len(code.Texts)-5:] {
+		addOn += text.Value
+	}
+	assert.Equal(t, "51995", addOn)
+
+	b.ISBN = ""
+	_, err = Barcode(b, testConfig)
+	assert.ErrorIs(t, err, models.ErrInvalidISBN)
+	b.ISBN = "4006381333931"
+	_, err = Barcode(b, testConfig)
+	assert.ErrorIs(t, err, barcode.ErrNotBookland)
+}
+
+var pageObject = regexp.MustCompile(`/Type /Page\b[^s]`)
+
+func TestWriteSheet(t *testing.T) {
+	books := make([]models.Book, PerPage+1)
+	for i := range books {
+		books[i] = testBook()
+	}
+	books[3].ISBN = ""
+	books[4].Title = "Очень длинное название книги, которое не помещается на этикетку даже в две строки шрифтом восьмого кегля"
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSheet(&buf, books, testConfig))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.Len(t, pageObject.FindAll(buf.Bytes(), -1), 2, "25 labels take two pages")
+
+	buf.Reset()
+	require.NoError(t, WriteSheet(&buf, nil, testConfig))
+	assert.Len(t, pageObject.FindAll(buf.Bytes(), -1), 1)
+}
+
+func TestWrap(t *testing.T) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(fontFamily, "", goregular.TTF)
+	pdf.SetFont(fontFamily, "", 8)
+	width := pdf.GetStringWidth("Пикник на обочине")
+
+	assert.Equal(t, []string{"Пикник на обочине"}, wrap(pdf, "  Пикник   на обочине ", width, 2))
+	assert.Equal(t, []string{"Пикник на обочине", "Пикник на обочине"}, wrap(pdf, "Пикник на обочине Пикник на обочине", width, 2))
+
+	lines := wrap(pdf, "Пикник на обочине Пикник на обочине Пикник", width, 2)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Пикник на обочине", lines[0])
+	assert.Regexp(t, "…$", lines[1])
+	assert.LessOrEqual(t, pdf.GetStringWidth(lines[1]), width)
+
+	assert.Empty(t, wrap(pdf, " ", width, 2))
+}
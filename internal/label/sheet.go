@@ -0,0 +1,147 @@
+package label
+
+import (
+	"io"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"book-store-api/internal/barcode"
+	"book-store-api/internal/models"
+)
+
+// Раскладка листа A4 под стандартные самоклеящиеся этикетки 3×8 по 70×36 мм.
+const (
+	columns     = 3
+	rows        = 8
+	PerPage     = columns * rows
+	labelWidth  = 70.0
+	labelHeight = 36.0
+	sheetTop    = 4.5
+	padding     = 2.5
+	qrSide      = 13.0
+	lineHeight  = 3.4
+)
+
+// fontFamily — Go Regular/Bold: встраиваются в PDF и содержат кириллицу.
+const fontFamily = "go"
+
+// WriteSheet пишет PDF-лист этикеток по одной на книгу в порядке books.
+func WriteSheet(w io.Writer, books []models.Book, cfg Config) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetCreator("book-store-api", true)
+	pdf.SetTitle("Book labels", true)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", gobold.TTF)
+	pdf.SetFillColor(0, 0, 0)
+
+	for i, b := range books {
+		if i%PerPage == 0 {
+			pdf.AddPage()
+		}
+		cell := i % PerPage
+		writeLabel(pdf, b, cfg, float64(cell%columns)*labelWidth, sheetTop+float64(cell/columns)*labelHeight)
+	}
+	if len(books) == 0 {
+		pdf.AddPage()
+	}
+	return pdf.Output(w)
+}
+
+// writeLabel: название и автор слева сверху, QR справа сверху, штрихкод снизу, цена справа снизу.
+// Без штрихкода вместо него печатается ISBN как есть.
+func writeLabel(pdf *fpdf.Fpdf, b models.Book, cfg Config, x, y float64) {
+	left, top := x+padding, y+padding
+	inner := labelWidth - 2*padding
+	textWidth := inner - qrSide - 2
+
+	if code, err := QR(b, cfg); err == nil {
+		drawSymbol(pdf, code, x+labelWidth-padding-qrSide, top, qrSide/code.Width)
+	}
+
+	baseline := top + 2.8
+	pdf.SetFont(fontFamily, "B", 8)
+	for _, line := range wrap(pdf, b.Title, textWidth, 2) {
+		pdf.Text(left, baseline, line)
+		baseline += lineHeight
+	}
+	pdf.SetFont(fontFamily, "", 7)
+	if author := wrap(pdf, b.Author, textWidth, 1); len(author) > 0 {
+		pdf.Text(left, baseline, author[0])
+	}
+
+	bottom := y + labelHeight - padding
+	price := cfg.Price(b)
+	pdf.SetFont(fontFamily, "B", 9)
+	priceWidth := pdf.GetStringWidth(price)
+	pdf.Text(left+inner-priceWidth, bottom, price)
+
+	codeTop := top + qrSide + 0.5
+	code, err := Barcode(b, cfg)
+	if err != nil {
+		pdf.SetFont(fontFamily, "", 7)
+		if b.ISBN != "" {
+			pdf.Text(left, bottom, "ISBN "+b.ISBN)
+		}
+		return
+	}
+	maxWidth := inner - priceWidth - 1.5
+	scale := min(maxWidth/code.Width, (bottom-codeTop)/code.Height)
+	drawSymbol(pdf, code, left, bottom-code.Height*scale, scale)
+}
+
+// drawSymbol рисует штрихкод с левым верхним углом в (x, y), scale миллиметров на модуль.
+func drawSymbol(pdf *fpdf.Fpdf, s barcode.Symbol, x, y, scale float64) {
+	for _, r := range s.Bars {
+		pdf.Rect(x+r.X*scale, y+r.Y*scale, r.W*scale, r.H*scale, "F")
+	}
+	if len(s.Texts) == 0 {
+		return
+	}
+	// кегль в пунктах, модули — в миллиметрах
+	pdf.SetFont(fontFamily, "", s.Texts[0].Size*scale/25.4*72)
+	for _, t := range s.Texts {
+		pdf.Text(x+t.X*scale-pdf.GetStringWidth(t.Value)/2, y+t.Y*scale, t.Value)
+	}
+}
+
+// wrap разбивает текст по словам на не более чем maxLines строк шириной width;
+// не поместившееся обрезается многоточием.
+func wrap(pdf *fpdf.Fpdf, text string, width float64, maxLines int) []string {
+	var lines []string
+	line := ""
+	words := strings.Fields(text)
+	for i, word := range words {
+		candidate := strings.TrimSpace(line + " " + word)
+		if line == "" || pdf.GetStringWidth(candidate) <= width {
+			line = candidate
+			continue
+		}
+		if len(lines) == maxLines-1 {
+			line = candidate + " " + strings.Join(words[i+1:], " ")
+			break
+		}
+		lines = append(lines, truncate(pdf, line, width))
+		line = word
+	}
+	if line == "" {
+		return lines
+	}
+	return append(lines, truncate(pdf, line, width))
+}
+
+// truncate обрезает строку по ширине, добавляя многоточие.
+func truncate(pdf *fpdf.Fpdf, s string, width float64) string {
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+	runes := []rune(strings.TrimSpace(s))
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimSpace(string(runes)) + "…"
+}
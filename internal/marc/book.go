@@ -0,0 +1,108 @@
+package marc
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"book-store-api/internal/models"
+)
+
+// bookLeader: новая запись (n), текстовый материал (a), монография (m), Unicode (a),
+// минимальный уровень описания (7), форма каталогизации неизвестна (u).
+const bookLeader = "00000nam a22000007u 4500"
+
+// descriptionChunk — предел байтов одного 520: поле ограничено 9999 байтами
+// вместе с индикаторами и разделителями, длинная аннотация делится на несколько 520.
+const descriptionChunk = 9000
+
+type Config struct {
+	// OrgCode — код организации MARC для 003, пусто — поле не пишется
+	OrgCode string
+	// Currency и PriceDecimals задают 365: валюту и число знаков после запятой в целой цене
+	Currency      string
+	PriceDecimals int
+	// PricesIncludeTax выбирает тип цены по списку ONIX 58: 02 с налогом, 01 без
+	PricesIncludeTax bool
+}
+
+// BookRecord отображает книгу на библиографическую запись: 001 id, 005 время изменения,
+// 008 дата создания, 020 ISBN, 100 автор, 245 заглавие, 520 аннотация, 365 цена.
+// Невалидный ISBN пишется в 020 $z, как принято для ошибочных номеров.
+func BookRecord(b models.Book, cfg Config) Record {
+	rec := Record{Leader: bookLeader}
+	add := func(f Field) { rec.Fields = append(rec.Fields, f) }
+
+	add(Field{Tag: "001", Value: b.ID.String()})
+	if cfg.OrgCode != "" {
+		add(Field{Tag: "003", Value: cfg.OrgCode})
+	}
+	if !b.UpdatedAt.IsZero() {
+		add(Field{Tag: "005", Value: b.UpdatedAt.UTC().Format("20060102150405.0")})
+	}
+	add(Field{Tag: "008", Value: fixedData(b)})
+
+	if isbn, err := models.ISBN13(b.ISBN); err == nil {
+		add(Field{Tag: "020", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{{'a', isbn}}})
+	} else if strings.TrimSpace(b.ISBN) != "" {
+		add(Field{Tag: "020", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{{'z', b.ISBN}}})
+	}
+
+	titleInd1 := byte('0')
+	if author := strings.TrimSpace(b.Author); author != "" {
+		// запятая — признак инвертированного имени "Фамилия, Имя"
+		nameInd1 := byte('0')
+		if strings.Contains(author, ",") {
+			nameInd1 = '1'
+		}
+		add(Field{Tag: "100", Ind1: nameInd1, Ind2: ' ', Subfields: []Subfield{{'a', author}}})
+		titleInd1 = '1'
+	}
+	add(Field{Tag: "245", Ind1: titleInd1, Ind2: '0', Subfields: []Subfield{{'a', b.Title}}})
+
+	for _, chunk := range splitText(strings.TrimSpace(b.Description), descriptionChunk) {
+		add(Field{Tag: "520", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{{'a', chunk}}})
+	}
+
+	priceType := "01"
+	if cfg.PricesIncludeTax {
+		priceType = "02"
+	}
+	add(Field{Tag: "365", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{
+		{'a', priceType},
+		{'b', models.FormatMinorUnits(b.Price, cfg.PriceDecimals)},
+		{'c', cfg.Currency},
+		{'2', "onixpt"},
+	}})
+	return rec
+}
+
+// fixedData — 008 из 40 символов: дата создания записи, неизвестные даты
+// публикации, неизвестная страна и язык; остальные позиции не заполняются.
+func fixedData(b models.Book) string {
+	entered := "||||||"
+	if !b.CreatedAt.IsZero() {
+		entered = b.CreatedAt.UTC().Format("060102")
+	}
+	return entered + "nuuuuuuuuxx " + strings.Repeat("|", 17) + "und" + " d"
+}
+
+// splitText режет текст на части не длиннее limit байтов по границе слова,
+// а если пробела нет — по границе руны.
+func splitText(s string, limit int) []string {
+	var chunks []string
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if i := strings.LastIndexByte(s[:cut], ' '); i > 0 {
+			cut = i
+		}
+		chunks = append(chunks, strings.TrimSpace(s[:cut]))
+		s = strings.TrimSpace(s[cut:])
+	}
+	if s != "" {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
@@ -0,0 +1,139 @@
+package marc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var (
+	ErrRecordTooLong = errors.New("marc record exceeds 99999 bytes")
+	ErrMalformed     = errors.New("malformed marc record")
+)
+
+// MarshalBinary кодирует запись в ISO 2709: лидер, справочник и поля.
+// Длины считаются в байтах UTF-8.
+func (r Record) MarshalBinary() ([]byte, error) {
+	var (
+		dir  bytes.Buffer
+		data bytes.Buffer
+	)
+	for _, f := range r.Fields {
+		start := data.Len()
+		if f.IsControl() {
+			data.WriteString(f.Value)
+		} else {
+			data.WriteByte(indicator(f.Ind1))
+			data.WriteByte(indicator(f.Ind2))
+			for _, sf := range f.Subfields {
+				data.WriteByte(subfieldDelimiter)
+				data.WriteByte(sf.Code)
+				data.WriteString(sf.Value)
+			}
+		}
+		data.WriteByte(fieldTerminator)
+
+		length := data.Len() - start
+		if length > maxFieldLen {
+			return nil, fmt.Errorf("marc field %s is %d bytes, limit is %d", f.Tag, length, maxFieldLen)
+		}
+		fmt.Fprintf(&dir, "%3s%04d%05d", f.Tag, length, start)
+	}
+	dir.WriteByte(fieldTerminator)
+
+	base := leaderLen + dir.Len()
+	total := base + data.Len() + 1
+	if total > maxRecordLen {
+		return nil, ErrRecordTooLong
+	}
+
+	out := make([]byte, 0, total)
+	out = append(out, r.leader(total, base)...)
+	out = append(out, dir.Bytes()...)
+	out = append(out, data.Bytes()...)
+	return append(out, recordTerminator), nil
+}
+
+// leader подставляет длину записи, базовый адрес и постоянные позиции
+// (два индикатора, коды подполей из двух символов, карта справочника 4500).
+func (r Record) leader(total, base int) string {
+	l := []byte(fmt.Sprintf("%-24s", r.Leader)[:leaderLen])
+	copy(l[0:5], fmt.Sprintf("%05d", total))
+	l[10], l[11] = '2', '2'
+	copy(l[12:17], fmt.Sprintf("%05d", base))
+	copy(l[20:24], "4500")
+	return string(l)
+}
+
+func indicator(b byte) byte {
+	if b == 0 {
+		return ' '
+	}
+	return b
+}
+
+// ReadBinary читает одну запись ISO 2709; в конце потока возвращает io.EOF.
+func ReadBinary(r io.Reader) (Record, error) {
+	head := make([]byte, 5)
+	if _, err := io.ReadFull(r, head); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return Record{}, fmt.Errorf("%w: truncated leader", ErrMalformed)
+		}
+		return Record{}, err
+	}
+	total, err := strconv.Atoi(string(head))
+	if err != nil || total < leaderLen+2 {
+		return Record{}, fmt.Errorf("%w: invalid record length %q", ErrMalformed, head)
+	}
+	raw := make([]byte, total)
+	copy(raw, head)
+	if _, err := io.ReadFull(r, raw[5:]); err != nil {
+		return Record{}, fmt.Errorf("%w: truncated record", ErrMalformed)
+	}
+	return parseBinary(raw)
+}
+
+func parseBinary(raw []byte) (Record, error) {
+	if raw[len(raw)-1] != recordTerminator {
+		return Record{}, fmt.Errorf("%w: record terminator is missing", ErrMalformed)
+	}
+	base, err := strconv.Atoi(string(raw[12:17]))
+	if err != nil || base <= leaderLen || base > len(raw) || raw[base-1] != fieldTerminator {
+		return Record{}, fmt.Errorf("%w: invalid base address", ErrMalformed)
+	}
+	dir := raw[leaderLen : base-1]
+	if len(dir)%dirEntryLen != 0 {
+		return Record{}, fmt.Errorf("%w: directory length %d", ErrMalformed, len(dir))
+	}
+
+	rec := Record{Leader: string(raw[:leaderLen])}
+	data := raw[base:]
+	for i := 0; i < len(dir); i += dirEntryLen {
+		entry := dir[i : i+dirEntryLen]
+		length, err1 := strconv.Atoi(string(entry[3:7]))
+		start, err2 := strconv.Atoi(string(entry[7:12]))
+		if err1 != nil || err2 != nil || length < 1 || start+length > len(data) {
+			return Record{}, fmt.Errorf("%w: invalid directory entry %q", ErrMalformed, entry)
+		}
+		body := data[start : start+length-1]
+		f := Field{Tag: string(entry[:3])}
+		if f.IsControl() {
+			f.Value = string(body)
+		} else {
+			if len(body) < 2 {
+				return Record{}, fmt.Errorf("%w: field %s has no indicators", ErrMalformed, f.Tag)
+			}
+			f.Ind1, f.Ind2 = body[0], body[1]
+			for _, part := range bytes.Split(body[2:], []byte{subfieldDelimiter})[1:] {
+				if len(part) == 0 {
+					return Record{}, fmt.Errorf("%w: empty subfield in %s", ErrMalformed, f.Tag)
+				}
+				f.Subfields = append(f.Subfields, Subfield{Code: part[0], Value: string(part[1:])})
+			}
+		}
+		rec.Fields = append(rec.Fields, f)
+	}
+	return rec, nil
+}
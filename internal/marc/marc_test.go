@@ -0,0 +1,226 @@
+package marc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+)
+
+var testConfig = Config{OrgCode: "BkStr", Currency: "RUB", PriceDecimals: 2, PricesIncludeTax: true}
+
+func testBook() models.Book {
+	return models.Book{
+		ID:          uuid.MustParse("5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01"),
+		Title:       "Солярис",
+		Author:      "Лем, Станислав",
+		Description: "Океан & планета <контакт>",
+		ISBN:        "5-17-118366-X",
+		Price:       125050,
+		CreatedAt:   time.Date(2025, 10, 28, 9, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2025, 10, 29, 10, 30, 15, 0, time.UTC),
+	}
+}
+
+func TestMarshalBinary_Layout(t *testing.T) {
+	rec := Record{Leader: bookLeader, Fields: []Field{
+		{Tag: "001", Value: "id1"},
+		{Tag: "245", Ind1: '0', Ind2: '0', Subfields: []Subfield{{'a', "Ёж"}}},
+	}}
+
+	raw, err := rec.MarshalBinary()
+	require.NoError(t, err)
+
+	// лидер 24 + справочник 2*12 + терминатор = 49; данные: "id1"+FT (4) и "00"+US+"a"+"Ёж"(4 байта)+FT (9)
+	want := "00063nam a2200049" + "7u 4500" +
+		"001000400000" + "245000900004" + "\x1e" +
+		"id1\x1e" + "00\x1faЁж\x1e" + "\x1d"
+	assert.Equal(t, want, string(raw))
+}
+
+func TestBookRecord_BinaryRoundTrip(t *testing.T) {
+	var stream bytes.Buffer
+	books := []models.Book{testBook(), {ID: uuid.New(), Title: "Без автора", ISBN: "not an isbn", Price: 7}}
+	for _, b := range books {
+		raw, err := BookRecord(b, testConfig).MarshalBinary()
+		require.NoError(t, err)
+		stream.Write(raw)
+	}
+
+	rec, err := ReadBinary(&stream)
+	require.NoError(t, err)
+	assert.Equal(t, "nam a22", rec.Leader[5:12])
+	assert.Equal(t, "5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01", rec.Get("001")[0].Value)
+	assert.Equal(t, "BkStr", rec.Get("003")[0].Value)
+	assert.Equal(t, "20251029103015.0", rec.Get("005")[0].Value)
+	assert.Len(t, rec.Get("008")[0].Value, 40)
+	assert.Equal(t, "251028", rec.Get("008")[0].Value[:6])
+	assert.Equal(t, "9785171183660", rec.Get("020")[0].Subfield('a'))
+	author := rec.Get("100")[0]
+	assert.Equal(t, byte('1'), author.Ind1, "inverted name")
+	assert.Equal(t, "Лем, Станислав", author.Subfield('a'))
+	title := rec.Get("245")[0]
+	assert.Equal(t, [2]byte{'1', '0'}, [2]byte{title.Ind1, title.Ind2})
+	assert.Equal(t, "Солярис", title.Subfield('a'))
+	assert.Equal(t, "Океан & планета <контакт>", rec.Get("520")[0].Subfield('a'))
+	price := rec.Get("365")[0]
+	assert.Equal(t, []Subfield{{'a', "02"}, {'b', "1250.50"}, {'c', "RUB"}, {'2', "onixpt"}}, price.Subfields)
+
+	rec, err = ReadBinary(&stream)
+	require.NoError(t, err)
+	assert.Equal(t, "not an isbn", rec.Get("020")[0].Subfield('z'), "invalid ISBN goes to $z")
+	assert.Empty(t, rec.Get("100"))
+	assert.Equal(t, byte('0'), rec.Get("245")[0].Ind1, "no main entry")
+	assert.Equal(t, "0.07", rec.Get("365")[0].Subfield('b'))
+
+	_, err = ReadBinary(&stream)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestBookRecord_XMLRoundTrip(t *testing.T) {
+	b := testBook()
+	rec := BookRecord(b, testConfig)
+
+	var buf bytes.Buffer
+	w := NewXMLWriter(&buf)
+	require.NoError(t, w.Begin())
+	require.NoError(t, w.Write(rec))
+	require.NoError(t, w.Write(rec))
+	require.NoError(t, w.End())
+
+	out := buf.String()
+	assert.Contains(t, out, `<collection xmlns="`+XMLNamespace+`">`)
+	assert.Contains(t, out, `<datafield tag="245" ind1="1" ind2="0">`)
+	assert.Contains(t, out, `<subfield code="a">Океан &amp; планета &lt;контакт&gt;</subfield>`)
+
+	records, err := ReadXML(&buf)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	raw, err := rec.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, string(raw[:leaderLen]), records[0].Leader, "MARCXML leader carries the binary lengths")
+	assert.Equal(t, rec.Fields, records[0].Fields)
+}
+
+// testdata/solaris.* собраны не этим пакетом: .mrc — отдельным скриптом по ISO 2709,
+// .xml — вручную, как MARCXML отдают каталоги (префикс marc:, схема). Обе описывают
+// testBook с testConfig, поэтому симметричная ошибка писателя и читателя на них видна.
+var solarisFields = []Field{
+	{Tag: "001", Value: "5f0c1f7e-6a55-4c53-9d0e-3f2f8f2f1a01"},
+	{Tag: "003", Value: "BkStr"},
+	{Tag: "005", Value: "20251029103015.0"},
+	{Tag: "008", Value: "251028nuuuuuuuuxx |||||||||||||||||und d"},
+	{Tag: "020", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{{'a', "9785171183660"}}},
+	{Tag: "100", Ind1: '1', Ind2: ' ', Subfields: []Subfield{{'a', "Лем, Станислав"}}},
+	{Tag: "245", Ind1: '1', Ind2: '0', Subfields: []Subfield{{'a', "Солярис"}}},
+	{Tag: "520", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{{'a', "Океан & планета <контакт>"}}},
+	{Tag: "365", Ind1: ' ', Ind2: ' ', Subfields: []Subfield{{'a', "02"}, {'b', "1250.50"}, {'c', "RUB"}, {'2', "onixpt"}}},
+}
+
+func TestBookRecord_ReferenceBinary(t *testing.T) {
+	want, err := os.ReadFile("testdata/solaris.mrc")
+	require.NoError(t, err)
+
+	raw, err := BookRecord(testBook(), testConfig).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(raw), "writer output matches the reference record byte for byte")
+
+	rec, err := ReadBinary(bytes.NewReader(want))
+	require.NoError(t, err)
+	assert.Equal(t, "00381nam a22001337u 4500", rec.Leader)
+	assert.Equal(t, solarisFields, rec.Fields)
+}
+
+// slimCollection разбирает MARCXML стандартным encoding/xml со строгими пространствами имён,
+// мимо ReadXML, чтобы проверять XMLWriter не его же читателем.
+type slimCollection struct {
+	XMLName xml.Name `xml:"http://www.loc.gov/MARC21/slim collection"`
+	Records []struct {
+		Leader   string `xml:"http://www.loc.gov/MARC21/slim leader"`
+		Controls []struct {
+			Tag   string `xml:"tag,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"http://www.loc.gov/MARC21/slim controlfield"`
+		Data []struct {
+			Tag       string `xml:"tag,attr"`
+			Ind1      string `xml:"ind1,attr"`
+			Ind2      string `xml:"ind2,attr"`
+			Subfields []struct {
+				Code  string `xml:"code,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"http://www.loc.gov/MARC21/slim subfield"`
+		} `xml:"http://www.loc.gov/MARC21/slim datafield"`
+	} `xml:"http://www.loc.gov/MARC21/slim record"`
+}
+
+func TestBookRecord_ReferenceXML(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/solaris.xml")
+	require.NoError(t, err)
+
+	records, err := ReadXML(bytes.NewReader(fixture))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "00381nam a22001337u 4500", records[0].Leader)
+	assert.Equal(t, solarisFields, records[0].Fields)
+
+	var buf bytes.Buffer
+	w := NewXMLWriter(&buf)
+	require.NoError(t, w.Begin())
+	require.NoError(t, w.Write(BookRecord(testBook(), testConfig)))
+	require.NoError(t, w.End())
+
+	var want, got slimCollection
+	require.NoError(t, xml.Unmarshal(fixture, &want))
+	require.Len(t, want.Records, 1)
+	require.Len(t, want.Records[0].Data, 5)
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &got), buf.String())
+	require.Len(t, got.Records, 1, "records are in the MARC 21 slim namespace")
+	assert.Equal(t, want, got)
+}
+
+func TestBookRecord_LongDescriptionIsSplit(t *testing.T) {
+	b := testBook()
+	// 5000 символов кириллицы — около 10 КБ, больше предела одного поля
+	b.Description = strings.Repeat("слово ", 1000)
+
+	rec := BookRecord(b, testConfig)
+	notes := rec.Get("520")
+	require.Equal(t, 2, len(notes))
+	for _, n := range notes {
+		assert.LessOrEqual(t, len(n.Subfield('a')), descriptionChunk)
+		assert.False(t, strings.HasSuffix(n.Subfield('a'), " "))
+	}
+	assert.Equal(t, strings.TrimSpace(b.Description), notes[0].Subfield('a')+" "+notes[1].Subfield('a'))
+
+	raw, err := rec.MarshalBinary()
+	require.NoError(t, err)
+	parsed, err := ReadBinary(bytes.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, rec.Fields, parsed.Fields)
+}
+
+func TestReadBinary_Malformed(t *testing.T) {
+	raw, err := BookRecord(testBook(), testConfig).MarshalBinary()
+	require.NoError(t, err)
+
+	for name, data := range map[string][]byte{
+		"truncated":     raw[:len(raw)-10],
+		"bad length":    append([]byte("abcde"), raw[5:]...),
+		"no terminator": append(append([]byte{}, raw[:len(raw)-1]...), ' '),
+		"short leader":  []byte("001"),
+		"bad base":      append(append([]byte{}, raw[:12]...), append([]byte("99999"), raw[17:]...)...),
+	} {
+		_, err := ReadBinary(bytes.NewReader(data))
+		assert.True(t, errors.Is(err, ErrMalformed), "%s: %v", name, err)
+	}
+}
@@ -0,0 +1,128 @@
+package marc
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const XMLNamespace = "http://www.loc.gov/MARC21/slim"
+
+type xmlRecord struct {
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// XMLWriter пишет записи в один элемент collection по мере поступления.
+type XMLWriter struct {
+	enc  *xml.Encoder
+	root xml.StartElement
+}
+
+func NewXMLWriter(w io.Writer) *XMLWriter {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return &XMLWriter{enc: enc, root: xml.StartElement{Name: xml.Name{Space: XMLNamespace, Local: "collection"}}}
+}
+
+func (w *XMLWriter) Begin() error {
+	if err := w.enc.EncodeToken(xml.ProcInst{Target: "xml", Inst: []byte(`version="1.0" encoding="UTF-8"`)}); err != nil {
+		return err
+	}
+	return w.enc.EncodeToken(w.root)
+}
+
+// Write пишет запись; лидер совпадает с лидером бинарной записи, включая длины.
+func (w *XMLWriter) Write(r Record) error {
+	raw, err := r.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	x := xmlRecord{Leader: string(raw[:leaderLen])}
+	for _, f := range r.Fields {
+		if f.IsControl() {
+			x.ControlFields = append(x.ControlFields, xmlControlField{Tag: f.Tag, Value: f.Value})
+			continue
+		}
+		df := xmlDataField{Tag: f.Tag, Ind1: string(indicator(f.Ind1)), Ind2: string(indicator(f.Ind2))}
+		for _, sf := range f.Subfields {
+			df.Subfields = append(df.Subfields, xmlSubfield{Code: string(sf.Code), Value: sf.Value})
+		}
+		x.DataFields = append(x.DataFields, df)
+	}
+	return w.enc.EncodeElement(x, xml.StartElement{Name: xml.Name{Local: "record"}})
+}
+
+func (w *XMLWriter) Flush() error {
+	return w.enc.Flush()
+}
+
+func (w *XMLWriter) End() error {
+	if err := w.enc.EncodeToken(w.root.End()); err != nil {
+		return err
+	}
+	return w.enc.Flush()
+}
+
+// ReadXML читает все элементы record: из collection или одиночную запись.
+// Контрольные поля при чтении идут перед полями данных, как того требует MARC 21.
+func ReadXML(r io.Reader) ([]Record, error) {
+	dec := xml.NewDecoder(r)
+	var records []Record
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		var x xmlRecord
+		if err := dec.DecodeElement(&x, &start); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+		rec := Record{Leader: x.Leader}
+		for _, cf := range x.ControlFields {
+			rec.Fields = append(rec.Fields, Field{Tag: cf.Tag, Value: cf.Value})
+		}
+		for _, df := range x.DataFields {
+			f := Field{Tag: df.Tag, Ind1: firstByte(df.Ind1), Ind2: firstByte(df.Ind2)}
+			for _, sf := range df.Subfields {
+				f.Subfields = append(f.Subfields, Subfield{Code: firstByte(sf.Code), Value: sf.Value})
+			}
+			rec.Fields = append(rec.Fields, f)
+		}
+		records = append(records, rec)
+	}
+}
+
+func firstByte(s string) byte {
+	if s == "" {
+		return ' '
+	}
+	return s[0]
+}
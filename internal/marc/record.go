@@ -0,0 +1,64 @@
+// Package marc пишет и читает библиографические записи MARC 21 в двух сериализациях:
+// бинарной ISO 2709 и MARCXML.
+package marc
+
+import "strings"
+
+const (
+	// разделители ISO 2709
+	subfieldDelimiter = 0x1F
+	fieldTerminator   = 0x1E
+	recordTerminator  = 0x1D
+
+	leaderLen    = 24
+	dirEntryLen  = 12
+	maxRecordLen = 99999
+	maxFieldLen  = 9999
+)
+
+// Record — запись MARC. Leader хранит только кодируемые позиции (статус, тип,
+// уровень, кодировка); длины и базовый адрес вычисляются при записи.
+type Record struct {
+	Leader string
+	Fields []Field
+}
+
+// Field — контрольное поле (теги 00X, только Value) или поле данных
+// с индикаторами и подполями.
+type Field struct {
+	Tag       string
+	Value     string
+	Ind1      byte
+	Ind2      byte
+	Subfields []Subfield
+}
+
+type Subfield struct {
+	Code  byte
+	Value string
+}
+
+func (f Field) IsControl() bool {
+	return strings.HasPrefix(f.Tag, "00")
+}
+
+// Subfield возвращает первое подполе с кодом code.
+func (f Field) Subfield(code byte) string {
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			return sf.Value
+		}
+	}
+	return ""
+}
+
+// Get возвращает все поля с тегом tag в порядке записи.
+func (r Record) Get(tag string) []Field {
+	var fields []Field
+	for _, f := range r.Fields {
+		if f.Tag == tag {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeCatalogRead  = "catalog:read"
+	ScopeCatalogWrite = "catalog:write"
+	ScopeStockWrite   = "stock:write"
+)
+
+var knownScopes = map[string]struct{}{
+	ScopeCatalogRead:  {},
+	ScopeCatalogWrite: {},
+	ScopeStockWrite:   {},
+}
+
+// APIKey хранит только префикс для поиска и хэш самого ключа.
+type APIKey struct {
+	ID         uuid.UUID
+	Name       string
+	Prefix     string
+	Hash       string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type APIKeyParams struct {
+	ID        uuid.UUID
+	Name      string
+	Prefix    string
+	Hash      string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+// IssuedAPIKey возвращается один раз при создании или ротации, Secret больше нигде не хранится.
+type IssuedAPIKey struct {
+	Key    APIKey
+	Secret string
+}
+
+func NewAPIKey(params APIKeyParams, now time.Time) (APIKey, error) {
+	if err := validateAPIKey(params, now); err != nil {
+		return APIKey{}, err
+	}
+
+	return APIKey{
+		ID:        params.ID,
+		Name:      params.Name,
+		Prefix:    params.Prefix,
+		Hash:      params.Hash,
+		Scopes:    params.Scopes,
+		ExpiresAt: params.ExpiresAt,
+		CreatedAt: now,
+	}, nil
+}
+
+func (k APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil && !k.RevokedAt.After(now) {
+		return false
+	}
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(now) {
+		return false
+	}
+	return true
+}
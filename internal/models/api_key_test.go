@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	valid := APIKeyParams{ID: uuid.New(), Name: "warehouse", Prefix: "abcd1234", Hash: "hash", Scopes: []string{ScopeStockWrite}}
+
+	tests := []struct {
+		name    string
+		mutate  func(p *APIKeyParams)
+		wantErr bool
+	}{
+		{"valid", func(p *APIKeyParams) {}, false},
+		{"no name", func(p *APIKeyParams) { p.Name = " " }, true},
+		{"no scopes", func(p *APIKeyParams) { p.Scopes = nil }, true},
+		{"unknown scope", func(p *APIKeyParams) { p.Scopes = []string{"orders:write"} }, true},
+		{"expired", func(p *APIKeyParams) { p.ExpiresAt = &past }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := valid
+			tt.mutate(&p)
+			if _, err := NewAPIKey(p, now); (err != nil) != tt.wantErr {
+				t.Errorf("NewAPIKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIKey_Active(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	assert.True(t, APIKey{}.Active(now))
+	assert.True(t, APIKey{ExpiresAt: &future}.Active(now))
+	assert.False(t, APIKey{ExpiresAt: &past}.Active(now))
+	assert.False(t, APIKey{RevokedAt: &past}.Active(now))
+	assert.True(t, APIKey{RevokedAt: &future}.Active(now), "key rotated with a grace period stays valid until revocation time")
+}
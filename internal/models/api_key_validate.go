@@ -0,0 +1,33 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func validateAPIKey(params APIKeyParams, now time.Time) error {
+	var verr ValidationError
+	if params.ID == uuid.Nil {
+		verr.Add("id", FieldRequired, "api key id is required")
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		verr.Add("name", FieldRequired, "api key name is required")
+	}
+	if params.Prefix == "" || params.Hash == "" {
+		verr.Add("secret", FieldRequired, "api key secret is required")
+	}
+	if len(params.Scopes) == 0 {
+		verr.Add("scopes", FieldRequired, "at least one scope is required")
+	}
+	for _, s := range params.Scopes {
+		if _, ok := knownScopes[s]; !ok {
+			verr.Addf("scopes", FieldUnknown, "unknown scope %q", s)
+		}
+	}
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(now) {
+		verr.Add("expires_at", FieldInvalid, "expiry must be in the future")
+	}
+	return verr.Err()
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	// AuditActionMerge — книга удалена как дубликат; в after — каноничная книга
+	AuditActionMerge = "merge"
+
+	AuditEntityBook = "book"
+)
+
+// AuditEvent — запись журнала аудита. Hash покрывает все поля и PrevHash,
+// поэтому изменение или удаление любой записи ломает цепочку после неё.
+type AuditEvent struct {
+	ID         int64
+	Actor      string
+	ActorType  string
+	RequestID  string
+	IP         string
+	Entity     string
+	EntityID   string
+	Action     string
+	Before     json.RawMessage
+	After      json.RawMessage
+	OccurredAt time.Time
+	PrevHash   string
+	Hash       string
+}
+
+type AuditFilter struct {
+	Actor    string
+	Entity   string
+	EntityID string
+	Action   string
+	From     *time.Time
+	To       *time.Time
+	// BeforeID — курсор: события с id меньше заданного, от новых к старым
+	BeforeID int64
+	Limit    int
+}
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// NormalizeAuditLimit подставляет размер страницы по умолчанию и ограничивает максимум.
+func NormalizeAuditLimit(limit int) int {
+	if limit <= 0 {
+		return defaultAuditLimit
+	}
+	return min(limit, maxAuditLimit)
+}
+
+// AuditVerification — результат проверки цепочки; BrokenAt — id первой испорченной записи.
+type AuditVerification struct {
+	Valid    bool
+	Checked  int
+	BrokenAt int64
+}
+
+// ComputeHash считает хэш события без учёта ID и самого Hash.
+func (e AuditEvent) ComputeHash() string {
+	payload, _ := json.Marshal(struct {
+		PrevHash   string          `json:"prev_hash"`
+		Actor      string          `json:"actor"`
+		ActorType  string          `json:"actor_type"`
+		RequestID  string          `json:"request_id"`
+		IP         string          `json:"ip"`
+		Entity     string          `json:"entity"`
+		EntityID   string          `json:"entity_id"`
+		Action     string          `json:"action"`
+		Before     json.RawMessage `json:"before"`
+		After      json.RawMessage `json:"after"`
+		OccurredAt string          `json:"occurred_at"`
+	}{
+		PrevHash:   e.PrevHash,
+		Actor:      e.Actor,
+		ActorType:  e.ActorType,
+		RequestID:  e.RequestID,
+		IP:         e.IP,
+		Entity:     e.Entity,
+		EntityID:   e.EntityID,
+		Action:     e.Action,
+		Before:     nullIfEmpty(e.Before),
+		After:      nullIfEmpty(e.After),
+		OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// BookAuditEvent — событие по книге без автора и времени; их дописывает репозиторий.
+// before и after — состояния из транзакции изменения, nil — книги нет.
+func BookAuditEvent(action string, id uuid.UUID, before, after *Book) AuditEvent {
+	return AuditEvent{
+		Entity:   AuditEntityBook,
+		EntityID: id.String(),
+		Action:   action,
+		Before:   bookAuditSnapshot(before),
+		After:    bookAuditSnapshot(after),
+	}
+}
+
+type bookSnapshot struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Author      string    `json:"author"`
+	ISBN        string    `json:"isbn"`
+	Price       int       `json:"price"`
+	Cover       string    `json:"cover_version,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func bookAuditSnapshot(b *Book) json.RawMessage {
+	if b == nil {
+		return nil
+	}
+	data, _ := json.Marshal(bookSnapshot{
+		ID:          b.ID,
+		Title:       b.Title,
+		Description: b.Description,
+		Author:      b.Author,
+		ISBN:        b.ISBN,
+		Price:       b.Price,
+		Cover:       b.Cover.Version,
+		UpdatedAt:   b.UpdatedAt.UTC(),
+	})
+	return data
+}
+
+// VerifyAuditChain проверяет подряд идущие события, prevHash — хэш записи перед первой из них.
+func VerifyAuditChain(prevHash string, events []AuditEvent) (string, int64, bool) {
+	for _, e := range events {
+		if e.PrevHash != prevHash || e.ComputeHash() != e.Hash {
+			return prevHash, e.ID, false
+		}
+		prevHash = e.Hash
+	}
+	return prevHash, 0, true
+}
+
+func nullIfEmpty(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage("null")
+	}
+	return raw
+}
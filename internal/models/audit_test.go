@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func auditChain() []AuditEvent {
+	at := time.Date(2025, 10, 24, 9, 0, 0, 123456000, time.UTC)
+	events := []AuditEvent{
+		{ID: 1, Actor: "staff-1", Entity: AuditEntityBook, EntityID: "b1", Action: AuditActionCreate, After: json.RawMessage(`{"price":100}`), OccurredAt: at},
+		{ID: 2, Actor: "staff-1", Entity: AuditEntityBook, EntityID: "b1", Action: AuditActionUpdate, Before: json.RawMessage(`{"price":100}`), After: json.RawMessage(`{"price":120}`), OccurredAt: at.Add(time.Minute)},
+		{ID: 3, Actor: "key-1", ActorType: "api_key", Entity: AuditEntityBook, EntityID: "b1", Action: AuditActionDelete, Before: json.RawMessage(`{"price":120}`), OccurredAt: at.Add(2 * time.Minute)},
+	}
+	prev := ""
+	for i := range events {
+		events[i].PrevHash = prev
+		events[i].Hash = events[i].ComputeHash()
+		prev = events[i].Hash
+	}
+	return events
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	t.Run("intact", func(t *testing.T) {
+		events := auditChain()
+		last, _, ok := VerifyAuditChain("", events)
+		assert.True(t, ok)
+		assert.Equal(t, events[2].Hash, last)
+	})
+
+	t.Run("edited snapshot", func(t *testing.T) {
+		events := auditChain()
+		events[1].After = json.RawMessage(`{"price":1}`)
+		_, brokenAt, ok := VerifyAuditChain("", events)
+		assert.False(t, ok)
+		assert.Equal(t, int64(2), brokenAt)
+	})
+
+	t.Run("deleted event", func(t *testing.T) {
+		events := auditChain()
+		_, brokenAt, ok := VerifyAuditChain("", append(events[:1], events[2:]...))
+		assert.False(t, ok)
+		assert.Equal(t, int64(3), brokenAt)
+	})
+
+	t.Run("rehashed event without relinking", func(t *testing.T) {
+		events := auditChain()
+		events[0].Actor = "someone-else"
+		events[0].Hash = events[0].ComputeHash()
+		_, brokenAt, ok := VerifyAuditChain("", events)
+		assert.False(t, ok)
+		assert.Equal(t, int64(2), brokenAt)
+	})
+}
+
+func TestBookAuditEvent(t *testing.T) {
+	before := Book{ID: uuid.New(), Title: "Солярис", Price: 100, UpdatedAt: time.Date(2025, 10, 24, 12, 0, 0, 0, time.FixedZone("MSK", 3*3600))}
+	after := before
+	after.Price = 120
+	after.Cover.Version = "v2"
+
+	e := BookAuditEvent(AuditActionUpdate, before.ID, &before, &after)
+	assert.Equal(t, AuditEntityBook, e.Entity)
+	assert.Equal(t, before.ID.String(), e.EntityID)
+	assert.JSONEq(t, `{"id":"`+before.ID.String()+`","title":"Солярис","description":"","author":"","isbn":"","price":100,"updated_at":"2025-10-24T09:00:00Z"}`, string(e.Before))
+	assert.Contains(t, string(e.After), `"cover_version":"v2"`)
+
+	e = BookAuditEvent(AuditActionDelete, before.ID, &before, nil)
+	assert.Empty(t, e.After)
+}
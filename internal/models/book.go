@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,7 @@ type Book struct {
 	Author      string
 	ISBN        string
 	Price       int
+	Cover       BookCover
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -24,11 +26,16 @@ type BookParams struct {
 	Author      string
 	ISBN        string
 	Price       int
+	Cover       BookCover
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
 func NewBook(book BookParams) (Book, error) {
+	book.Title = NormalizeText(book.Title)
+	book.Author = NormalizeText(book.Author)
+	book.Description = NormalizeText(book.Description)
+	book.ISBN = strings.TrimSpace(book.ISBN)
 	err := validateBook(book)
 	if err != nil {
 		return Book{}, err
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MaxBookBatchOps ограничивает размер пакета: он пишется одной транзакцией
+// и держит блокировки всех затронутых строк.
+const MaxBookBatchOps = 500
+
+type BookBatchMode string
+
+const (
+	// BatchAtomic — всё или ничего: любая ошибка элемента откатывает пакет.
+	BatchAtomic BookBatchMode = "atomic"
+	// BatchBestEffort пишет валидные элементы и пропускает остальные.
+	BatchBestEffort BookBatchMode = "best_effort"
+)
+
+type BookBatchOpType string
+
+const (
+	BatchOpCreate BookBatchOpType = "create"
+	BatchOpUpdate BookBatchOpType = "update"
+	BatchOpDelete BookBatchOpType = "delete"
+)
+
+type BookBatchStatus string
+
+const (
+	BatchItemCreated    BookBatchStatus = "created"
+	BatchItemUpdated    BookBatchStatus = "updated"
+	BatchItemDeleted    BookBatchStatus = "deleted"
+	BatchItemInvalid    BookBatchStatus = "invalid"
+	BatchItemNotFound   BookBatchStatus = "not_found"
+	BatchItemRolledBack BookBatchStatus = "rolled_back"
+)
+
+type BookBatchOp struct {
+	Op BookBatchOpType
+	// ID обязателен для update и delete
+	ID   uuid.UUID
+	Book BookParams
+}
+
+type BookBatchItem struct {
+	Index  int
+	Op     BookBatchOpType
+	ID     uuid.UUID
+	Status BookBatchStatus
+	Err    error
+}
+
+func (i BookBatchItem) Failed() bool {
+	return i.Status == BatchItemInvalid || i.Status == BatchItemNotFound || i.Status == BatchItemRolledBack
+}
+
+// BookChange — запись, которую репозиторий применяет в пакете.
+// Before пуст для create, After пуст для delete.
+type BookChange struct {
+	Op     BookBatchOpType
+	Before *Book
+	After  *Book
+}
+
+func (c BookChange) ID() uuid.UUID {
+	if c.After != nil {
+		return c.After.ID
+	}
+	return c.Before.ID
+}
+
+// Events — события outbox для изменения, как у одиночных операций.
+func (c BookChange) Events() []DomainEvent {
+	switch c.Op {
+	case BatchOpCreate:
+		return []DomainEvent{BookCreatedEvent(*c.After)}
+	case BatchOpUpdate:
+		return BookChangedEvents(*c.Before, *c.After)
+	default:
+		return []DomainEvent{BookDeletedEvent(*c.Before)}
+	}
+}
+
+type BookBatchResult struct {
+	Committed bool
+	Items     []BookBatchItem
+	// Changes — применённые изменения, для аудита; пусты, если пакет не записан
+	Changes []BookChange
+}
+
+func ValidateBookBatch(mode BookBatchMode, ops []BookBatchOp) error {
+	var verr ValidationError
+	if mode != BatchAtomic && mode != BatchBestEffort {
+		verr.Addf("mode", FieldUnknown, "mode must be %q or %q", BatchAtomic, BatchBestEffort)
+	}
+	switch {
+	case len(ops) == 0:
+		verr.Add("operations", FieldRequired, "at least one operation is required")
+	case len(ops) > MaxBookBatchOps:
+		verr.Addf("operations", FieldTooLong, "at most %d operations are allowed", MaxBookBatchOps)
+	}
+	return verr.Err()
+}
+
+// ValidateBookBatchOp проверяет операцию без обращения к базе; для create и update
+// возвращает нормализованную книгу.
+func ValidateBookBatchOp(op BookBatchOp) (Book, error) {
+	switch op.Op {
+	case BatchOpCreate:
+		return NewBook(op.Book)
+	case BatchOpUpdate:
+		op.Book.ID = op.ID
+		return NewBook(op.Book)
+	case BatchOpDelete:
+		if op.ID == uuid.Nil {
+			var verr ValidationError
+			verr.Add("id", FieldRequired, "book id is required")
+			return Book{}, verr.Err()
+		}
+		return Book{ID: op.ID}, nil
+	default:
+		return Book{}, fmt.Errorf("%w: unknown operation %q", ErrDomainValidation, op.Op)
+	}
+}
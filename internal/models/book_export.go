@@ -0,0 +1,106 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson"
+	ExportJSON   ExportFormat = "json"
+	// ExportONIX — сообщение ONIX 3.0; всегда со всеми колонками
+	ExportONIX ExportFormat = "onix"
+	// ExportMARC — записи MARC 21 в ISO 2709, ExportMARCXML — они же в MARCXML
+	ExportMARC    ExportFormat = "marc"
+	ExportMARCXML ExportFormat = "marcxml"
+)
+
+// FullRecord — формат описывает книгу целиком, выбор колонок к нему не применяется.
+func (f ExportFormat) FullRecord() bool {
+	return f == ExportONIX || f == ExportMARC || f == ExportMARCXML
+}
+
+// BookColumns — колонки выгрузки в порядке по умолчанию.
+var BookColumns = []string{"id", "title", "author", "description", "isbn", "price", "created_at", "updated_at"}
+
+// BookFilter — условия выборки книг; пустые поля не ограничивают выборку.
+type BookFilter struct {
+	IDs          []uuid.UUID
+	Author       string
+	ISBN         string
+	MinPrice     *int
+	MaxPrice     *int
+	UpdatedSince *time.Time
+}
+
+// DeletedBook — книга, удалённая из каталога; нужна дельта-выгрузкам, чтобы
+// получатель тоже снял её с продажи.
+type DeletedBook struct {
+	ID        uuid.UUID
+	ISBN      string
+	DeletedAt time.Time
+}
+
+type BookExport struct {
+	Format  ExportFormat
+	Columns []string
+	Filter  BookFilter
+}
+
+type BookExportParams struct {
+	Format ExportFormat
+	// Columns — колонки через запятую, пусто — все
+	Columns string
+	Filter  BookFilter
+}
+
+func NewBookExport(params BookExportParams) (BookExport, error) {
+	if params.Format == "" {
+		params.Format = ExportCSV
+	}
+	columns := BookColumns
+	if strings.TrimSpace(params.Columns) != "" && !params.Format.FullRecord() {
+		columns = splitList(params.Columns)
+	}
+	params.Filter.Author = NormalizeText(params.Filter.Author)
+	params.Filter.ISBN = NormalizeText(params.Filter.ISBN)
+
+	if err := validateBookExport(params.Format, columns, params.Filter); err != nil {
+		return BookExport{}, err
+	}
+	return BookExport{Format: params.Format, Columns: columns, Filter: params.Filter}, nil
+}
+
+func validateBookExport(format ExportFormat, columns []string, f BookFilter) error {
+	var verr ValidationError
+	switch format {
+	case ExportCSV, ExportNDJSON, ExportJSON, ExportONIX, ExportMARC, ExportMARCXML:
+	default:
+		verr.Addf("format", FieldUnknown, "format must be one of %q, %q, %q, %q, %q, %q",
+			ExportCSV, ExportNDJSON, ExportJSON, ExportONIX, ExportMARC, ExportMARCXML)
+	}
+	for i, c := range columns {
+		switch {
+		case !slices.Contains(BookColumns, c):
+			verr.Addf("columns", FieldUnknown, "unknown column %q", c)
+		case slices.Index(columns, c) != i:
+			verr.Addf("columns", FieldInvalid, "column %q is listed twice", c)
+		}
+	}
+	if f.MinPrice != nil && *f.MinPrice < 0 {
+		verr.Add("min_price", FieldNegative, "min_price is negative")
+	}
+	if f.MaxPrice != nil && *f.MaxPrice < 0 {
+		verr.Add("max_price", FieldNegative, "max_price is negative")
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		verr.Add("max_price", FieldInvalid, "max_price is less than min_price")
+	}
+	return verr.Err()
+}
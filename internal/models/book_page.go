@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBookPageSize = 50
+	maxBookPageSize     = 500
+)
+
+// BookCursor — позиция в списке книг, упорядоченном по (created_at, id).
+// Клиенту отдаётся непрозрачной строкой.
+type BookCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type BookPage struct {
+	Books []Book
+	// Next — курсор следующей страницы, nil на последней
+	Next *BookCursor
+}
+
+func CursorOf(b Book) BookCursor {
+	return BookCursor{CreatedAt: b.CreatedAt, ID: b.ID}
+}
+
+func (c BookCursor) String() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseBookCursor(s string) (BookCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return BookCursor{}, fmt.Errorf("%w: invalid page token", ErrDomainValidation)
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return BookCursor{}, fmt.Errorf("%w: invalid page token", ErrDomainValidation)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return BookCursor{}, fmt.Errorf("%w: invalid page token", ErrDomainValidation)
+	}
+	bookID, err := uuid.Parse(id)
+	if err != nil {
+		return BookCursor{}, fmt.Errorf("%w: invalid page token", ErrDomainValidation)
+	}
+	return BookCursor{CreatedAt: createdAt, ID: bookID}, nil
+}
+
+// NormalizeBookPageSize подставляет размер страницы по умолчанию и ограничивает максимум.
+func NormalizeBookPageSize(size int) int {
+	if size <= 0 {
+		return defaultBookPageSize
+	}
+	return min(size, maxBookPageSize)
+}
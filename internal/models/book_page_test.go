@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookCursor_RoundTrip(t *testing.T) {
+	c := BookCursor{CreatedAt: time.Date(2025, 10, 26, 9, 30, 0, 123456000, time.UTC), ID: uuid.New()}
+
+	parsed, err := ParseBookCursor(c.String())
+	require.NoError(t, err)
+	assert.True(t, c.CreatedAt.Equal(parsed.CreatedAt))
+	assert.Equal(t, c.ID, parsed.ID)
+
+	for _, bad := range []string{"", "%%%", "bm90LWEtY3Vyc29y"} {
+		_, err := ParseBookCursor(bad)
+		assert.ErrorIs(t, err, ErrDomainValidation, bad)
+	}
+}
+
+func TestNormalizeBookPageSize(t *testing.T) {
+	assert.Equal(t, 50, NormalizeBookPageSize(0))
+	assert.Equal(t, 10, NormalizeBookPageSize(10))
+	assert.Equal(t, 500, NormalizeBookPageSize(10_000))
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"slices"
+	"strings"
+)
+
+// IncludeAuthors — связь книги с автором: имя и число его книг в каталоге.
+const IncludeAuthors = "authors"
+
+// BookIncludes — связи, которые можно встроить в ответ с книгами.
+var BookIncludes = []string{IncludeAuthors}
+
+// FieldCover — миниатюры обложки. Это поле ответа, а не колонка выгрузки:
+// из базы для него читаются CoverColumns.
+const FieldCover = "cover"
+
+var CoverColumns = []string{"cover_version", "cover_width", "cover_height"}
+
+// BookSelection — какие поля книги отдать и какие связи встроить.
+// Пустой Fields означает все поля, id отдаётся всегда.
+type BookSelection struct {
+	Fields  []string
+	Include []string
+}
+
+type BookSelectionParams struct {
+	// Fields и Include — списки через запятую
+	Fields  string
+	Include string
+}
+
+type AuthorSummary struct {
+	Name      string
+	BookCount int
+}
+
+// BookRelations — встроенные связи книг, по ключу связи.
+type BookRelations struct {
+	Authors map[string]AuthorSummary
+}
+
+func NewBookSelection(params BookSelectionParams) (BookSelection, error) {
+	sel := BookSelection{Fields: splitList(params.Fields), Include: splitList(params.Include)}
+	if err := validateBookSelection(sel); err != nil {
+		return BookSelection{}, err
+	}
+	if len(sel.Fields) > 0 && !slices.Contains(sel.Fields, "id") {
+		sel.Fields = append([]string{"id"}, sel.Fields...)
+	}
+	return sel, nil
+}
+
+func validateBookSelection(sel BookSelection) error {
+	var verr ValidationError
+	for i, f := range sel.Fields {
+		switch {
+		case !slices.Contains(BookColumns, f) && f != FieldCover:
+			verr.Addf("fields", FieldUnknown, "unknown field %q", f)
+		case slices.Index(sel.Fields, f) != i:
+			verr.Addf("fields", FieldInvalid, "field %q is listed twice", f)
+		}
+	}
+	for i, inc := range sel.Include {
+		switch {
+		case !slices.Contains(BookIncludes, inc):
+			verr.Addf("include", FieldUnknown, "unknown relation %q, supported: %s", inc, strings.Join(BookIncludes, ", "))
+		case slices.Index(sel.Include, inc) != i:
+			verr.Addf("include", FieldInvalid, "relation %q is listed twice", inc)
+		}
+	}
+	return verr.Err()
+}
+
+// Partial — ответ отличается от полного BookDTO.
+func (s BookSelection) Partial() bool {
+	return len(s.Fields) > 0 || len(s.Include) > 0
+}
+
+func (s BookSelection) HasField(name string) bool {
+	return len(s.Fields) == 0 || slices.Contains(s.Fields, name)
+}
+
+func (s BookSelection) Includes(relation string) bool {
+	return slices.Contains(s.Include, relation)
+}
+
+// Columns — колонки для чтения из базы в порядке BookColumns: выбранные поля
+// и те, без которых не подгрузить связи. Колонки обложки идут последними.
+func (s BookSelection) Columns() []string {
+	columns := make([]string, 0, len(BookColumns)+len(CoverColumns))
+	for _, c := range BookColumns {
+		if s.HasField(c) || (c == "author" && s.Includes(IncludeAuthors)) {
+			columns = append(columns, c)
+		}
+	}
+	if s.HasField(FieldCover) {
+		columns = append(columns, CoverColumns...)
+	}
+	return columns
+}
+
+// splitList разбирает список через запятую, приводя элементы к нижнему регистру.
+func splitList(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		items = append(items, strings.ToLower(strings.TrimSpace(item)))
+	}
+	return items
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBookSelection(t *testing.T) {
+	sel, err := NewBookSelection(BookSelectionParams{})
+	require.NoError(t, err)
+	assert.False(t, sel.Partial())
+	assert.Equal(t, append(slices.Clone(BookColumns), CoverColumns...), sel.Columns())
+
+	sel, err = NewBookSelection(BookSelectionParams{Fields: " Price, title ", Include: "authors"})
+	require.NoError(t, err)
+	assert.True(t, sel.Partial())
+	assert.Equal(t, []string{"id", "price", "title"}, sel.Fields, "id is always returned")
+	assert.Equal(t, []string{"id", "title", "author", "price"}, sel.Columns(), "author is read for the relation")
+	assert.False(t, sel.HasField("author"))
+
+	sel, err = NewBookSelection(BookSelectionParams{Fields: "cover"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "cover_version", "cover_width", "cover_height"}, sel.Columns())
+
+	_, err = NewBookSelection(BookSelectionParams{Fields: "title,title,pages", Include: "categories"})
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr.Fields, 3)
+	assert.Equal(t, FieldInvalid, verr.Fields[0].Code)
+	assert.Equal(t, FieldUnknown, verr.Fields[1].Code)
+	assert.Equal(t, "include", verr.Fields[2].Field)
+}
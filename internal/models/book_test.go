@@ -1,6 +1,9 @@
 package models
 
 import (
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -47,3 +50,81 @@ func TestValidBook(t *testing.T) {
 		})
 	}
 }
+
+func TestValidBook_ReportsEveryField(t *testing.T) {
+	t.Parallel()
+	err := validateBook(BookParams{Price: -1})
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("validateBook() error = %v, want *ValidationError", err)
+	}
+	if !errors.Is(err, ErrDomainValidation) {
+		t.Errorf("validateBook() error does not wrap ErrDomainValidation")
+	}
+
+	var fields []string
+	for _, f := range verr.Fields {
+		fields = append(fields, f.Field)
+	}
+	want := []string{"id", "title", "author", "isbn", "price"}
+	if !slices.Equal(fields, want) {
+		t.Errorf("validateBook() fields = %v, want %v", fields, want)
+	}
+}
+
+func TestNewBook_Normalizes(t *testing.T) {
+	t.Parallel()
+	book, err := NewBook(BookParams{
+		ID:          uuid.New(),
+		Title:       "  Café Society\n",
+		Author:      "\tAmélie ",
+		Description: " Roman ",
+		ISBN:        " 978-5-17-118366-1 ",
+		Price:       10,
+	})
+	if err != nil {
+		t.Fatalf("NewBook() error = %v", err)
+	}
+	if book.Title != "Café Society" {
+		t.Errorf("NewBook() title = %q, want NFC without surrounding spaces", book.Title)
+	}
+	if book.Author != "Amélie" || book.Description != "Roman" || book.ISBN != "978-5-17-118366-1" {
+		t.Errorf("NewBook() = %+v, want trimmed fields", book)
+	}
+}
+
+func TestValidBook_Lengths(t *testing.T) {
+	t.Parallel()
+	valid := BookParams{ID: uuid.New(), Title: "T", Author: "A", ISBN: "1", Price: 1}
+	tests := []struct {
+		name   string
+		modify func(*BookParams)
+		field  string
+	}{
+		{"title at limit in runes", func(b *BookParams) { b.Title = strings.Repeat("я", maxBookTitleLen) }, ""},
+		{"title too long", func(b *BookParams) { b.Title = strings.Repeat("я", maxBookTitleLen+1) }, "title"},
+		{"author too long", func(b *BookParams) { b.Author = strings.Repeat("a", maxBookAuthorLen+1) }, "author"},
+		{"description too long", func(b *BookParams) { b.Description = strings.Repeat("a", maxBookDescriptionLen+1) }, "description"},
+		{"blank title", func(b *BookParams) { b.Title = "   " }, "title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			params := valid
+			tt.modify(&params)
+			_, err := NewBook(params)
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("NewBook() error = %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) || len(verr.Fields) != 1 || verr.Fields[0].Field != tt.field {
+				t.Errorf("NewBook() error = %v, want single %s violation", err, tt.field)
+			}
+		})
+	}
+}
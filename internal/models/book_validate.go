@@ -1,26 +1,27 @@
 package models
 
 import (
-	"fmt"
-
 	"github.com/google/uuid"
 )
 
+const (
+	maxBookTitleLen       = 255
+	maxBookAuthorLen      = 255
+	maxBookDescriptionLen = 5000
+	maxBookISBNLen        = 32
+)
+
 func validateBook(book BookParams) error {
+	var verr ValidationError
 	if book.ID == uuid.Nil {
-		return fmt.Errorf("%w: book id is required", ErrDomainValidation)
-	}
-	if book.Title == "" {
-		return fmt.Errorf("%w: book title is required", ErrDomainValidation)
-	}
-	if book.Author == "" {
-		return fmt.Errorf("%w: book author is required", ErrDomainValidation)
-	}
-	if book.ISBN == "" {
-		return fmt.Errorf("%w: book isbn is required", ErrDomainValidation)
+		verr.Add("id", FieldRequired, "book id is required")
 	}
+	checkText(&verr, "title", "book title", book.Title, true, maxBookTitleLen)
+	checkText(&verr, "author", "book author", book.Author, true, maxBookAuthorLen)
+	checkText(&verr, "description", "book description", book.Description, false, maxBookDescriptionLen)
+	checkText(&verr, "isbn", "book isbn", book.ISBN, true, maxBookISBNLen)
 	if book.Price < 0 {
-		return fmt.Errorf("%w: book price is negative", ErrDomainValidation)
+		verr.Add("price", FieldNegative, "book price is negative")
 	}
-	return nil
+	return verr.Err()
 }
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Форматы миниатюр обложки.
+const (
+	CoverJPEG = "jpeg"
+	CoverWebP = "webp"
+)
+
+// Пределы загружаемой обложки: меньше CoverMinSide — слишком мелко для витрины,
+// больше CoverMaxSide — защита от изображений, которые раздуваются при декодировании.
+const (
+	CoverMinSide = 100
+	CoverMaxSide = 10000
+)
+
+// CoverSize — миниатюра шириной Width; узкие обложки не растягиваются.
+type CoverSize struct {
+	Name  string
+	Width int
+}
+
+var (
+	CoverSizes   = []CoverSize{{"small", 160}, {"medium", 400}, {"large", 800}}
+	CoverFormats = []string{CoverJPEG, CoverWebP}
+	// CoverContentTypes — что принимается на загрузку; тип определяется по содержимому файла.
+	CoverContentTypes = []string{"image/jpeg", "image/png", "image/webp"}
+)
+
+var coverVersionRe = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// BookCover — загруженная обложка. Version меняется при каждой загрузке, поэтому
+// URL миниатюр неизменяемы и их можно кэшировать навсегда. Width и Height — размер
+// исходника после поворота по EXIF. Пустой Version — обложки нет.
+type BookCover struct {
+	Version string
+	Width   int
+	Height  int
+}
+
+// CoverImage — одна миниатюра обложки.
+type CoverImage struct {
+	Size   string
+	Format string
+	Width  int
+	Height int
+	// Path — ключ в хранилище и путь URL относительно API
+	Path string
+}
+
+// Blob — объект из хранилища обложек. Body закрывает получатель.
+type Blob struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+func (c BookCover) Empty() bool {
+	return c.Version == ""
+}
+
+// Images перечисляет миниатюры обложки книги id во всех размерах и форматах.
+func (c BookCover) Images(id uuid.UUID) []CoverImage {
+	if c.Empty() {
+		return nil
+	}
+	images := make([]CoverImage, 0, len(CoverSizes)*len(CoverFormats))
+	for _, size := range CoverSizes {
+		w, h := c.Scaled(size.Width)
+		for _, format := range CoverFormats {
+			images = append(images, CoverImage{
+				Size:   size.Name,
+				Format: format,
+				Width:  w,
+				Height: h,
+				Path:   CoverPath(id, c.Version, size.Name, format),
+			})
+		}
+	}
+	return images
+}
+
+// Scaled — размер миниатюры шириной не больше width с сохранением пропорций.
+func (c BookCover) Scaled(width int) (int, int) {
+	if c.Width <= width {
+		return c.Width, c.Height
+	}
+	return width, max(1, (c.Height*width+c.Width/2)/c.Width)
+}
+
+func CoverPath(id uuid.UUID, version, size, format string) string {
+	return fmt.Sprintf("covers/%s/%s/%s.%s", id, version, size, CoverExt(format))
+}
+
+// ParseCoverPath проверяет части URL миниатюры и собирает из них ключ хранилища.
+// Неизвестные размеры, форматы и версии не доходят до хранилища.
+func ParseCoverPath(id, version, file string) (string, bool) {
+	uid, err := uuid.Parse(id)
+	if err != nil || !coverVersionRe.MatchString(version) {
+		return "", false
+	}
+	name, ext, _ := strings.Cut(file, ".")
+	for _, size := range CoverSizes {
+		for _, format := range CoverFormats {
+			if size.Name == name && CoverExt(format) == ext {
+				return CoverPath(uid, version, name, format), true
+			}
+		}
+	}
+	return "", false
+}
+
+func CoverExt(format string) string {
+	if format == CoverJPEG {
+		return "jpg"
+	}
+	return format
+}
+
+func CoverContentType(format string) string {
+	return "image/" + format
+}
+
+// ValidateCoverSize проверяет размер исходника в пикселях.
+func ValidateCoverSize(width, height int) error {
+	var verr ValidationError
+	switch {
+	case width < CoverMinSide || height < CoverMinSide:
+		verr.Addf("file", FieldTooShort, "image must be at least %dx%d pixels", CoverMinSide, CoverMinSide)
+	case width > CoverMaxSide || height > CoverMaxSide:
+		verr.Addf("file", FieldTooLong, "image must be at most %dx%d pixels", CoverMaxSide, CoverMaxSide)
+	}
+	return verr.Err()
+}
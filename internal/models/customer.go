@@ -0,0 +1,131 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TokenPurpose string
+
+const (
+	TokenEmailVerification TokenPurpose = "email_verification"
+	TokenPasswordReset     TokenPurpose = "password_reset"
+)
+
+type Customer struct {
+	ID            uuid.UUID
+	Email         string
+	PasswordHash  string
+	FullName      string
+	Phone         string
+	EmailVerified bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type RegistrationParams struct {
+	Email    string
+	Password string
+	FullName string
+	Phone    string
+}
+
+type ProfileParams struct {
+	ID       uuid.UUID
+	FullName string
+	Phone    string
+}
+
+type Address struct {
+	ID         uuid.UUID
+	CustomerID uuid.UUID
+	Label      string
+	Recipient  string
+	Line1      string
+	Line2      string
+	City       string
+	PostalCode string
+	Country    string
+	IsDefault  bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type AddressParams struct {
+	ID         uuid.UUID
+	CustomerID uuid.UUID
+	Label      string
+	Recipient  string
+	Line1      string
+	Line2      string
+	City       string
+	PostalCode string
+	Country    string
+	IsDefault  bool
+}
+
+// CustomerToken хранит только хэш одноразового токена из письма.
+type CustomerToken struct {
+	Hash       string
+	CustomerID uuid.UUID
+	Purpose    TokenPurpose
+	ExpiresAt  time.Time
+}
+
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func NewRegistration(params RegistrationParams) (RegistrationParams, error) {
+	params.Email = NormalizeEmail(params.Email)
+	params.FullName = strings.TrimSpace(params.FullName)
+	params.Phone = strings.TrimSpace(params.Phone)
+	if err := validateRegistration(params); err != nil {
+		return RegistrationParams{}, err
+	}
+
+	return params, nil
+}
+
+func NewProfile(params ProfileParams) (ProfileParams, error) {
+	params.FullName = strings.TrimSpace(params.FullName)
+	params.Phone = strings.TrimSpace(params.Phone)
+	if err := validateProfile(params); err != nil {
+		return ProfileParams{}, err
+	}
+
+	return params, nil
+}
+
+func NewAddress(params AddressParams) (Address, error) {
+	params.Country = strings.ToUpper(strings.TrimSpace(params.Country))
+	if err := validateAddress(params); err != nil {
+		return Address{}, err
+	}
+
+	return Address{
+		ID:         params.ID,
+		CustomerID: params.CustomerID,
+		Label:      params.Label,
+		Recipient:  params.Recipient,
+		Line1:      params.Line1,
+		Line2:      params.Line2,
+		City:       params.City,
+		PostalCode: params.PostalCode,
+		Country:    params.Country,
+		IsDefault:  params.IsDefault,
+	}, nil
+}
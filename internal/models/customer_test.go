@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRegistration(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		params  RegistrationParams
+		wantErr bool
+	}{
+		{"valid", RegistrationParams{Email: " Reader@Example.com ", Password: "long-enough", FullName: "Reader"}, false},
+		{"invalid email", RegistrationParams{Email: "reader", Password: "long-enough", FullName: "Reader"}, true},
+		{"email with name", RegistrationParams{Email: "Reader <reader@example.com>", Password: "long-enough", FullName: "Reader"}, true},
+		{"short password", RegistrationParams{Email: "reader@example.com", Password: "short", FullName: "Reader"}, true},
+		{"no name", RegistrationParams{Email: "reader@example.com", Password: "long-enough"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewRegistration(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewRegistration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Email != "reader@example.com" {
+				t.Errorf("expected normalized email, got %q", got.Email)
+			}
+		})
+	}
+}
+
+func TestNewAddress(t *testing.T) {
+	t.Parallel()
+	valid := AddressParams{
+		ID:         uuid.New(),
+		CustomerID: uuid.New(),
+		Recipient:  "Reader",
+		Line1:      "Main st. 1",
+		City:       "Berlin",
+		Country:    "de",
+	}
+
+	addr, err := NewAddress(valid)
+	if err != nil {
+		t.Fatalf("NewAddress() unexpected error = %v", err)
+	}
+	if addr.Country != "DE" {
+		t.Errorf("expected upper-case country, got %q", addr.Country)
+	}
+
+	invalid := valid
+	invalid.City = ""
+	if _, err := NewAddress(invalid); err == nil {
+		t.Errorf("expected error for address without city")
+	}
+}
+
+func TestNewRegistration_ReportsEveryField(t *testing.T) {
+	t.Parallel()
+	_, err := NewRegistration(RegistrationParams{Email: "reader", Password: "short"})
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("NewRegistration() error = %v, want *ValidationError", err)
+	}
+	var got []string
+	for _, f := range verr.Fields {
+		got = append(got, f.Field+":"+f.Code)
+	}
+	want := []string{"email:invalid", "full_name:required", "password:too_short"}
+	if !slices.Equal(got, want) {
+		t.Errorf("NewRegistration() violations = %v, want %v", got, want)
+	}
+}
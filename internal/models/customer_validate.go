@@ -0,0 +1,76 @@
+package models
+
+import (
+	"net/mail"
+
+	"github.com/google/uuid"
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt учитывает только первые 72 байта пароля
+	maxPasswordLength = 72
+)
+
+func ValidatePassword(password string) error {
+	var verr ValidationError
+	checkPassword(&verr, password)
+	return verr.Err()
+}
+
+func checkPassword(verr *ValidationError, password string) {
+	if len(password) < minPasswordLength {
+		verr.Addf("password", FieldTooShort, "password must be at least %d characters", minPasswordLength)
+	}
+	if len(password) > maxPasswordLength {
+		verr.Addf("password", FieldTooLong, "password must be at most %d bytes", maxPasswordLength)
+	}
+}
+
+func validateRegistration(params RegistrationParams) error {
+	var verr ValidationError
+	if params.Email == "" {
+		verr.Add("email", FieldRequired, "email is required")
+	} else if addr, err := mail.ParseAddress(params.Email); err != nil || addr.Address != params.Email {
+		verr.Add("email", FieldInvalid, "email is invalid")
+	}
+	if params.FullName == "" {
+		verr.Add("full_name", FieldRequired, "full name is required")
+	}
+	checkPassword(&verr, params.Password)
+	return verr.Err()
+}
+
+func validateProfile(params ProfileParams) error {
+	var verr ValidationError
+	if params.ID == uuid.Nil {
+		verr.Add("id", FieldRequired, "customer id is required")
+	}
+	if params.FullName == "" {
+		verr.Add("full_name", FieldRequired, "full name is required")
+	}
+	return verr.Err()
+}
+
+func validateAddress(params AddressParams) error {
+	var verr ValidationError
+	if params.ID == uuid.Nil {
+		verr.Add("id", FieldRequired, "address id is required")
+	}
+	if params.CustomerID == uuid.Nil {
+		verr.Add("customer_id", FieldRequired, "customer id is required")
+	}
+	if params.Recipient == "" {
+		verr.Add("recipient", FieldRequired, "recipient is required")
+	}
+	if params.Line1 == "" {
+		verr.Add("line1", FieldRequired, "address line is required")
+	}
+	if params.City == "" {
+		verr.Add("city", FieldRequired, "city is required")
+	}
+	if len(params.Country) != 2 {
+		verr.Add("country", FieldInvalid, "country must be ISO 3166-1 alpha-2 code")
+	}
+	return verr.Err()
+}
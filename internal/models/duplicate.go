@@ -0,0 +1,179 @@
+package models
+
+import (
+	"bytes"
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultDuplicateLimit = 50
+	maxDuplicateLimit     = 500
+
+	// DefaultDuplicateScore — порог, от которого пара считается кандидатом.
+	DefaultDuplicateScore = 0.6
+)
+
+// NormalizeDuplicateLimit подставляет размер выдачи по умолчанию и ограничивает максимум.
+func NormalizeDuplicateLimit(limit int) int {
+	if limit <= 0 {
+		return defaultDuplicateLimit
+	}
+	return min(limit, maxDuplicateLimit)
+}
+
+// DuplicatePair — оценка похожести двух книг. BookIDs[0] — книга, созданная раньше:
+// её обычно и оставляют каноничной.
+type DuplicatePair struct {
+	BookIDs          [2]uuid.UUID
+	Score            float64
+	ISBNMatch        bool
+	TitleSimilarity  float64
+	AuthorSimilarity float64
+}
+
+// DuplicateScan — результат последнего прохода по каталогу.
+type DuplicateScan struct {
+	ScannedAt time.Time
+	Books     int
+	Pairs     []DuplicatePair
+}
+
+type DuplicateCandidate struct {
+	Pair  DuplicatePair
+	Books [2]Book
+}
+
+type DuplicateReport struct {
+	ScannedAt  time.Time
+	Candidates []DuplicateCandidate
+}
+
+// Trigrams — множество триграмм строки как у pg_trgm: регистр не важен, каждое слово
+// из букв и цифр дополняется двумя пробелами слева и одним справа.
+func Trigrams(s string) []string {
+	var out []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(NormalizeText(s)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			out = append(out, string(runes[i:i+3]))
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// Similarity — доля общих триграмм (индекс Жаккара) двух отсортированных множеств.
+func Similarity(a, b []string) float64 {
+	shared := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch c := strings.Compare(a[i], b[j]); {
+		case c == 0:
+			shared++
+			i++
+			j++
+		case c < 0:
+			i++
+		default:
+			j++
+		}
+	}
+	if len(a)+len(b)-shared == 0 {
+		return 0
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// DuplicateScore сводит признаки в оценку от 0 до 1. Совпавший ISBN-13 сам по себе
+// даёт 0.7; без него всё решают название (60%) и автор (40%).
+func DuplicateScore(isbnMatch bool, title, author float64) float64 {
+	if isbnMatch {
+		return 0.7 + 0.15*title + 0.15*author
+	}
+	return 0.6*title + 0.4*author
+}
+
+// FindDuplicates ищет пары с оценкой не ниже minScore; книги ожидаются в порядке
+// создания. Без общего ISBN-13 оценка не выше 0.4 + 0.6·сходство названий, поэтому
+// minScore задаёт нижнюю границу сходства названий, и пары ищутся префиксным
+// фильтром: если сходство не ниже t, то среди первых len-⌈t·len⌉+1 самых редких
+// триграмм названий есть общая. Так не сравнивается каждая книга с каждой.
+func FindDuplicates(books []Book, minScore float64) []DuplicatePair {
+	minTitle := max((minScore-0.4)/0.6, 0)
+
+	titles := make([][]string, len(books))
+	freq := map[string]int{}
+	for i, b := range books {
+		titles[i] = Trigrams(b.Title)
+		for _, t := range titles[i] {
+			freq[t]++
+		}
+	}
+
+	var (
+		pairs     []DuplicatePair
+		byTrigram = map[string][]int{}
+		byISBN    = map[string][]int{}
+		isbns     = make([]string, len(books))
+		authors   = make([][]string, len(books))
+	)
+	for i, b := range books {
+		isbns[i], _ = ISBN13(b.ISBN)
+		authors[i] = Trigrams(b.Author)
+
+		prefix := slices.Clone(titles[i])
+		slices.SortFunc(prefix, func(a, b string) int {
+			return cmp.Or(cmp.Compare(freq[a], freq[b]), strings.Compare(a, b))
+		})
+		prefix = prefix[:min(len(prefix)-int(math.Ceil(minTitle*float64(len(prefix))))+1, len(prefix))]
+
+		seen := map[int]bool{}
+		var probe []int
+		for _, t := range prefix {
+			probe = append(probe, byTrigram[t]...)
+		}
+		if isbns[i] != "" {
+			probe = append(probe, byISBN[isbns[i]]...)
+		}
+		for _, j := range probe {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+			p := DuplicatePair{
+				BookIDs:          [2]uuid.UUID{books[j].ID, b.ID},
+				ISBNMatch:        isbns[i] != "" && isbns[i] == isbns[j],
+				TitleSimilarity:  Similarity(titles[i], titles[j]),
+				AuthorSimilarity: Similarity(authors[i], authors[j]),
+			}
+			p.Score = DuplicateScore(p.ISBNMatch, p.TitleSimilarity, p.AuthorSimilarity)
+			if p.Score >= minScore {
+				pairs = append(pairs, p)
+			}
+		}
+
+		for _, t := range prefix {
+			byTrigram[t] = append(byTrigram[t], i)
+		}
+		if isbns[i] != "" {
+			byISBN[isbns[i]] = append(byISBN[isbns[i]], i)
+		}
+	}
+
+	slices.SortFunc(pairs, func(a, b DuplicatePair) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			bytes.Compare(a.BookIDs[0][:], b.BookIDs[0][:]),
+			bytes.Compare(a.BookIDs[1][:], b.BookIDs[1][:]),
+		)
+	})
+	return pairs
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrigrams(t *testing.T) {
+	assert.Equal(t, []string{"  c", " ca", "at ", "cat"}, Trigrams("Cat"))
+	assert.Equal(t, Trigrams("Пикник на обочине"), Trigrams("пикник: на  обочине!"))
+	assert.Empty(t, Trigrams(" — "))
+
+	assert.InDelta(t, 1, Similarity(Trigrams("Лев Толстой"), Trigrams("Толстой, Лев")), 1e-9, "word order does not matter")
+	assert.Zero(t, Similarity(Trigrams("Солярис"), Trigrams("Дюна")))
+	assert.Zero(t, Similarity(nil, nil))
+}
+
+func TestFindDuplicates(t *testing.T) {
+	book := func(title, author, isbn string) Book {
+		return Book{ID: uuid.New(), Title: title, Author: author, ISBN: isbn}
+	}
+	books := []Book{
+		book("Пикник на обочине", "Аркадий и Борис Стругацкие", "978-5-17-118366-0"),
+		book("Солярис", "Станислав Лем", "978-5-17-090329-2"),
+		book("Пикник на обочине (повесть)", "Стругацкие А. и Б.", ""),
+		book("Roadside Picnic", "Strugatsky", "5-17-118366-X"),
+		book("Солярис", "Лем Станислав", ""),
+		book("Непобедимый", "Станислав Лем", ""),
+	}
+
+	pairs := FindDuplicates(books, DefaultDuplicateScore)
+	require.Len(t, pairs, 3, "%+v", pairs)
+
+	assert.Equal(t, [2]uuid.UUID{books[1].ID, books[4].ID}, pairs[0].BookIDs)
+	assert.InDelta(t, 1, pairs[0].Score, 1e-9)
+	assert.False(t, pairs[0].ISBNMatch)
+
+	assert.Equal(t, [2]uuid.UUID{books[0].ID, books[3].ID}, pairs[1].BookIDs, "ISBN-10 and ISBN-13 of the same book")
+	assert.True(t, pairs[1].ISBNMatch)
+	assert.GreaterOrEqual(t, pairs[1].Score, 0.7)
+
+	assert.Equal(t, [2]uuid.UUID{books[0].ID, books[2].ID}, pairs[2].BookIDs)
+	assert.Greater(t, pairs[2].TitleSimilarity, 0.6)
+
+	assert.Empty(t, FindDuplicates(books, 1.01))
+}
+
+// Префиксный фильтр не должен терять пары, которые нашёл бы полный перебор.
+func TestFindDuplicates_MatchesBruteForce(t *testing.T) {
+	words := []string{"пикник", "обочина", "солярис", "улитка", "склон", "понедельник", "суббота", "трудно", "богом", "жук"}
+	var books []Book
+	for i := range 60 {
+		books = append(books, Book{
+			ID:     uuid.New(),
+			Title:  fmt.Sprintf("%s %s %s", words[i%10], words[i*3%10], words[i*7%9]),
+			Author: fmt.Sprintf("Автор %c", 'А'+i%4),
+		})
+	}
+	for _, minScore := range []float64{0.45, DefaultDuplicateScore, 0.8} {
+		want := 0
+		for i := range books {
+			for j := range i {
+				title := Similarity(Trigrams(books[i].Title), Trigrams(books[j].Title))
+				author := Similarity(Trigrams(books[i].Author), Trigrams(books[j].Author))
+				if DuplicateScore(false, title, author) >= minScore {
+					want++
+				}
+			}
+		}
+		assert.Len(t, FindDuplicates(books, minScore), want, "min score %v", minScore)
+	}
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var ErrMetadataNotFound = errors.New("metadata not found")
+
+// Поля книги, которые можно заполнить из внешнего источника метаданных.
+const (
+	SuggestTitle       = "title"
+	SuggestAuthor      = "author"
+	SuggestDescription = "description"
+	SuggestCover       = "cover"
+)
+
+var SuggestFields = []string{SuggestTitle, SuggestAuthor, SuggestDescription, SuggestCover}
+
+// BookMetadata — сведения о книге из библиографического источника. PageCount
+// только показывается: число страниц в каталоге не хранится.
+type BookMetadata struct {
+	Source      string
+	ISBN        string
+	Title       string
+	Author      string
+	Description string
+	PageCount   int
+	CoverURL    string
+}
+
+// MetadataLookup — ответ источника для кэша; Found=false запоминает, что книги у источника нет.
+type MetadataLookup struct {
+	Found    bool
+	Metadata BookMetadata
+}
+
+// FieldSuggestion — предлагаемое значение поля рядом с текущим. Для обложки
+// Suggested — адрес изображения у источника.
+type FieldSuggestion struct {
+	Field     string
+	Current   string
+	Suggested string
+}
+
+type BookEnrichment struct {
+	BookID      uuid.UUID
+	Metadata    BookMetadata
+	Suggestions []FieldSuggestion
+}
+
+// Suggest сравнивает книгу с метаданными. Предлагаются только поля, которые источник
+// знает и которые отличаются от текущих; обложка — только книге без обложки.
+func Suggest(b Book, m BookMetadata) []FieldSuggestion {
+	var out []FieldSuggestion
+	text := func(field, current, suggested string) {
+		suggested = NormalizeText(suggested)
+		if suggested != "" && suggested != NormalizeText(current) {
+			out = append(out, FieldSuggestion{Field: field, Current: current, Suggested: suggested})
+		}
+	}
+	text(SuggestTitle, b.Title, m.Title)
+	text(SuggestAuthor, b.Author, m.Author)
+	text(SuggestDescription, b.Description, m.Description)
+	if m.CoverURL != "" && b.Cover.Empty() {
+		out = append(out, FieldSuggestion{Field: SuggestCover, Suggested: m.CoverURL})
+	}
+	return out
+}
+
+// ParseSuggestFields проверяет список принимаемых полей; повторы отбрасываются.
+func ParseSuggestFields(fields []string) ([]string, error) {
+	verr := &ValidationError{}
+	if len(fields) == 0 {
+		verr.Add("fields", FieldRequired, "fields are required")
+	}
+	var out []string
+	for _, f := range fields {
+		if !slices.Contains(SuggestFields, f) {
+			verr.Addf("fields", FieldUnknown, "%q is not one of %v", f, SuggestFields)
+			continue
+		}
+		if !slices.Contains(out, f) {
+			out = append(out, f)
+		}
+	}
+	if err := verr.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApplySuggestions переносит принятые текстовые поля в параметры книги;
+// обложка загружается отдельно.
+func ApplySuggestions(b Book, suggestions []FieldSuggestion, fields []string) BookParams {
+	params := BookParams(b)
+	for _, s := range suggestions {
+		if !slices.Contains(fields, s.Field) {
+			continue
+		}
+		switch s.Field {
+		case SuggestTitle:
+			params.Title = s.Suggested
+		case SuggestAuthor:
+			params.Author = s.Suggested
+		case SuggestDescription:
+			params.Description = s.Suggested
+		}
+	}
+	return params
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggest(t *testing.T) {
+	meta := BookMetadata{Title: " Солярис ", Author: "Станислав Лем", CoverURL: "https://covers.example/1.jpg"}
+
+	got := Suggest(Book{Title: "Солярис", Author: "Лем"}, meta)
+	assert.Equal(t, []FieldSuggestion{
+		{Field: SuggestAuthor, Current: "Лем", Suggested: "Станислав Лем"},
+		{Field: SuggestCover, Suggested: "https://covers.example/1.jpg"},
+	}, got, "title differs only by spaces, empty description is not suggested")
+
+	withCover := Book{Title: "Солярис", Author: "Станислав Лем", Cover: BookCover{Version: "00112233aabbccdd", Width: 100, Height: 100}}
+	assert.Empty(t, Suggest(withCover, meta), "uploaded cover is not replaced")
+}
+
+func TestParseSuggestFields(t *testing.T) {
+	fields, err := ParseSuggestFields([]string{"description", "cover", "description"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"description", "cover"}, fields)
+
+	_, err = ParseSuggestFields(nil)
+	assert.ErrorIs(t, err, ErrDomainValidation)
+	_, err = ParseSuggestFields([]string{"price"})
+	assert.ErrorContains(t, err, `"price"`)
+}
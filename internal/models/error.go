@@ -2,4 +2,8 @@ package models
 
 import "errors"
 
-var ErrDomainValidation = errors.New("domain validation error")
+var (
+	ErrDomainValidation = errors.New("domain validation error")
+	ErrTaxRateNotFound  = errors.New("tax rate not found")
+	ErrBlobNotFound     = errors.New("blob not found")
+)
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventBookCreated  = "BookCreated"
+	EventBookUpdated  = "BookUpdated"
+	EventBookDeleted  = "BookDeleted"
+	EventPriceChanged = "PriceChanged"
+
+	AggregateBook = "book"
+)
+
+var eventTypes = []string{EventBookCreated, EventBookUpdated, EventBookDeleted, EventPriceChanged}
+
+func IsEventType(t string) bool {
+	return slices.Contains(eventTypes, t)
+}
+
+// DomainEvent пишется в outbox в одной транзакции с изменением агрегата.
+type DomainEvent struct {
+	ID            uuid.UUID
+	AggregateType string
+	AggregateID   string
+	Type          string
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+}
+
+// EventEnvelope — представление события для внешних получателей (вебхуки, лента изменений).
+type EventEnvelope struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func (e DomainEvent) Envelope() json.RawMessage {
+	data, _ := json.Marshal(EventEnvelope{
+		ID:         e.ID,
+		Type:       e.Type,
+		OccurredAt: e.OccurredAt,
+		Data:       e.Payload,
+	})
+	return data
+}
+
+// OutboxMessage — событие из outbox вместе с состоянием доставки.
+type OutboxMessage struct {
+	ID        int64
+	Event     DomainEvent
+	Attempts  int
+	LastError string
+}
+
+type BookEventPayload struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Author      string    `json:"author"`
+	ISBN        string    `json:"isbn"`
+	Price       int       `json:"price"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type PriceChangedPayload struct {
+	BookID   uuid.UUID `json:"book_id"`
+	OldPrice int       `json:"old_price"`
+	NewPrice int       `json:"new_price"`
+}
+
+func BookCreatedEvent(b Book) DomainEvent {
+	return newBookEvent(EventBookCreated, b.ID, bookPayload(b))
+}
+
+func BookDeletedEvent(b Book) DomainEvent {
+	return newBookEvent(EventBookDeleted, b.ID, bookPayload(b))
+}
+
+// BookChangedEvents возвращает BookUpdated и, если изменилась цена, PriceChanged.
+func BookChangedEvents(old, updated Book) []DomainEvent {
+	events := []DomainEvent{newBookEvent(EventBookUpdated, updated.ID, bookPayload(updated))}
+	if old.Price != updated.Price {
+		events = append(events, newBookEvent(EventPriceChanged, updated.ID, PriceChangedPayload{
+			BookID:   updated.ID,
+			OldPrice: old.Price,
+			NewPrice: updated.Price,
+		}))
+	}
+	return events
+}
+
+func bookPayload(b Book) BookEventPayload {
+	return BookEventPayload{
+		ID:          b.ID,
+		Title:       b.Title,
+		Description: b.Description,
+		Author:      b.Author,
+		ISBN:        b.ISBN,
+		Price:       b.Price,
+		UpdatedAt:   b.UpdatedAt.UTC(),
+	}
+}
+
+func newBookEvent(eventType string, id uuid.UUID, payload any) DomainEvent {
+	// payload состоит из простых полей, ошибка маршалинга невозможна
+	data, _ := json.Marshal(payload)
+	return DomainEvent{
+		ID:            uuid.New(),
+		AggregateType: AggregateBook,
+		AggregateID:   id.String(),
+		Type:          eventType,
+		Payload:       data,
+		OccurredAt:    time.Now().UTC(),
+	}
+}
+
+// RetryPolicy задаёт экспоненциальную задержку между попытками публикации.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Backoff — задержка перед попыткой номер attempt+1 после attempt неудачных.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// Exhausted сообщает, что сообщение пора переносить в dead letter.
+func (p RetryPolicy) Exhausted(attempts int) bool {
+	return attempts >= p.MaxAttempts
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookChangedEvents(t *testing.T) {
+	old := Book{ID: uuid.New(), Title: "Old", Price: 100}
+
+	t.Run("same price", func(t *testing.T) {
+		updated := old
+		updated.Title = "New"
+		events := BookChangedEvents(old, updated)
+		require.Len(t, events, 1)
+		assert.Equal(t, EventBookUpdated, events[0].Type)
+		assert.Equal(t, old.ID.String(), events[0].AggregateID)
+	})
+
+	t.Run("price changed", func(t *testing.T) {
+		updated := old
+		updated.Price = 150
+		events := BookChangedEvents(old, updated)
+		require.Len(t, events, 2)
+		assert.Equal(t, EventPriceChanged, events[1].Type)
+
+		var payload PriceChangedPayload
+		require.NoError(t, json.Unmarshal(events[1].Payload, &payload))
+		assert.Equal(t, PriceChangedPayload{BookID: old.ID, OldPrice: 100, NewPrice: 150}, payload)
+	})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	assert.Equal(t, time.Second, p.Backoff(1))
+	assert.Equal(t, 2*time.Second, p.Backoff(2))
+	assert.Equal(t, 8*time.Second, p.Backoff(4))
+	assert.Equal(t, 10*time.Second, p.Backoff(5))
+	assert.Equal(t, 10*time.Second, p.Backoff(50))
+	assert.False(t, p.Exhausted(4))
+	assert.True(t, p.Exhausted(5))
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"cmp"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// FeedEvent — событие ленты изменений каталога. ID — идентификатор записи
+// Redis Stream вида "<ms>-<seq>", он же id события SSE для Last-Event-ID.
+type FeedEvent struct {
+	ID   string
+	Type string
+	Data json.RawMessage
+}
+
+// ValidFeedID проверяет формат "<ms>-<seq>".
+func ValidFeedID(id string) bool {
+	_, _, ok := parseFeedID(id)
+	return ok
+}
+
+// CompareFeedIDs сравнивает идентификаторы в порядке ленты, как strings.Compare.
+// Оба идентификатора должны быть валидны.
+func CompareFeedIDs(a, b string) int {
+	ams, aseq, _ := parseFeedID(a)
+	bms, bseq, _ := parseFeedID(b)
+	if ams != bms {
+		return cmp.Compare(ams, bms)
+	}
+	return cmp.Compare(aseq, bseq)
+}
+
+func parseFeedID(id string) (ms, seq uint64, ok bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareFeedIDs(t *testing.T) {
+	assert.Equal(t, 0, CompareFeedIDs("1700000000000-0", "1700000000000-0"))
+	assert.Equal(t, -1, CompareFeedIDs("1700000000000-2", "1700000000000-10"), "sequence is numeric, not lexical")
+	assert.Equal(t, 1, CompareFeedIDs("1700000000001-0", "1700000000000-99"))
+	assert.Equal(t, -1, CompareFeedIDs("999-0", "1000-0"))
+}
+
+func TestValidFeedID(t *testing.T) {
+	assert.True(t, ValidFeedID("1700000000000-0"))
+	for _, bad := range []string{"", "1700000000000", "abc-1", "1-x", "-1", "1--1"} {
+		assert.False(t, ValidFeedID(bad), bad)
+	}
+}
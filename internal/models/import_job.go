@@ -0,0 +1,182 @@
+package models
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportFormat string
+
+const (
+	ImportCSV  ImportFormat = "csv"
+	ImportXLSX ImportFormat = "xlsx"
+	// ImportONIX — сообщение ONIX 3.0; колонки и лист к нему не относятся
+	ImportONIX ImportFormat = "onix"
+)
+
+type ImportStatus string
+
+const (
+	ImportPending   ImportStatus = "pending"
+	ImportRunning   ImportStatus = "running"
+	ImportCompleted ImportStatus = "completed"
+	ImportFailed    ImportStatus = "failed"
+)
+
+// MaxImportRowErrors ограничивает отчёт об ошибках: счётчик Failed при этом
+// учитывает все строки, а в RowErrors попадают первые.
+const MaxImportRowErrors = 1000
+
+// ImportColumns — поля книги, которые можно сопоставить колонкам файла.
+var ImportColumns = []string{"title", "author", "description", "isbn", "price"}
+
+var requiredImportColumns = []string{"title", "author", "isbn", "price"}
+
+type ImportOptions struct {
+	// Mapping — поле книги -> заголовок колонки; не указанные поля ищутся по своему имени
+	Mapping map[string]string `json:"mapping,omitempty"`
+	// Delimiter — разделитель CSV, по умолчанию запятая
+	Delimiter string `json:"delimiter,omitempty"`
+	// Sheet — лист XLSX, по умолчанию первый
+	Sheet string `json:"sheet,omitempty"`
+	// UpsertByISBN обновляет книгу с тем же ISBN вместо создания новой
+	UpsertByISBN bool `json:"upsert_by_isbn"`
+	// DryRun только проверяет строки и считает, что было бы создано и обновлено
+	DryRun bool `json:"dry_run"`
+}
+
+// Column возвращает заголовок колонки для поля книги.
+func (o ImportOptions) Column(field string) string {
+	if c, ok := o.Mapping[field]; ok {
+		return c
+	}
+	return field
+}
+
+type ImportRowError struct {
+	Row int `json:"row"`
+	// Record — RecordReference продукта ONIX
+	Record  string       `json:"record,omitempty"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+type ImportJob struct {
+	ID       uuid.UUID
+	Status   ImportStatus
+	Format   ImportFormat
+	FileName string
+	Options  ImportOptions
+	// CreatedBy — кто загрузил файл; от его имени пишутся книги и аудит
+	CreatedByType string
+	CreatedBy     string
+	TotalRows     int
+	// ProcessedRows — строки, обработанные записанными пачками; с них продолжается задача после сбоя воркера
+	ProcessedRows int
+	Created       int
+	Updated       int
+	Deleted       int
+	Failed        int
+	RowErrors     []ImportRowError
+	Error         string
+	CreatedAt     time.Time
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+}
+
+// ImportTask — задача, взятая воркером, вместе с содержимым файла.
+type ImportTask struct {
+	Job  ImportJob
+	File []byte
+}
+
+type ImportJobParams struct {
+	FileName      string
+	Format        ImportFormat
+	File          []byte
+	Options       ImportOptions
+	CreatedByType string
+	CreatedBy     string
+}
+
+func NewImportJob(params ImportJobParams) (ImportJob, error) {
+	if params.Format == "" {
+		params.Format = ImportFormat(strings.TrimPrefix(strings.ToLower(path.Ext(params.FileName)), "."))
+		if params.Format == "xml" {
+			params.Format = ImportONIX
+		}
+	}
+	if params.Format == ImportONIX {
+		// продукты ONIX адресуются по ISBN: повторная поставка обновляет книги, а не дублирует
+		params.Options.UpsertByISBN = true
+	}
+	if err := validateImportJob(params); err != nil {
+		return ImportJob{}, err
+	}
+
+	return ImportJob{
+		ID:            uuid.New(),
+		Status:        ImportPending,
+		Format:        params.Format,
+		FileName:      params.FileName,
+		Options:       params.Options,
+		CreatedByType: params.CreatedByType,
+		CreatedBy:     params.CreatedBy,
+	}, nil
+}
+
+func validateImportJob(params ImportJobParams) error {
+	var verr ValidationError
+	switch params.Format {
+	case ImportCSV, ImportXLSX, ImportONIX:
+	default:
+		verr.Addf("format", FieldUnknown, "format must be %q, %q or %q", ImportCSV, ImportXLSX, ImportONIX)
+	}
+	if len(params.File) == 0 {
+		verr.Add("file", FieldRequired, "file is required")
+	}
+	for _, field := range slices.Sorted(maps.Keys(params.Options.Mapping)) {
+		column := params.Options.Mapping[field]
+		if !slices.Contains(ImportColumns, field) {
+			verr.Addf("mapping."+field, FieldUnknown, "unknown book field %q", field)
+		} else if strings.TrimSpace(column) == "" {
+			verr.Add("mapping."+field, FieldRequired, "column name is required")
+		}
+	}
+	if d := params.Options.Delimiter; d != "" && len([]rune(d)) != 1 {
+		verr.Add("delimiter", FieldInvalid, "delimiter must be a single character")
+	}
+	return verr.Err()
+}
+
+// ImportHeader сопоставляет поля книги индексам колонок по строке заголовка.
+// Регистр и пробелы вокруг заголовков не учитываются.
+func ImportHeader(header []string, opts ImportOptions) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	var verr ValidationError
+	columns := make(map[string]int, len(ImportColumns))
+	for _, field := range ImportColumns {
+		name := opts.Column(field)
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			if slices.Contains(requiredImportColumns, field) {
+				verr.Addf("mapping."+field, FieldRequired, "column %q not found in header", name)
+			}
+			continue
+		}
+		columns[field] = i
+	}
+	return columns, verr.Err()
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidISBN = errors.New("invalid isbn")
+
+// ISBN13 приводит ISBN-10 или ISBN-13 в любой записи (дефисы, пробелы, префикс "ISBN"
+// с меткой формата или без: "ISBN-13: 978…") к 13 цифрам без разделителей и проверяет
+// контрольную цифру.
+func ISBN13(raw string) (string, error) {
+	s := strings.ToUpper(strings.TrimSpace(raw))
+	if rest, ok := strings.CutPrefix(s, "ISBN"); ok {
+		s = trimISBNLabel(strings.TrimLeft(rest, " "))
+	}
+	s = strings.TrimLeft(s, ":- ")
+
+	digits := make([]byte, 0, 13)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9', c == 'X' && len(digits) == 9 && i == len(s)-1:
+			digits = append(digits, c)
+		case c == '-' || c == ' ':
+		default:
+			return "", ErrInvalidISBN
+		}
+	}
+
+	switch len(digits) {
+	case 10:
+		if isbn10Check(digits[:9]) != digits[9] {
+			return "", ErrInvalidISBN
+		}
+		isbn := append([]byte("978"), digits[:9]...)
+		return string(append(isbn, isbn13Check(isbn))), nil
+	case 13:
+		if digits[12] == 'X' || isbn13Check(digits[:12]) != digits[12] {
+			return "", ErrInvalidISBN
+		}
+		return string(digits), nil
+	}
+	return "", ErrInvalidISBN
+}
+
+// trimISBNLabel убирает "-13"/"-10" после "ISBN". Метка без дефиса ("ISBN13:", "ISBN 10:")
+// снимается, только если за ней идёт разделитель, чтобы не съесть первые цифры номера.
+func trimISBNLabel(s string) string {
+	for _, label := range []string{"-13", "-10", "13", "10"} {
+		rest, ok := strings.CutPrefix(s, label)
+		if !ok {
+			continue
+		}
+		if label[0] == '-' || rest == "" || rest[0] == ':' || rest[0] == ' ' {
+			return rest
+		}
+	}
+	return s
+}
+
+func isbn10Check(d []byte) byte {
+	sum := 0
+	for i, c := range d {
+		sum += (10 - i) * int(c-'0')
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+	return byte('0' + check)
+}
+
+func isbn13Check(d []byte) byte {
+	sum := 0
+	for i, c := range d {
+		w := 1
+		if i%2 == 1 {
+			w = 3
+		}
+		sum += w * int(c-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
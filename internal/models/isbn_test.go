@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestISBN13(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"978-5-17-118366-0", "9785171183660", false},
+		{"ISBN: 978 5 17 118366 0", "9785171183660", false},
+		{"ISBN-13: 978-5-17-118366-0", "9785171183660", false},
+		{"isbn-13 9785171183660", "9785171183660", false},
+		{"ISBN13: 978-5-17-118366-0", "9785171183660", false},
+		{"ISBN-10: 0-306-40615-2", "9780306406157", false},
+		{"ISBN 10: 0-306-40615-2", "9780306406157", false},
+		{"ISBN1000000001", "9781000000009", false},
+		{"0-306-40615-2", "9780306406157", false},
+		{"0-8044-2957-x", "9780804429573", false},
+		{"978-5-17-118366-1", "", true},
+		{"0-306-40615-3", "", true},
+		{"X-306-40615-2", "", true},
+		{"kdslf1", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			t.Parallel()
+			got, err := ISBN13(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ISBN13() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidISBN) {
+				t.Errorf("ISBN13() error = %v, want ErrInvalidISBN", err)
+			}
+			if got != tt.want {
+				t.Errorf("ISBN13() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
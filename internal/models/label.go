@@ -0,0 +1,35 @@
+package models
+
+import "github.com/google/uuid"
+
+// MaxLabelBooks — этикеток в одном запросе: десять листов A4 по 24.
+const MaxLabelBooks = 240
+
+// ParseLabelIDs разбирает id книг для листа этикеток. Повтор id — несколько
+// этикеток одной книги, порядок сохраняется.
+func ParseLabelIDs(raw []string) ([]uuid.UUID, error) {
+	verr := &ValidationError{}
+	switch {
+	case len(raw) == 0:
+		verr.Add("ids", FieldRequired, "ids are required")
+	case len(raw) > MaxLabelBooks:
+		verr.Addf("ids", FieldTooLong, "at most %d ids are allowed", MaxLabelBooks)
+	}
+	if err := verr.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, s := range raw {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			verr.Addf("ids", FieldInvalid, "%q is not a valid uuid", s)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if err := verr.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
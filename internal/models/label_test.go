@@ -0,0 +1,32 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelIDs(t *testing.T) {
+	id := uuid.New()
+	ids, err := ParseLabelIDs([]string{id.String(), id.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{id, id}, ids)
+
+	for name, tc := range map[string]struct {
+		raw  []string
+		code string
+	}{
+		"empty":     {nil, FieldRequired},
+		"too many":  {strings.Split(strings.Repeat(id.String()+",", MaxLabelBooks), ","), FieldTooLong},
+		"not uuids": {[]string{id.String(), "42"}, FieldInvalid},
+	} {
+		_, err := ParseLabelIDs(tc.raw)
+		var verr *ValidationError
+		require.True(t, errors.As(err, &verr), name)
+		assert.Equal(t, tc.code, verr.Fields[0].Code, name)
+	}
+}
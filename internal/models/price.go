@@ -0,0 +1,19 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatMinorUnits записывает целую цену в минимальных единицах десятичной дробью
+// с decimals знаками после точки: 125050 и 2 дают "1250.50".
+func FormatMinorUnits(v, decimals int) string {
+	s := strconv.Itoa(v)
+	if decimals <= 0 {
+		return s
+	}
+	if len(s) <= decimals {
+		s = strings.Repeat("0", decimals-len(s)+1) + s
+	}
+	return s[:len(s)-decimals] + "." + s[len(s)-decimals:]
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// StaffLoginState живёт между редиректом на IdP и callback, ключ — параметр state.
+type StaffLoginState struct {
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// StaffLoginRedirect — адрес авторизации на IdP и state, который должен вернуться
+// в callback из того же браузера.
+type StaffLoginRedirect struct {
+	AuthURL   string
+	State     string
+	ExpiresAt time.Time
+}
+
+// StaffIdentity — проверенные claims из ID token корпоративного IdP.
+type StaffIdentity struct {
+	Subject string
+	Email   string
+	Name    string
+	Groups  []string
+}
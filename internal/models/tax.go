@@ -0,0 +1,129 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Базисные пункты: 2000 = 20%
+const rateBasis = 10000
+
+type ProductFormat string
+
+const (
+	FormatPrint     ProductFormat = "print"
+	FormatEbook     ProductFormat = "ebook"
+	FormatAudiobook ProductFormat = "audiobook"
+)
+
+func (f ProductFormat) Valid() bool {
+	switch f {
+	case FormatPrint, FormatEbook, FormatAudiobook:
+		return true
+	default:
+		return false
+	}
+}
+
+type TaxRate struct {
+	Country       string
+	Format        ProductFormat
+	RateBP        int
+	EffectiveFrom time.Time
+}
+
+type TaxLineParams struct {
+	BookID   uuid.UUID
+	Format   ProductFormat
+	Quantity int
+}
+
+type TaxQuoteParams struct {
+	Country string
+	Date    time.Time
+	Lines   []TaxLineParams
+}
+
+type TaxLine struct {
+	BookID    uuid.UUID
+	Format    ProductFormat
+	Quantity  int
+	UnitPrice int
+	RateBP    int
+	Net       int
+	Tax       int
+	Gross     int
+}
+
+type TaxQuote struct {
+	Country          string
+	Date             time.Time
+	PricesIncludeTax bool
+	Lines            []TaxLine
+	Net              int
+	Tax              int
+	Gross            int
+}
+
+func NewTaxQuoteParams(params TaxQuoteParams) (TaxQuoteParams, error) {
+	if err := validateTaxQuote(params); err != nil {
+		return TaxQuoteParams{}, err
+	}
+
+	return params, nil
+}
+
+// CalculateTax раскладывает сумму строки на net/tax/gross.
+// Если цена включает налог, налог выделяется из неё, иначе начисляется сверху.
+func CalculateTax(unitPrice, quantity, rateBP int, pricesIncludeTax bool) (net, tax, gross int) {
+	amount := unitPrice * quantity
+	if pricesIncludeTax {
+		gross = amount
+		net = roundDiv(gross*rateBasis, rateBasis+rateBP)
+		return net, gross - net, gross
+	}
+
+	net = amount
+	tax = roundDiv(net*rateBP, rateBasis)
+	return net, tax, net + tax
+}
+
+// EffectiveRate выбирает ставку с самой поздней датой вступления в силу, не позже date.
+func EffectiveRate(rates []TaxRate, date time.Time) (TaxRate, bool) {
+	var (
+		best  TaxRate
+		found bool
+	)
+	for _, r := range rates {
+		if r.EffectiveFrom.After(date) {
+			continue
+		}
+		if !found || r.EffectiveFrom.After(best.EffectiveFrom) {
+			best, found = r, true
+		}
+	}
+
+	return best, found
+}
+
+func NewTaxQuote(country string, date time.Time, pricesIncludeTax bool, lines []TaxLine) TaxQuote {
+	quote := TaxQuote{
+		Country:          country,
+		Date:             date,
+		PricesIncludeTax: pricesIncludeTax,
+		Lines:            lines,
+	}
+	for _, l := range lines {
+		quote.Net += l.Net
+		quote.Tax += l.Tax
+		quote.Gross += l.Gross
+	}
+
+	return quote
+}
+
+// roundDiv делит с округлением половины вверх, аргументы неотрицательные.
+func roundDiv(a, b int) int {
+	return (a + b/2) / b
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateTax(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		price     int
+		quantity  int
+		rateBP    int
+		inclusive bool
+		wantNet   int
+		wantTax   int
+		wantGross int
+	}{
+		{"exclusive 20%", 1000, 2, 2000, false, 2000, 400, 2400},
+		{"inclusive 20%", 1200, 1, 2000, true, 1000, 200, 1200},
+		{"inclusive 10% rounding", 999, 1, 1000, true, 908, 91, 999},
+		{"exclusive 5.5% rounding", 1999, 1, 550, false, 1999, 110, 2109},
+		{"zero rate", 500, 3, 0, true, 1500, 0, 1500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			net, tax, gross := CalculateTax(tt.price, tt.quantity, tt.rateBP, tt.inclusive)
+			assert.Equal(t, tt.wantNet, net)
+			assert.Equal(t, tt.wantTax, tax)
+			assert.Equal(t, tt.wantGross, gross)
+		})
+	}
+}
+
+func TestEffectiveRate(t *testing.T) {
+	t.Parallel()
+	rates := []TaxRate{
+		{Country: "DE", Format: FormatEbook, RateBP: 1900, EffectiveFrom: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Country: "DE", Format: FormatEbook, RateBP: 700, EffectiveFrom: time.Date(2019, 12, 18, 0, 0, 0, 0, time.UTC)},
+	}
+
+	rate, ok := EffectiveRate(rates, time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, 1900, rate.RateBP)
+
+	rate, ok = EffectiveRate(rates, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, 700, rate.RateBP)
+
+	_, ok = EffectiveRate(rates, time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestValidateTaxQuote(t *testing.T) {
+	t.Parallel()
+	line := TaxLineParams{BookID: uuid.New(), Format: FormatPrint, Quantity: 1}
+	tests := []struct {
+		name    string
+		params  TaxQuoteParams
+		wantErr bool
+	}{
+		{"valid", TaxQuoteParams{Country: "DE", Lines: []TaxLineParams{line}}, false},
+		{"bad country", TaxQuoteParams{Country: "DEU", Lines: []TaxLineParams{line}}, true},
+		{"no lines", TaxQuoteParams{Country: "DE"}, true},
+		{"bad format", TaxQuoteParams{Country: "DE", Lines: []TaxLineParams{{BookID: uuid.New(), Format: "vinyl", Quantity: 1}}}, true},
+		{"zero quantity", TaxQuoteParams{Country: "DE", Lines: []TaxLineParams{{BookID: uuid.New(), Format: FormatPrint}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateTaxQuote(tt.params); (err != nil) != tt.wantErr {
+				t.Errorf("validateTaxQuote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func validateTaxQuote(params TaxQuoteParams) error {
+	var verr ValidationError
+	if len(params.Country) != 2 {
+		verr.Add("country", FieldInvalid, "country must be ISO 3166-1 alpha-2 code")
+	}
+	if len(params.Lines) == 0 {
+		verr.Add("lines", FieldRequired, "quote lines are required")
+	}
+	for i, l := range params.Lines {
+		field := fmt.Sprintf("lines[%d]", i)
+		if l.BookID == uuid.Nil {
+			verr.Addf(field+".book_id", FieldRequired, "line %d: book id is required", i)
+		}
+		if !l.Format.Valid() {
+			verr.Addf(field+".format", FieldUnknown, "line %d: unknown product format %q", i, l.Format)
+		}
+		if l.Quantity <= 0 {
+			verr.Addf(field+".quantity", FieldInvalid, "line %d: quantity must be positive", i)
+		}
+	}
+	return verr.Err()
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Коды нарушений полей. Стабильны: клиенты сопоставляют их с текстами у себя.
+const (
+	FieldRequired = "required"
+	FieldNegative = "negative"
+	FieldTooLong  = "too_long"
+	FieldTooShort = "too_short"
+	FieldInvalid  = "invalid"
+	FieldUnknown  = "unknown_value"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError собирает все нарушения, а не только первое.
+// errors.Is(err, ErrDomainValidation) для неё истинно.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Add(field, code, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+func (e *ValidationError) Addf(field, code, format string, args ...any) {
+	e.Add(field, code, fmt.Sprintf(format, args...))
+}
+
+// Err возвращает nil, если нарушений нет.
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Message)
+	}
+	return ErrDomainValidation.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrDomainValidation
+}
+
+// NormalizeText обрезает пробелы по краям и приводит строку к NFC, чтобы одинаково
+// выглядящие названия, набранные с составными и готовыми символами, совпадали.
+func NormalizeText(s string) string {
+	return norm.NFC.String(strings.TrimSpace(s))
+}
+
+// checkText проверяет обязательность и длину в символах, а не в байтах.
+func checkText(verr *ValidationError, field, name, value string, required bool, maxLen int) {
+	switch {
+	case value == "":
+		if required {
+			verr.Addf(field, FieldRequired, "%s is required", name)
+		}
+	case utf8.RuneCountInString(value) > maxLen:
+		verr.Addf(field, FieldTooLong, "%s must be at most %d characters", name, maxLen)
+	}
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookAllEvents в списке типов подписывает на все события.
+const WebhookAllEvents = "*"
+
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+type WebhookSubscription struct {
+	ID         uuid.UUID
+	URL        string
+	EventTypes []string
+	Secret     string
+	Active     bool
+	// Failures — неудачные попытки подряд, по достижении порога подписка отключается
+	Failures   int
+	DisabledAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type WebhookSubscriptionParams struct {
+	ID         uuid.UUID
+	URL        string
+	EventTypes []string
+	Secret     string
+	Active     bool
+}
+
+func NewWebhookSubscription(params WebhookSubscriptionParams) (WebhookSubscription, error) {
+	if err := validateWebhookSubscription(params); err != nil {
+		return WebhookSubscription{}, err
+	}
+
+	return WebhookSubscription{
+		ID:         params.ID,
+		URL:        params.URL,
+		EventTypes: params.EventTypes,
+		Secret:     params.Secret,
+		Active:     params.Active,
+	}, nil
+}
+
+func (s WebhookSubscription) Matches(eventType string) bool {
+	return slices.Contains(s.EventTypes, WebhookAllEvents) || slices.Contains(s.EventTypes, eventType)
+}
+
+// WebhookDelivery — одна доставка события подписчику, она же запись журнала доставок.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	LastAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+	// RedeliveryOf — исходная доставка, если эта создана вручную через redeliver
+	RedeliveryOf *uuid.UUID
+	CreatedAt    time.Time
+}
+
+// WebhookJob — доставка, взятая воркером, вместе с адресом и секретом подписки.
+type WebhookJob struct {
+	Delivery WebhookDelivery
+	URL      string
+	Secret   string
+}
+
+// WebhookAttempt — результат одной попытки отправки.
+type WebhookAttempt struct {
+	DeliveryID     uuid.UUID
+	SubscriptionID uuid.UUID
+	StatusCode     int
+	Err            string
+	At             time.Time
+}
+
+func (a WebhookAttempt) Succeeded() bool {
+	return a.Err == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// NormalizeDeliveryLimit подставляет размер страницы журнала доставок по умолчанию и ограничивает максимум.
+func NormalizeDeliveryLimit(limit int) int {
+	if limit <= 0 {
+		return defaultDeliveryLimit
+	}
+	return min(limit, maxDeliveryLimit)
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+const minWebhookSecretLen = 16
+
+func validateWebhookSubscription(params WebhookSubscriptionParams) error {
+	var verr ValidationError
+	if params.ID == uuid.Nil {
+		verr.Add("id", FieldRequired, "subscription id is required")
+	}
+	u, err := url.Parse(params.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		verr.Add("url", FieldInvalid, "url must be an absolute http(s) url")
+	} else if u.User != nil {
+		verr.Add("url", FieldInvalid, "url must not contain credentials")
+	}
+	if len(params.EventTypes) == 0 {
+		verr.Add("event_types", FieldRequired, "at least one event type is required")
+	}
+	for _, t := range params.EventTypes {
+		if t != WebhookAllEvents && !IsEventType(t) {
+			verr.Addf("event_types", FieldUnknown, "unknown event type %q", t)
+		}
+	}
+	if len(params.Secret) < minWebhookSecretLen {
+		verr.Addf("secret", FieldTooShort, "secret must be at least %d characters", minWebhookSecretLen)
+	}
+	return verr.Err()
+}
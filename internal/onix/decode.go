@@ -0,0 +1,269 @@
+package onix
+
+import (
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"book-store-api/internal/models"
+)
+
+// ErrMalformed — файл не разбирается как сообщение ONIX 3.0.
+var ErrMalformed = errors.New("malformed onix message")
+
+// Config — параметры отображения, общие для импорта и выгрузки.
+type Config struct {
+	SenderName string
+	// RecordPrefix — префикс RecordReference, обычно обратный домен отправителя
+	RecordPrefix string
+	// Currency — валюта цены книги (ISO 4217)
+	Currency string
+	// PriceDecimals — сколько последних разрядов целой цены книги приходится на дробную часть
+	PriceDecimals int
+	// PricesIncludeTax выбирает цену с налогом (тип 02) или без (тип 01)
+	PricesIncludeTax bool
+}
+
+// Record — продукт сообщения, отображённый на параметры книги.
+type Record struct {
+	// Line — строка файла, на которой начинается Product
+	Line      int
+	Reference string
+	// Delete — продукт снят с продажи (NotificationType 05); в Params заполнен только ISBN
+	Delete bool
+	Params models.BookParams
+	// Err — *models.ValidationError со всеми проблемами продукта
+	Err error
+}
+
+// Decode читает сообщение потоково и вызывает fn для каждого Product.
+// Понимает полные и короткие теги. Ошибка fn прерывает чтение и возвращается как есть.
+func Decode(r io.Reader, cfg Config, fn func(Record) error) error {
+	raw := xml.NewDecoder(r)
+	// в аннотациях встречаются HTML-сущности вроде &nbsp;
+	raw.Entity = xml.HTMLEntity
+	dec := xml.NewTokenDecoder(referenceTokens{r: raw})
+
+	root := false
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			if !root {
+				return fmt.Errorf("%w: ONIXMessage element is missing", ErrMalformed)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "ONIXMessage":
+			root = true
+			for _, a := range start.Attr {
+				if a.Name.Local == "release" && !strings.HasPrefix(a.Value, "3.") {
+					return fmt.Errorf("%w: release %s is not supported, expected 3.x", ErrMalformed, a.Value)
+				}
+			}
+		case "Product":
+			line, _ := raw.InputPos()
+			var p Product
+			if err := dec.DecodeElement(&p, &start); err != nil {
+				return fmt.Errorf("%w: %w", ErrMalformed, err)
+			}
+			rec := p.record(cfg)
+			rec.Line = line
+			if err := fn(rec); err != nil {
+				return err
+			}
+		default:
+			if root {
+				if err := dec.Skip(); err != nil {
+					return fmt.Errorf("%w: %w", ErrMalformed, err)
+				}
+			}
+		}
+	}
+}
+
+func (p Product) record(cfg Config) Record {
+	var verr models.ValidationError
+	rec := Record{Reference: strings.TrimSpace(p.RecordReference)}
+	rec.Params.ISBN = p.isbn(&verr)
+
+	switch strings.TrimSpace(p.NotificationType) {
+	case NotificationDelete:
+		rec.Delete = true
+		rec.Err = verr.Err()
+		return rec
+	case NotificationEarly, NotificationAdvance, NotificationConfirm:
+	case NotificationBlock:
+		verr.Add("notification_type", models.FieldInvalid, "block updates (notification type 04) are not supported")
+	case "":
+		verr.Add("notification_type", models.FieldRequired, "NotificationType is required")
+	default:
+		verr.Addf("notification_type", models.FieldUnknown, "unknown notification type %q", p.NotificationType)
+	}
+
+	if p.DescriptiveDetail != nil {
+		rec.Params.Title = p.DescriptiveDetail.title()
+		rec.Params.Author = p.DescriptiveDetail.authors()
+	}
+	if rec.Params.Title == "" {
+		verr.Add("title", models.FieldRequired, "distinctive title (TitleType 01) is missing")
+	}
+	if rec.Params.Author == "" {
+		verr.Add("author", models.FieldRequired, "no contributor with role A01")
+	}
+	if p.CollateralDetail != nil {
+		rec.Params.Description = p.CollateralDetail.description()
+	}
+	rec.Params.Price = p.price(cfg, &verr)
+
+	rec.Err = verr.Err()
+	return rec
+}
+
+// isbn берёт ISBN-13, иначе GTIN-13 книжного диапазона или ISBN-10, и приводит его к 13 цифрам.
+func (p Product) isbn(verr *models.ValidationError) string {
+	for _, idType := range []string{IDTypeISBN13, IDTypeGTIN13, IDTypeISBN10} {
+		for _, id := range p.Identifiers {
+			if strings.TrimSpace(id.ProductIDType) != idType {
+				continue
+			}
+			isbn, err := models.ISBN13(id.IDValue)
+			if err != nil || (idType == IDTypeGTIN13 && !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979")) {
+				verr.Addf("isbn", models.FieldInvalid, "product identifier %q is not a valid ISBN", id.IDValue)
+				return strings.TrimSpace(id.IDValue)
+			}
+			return isbn
+		}
+	}
+	verr.Add("isbn", models.FieldRequired, "product has no ISBN identifier")
+	return ""
+}
+
+func (d DescriptiveDetail) title() string {
+	for _, td := range d.TitleDetails {
+		if strings.TrimSpace(td.TitleType) != TitleTypeDistinctive {
+			continue
+		}
+		for _, e := range td.TitleElements {
+			if strings.TrimSpace(e.TitleElementLevel) == TitleLevelProduct {
+				return e.Title()
+			}
+		}
+		if len(td.TitleElements) > 0 {
+			return td.TitleElements[0].Title()
+		}
+	}
+	return ""
+}
+
+// authors перечисляет авторов через запятую в порядке SequenceNumber.
+func (d DescriptiveDetail) authors() string {
+	contributors := slices.Clone(d.Contributors)
+	slices.SortStableFunc(contributors, func(a, b Contributor) int {
+		return cmp.Compare(a.SequenceNumber, b.SequenceNumber)
+	})
+
+	var names []string
+	for _, c := range contributors {
+		if !slices.ContainsFunc(c.ContributorRole, func(r string) bool { return strings.TrimSpace(r) == RoleAuthor }) {
+			continue
+		}
+		if name := c.Name(); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func (c CollateralDetail) description() string {
+	for _, textType := range []string{TextDescription, TextShortDescription} {
+		for _, tc := range c.TextContents {
+			if strings.TrimSpace(tc.TextType) == textType && tc.Text.Value != "" {
+				return tc.Text.Value
+			}
+		}
+	}
+	return ""
+}
+
+// price выбирает цену в валюте каталога с тем же учётом налога, что и цены книг.
+// Тип и валюта, не указанные в Price, считаются подходящими.
+func (p Product) price(cfg Config, verr *models.ValidationError) int {
+	types := []string{PriceRRPExcludingTax, PriceFixedExcludingTax}
+	if cfg.PricesIncludeTax {
+		types = []string{PriceRRPIncludingTax, PriceFixedIncludingTax}
+	}
+
+	var (
+		best     *Price
+		bestRank int
+		priced   bool
+	)
+	for _, supply := range p.ProductSupply {
+		for _, sd := range supply.SupplyDetails {
+			for i := range sd.Prices {
+				price := &sd.Prices[i]
+				priced = true
+				if c := strings.TrimSpace(price.CurrencyCode); c != "" && !strings.EqualFold(c, cfg.Currency) {
+					continue
+				}
+				priceType := cmp.Or(strings.TrimSpace(price.PriceType), PriceRRPExcludingTax)
+				rank := slices.Index(types, priceType)
+				if rank >= 0 && (best == nil || rank < bestRank) {
+					best, bestRank = price, rank
+				}
+			}
+		}
+	}
+
+	switch {
+	case best != nil:
+		v, err := parsePrice(best.PriceAmount, cfg.PriceDecimals)
+		if err != nil {
+			verr.Addf("price", models.FieldInvalid, "price amount %q: %v", best.PriceAmount, err)
+		}
+		return v
+	case priced:
+		verr.Addf("price", models.FieldInvalid, "no price of type %s in %s", strings.Join(types, " or "), cfg.Currency)
+	default:
+		verr.Add("price", models.FieldRequired, "product has no price")
+	}
+	return 0
+}
+
+func parsePrice(amount string, decimals int) (int, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	frac = strings.TrimRight(frac, "0")
+	if !digits(whole) || (frac != "" && !digits(frac)) {
+		return 0, errors.New("not a decimal number")
+	}
+	if len(frac) > decimals {
+		return 0, fmt.Errorf("more than %d decimal places", decimals)
+	}
+	return strconv.Atoi(whole + frac + strings.Repeat("0", decimals-len(frac)))
+}
+
+func digits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
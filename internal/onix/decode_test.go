@@ -0,0 +1,213 @@
+package onix
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+)
+
+var testConfig = Config{SenderName: "Book Store", Currency: "RUB", PriceDecimals: 2, PricesIncludeTax: true}
+
+func decodeAll(t *testing.T, msg string) []Record {
+	t.Helper()
+	var recs []Record
+	require.NoError(t, Decode(strings.NewReader(msg), testConfig, func(r Record) error {
+		recs = append(recs, r)
+		return nil
+	}))
+	return recs
+}
+
+const referenceMessage = `<?xml version="1.0" encoding="UTF-8"?>
+<ONIXMessage release="3.0" xmlns="http://ns.editeur.org/onix/3.0/reference">
+  <Header><Sender><SenderName>Publisher</SenderName></Sender><SentDateTime>20251029</SentDateTime></Header>
+  <Product>
+    <RecordReference>pub.1</RecordReference>
+    <NotificationType>03</NotificationType>
+    <ProductIdentifier><ProductIDType>02</ProductIDType><IDValue>0-306-40615-2</IDValue></ProductIdentifier>
+    <DescriptiveDetail>
+      <ProductComposition>00</ProductComposition>
+      <ProductForm>BC</ProductForm>
+      <TitleDetail>
+        <TitleType>01</TitleType>
+        <TitleElement><TitleElementLevel>01</TitleElementLevel><TitlePrefix>The</TitlePrefix><TitleWithoutPrefix>Cyberiad</TitleWithoutPrefix></TitleElement>
+      </TitleDetail>
+      <Contributor><SequenceNumber>2</SequenceNumber><ContributorRole>A01</ContributorRole><NamesBeforeKey>Daniel</NamesBeforeKey><KeyNames>Mróz</KeyNames></Contributor>
+      <Contributor><SequenceNumber>3</SequenceNumber><ContributorRole>B06</ContributorRole><PersonName>Michael Kandel</PersonName></Contributor>
+      <Contributor><SequenceNumber>1</SequenceNumber><ContributorRole>A01</ContributorRole><PersonName>Stanisław Lem</PersonName></Contributor>
+      <Subject><SubjectSchemeIdentifier>10</SubjectSchemeIdentifier><SubjectCode>FIC028000</SubjectCode></Subject>
+    </DescriptiveDetail>
+    <CollateralDetail>
+      <TextContent><TextType>02</TextType><ContentAudience>00</ContentAudience><Text>Short</Text></TextContent>
+      <TextContent><TextType>03</TextType><ContentAudience>00</ContentAudience>
+        <Text textformat="05"><p>Fables&nbsp;for the</p><p><em>cybernetic</em> age</p></Text>
+      </TextContent>
+    </CollateralDetail>
+    <ProductSupply><SupplyDetail>
+      <Supplier><SupplierRole>01</SupplierRole><SupplierName>Publisher</SupplierName></Supplier>
+      <ProductAvailability>20</ProductAvailability>
+      <Price><PriceType>01</PriceType><PriceAmount>10.00</PriceAmount><CurrencyCode>RUB</CurrencyCode></Price>
+      <Price><PriceType>02</PriceType><PriceAmount>12.5</PriceAmount><CurrencyCode>EUR</CurrencyCode></Price>
+      <Price><PriceType>02</PriceType><PriceAmount>1250.50</PriceAmount><CurrencyCode>RUB</CurrencyCode></Price>
+    </SupplyDetail></ProductSupply>
+  </Product>
+  <Product>
+    <RecordReference>pub.2</RecordReference>
+    <NotificationType>05</NotificationType>
+    <ProductIdentifier><ProductIDType>15</ProductIDType><IDValue>978-5-17-118366-0</IDValue></ProductIdentifier>
+  </Product>
+</ONIXMessage>`
+
+func TestDecode_ReferenceTags(t *testing.T) {
+	recs := decodeAll(t, referenceMessage)
+	require.Len(t, recs, 2)
+
+	r := recs[0]
+	require.NoError(t, r.Err)
+	assert.Equal(t, "pub.1", r.Reference)
+	assert.Equal(t, 4, r.Line)
+	assert.False(t, r.Delete)
+	assert.Equal(t, models.BookParams{
+		Title:       "The Cyberiad",
+		Author:      "Stanisław Lem, Daniel Mróz",
+		Description: "Fables for the cybernetic age",
+		ISBN:        "9780306406157",
+		Price:       125050,
+	}, r.Params)
+
+	assert.True(t, recs[1].Delete)
+	assert.Equal(t, "9785171183660", recs[1].Params.ISBN)
+	assert.NoError(t, recs[1].Err)
+}
+
+func TestDecode_ShortTags(t *testing.T) {
+	msg := `<ONIXmessage release="3.0" xmlns="http://ns.editeur.org/onix/3.0/short">
+  <header><sender><x298>Publisher</x298></sender></header>
+  <product>
+    <a001>pub.1</a001><a002>03</a002>
+    <productidentifier><b221>15</b221><b244>9785171183660</b244></productidentifier>
+    <descriptivedetail>
+      <titledetail><b202>01</b202><titleelement><x409>01</x409><b203>Пикник на обочине</b203></titleelement></titledetail>
+      <contributor><b034>1</b034><b035>A01</b035><b039>Аркадий</b039><b040>Стругацкий</b040></contributor>
+    </descriptivedetail>
+    <collateraldetail><textcontent><x426>03</x426><x427>00</x427><d104 textformat="02">&lt;p&gt;Повесть &amp;amp; рассказ&lt;/p&gt;</d104></textcontent></collateraldetail>
+    <productsupply><supplydetail><price><j151>350</j151></price></supplydetail></productsupply>
+  </product>
+</ONIXmessage>`
+
+	cfg := testConfig
+	cfg.PricesIncludeTax = false
+	var recs []Record
+	require.NoError(t, Decode(strings.NewReader(msg), cfg, func(r Record) error {
+		recs = append(recs, r)
+		return nil
+	}))
+	require.Len(t, recs, 1)
+	require.NoError(t, recs[0].Err)
+	assert.Equal(t, models.BookParams{
+		Title:       "Пикник на обочине",
+		Author:      "Аркадий Стругацкий",
+		Description: "Повесть & рассказ",
+		ISBN:        "9785171183660",
+		Price:       35000,
+	}, recs[0].Params, "price without type and currency defaults to RRP excluding tax in the catalog currency")
+}
+
+func TestDecode_ReportsEveryProblemPerProduct(t *testing.T) {
+	msg := `<ONIXMessage release="3.0">
+  <Product>
+    <RecordReference>bad</RecordReference>
+    <NotificationType>04</NotificationType>
+    <ProductIdentifier><ProductIDType>15</ProductIDType><IDValue>9785171183661</IDValue></ProductIdentifier>
+    <ProductSupply><SupplyDetail><Price><PriceType>02</PriceType><PriceAmount>9.999</PriceAmount></Price></SupplyDetail></ProductSupply>
+  </Product>
+  <Product>
+    <RecordReference>no-price</RecordReference>
+    <NotificationType>03</NotificationType>
+    <ProductIdentifier><ProductIDType>03</ProductIDType><IDValue>4006381333931</IDValue></ProductIdentifier>
+    <ProductSupply><SupplyDetail><Price><PriceType>02</PriceType><PriceAmount>10</PriceAmount><CurrencyCode>USD</CurrencyCode></Price></SupplyDetail></ProductSupply>
+  </Product>
+</ONIXMessage>`
+
+	recs := decodeAll(t, msg)
+	require.Len(t, recs, 2)
+
+	var verr *models.ValidationError
+	require.ErrorAs(t, recs[0].Err, &verr)
+	assert.Equal(t, []string{"isbn", "notification_type", "title", "author", "price"}, fieldNames(verr))
+	assert.Equal(t, "bad", recs[0].Reference)
+
+	require.ErrorAs(t, recs[1].Err, &verr)
+	assert.Contains(t, fieldNames(verr), "isbn", "GTIN outside the book range is not an ISBN")
+	assert.Contains(t, verr.Error(), "no price of type 02 or 04 in RUB")
+}
+
+func TestDecode_Malformed(t *testing.T) {
+	for name, msg := range map[string]string{
+		"not xml":   "isbn,title\n",
+		"onix 2.1":  `<ONIXMessage release="2.1"><Product/></ONIXMessage>`,
+		"truncated": `<ONIXMessage release="3.0"><Product><RecordReference>1`,
+	} {
+		err := Decode(strings.NewReader(msg), testConfig, func(Record) error { return nil })
+		assert.ErrorIs(t, err, ErrMalformed, name)
+	}
+
+	stop := errors.New("stop")
+	err := Decode(strings.NewReader(referenceMessage), testConfig, func(Record) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	cfg := testConfig
+	cfg.RecordPrefix = "local.bookstore"
+	book := models.Book{
+		ID: uuid.New(), Title: "Solaris", Author: "Stanisław Lem", Description: "Ocean <&> planet -- contact",
+		ISBN: "978-0-306-40615-7", Price: 5,
+	}
+	gone := models.DeletedBook{ID: uuid.New(), ISBN: "broken"}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, cfg)
+	require.NoError(t, w.Begin(time.Date(2025, 10, 29, 9, 30, 0, 0, time.FixedZone("MSK", 3*3600))))
+	require.NoError(t, w.WriteBook(book))
+	err := w.WriteBook(models.Book{ID: uuid.New(), ISBN: "1"})
+	var verr *models.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, []string{"isbn", "title", "author"}, fieldNames(verr))
+	require.NoError(t, w.Comment("skipped -- invalid"))
+	require.NoError(t, w.WriteDeleted(gone))
+	require.NoError(t, w.End())
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, out, `<ONIXMessage xmlns="`+Namespace+`" release="3.0">`)
+	assert.Contains(t, out, "<SentDateTime>20251029T0630Z</SentDateTime>")
+	assert.Contains(t, out, "<!-- skipped - - invalid -->")
+
+	recs := decodeAll(t, out)
+	require.Len(t, recs, 2)
+	require.NoError(t, recs[0].Err)
+	assert.Equal(t, "local.bookstore."+book.ID.String(), recs[0].Reference)
+	assert.Equal(t, models.BookParams{
+		Title: book.Title, Author: book.Author, Description: book.Description, ISBN: "9780306406157", Price: 5,
+	}, recs[0].Params)
+
+	assert.True(t, recs[1].Delete)
+	assert.Error(t, recs[1].Err, "deleted book without a valid ISBN carries only the proprietary id")
+}
+
+func fieldNames(verr *models.ValidationError) []string {
+	names := make([]string, 0, len(verr.Fields))
+	for _, f := range verr.Fields {
+		names = append(names, f.Field)
+	}
+	return names
+}
@@ -0,0 +1,158 @@
+package onix
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"book-store-api/internal/models"
+)
+
+// Writer пишет сообщение потоково: Begin — заголовок, затем продукты по одному, End закрывает корень.
+type Writer struct {
+	enc  *xml.Encoder
+	cfg  Config
+	root xml.StartElement
+}
+
+func NewWriter(w io.Writer, cfg Config) *Writer {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return &Writer{
+		enc: enc,
+		cfg: cfg,
+		root: xml.StartElement{
+			Name: xml.Name{Space: Namespace, Local: "ONIXMessage"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "release"}, Value: Release}},
+		},
+	}
+}
+
+func (w *Writer) Begin(sent time.Time) error {
+	if err := w.enc.EncodeToken(xml.ProcInst{Target: "xml", Inst: []byte(`version="1.0" encoding="UTF-8"`)}); err != nil {
+		return err
+	}
+	if err := w.enc.EncodeToken(w.root); err != nil {
+		return err
+	}
+	return w.enc.EncodeElement(Header{
+		SenderName:   w.cfg.SenderName,
+		SentDateTime: sent.UTC().Format(sentDateTimeLayout),
+	}, xml.StartElement{Name: xml.Name{Local: "Header"}})
+}
+
+// WriteBook пишет книгу как Product с NotificationType 03. Если книгу нельзя
+// выразить валидным продуктом, ничего не пишет и возвращает *models.ValidationError.
+func (w *Writer) WriteBook(b models.Book) error {
+	p, err := BookProduct(b, w.cfg)
+	if err != nil {
+		return err
+	}
+	return w.writeProduct(p)
+}
+
+// WriteDeleted пишет удаление (NotificationType 05) с теми идентификаторами, что известны.
+func (w *Writer) WriteDeleted(d models.DeletedBook) error {
+	return w.writeProduct(Product{
+		RecordReference:  w.cfg.recordReference(d.ID.String()),
+		NotificationType: NotificationDelete,
+		Identifiers:      w.cfg.identifiers(d.ID.String(), d.ISBN),
+	})
+}
+
+// Comment пишет XML-комментарий, например о пропущенном продукте.
+func (w *Writer) Comment(text string) error {
+	// "--" внутри комментария запрещён
+	text = strings.ReplaceAll(text, "--", "- -")
+	return w.enc.EncodeToken(xml.Comment(" " + text + " "))
+}
+
+func (w *Writer) Flush() error {
+	return w.enc.Flush()
+}
+
+func (w *Writer) End() error {
+	if err := w.enc.EncodeToken(w.root.End()); err != nil {
+		return err
+	}
+	return w.enc.Flush()
+}
+
+func (w *Writer) writeProduct(p Product) error {
+	return w.enc.EncodeElement(p, xml.StartElement{Name: xml.Name{Local: "Product"}})
+}
+
+// BookProduct отображает книгу на Product; ISBN приводится к ISBN-13.
+func BookProduct(b models.Book, cfg Config) (Product, error) {
+	var verr models.ValidationError
+	isbn, err := models.ISBN13(b.ISBN)
+	if err != nil {
+		verr.Addf("isbn", models.FieldInvalid, "isbn %q is not a valid ISBN-10 or ISBN-13", b.ISBN)
+	}
+	if strings.TrimSpace(b.Title) == "" {
+		verr.Add("title", models.FieldRequired, "book title is required")
+	}
+	if strings.TrimSpace(b.Author) == "" {
+		verr.Add("author", models.FieldRequired, "book author is required")
+	}
+	if b.Price < 0 {
+		verr.Add("price", models.FieldNegative, "book price is negative")
+	}
+	if err := verr.Err(); err != nil {
+		return Product{}, err
+	}
+
+	priceType := PriceRRPExcludingTax
+	if cfg.PricesIncludeTax {
+		priceType = PriceRRPIncludingTax
+	}
+	p := Product{
+		RecordReference:  cfg.recordReference(b.ID.String()),
+		NotificationType: NotificationConfirm,
+		Identifiers:      cfg.identifiers(b.ID.String(), isbn),
+		DescriptiveDetail: &DescriptiveDetail{
+			ProductComposition: CompositionSingleItem,
+			ProductForm:        FormBook,
+			TitleDetails: []TitleDetail{{
+				TitleType:     TitleTypeDistinctive,
+				TitleElements: []TitleElement{{TitleElementLevel: TitleLevelProduct, TitleText: b.Title}},
+			}},
+			Contributors: []Contributor{{SequenceNumber: 1, ContributorRole: []string{RoleAuthor}, PersonName: b.Author}},
+		},
+		ProductSupply: []ProductSupply{{SupplyDetails: []SupplyDetail{{
+			Supplier:            Supplier{SupplierRole: SupplierPublisher, SupplierName: cfg.SenderName},
+			ProductAvailability: AvailabilityAvailable,
+			Prices: []Price{{
+				PriceType:    priceType,
+				PriceAmount:  models.FormatMinorUnits(b.Price, cfg.PriceDecimals),
+				CurrencyCode: cfg.Currency,
+			}},
+		}}}},
+	}
+	if strings.TrimSpace(b.Description) != "" {
+		p.CollateralDetail = &CollateralDetail{TextContents: []TextContent{{
+			TextType:        TextDescription,
+			ContentAudience: AudienceUnrestricted,
+			Text:            Text{Format: TextFormatDefault, Value: b.Description},
+		}}}
+	}
+	return p, nil
+}
+
+func (c Config) recordReference(id string) string {
+	if c.RecordPrefix == "" {
+		return id
+	}
+	return c.RecordPrefix + "." + id
+}
+
+// identifiers — собственный id книги и ISBN-13, если он валиден.
+func (c Config) identifiers(id, isbn string) []ProductIdentifier {
+	ids := []ProductIdentifier{{ProductIDType: IDTypeProprietary, IDTypeName: fmt.Sprintf("%s ID", c.SenderName), IDValue: id}}
+	if isbn, err := models.ISBN13(isbn); err == nil {
+		ids = append(ids, ProductIdentifier{ProductIDType: IDTypeISBN13, IDValue: isbn})
+	}
+	return ids
+}
@@ -0,0 +1,171 @@
+// Package onix читает и пишет ONIX for Books 3.0. Поддерживается то подмножество
+// сообщения, которое отображается на models.Book: идентификатор, заглавие,
+// авторы, аннотация и цена. Темы (Subject) и прочие блоки при импорте пропускаются.
+package onix
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+const (
+	Namespace = "http://ns.editeur.org/onix/3.0/reference"
+	Release   = "3.0"
+
+	// sentDateTimeLayout — формат SentDateTime из спецификации, всегда в UTC
+	sentDateTimeLayout = "20060102T1504Z"
+)
+
+// Коды из списков EDItEUR, которые используются при отображении.
+const (
+	// список 1
+	NotificationEarly   = "01"
+	NotificationAdvance = "02"
+	NotificationConfirm = "03"
+	NotificationBlock   = "04"
+	NotificationDelete  = "05"
+	// список 5
+	IDTypeProprietary = "01"
+	IDTypeISBN10      = "02"
+	IDTypeGTIN13      = "03"
+	IDTypeISBN13      = "15"
+	// список 15 и 149
+	TitleTypeDistinctive = "01"
+	TitleLevelProduct    = "01"
+	// список 17
+	RoleAuthor = "A01"
+	// список 153 и 154
+	TextShortDescription = "02"
+	TextDescription      = "03"
+	AudienceUnrestricted = "00"
+	// список 34
+	TextFormatHTML    = "02"
+	TextFormatDefault = "06"
+	// список 58
+	PriceRRPExcludingTax   = "01"
+	PriceRRPIncludingTax   = "02"
+	PriceFixedExcludingTax = "03"
+	PriceFixedIncludingTax = "04"
+	// список 2, 150, 93 и 65
+	CompositionSingleItem = "00"
+	FormBook              = "BA"
+	SupplierPublisher     = "01"
+	AvailabilityAvailable = "20"
+)
+
+type Message struct {
+	XMLName  xml.Name  `xml:"ONIXMessage"`
+	Release  string    `xml:"release,attr"`
+	Header   Header    `xml:"Header"`
+	Products []Product `xml:"Product"`
+}
+
+type Header struct {
+	SenderName   string `xml:"Sender>SenderName"`
+	SentDateTime string `xml:"SentDateTime"`
+}
+
+type Product struct {
+	RecordReference   string              `xml:"RecordReference"`
+	NotificationType  string              `xml:"NotificationType"`
+	Identifiers       []ProductIdentifier `xml:"ProductIdentifier"`
+	DescriptiveDetail *DescriptiveDetail  `xml:"DescriptiveDetail"`
+	CollateralDetail  *CollateralDetail   `xml:"CollateralDetail"`
+	ProductSupply     []ProductSupply     `xml:"ProductSupply"`
+}
+
+type ProductIdentifier struct {
+	ProductIDType string `xml:"ProductIDType"`
+	IDTypeName    string `xml:"IDTypeName,omitempty"`
+	IDValue       string `xml:"IDValue"`
+}
+
+type DescriptiveDetail struct {
+	ProductComposition string        `xml:"ProductComposition"`
+	ProductForm        string        `xml:"ProductForm"`
+	TitleDetails       []TitleDetail `xml:"TitleDetail"`
+	Contributors       []Contributor `xml:"Contributor"`
+	Subjects           []Subject     `xml:"Subject"`
+}
+
+type TitleDetail struct {
+	TitleType     string         `xml:"TitleType"`
+	TitleElements []TitleElement `xml:"TitleElement"`
+}
+
+type TitleElement struct {
+	TitleElementLevel  string `xml:"TitleElementLevel"`
+	TitleText          string `xml:"TitleText,omitempty"`
+	TitlePrefix        string `xml:"TitlePrefix,omitempty"`
+	TitleWithoutPrefix string `xml:"TitleWithoutPrefix,omitempty"`
+	Subtitle           string `xml:"Subtitle,omitempty"`
+}
+
+// Title собирает заглавие из TitleText или из префикса и остатка.
+func (e TitleElement) Title() string {
+	if t := strings.TrimSpace(e.TitleText); t != "" {
+		return t
+	}
+	return strings.TrimSpace(strings.TrimSpace(e.TitlePrefix) + " " + strings.TrimSpace(e.TitleWithoutPrefix))
+}
+
+type Contributor struct {
+	SequenceNumber     int      `xml:"SequenceNumber,omitempty"`
+	ContributorRole    []string `xml:"ContributorRole"`
+	PersonName         string   `xml:"PersonName,omitempty"`
+	PersonNameInverted string   `xml:"PersonNameInverted,omitempty"`
+	NamesBeforeKey     string   `xml:"NamesBeforeKey,omitempty"`
+	KeyNames           string   `xml:"KeyNames,omitempty"`
+	CorporateName      string   `xml:"CorporateName,omitempty"`
+}
+
+// Name — имя в прямом порядке, если оно есть в записи.
+func (c Contributor) Name() string {
+	switch {
+	case strings.TrimSpace(c.PersonName) != "":
+		return strings.TrimSpace(c.PersonName)
+	case strings.TrimSpace(c.KeyNames) != "":
+		return strings.TrimSpace(strings.TrimSpace(c.NamesBeforeKey) + " " + strings.TrimSpace(c.KeyNames))
+	case strings.TrimSpace(c.PersonNameInverted) != "":
+		return strings.TrimSpace(c.PersonNameInverted)
+	}
+	return strings.TrimSpace(c.CorporateName)
+}
+
+// Subject читается для полноты записи; в модели книги тем нет.
+type Subject struct {
+	SchemeIdentifier string `xml:"SubjectSchemeIdentifier"`
+	Code             string `xml:"SubjectCode,omitempty"`
+	HeadingText      string `xml:"SubjectHeadingText,omitempty"`
+}
+
+type CollateralDetail struct {
+	TextContents []TextContent `xml:"TextContent"`
+}
+
+type TextContent struct {
+	TextType        string `xml:"TextType"`
+	ContentAudience string `xml:"ContentAudience"`
+	Text            Text   `xml:"Text"`
+}
+
+type ProductSupply struct {
+	SupplyDetails []SupplyDetail `xml:"SupplyDetail"`
+}
+
+type SupplyDetail struct {
+	Supplier            Supplier `xml:"Supplier"`
+	ProductAvailability string   `xml:"ProductAvailability"`
+	Prices              []Price  `xml:"Price"`
+}
+
+type Supplier struct {
+	SupplierRole string `xml:"SupplierRole"`
+	SupplierName string `xml:"SupplierName"`
+}
+
+type Price struct {
+	PriceType    string `xml:"PriceType,omitempty"`
+	PriceAmount  string `xml:"PriceAmount"`
+	CurrencyCode string `xml:"CurrencyCode,omitempty"`
+}
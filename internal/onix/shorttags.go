@@ -0,0 +1,84 @@
+package onix
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// referenceNames переводит короткие теги ONIX в полные для тех элементов,
+// которые читает Product. Составные элементы в коротком виде — это полное имя
+// в нижнем регистре, элементы данных — коды вида b203.
+var referenceNames = func() map[string]string {
+	names := map[string]string{
+		"ONIXmessage": "ONIXMessage",
+		"a001":        "RecordReference",
+		"a002":        "NotificationType",
+		"b221":        "ProductIDType",
+		"b233":        "IDTypeName",
+		"b244":        "IDValue",
+		"x314":        "ProductComposition",
+		"b012":        "ProductForm",
+		"b202":        "TitleType",
+		"x409":        "TitleElementLevel",
+		"b203":        "TitleText",
+		"b030":        "TitlePrefix",
+		"b031":        "TitleWithoutPrefix",
+		"b029":        "Subtitle",
+		"b034":        "SequenceNumber",
+		"b035":        "ContributorRole",
+		"b036":        "PersonName",
+		"b037":        "PersonNameInverted",
+		"b039":        "NamesBeforeKey",
+		"b040":        "KeyNames",
+		"b047":        "CorporateName",
+		"b067":        "SubjectSchemeIdentifier",
+		"b069":        "SubjectCode",
+		"b070":        "SubjectHeadingText",
+		"x426":        "TextType",
+		"x427":        "ContentAudience",
+		"d104":        "Text",
+		"j292":        "SupplierRole",
+		"j137":        "SupplierName",
+		"j396":        "ProductAvailability",
+		"x462":        "PriceType",
+		"j151":        "PriceAmount",
+		"j152":        "CurrencyCode",
+		"x298":        "SenderName",
+		"x307":        "SentDateTime",
+	}
+	for _, composite := range []string{
+		"ONIXMessage", "Header", "Sender", "Product", "ProductIdentifier", "DescriptiveDetail",
+		"TitleDetail", "TitleElement", "Contributor", "Subject", "CollateralDetail", "TextContent",
+		"ProductSupply", "SupplyDetail", "Supplier", "Price",
+	} {
+		names[strings.ToLower(composite)] = composite
+	}
+	return names
+}()
+
+// referenceTokens переименовывает короткие теги на лету, так что один набор
+// структур читает обе формы сообщения. Разметка внутри Text не затрагивается:
+// её имена не пересекаются с кодами ONIX.
+type referenceTokens struct {
+	r xml.TokenReader
+}
+
+func (t referenceTokens) Token() (xml.Token, error) {
+	tok, err := t.r.Token()
+	switch el := tok.(type) {
+	case xml.StartElement:
+		el.Name.Local = referenceName(el.Name.Local)
+		return el, err
+	case xml.EndElement:
+		el.Name.Local = referenceName(el.Name.Local)
+		return el, err
+	}
+	return tok, err
+}
+
+func referenceName(name string) string {
+	if full, ok := referenceNames[name]; ok {
+		return full
+	}
+	return name
+}
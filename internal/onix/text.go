@@ -0,0 +1,55 @@
+package onix
+
+import (
+	"encoding/xml"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Text — аннотация. В поставке она бывает XHTML-разметкой внутри элемента
+// или экранированным HTML; при чтении остаётся только текст.
+type Text struct {
+	Format string `xml:"textformat,attr,omitempty"`
+	Value  string `xml:",chardata"`
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+func (t *Text) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		if a.Name.Local == "textformat" {
+			t.Format = a.Value
+		}
+	}
+
+	var b strings.Builder
+	for depth := 0; ; {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			b.Write(tok)
+		case xml.StartElement:
+			// границы абзацев и переносов не должны склеивать слова
+			b.WriteByte(' ')
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				t.Value = plainText(b.String(), t.Format == TextFormatHTML)
+				return nil
+			}
+			b.WriteByte(' ')
+			depth--
+		}
+	}
+}
+
+func plainText(s string, escapedHTML bool) string {
+	if escapedHTML {
+		s = html.UnescapeString(htmlTag.ReplaceAllString(s, " "))
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
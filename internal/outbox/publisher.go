@@ -0,0 +1,47 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+
+	"book-store-api/internal/models"
+)
+
+// Publisher доставляет событие во внешнюю систему. Доставка at-least-once:
+// получатели должны быть идемпотентны по Event.ID.
+type Publisher interface {
+	Publish(ctx context.Context, event models.DomainEvent) error
+}
+
+// LogPublisher пишет события в лог — для локальной разработки и как заглушка.
+type LogPublisher struct {
+	logger *slog.Logger
+}
+
+func NewLogPublisher(logger *slog.Logger) *LogPublisher {
+	return &LogPublisher{logger: logger}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, e models.DomainEvent) error {
+	p.logger.Info("domain event",
+		"event_id", e.ID,
+		"type", e.Type,
+		"aggregate", e.AggregateType,
+		"aggregate_id", e.AggregateID,
+		"payload", string(e.Payload),
+	)
+	return nil
+}
+
+// MultiPublisher отдаёт событие всем публикаторам по очереди. Ошибка любого из них
+// возвращает событие на повтор целиком, поэтому каждый получатель должен быть идемпотентен.
+type MultiPublisher []Publisher
+
+func (m MultiPublisher) Publish(ctx context.Context, e models.DomainEvent) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
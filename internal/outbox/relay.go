@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"book-store-api/internal/models"
+)
+
+type Store interface {
+	Process(ctx context.Context, limit int, policy models.RetryPolicy, publish func(context.Context, models.OutboxMessage) error) (int, error)
+}
+
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Retry        models.RetryPolicy
+}
+
+// Relay переносит события из outbox в Publisher. Несколько реплик могут работать
+// одновременно: строки разбираются через SKIP LOCKED.
+type Relay struct {
+	store     Store
+	publisher Publisher
+	cfg       RelayConfig
+	logger    *slog.Logger
+}
+
+func NewRelay(store Store, publisher Publisher, cfg RelayConfig, logger *slog.Logger) *Relay {
+	return &Relay{store: store, publisher: publisher, cfg: cfg, logger: logger}
+}
+
+// Run работает до отмены ctx.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain разбирает очередь пачками, пока она не опустеет.
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := r.store.Process(ctx, r.cfg.BatchSize, r.cfg.Retry, r.publish)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Error("outbox relay error", "err", err)
+			}
+			return
+		}
+		if n < r.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+func (r *Relay) publish(ctx context.Context, m models.OutboxMessage) error {
+	err := r.publisher.Publish(ctx, m.Event)
+	if err == nil {
+		return nil
+	}
+
+	if r.cfg.Retry.Exhausted(m.Attempts + 1) {
+		r.logger.Error("outbox event moved to dead letter", "event_id", m.Event.ID, "type", m.Event.Type, "attempts", m.Attempts+1, "err", err)
+	} else {
+		r.logger.Warn("outbox publish failed", "event_id", m.Event.ID, "type", m.Event.Type, "attempt", m.Attempts+1, "err", err)
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+)
+
+var testPolicy = models.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}
+
+func newTestRelay(store Store, publisher Publisher) *Relay {
+	cfg := RelayConfig{PollInterval: time.Hour, BatchSize: 2, Retry: testPolicy}
+	return NewRelay(store, publisher, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func message(aggregateID string, attempts int) models.OutboxMessage {
+	return models.OutboxMessage{
+		ID:       1,
+		Attempts: attempts,
+		Event:    models.DomainEvent{ID: uuid.New(), AggregateType: models.AggregateBook, AggregateID: aggregateID, Type: models.EventBookUpdated},
+	}
+}
+
+func TestRelay_DrainsFullBatches(t *testing.T) {
+	batches := [][]models.OutboxMessage{
+		{message("a", 0), message("b", 0)},
+		{message("a", 0), message("c", 0)},
+		{message("d", 0)},
+	}
+	var published []string
+
+	store := &StoreMock{
+		ProcessFunc: func(ctx context.Context, limit int, policy models.RetryPolicy, publish func(context.Context, models.OutboxMessage) error) (int, error) {
+			assert.Equal(t, 2, limit)
+			assert.Equal(t, testPolicy, policy)
+			batch := batches[0]
+			batches = batches[1:]
+			for _, m := range batch {
+				require.NoError(t, publish(ctx, m))
+			}
+			return len(batch), nil
+		},
+	}
+	publisher := &PublisherMock{
+		PublishFunc: func(ctx context.Context, e models.DomainEvent) error {
+			published = append(published, e.AggregateID)
+			return nil
+		},
+	}
+
+	newTestRelay(store, publisher).drain(context.Background())
+
+	assert.Len(t, store.ProcessCalls(), 3, "stops after a partial batch")
+	assert.Equal(t, []string{"a", "b", "a", "c", "d"}, published)
+}
+
+func TestRelay_PublishErrorIsReturnedToStore(t *testing.T) {
+	boom := errors.New("broker unavailable")
+	var results []error
+
+	store := &StoreMock{
+		ProcessFunc: func(ctx context.Context, limit int, policy models.RetryPolicy, publish func(context.Context, models.OutboxMessage) error) (int, error) {
+			for _, m := range []models.OutboxMessage{message("a", 0), message("b", 2)} {
+				results = append(results, publish(ctx, m))
+			}
+			return 2, nil
+		},
+	}
+	publisher := &PublisherMock{
+		PublishFunc: func(ctx context.Context, e models.DomainEvent) error { return boom },
+	}
+	relay := newTestRelay(store, publisher)
+	relay.cfg.BatchSize = 10
+
+	relay.drain(context.Background())
+
+	require.Len(t, results, 2)
+	assert.ErrorIs(t, results[0], boom, "store schedules a retry")
+	assert.ErrorIs(t, results[1], boom, "store moves the last attempt to dead letter")
+}
+
+func TestRelay_StopsOnStoreError(t *testing.T) {
+	store := &StoreMock{
+		ProcessFunc: func(ctx context.Context, limit int, policy models.RetryPolicy, publish func(context.Context, models.OutboxMessage) error) (int, error) {
+			return 0, errors.New("connection reset")
+		},
+	}
+
+	newTestRelay(store, &PublisherMock{}).drain(context.Background())
+	assert.Len(t, store.ProcessCalls(), 1)
+}
+
+func TestRelay_RunStopsOnCancel(t *testing.T) {
+	store := &StoreMock{
+		ProcessFunc: func(ctx context.Context, limit int, policy models.RetryPolicy, publish func(context.Context, models.OutboxMessage) error) (int, error) {
+			return 0, nil
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		newTestRelay(store, &PublisherMock{}).Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop")
+	}
+}
@@ -0,0 +1,34 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// FallbackLimiter переключается на локальный лимитер, пока primary (Redis) недоступен.
+type FallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	logger   *slog.Logger
+	degraded atomic.Bool
+}
+
+func NewFallbackLimiter(primary, fallback Limiter, logger *slog.Logger) *FallbackLimiter {
+	return &FallbackLimiter{primary: primary, fallback: fallback, logger: logger}
+}
+
+func (l *FallbackLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	res, err := l.primary.Allow(ctx, key, limit)
+	if err == nil {
+		if l.degraded.CompareAndSwap(true, false) {
+			l.logger.Info("rate limiter recovered")
+		}
+		return res, nil
+	}
+
+	if l.degraded.CompareAndSwap(false, true) {
+		l.logger.Warn("rate limiter degraded to in-memory", "err", err)
+	}
+	return l.fallback.Allow(ctx, key, limit)
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+// MemoryLimiter — тот же скользящий лог, но в памяти процесса.
+// Лимит соблюдается только в пределах одной реплики.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	hits      map[string][]time.Time
+	windows   map[string]time.Duration
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		hits:    make(map[string][]time.Time),
+		windows: make(map[string]time.Duration),
+		now:     time.Now,
+	}
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	hits := prune(l.hits[key], now.Add(-limit.Window))
+	allowed := len(hits) < limit.Requests
+	if allowed {
+		hits = append(hits, now)
+	}
+	l.hits[key] = hits
+	l.windows[key] = limit.Window
+
+	reset := limit.Window
+	if len(hits) > 0 {
+		reset = hits[0].Add(limit.Window).Sub(now)
+	}
+	return Result{
+		Allowed:   allowed,
+		Limit:     limit.Requests,
+		Remaining: max(limit.Requests-len(hits), 0),
+		Reset:     reset,
+	}, nil
+}
+
+// sweep удаляет клиентов, у которых окно уже пустое, чтобы карта не росла бесконечно.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, hits := range l.hits {
+		if len(prune(hits, now.Add(-l.windows[key]))) == 0 {
+			delete(l.hits, key)
+			delete(l.windows, key)
+		}
+	}
+}
+
+func prune(hits []time.Time, since time.Time) []time.Time {
+	i := 0
+	for i < len(hits) && !hits[i].After(since) {
+		i++
+	}
+	return hits[i:]
+}
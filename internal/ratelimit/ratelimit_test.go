@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("* ip=100/1m; GET /book  ip=20/30s;* api_key=1000/1m;")
+	require.NoError(t, err)
+
+	limit, matched, ok := rules.Lookup("GET /book", KindIP)
+	assert.True(t, ok)
+	assert.Equal(t, "GET /book", matched)
+	assert.Equal(t, Limit{Requests: 20, Window: 30 * time.Second}, limit)
+
+	limit, matched, ok = rules.Lookup("GET /book/{id}", KindIP)
+	assert.True(t, ok)
+	assert.Equal(t, AnyRoute, matched)
+	assert.Equal(t, 100, limit.Requests)
+
+	_, _, ok = rules.Lookup("GET /book", KindUser)
+	assert.False(t, ok)
+
+	for _, bad := range []string{"GET /book", "* robot=1/1m", "* ip=0/1m", "* ip=10/soon", "ip=10/1m"} {
+		_, err := ParseRules(bad)
+		assert.ErrorIs(t, err, ErrInvalidRule, bad)
+	}
+}
+
+func testSlidingWindow(t *testing.T, l Limiter, advance func(time.Duration)) {
+	t.Helper()
+	ctx := context.Background()
+	limit := Limit{Requests: 3, Window: time.Minute}
+
+	for i := range 3 {
+		res, err := l.Allow(ctx, "ip:10.0.0.1", limit)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2-i, res.Remaining)
+		advance(10 * time.Second)
+	}
+
+	res, err := l.Allow(ctx, "ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 30*time.Second, res.Reset, "first hit leaves the window in 30s")
+
+	other, err := l.Allow(ctx, "ip:10.0.0.2", limit)
+	require.NoError(t, err)
+	assert.True(t, other.Allowed, "clients are limited independently")
+
+	advance(31 * time.Second)
+	res, err = l.Allow(ctx, "ip:10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "oldest hit slid out of the window")
+}
+
+func TestRedisLimiter(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+
+	now := time.Date(2025, 10, 23, 12, 0, 0, 0, time.UTC)
+	l := NewRedisLimiter(client, "ratelimit:")
+	l.now = func() time.Time { return now }
+
+	testSlidingWindow(t, l, func(d time.Duration) { now = now.Add(d) })
+	assert.True(t, srv.Exists("ratelimit:ip:10.0.0.1"))
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	now := time.Date(2025, 10, 23, 12, 0, 0, 0, time.UTC)
+	l := NewMemoryLimiter()
+	l.now = func() time.Time { return now }
+
+	testSlidingWindow(t, l, func(d time.Duration) { now = now.Add(d) })
+
+	now = now.Add(2 * sweepInterval)
+	_, err := l.Allow(context.Background(), "ip:10.0.0.3", Limit{Requests: 1, Window: time.Minute})
+	require.NoError(t, err)
+	assert.NotContains(t, l.hits, "ip:10.0.0.2", "idle clients are swept")
+}
+
+func TestFallbackLimiter(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr(), MaxRetries: -1})
+	l := NewFallbackLimiter(NewRedisLimiter(client, "ratelimit:"), NewMemoryLimiter(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+	limit := Limit{Requests: 1, Window: time.Minute}
+
+	res, err := l.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	srv.Close()
+
+	res, err = l.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "in-memory limiter starts from an empty window")
+	assert.True(t, l.degraded.Load())
+
+	res, err = l.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed, "limit still holds while redis is down")
+}
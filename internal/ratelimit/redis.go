@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript хранит время запросов в ZSET и атомарно проверяет лимит,
+// поэтому все реплики видят общий счётчик.
+// KEYS[1] — ключ клиента, ARGV: now (мкс), window (мкс), limit, member.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', key, math.ceil(window / 1000))
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+local reset = window
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, count, reset}
+`)
+
+type RedisLimiter struct {
+	client redis.Scripter
+	prefix string
+	seq    atomic.Uint64
+	now    func() time.Time
+}
+
+func NewRedisLimiter(client redis.Scripter, prefix string) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: prefix, now: time.Now}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := l.now().UnixMicro()
+	// member должен быть уникальным, иначе одновременные запросы схлопнутся в один
+	member := strconv.FormatInt(now, 10) + "-" + strconv.FormatUint(l.seq.Add(1), 10)
+
+	res, err := slidingWindowScript.Run(ctx, l.client, []string{l.prefix + key},
+		now, limit.Window.Microseconds(), limit.Requests, member,
+	).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	count := int(res[1])
+	return Result{
+		Allowed:   res[0] == 1,
+		Limit:     limit.Requests,
+		Remaining: max(limit.Requests-count, 0),
+		Reset:     time.Duration(res[2]) * time.Microsecond,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind — тип клиента, по которому считается лимит.
+type Kind string
+
+const (
+	KindIP     Kind = "ip"
+	KindUser   Kind = "user"
+	KindAPIKey Kind = "api_key"
+)
+
+// AnyRoute задаёт лимит по умолчанию, общий для всех маршрутов без своего правила.
+const AnyRoute = "*"
+
+var ErrInvalidRule = errors.New("invalid rate limit rule")
+
+type ruleKey struct {
+	route string
+	kind  Kind
+}
+
+type Rules map[ruleKey]Limit
+
+// Lookup возвращает правило маршрута, а если его нет — правило по умолчанию.
+// matched нужен для ключа счётчика: маршруты без своего правила делят общий счётчик.
+func (r Rules) Lookup(route string, kind Kind) (limit Limit, matched string, ok bool) {
+	if limit, ok := r[ruleKey{route: route, kind: kind}]; ok {
+		return limit, route, true
+	}
+	if limit, ok := r[ruleKey{route: AnyRoute, kind: kind}]; ok {
+		return limit, AnyRoute, true
+	}
+	return Limit{}, "", false
+}
+
+// ParseRules разбирает правила вида "GET /book ip=20/1m; * user=300/1m".
+// Маршрут — метод и шаблон пути mux либо "*".
+func ParseRules(s string) (Rules, error) {
+	rules := Rules{}
+	for _, raw := range strings.Split(s, ";") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		idx := strings.LastIndex(raw, " ")
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRule, raw)
+		}
+		route := strings.Join(strings.Fields(raw[:idx]), " ")
+		kindPart, limitPart, found := strings.Cut(raw[idx+1:], "=")
+		if !found || route == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRule, raw)
+		}
+
+		kind := Kind(kindPart)
+		switch kind {
+		case KindIP, KindUser, KindAPIKey:
+		default:
+			return nil, fmt.Errorf("%w: unknown client kind %q", ErrInvalidRule, kindPart)
+		}
+
+		limit, err := ParseLimit(limitPart)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidRule, raw, err)
+		}
+		rules[ruleKey{route: route, kind: kind}] = limit
+	}
+	return rules, nil
+}
+
+// ParseLimit разбирает лимит вида "120/1m".
+func ParseLimit(s string) (Limit, error) {
+	countPart, windowPart, found := strings.Cut(s, "/")
+	if !found {
+		return Limit{}, errors.New("expected <requests>/<window>")
+	}
+	count, err := strconv.Atoi(countPart)
+	if err != nil || count <= 0 {
+		return Limit{}, errors.New("requests must be a positive integer")
+	}
+	window, err := time.ParseDuration(windowPart)
+	if err != nil || window <= 0 {
+		return Limit{}, errors.New("window must be a positive duration")
+	}
+	return Limit{Requests: count, Window: window}, nil
+}
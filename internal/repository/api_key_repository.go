@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const apiKeyColumns = `uuid, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at`
+
+const (
+	insertAPIKeySQL = `INSERT INTO api_keys (uuid, name, prefix, key_hash, scopes, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, NOW())`
+	// время отзыва у уже отозванного раньше ключа не сдвигается
+	revokeAPIKeySQL = `UPDATE api_keys SET revoked_at = LEAST(COALESCE(revoked_at, $2), $2) WHERE uuid=$1`
+)
+
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{pool: pool}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, k models.APIKey) error {
+	_, err := r.pool.Exec(ctx, insertAPIKeySQL, k.ID, k.Name, k.Prefix, k.Hash, k.Scopes, k.ExpiresAt)
+	if isUniqueViolation(err) {
+		return ErrAlreadyExists
+	}
+	return err
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+apiKeyColumns+` FROM api_keys ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *APIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (models.APIKey, error) {
+	return r.getOne(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE uuid=$1`, id)
+}
+
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (models.APIKey, error) {
+	return r.getOne(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE prefix=$1`, prefix)
+}
+
+// Revoke не сдвигает время отзыва у уже отозванного раньше ключа.
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	commandTag, err := r.pool.Exec(ctx, revokeAPIKeySQL, id, at)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Rotate в одной транзакции назначает отзыв старого ключа на revokeAt и сохраняет новый:
+// ключ, секрет которого клиент так и не получил, не должен остаться в базе.
+func (r *APIKeyRepository) Rotate(ctx context.Context, oldID uuid.UUID, revokeAt time.Time, k models.APIKey) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		commandTag, err := tx.Exec(ctx, revokeAPIKeySQL, oldID, revokeAt)
+		if err != nil {
+			return err
+		}
+		if commandTag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+		_, err = tx.Exec(ctx, insertAPIKeySQL, k.ID, k.Name, k.Prefix, k.Hash, k.Scopes, k.ExpiresAt)
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+		return err
+	})
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.pool.Exec(ctx, `UPDATE api_keys SET last_used_at=$2 WHERE uuid=$1`, id, at)
+	return err
+}
+
+func (r *APIKeyRepository) getOne(ctx context.Context, query string, arg any) (models.APIKey, error) {
+	k, err := scanAPIKey(r.pool.QueryRow(ctx, query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.APIKey{}, ErrNotFound
+	}
+	if err != nil {
+		return models.APIKey{}, err
+	}
+	return k, nil
+}
+
+func scanAPIKey(row pgx.Row) (models.APIKey, error) {
+	var k models.APIKey
+	err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.Hash, &k.Scopes, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt)
+	return k, err
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/reqctx"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// auditChainLock — ключ advisory lock, сериализующий запись в цепочку.
+const auditChainLock = 7_310_032
+
+type AuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{pool: pool}
+}
+
+// insertAudit дописывает события в конец цепочки в транзакции изменения, которое они
+// описывают: откат изменения откатывает и событие. Автор, запрос и IP берутся из контекста;
+// без reqctx.Actor изменение не аудируется. Advisory lock держится до конца транзакции,
+// поэтому события попадают в цепочку в порядке фиксации.
+func insertAudit(ctx context.Context, tx pgx.Tx, events ...models.AuditEvent) error {
+	actor, ok := reqctx.ActorFrom(ctx)
+	if !ok || len(events) == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, auditChainLock); err != nil {
+		return err
+	}
+
+	var prevHash string
+	err := tx.QueryRow(ctx, `SELECT hash FROM audit_events ORDER BY id DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	// Postgres хранит микросекунды, иначе хэш не сойдётся после чтения
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	for _, e := range events {
+		e.Actor, e.ActorType = actor.ID, actor.Type
+		e.RequestID, e.IP = reqctx.RequestID(ctx), reqctx.ClientIP(ctx)
+		e.OccurredAt = now
+		e.PrevHash = prevHash
+		e.Hash = e.ComputeHash()
+
+		_, err := tx.Exec(ctx,
+			`INSERT INTO audit_events (actor, actor_type, request_id, ip, entity, entity_id, action, before_state, after_state, occurred_at, prev_hash, hash)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+			e.Actor, e.ActorType, e.RequestID, e.IP, e.Entity, e.EntityID, e.Action,
+			nullableJSON(e.Before), nullableJSON(e.After), e.OccurredAt, e.PrevHash, e.Hash,
+		)
+		if err != nil {
+			return err
+		}
+		prevHash = e.Hash
+	}
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, f models.AuditFilter) ([]models.AuditEvent, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if f.Actor != "" {
+		add("actor = $%d", f.Actor)
+	}
+	if f.Entity != "" {
+		add("entity = $%d", f.Entity)
+	}
+	if f.EntityID != "" {
+		add("entity_id = $%d", f.EntityID)
+	}
+	if f.Action != "" {
+		add("action = $%d", f.Action)
+	}
+	if f.From != nil {
+		add("occurred_at >= $%d", *f.From)
+	}
+	if f.To != nil {
+		add("occurred_at < $%d", *f.To)
+	}
+	if f.BeforeID > 0 {
+		add("id < $%d", f.BeforeID)
+	}
+
+	query := `SELECT ` + auditColumns + ` FROM audit_events`
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit)
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAuditEvents(rows)
+}
+
+// Chain возвращает события по возрастанию id начиная после afterID — для проверки цепочки.
+func (r *AuditRepository) Chain(ctx context.Context, afterID int64, limit int) ([]models.AuditEvent, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+auditColumns+` FROM audit_events WHERE id > $1 ORDER BY id LIMIT $2`, afterID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanAuditEvents(rows)
+}
+
+// DeletedBooks — книги, удалённые (в том числе слиянием) начиная с since и не созданные заново с тем же id.
+// ISBN берётся из состояния до удаления.
+func (r *AuditRepository) DeletedBooks(ctx context.Context, since time.Time) ([]models.DeletedBook, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT DISTINCT ON (e.entity_id) e.entity_id::uuid, COALESCE(e.before_state->>'isbn', ''), e.occurred_at
+		 FROM audit_events e
+		 WHERE e.entity = $1 AND e.action = ANY($2) AND e.occurred_at >= $3
+		   AND NOT EXISTS (SELECT 1 FROM books b WHERE b.uuid = e.entity_id::uuid)
+		 ORDER BY e.entity_id, e.id DESC`,
+		models.AuditEntityBook, []string{models.AuditActionDelete, models.AuditActionMerge}, since,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deleted := []models.DeletedBook{}
+	for rows.Next() {
+		var d models.DeletedBook
+		if err := rows.Scan(&d.ID, &d.ISBN, &d.DeletedAt); err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, d)
+	}
+	return deleted, rows.Err()
+}
+
+const auditColumns = `id, actor, actor_type, request_id, ip, entity, entity_id, action, before_state::text, after_state::text, occurred_at, prev_hash, hash`
+
+func scanAuditEvents(rows pgx.Rows) ([]models.AuditEvent, error) {
+	defer rows.Close()
+
+	events := []models.AuditEvent{}
+	for rows.Next() {
+		var (
+			e             models.AuditEvent
+			before, after *string
+		)
+		if err := rows.Scan(&e.ID, &e.Actor, &e.ActorType, &e.RequestID, &e.IP, &e.Entity, &e.EntityID, &e.Action,
+			&before, &after, &e.OccurredAt, &e.PrevHash, &e.Hash); err != nil {
+			return nil, err
+		}
+		if before != nil {
+			e.Before = []byte(*before)
+		}
+		if after != nil {
+			e.After = []byte(*after)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func nullableJSON(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// SetCover заменяет обложку книги и отдаёт прежнюю, чтобы вызывающий удалил её миниатюры.
+// BookUpdated в outbox и событие аудита пишутся в той же транзакции, как у Update.
+func (r *BookRepository) SetCover(ctx context.Context, id uuid.UUID, cover models.BookCover) (models.BookCover, error) {
+	var old models.Book
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx,
+			`SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM books WHERE uuid=$1 FOR UPDATE`, id,
+		).Scan(&old.ID, &old.Title, &old.Description, &old.Author, &old.ISBN, &old.Price, &old.Cover.Version, &old.Cover.Width, &old.Cover.Height, &old.CreatedAt, &old.UpdatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		updated := old
+		updated.Cover = cover
+		err = tx.QueryRow(ctx,
+			`UPDATE books SET cover_version=$1, cover_width=$2, cover_height=$3, updated_at=NOW() WHERE uuid=$4 RETURNING updated_at`,
+			cover.Version, cover.Width, cover.Height, id,
+		).Scan(&updated.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		if err := insertOutbox(ctx, tx, models.BookChangedEvents(old, updated)...); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, models.BookAuditEvent(models.AuditActionUpdate, id, &old, &updated))
+	})
+	if err != nil {
+		return models.BookCover{}, err
+	}
+	return old.Cover, nil
+}
@@ -7,6 +7,8 @@ import (
 
 	"book-store-api/internal/models"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,17 +20,26 @@ func NewBookRepository(pool *pgxpool.Pool) *BookRepository {
 	return &BookRepository{pool: pool}
 }
 
+// Create сохраняет книгу, событие BookCreated в outbox и событие аудита одной транзакцией.
 func (r *BookRepository) Create(ctx context.Context, book models.Book) error {
-	_, err := r.pool.Exec(ctx,
-		`INSERT INTO books (uuid, title, description, author, isbn, price, created_at, updated_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())`,
-		book.ID, book.Title, book.Description, book.Author, book.ISBN, book.Price,
-	)
-	return err
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx,
+			`INSERT INTO books (uuid, title, description, author, isbn, price, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING created_at, updated_at`,
+			book.ID, book.Title, book.Description, book.Author, book.ISBN, book.Price,
+		).Scan(&book.CreatedAt, &book.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		if err := insertOutbox(ctx, tx, models.BookCreatedEvent(book)); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, models.BookAuditEvent(models.AuditActionCreate, book.ID, nil, &book))
+	})
 }
 
 func (r *BookRepository) GetAll(ctx context.Context) ([]models.Book, error) {
-	rows, err := r.pool.Query(ctx, `SELECT uuid, title, description, author, isbn, price, created_at, updated_at FROM books`)
+	rows, err := r.pool.Query(ctx, `SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM books`)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +48,7 @@ func (r *BookRepository) GetAll(ctx context.Context) ([]models.Book, error) {
 	var books []models.Book
 	for rows.Next() {
 		var b models.Book
-		if err := rows.Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.CreatedAt, &b.UpdatedAt); err != nil {
+		if err := rows.Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.Cover.Version, &b.Cover.Width, &b.Cover.Height, &b.CreatedAt, &b.UpdatedAt); err != nil {
 			return nil, err
 		}
 		books = append(books, b)
@@ -48,8 +59,8 @@ func (r *BookRepository) GetAll(ctx context.Context) ([]models.Book, error) {
 func (r *BookRepository) GetById(ctx context.Context, id string) (models.Book, error) {
 	var b models.Book
 	err := r.pool.QueryRow(ctx,
-		`SELECT uuid, title, description, author, isbn, price, created_at, updated_at FROM books WHERE uuid=$1`, id,
-	).Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.CreatedAt, &b.UpdatedAt)
+		`SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM books WHERE uuid=$1`, id,
+	).Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.Cover.Version, &b.Cover.Width, &b.Cover.Height, &b.CreatedAt, &b.UpdatedAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return models.Book{}, ErrNotFound
 	}
@@ -59,34 +70,95 @@ func (r *BookRepository) GetById(ctx context.Context, id string) (models.Book, e
 	return b, nil
 }
 
-func (r *BookRepository) Update(ctx context.Context, book models.Book) error {
-	commandTag, err := r.pool.Exec(ctx,
-		`UPDATE books SET title=$1, description=$2, author=$3, isbn=$4, price=$5, updated_at=NOW() WHERE uuid=$6`,
-		book.Title, book.Description, book.Author, book.ISBN, book.Price, book.ID,
-	)
-	if err != nil {
-		return err
-	}
-	if commandTag.RowsAffected() == 0 {
-		return ErrNotFound
-	}
-	return nil
+// Update блокирует строку, чтобы PriceChanged считался от актуальной цены.
+// Даты и обложка, которые Update не меняет, дописываются в book из базы.
+// Непустой book.UpdatedAt — условие: если строку с тех пор меняли, вернётся ErrConflict.
+func (r *BookRepository) Update(ctx context.Context, book *models.Book) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var old models.Book
+		err := tx.QueryRow(ctx,
+			`SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM books WHERE uuid=$1 FOR UPDATE`, book.ID,
+		).Scan(&old.ID, &old.Title, &old.Description, &old.Author, &old.ISBN, &old.Price, &old.Cover.Version, &old.Cover.Width, &old.Cover.Height, &old.CreatedAt, &old.UpdatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !book.UpdatedAt.IsZero() && !book.UpdatedAt.Equal(old.UpdatedAt) {
+			return ErrConflict
+		}
+
+		err = tx.QueryRow(ctx,
+			`UPDATE books SET title=$1, description=$2, author=$3, isbn=$4, price=$5, updated_at=NOW() WHERE uuid=$6
+			 RETURNING cover_version, cover_width, cover_height, created_at, updated_at`,
+			book.Title, book.Description, book.Author, book.ISBN, book.Price, book.ID,
+		).Scan(&book.Cover.Version, &book.Cover.Width, &book.Cover.Height, &book.CreatedAt, &book.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		if err := insertOutbox(ctx, tx, models.BookChangedEvents(old, *book)...); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, models.BookAuditEvent(models.AuditActionUpdate, book.ID, &old, book))
+	})
 }
 
 func (r *BookRepository) Delete(ctx context.Context, id string) error {
-	commandTag, err := r.pool.Exec(ctx, `DELETE FROM books WHERE uuid=$1`, id)
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		old, err := deleteBook(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, models.BookAuditEvent(models.AuditActionDelete, old.ID, &old, nil))
+	})
+}
+
+// Merge удаляет дубликат, пока каноничная книга заблокирована от удаления, и пишет
+// событие аудита merge: before — дубликат, after — каноничная книга. Возвращает обе
+// книги: удалённая строка дубликата нужна, чтобы убрать его обложку.
+func (r *BookRepository) Merge(ctx context.Context, canonicalID, duplicateID string) (canonical, duplicate models.Book, err error) {
+	err = pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx,
+			`SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM books WHERE uuid=$1 FOR SHARE`, canonicalID,
+		).Scan(&canonical.ID, &canonical.Title, &canonical.Description, &canonical.Author, &canonical.ISBN, &canonical.Price, &canonical.Cover.Version, &canonical.Cover.Width, &canonical.Cover.Height, &canonical.CreatedAt, &canonical.UpdatedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		duplicate, err = deleteBook(ctx, tx, duplicateID)
+		if err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, models.BookAuditEvent(models.AuditActionMerge, duplicate.ID, &duplicate, &canonical))
+	})
 	if err != nil {
-		return err
+		return models.Book{}, models.Book{}, err
 	}
-	if commandTag.RowsAffected() == 0 {
-		return ErrNotFound
+	return canonical, duplicate, nil
+}
+
+// deleteBook удаляет книгу и пишет BookDeleted в outbox; возвращает удалённую строку.
+func deleteBook(ctx context.Context, tx pgx.Tx, id string) (models.Book, error) {
+	var old models.Book
+	err := tx.QueryRow(ctx,
+		`DELETE FROM books WHERE uuid=$1 RETURNING uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at`, id,
+	).Scan(&old.ID, &old.Title, &old.Description, &old.Author, &old.ISBN, &old.Price, &old.Cover.Version, &old.Cover.Width, &old.Cover.Height, &old.CreatedAt, &old.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Book{}, ErrNotFound
 	}
-	return nil
+	if err != nil {
+		return models.Book{}, err
+	}
+	return old, insertOutbox(ctx, tx, models.BookDeletedEvent(old))
 }
 
 func (r *BookRepository) GetAllWithLimit(ctx context.Context, limit int) ([]models.Book, error) {
 	rows, err := r.pool.Query(ctx,
-		`SELECT uuid, title, description, author, isbn, price, created_at, updated_at FROM books ORDER BY created_at LIMIT $1`, limit,
+		`SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM books ORDER BY created_at LIMIT $1`, limit,
 	)
 	if err != nil {
 		return nil, err
@@ -96,10 +168,193 @@ func (r *BookRepository) GetAllWithLimit(ctx context.Context, limit int) ([]mode
 	var books []models.Book
 	for rows.Next() {
 		var b models.Book
-		if err := rows.Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.CreatedAt, &b.UpdatedAt); err != nil {
+		if err := rows.Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.Cover.Version, &b.Cover.Width, &b.Cover.Height, &b.CreatedAt, &b.UpdatedAt); err != nil {
 			return nil, err
 		}
 		books = append(books, b)
 	}
 	return books, nil
 }
+
+// ListPage — keyset-пагинация по (created_at, uuid): страница не зависит от смещения
+// и не съезжает при вставках в начало.
+func (r *BookRepository) ListPage(ctx context.Context, after *models.BookCursor, limit int) ([]models.Book, error) {
+	query := `SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM books
+		ORDER BY created_at, uuid LIMIT $1`
+	args := []any{limit}
+	if after != nil {
+		query = `SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM books
+		WHERE (created_at, uuid) > ($2, $3)
+		ORDER BY created_at, uuid LIMIT $1`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	books := []models.Book{}
+	for rows.Next() {
+		var b models.Book
+		if err := rows.Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.Cover.Version, &b.Cover.Width, &b.Cover.Height, &b.CreatedAt, &b.UpdatedAt); err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	}
+	return books, rows.Err()
+}
+
+// ListByAuthors одним запросом отдаёт до perAuthor первых книг каждого автора.
+func (r *BookRepository) ListByAuthors(ctx context.Context, authors []string, perAuthor int) ([]models.Book, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM (
+		   SELECT *, row_number() OVER (PARTITION BY author ORDER BY created_at, uuid) AS rn
+		   FROM books WHERE author = ANY($1)
+		 ) b WHERE rn <= $2
+		 ORDER BY author, created_at, uuid`,
+		authors, perAuthor,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	books := []models.Book{}
+	for rows.Next() {
+		var b models.Book
+		if err := rows.Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.Cover.Version, &b.Cover.Width, &b.Cover.Height, &b.CreatedAt, &b.UpdatedAt); err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	}
+	return books, rows.Err()
+}
+
+func (r *BookRepository) CountByAuthors(ctx context.Context, authors []string) (map[string]int, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT author, count(*) FROM books WHERE author = ANY($1) GROUP BY author`, authors,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(authors))
+	for rows.Next() {
+		var author string
+		var n int
+		if err := rows.Scan(&author, &n); err != nil {
+			return nil, err
+		}
+		counts[author] = n
+	}
+	return counts, rows.Err()
+}
+
+// ListByISBNs отдаёт по одной книге на ISBN; если ISBN повторяется в каталоге,
+// берётся самая ранняя.
+func (r *BookRepository) ListByISBNs(ctx context.Context, isbns []string) ([]models.Book, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT DISTINCT ON (isbn) uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at
+		 FROM books WHERE isbn = ANY($1)
+		 ORDER BY isbn, created_at, uuid`,
+		isbns,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	books := []models.Book{}
+	for rows.Next() {
+		var b models.Book
+		if err := rows.Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.Cover.Version, &b.Cover.Width, &b.Cover.Height, &b.CreatedAt, &b.UpdatedAt); err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	}
+	return books, rows.Err()
+}
+
+// ApplyBatch блокирует существующие строки ids, отдаёт их plan и применяет
+// возвращённые изменения в той же транзакции: новые книги и события outbox
+// пишутся через COPY, обновления и удаления — одним pgx.Batch.
+// Ошибка plan откатывает транзакцию и возвращается как есть.
+func (r *BookRepository) ApplyBatch(ctx context.Context, ids []uuid.UUID, plan func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error)) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		existing := make(map[uuid.UUID]models.Book, len(ids))
+		if len(ids) > 0 {
+			rows, err := tx.Query(ctx,
+				`SELECT uuid, title, description, author, isbn, price, cover_version, cover_width, cover_height, created_at, updated_at FROM books
+				 WHERE uuid = ANY($1) ORDER BY uuid FOR UPDATE`, ids,
+			)
+			if err != nil {
+				return err
+			}
+			books, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Book, error) {
+				var b models.Book
+				err := row.Scan(&b.ID, &b.Title, &b.Description, &b.Author, &b.ISBN, &b.Price, &b.Cover.Version, &b.Cover.Width, &b.Cover.Height, &b.CreatedAt, &b.UpdatedAt)
+				return b, err
+			})
+			if err != nil {
+				return err
+			}
+			for _, b := range books {
+				existing[b.ID] = b
+			}
+		}
+
+		changes, err := plan(existing)
+		if err != nil {
+			return err
+		}
+
+		var created [][]any
+		var events []models.DomainEvent
+		var audit []models.AuditEvent
+		batch := &pgx.Batch{}
+		for _, c := range changes {
+			switch c.Op {
+			case models.BatchOpCreate:
+				b := c.After
+				created = append(created, []any{b.ID, b.Title, b.Description, b.Author, b.ISBN, b.Price, b.CreatedAt, b.UpdatedAt})
+			case models.BatchOpUpdate:
+				b := c.After
+				batch.Queue(
+					`UPDATE books SET title=$1, description=$2, author=$3, isbn=$4, price=$5, updated_at=$6 WHERE uuid=$7`,
+					b.Title, b.Description, b.Author, b.ISBN, b.Price, b.UpdatedAt, b.ID,
+				)
+			case models.BatchOpDelete:
+				batch.Queue(`DELETE FROM books WHERE uuid=$1`, c.Before.ID)
+			}
+			events = append(events, c.Events()...)
+			audit = append(audit, models.BookAuditEvent(batchAuditActions[c.Op], c.ID(), c.Before, c.After))
+		}
+
+		if len(created) > 0 {
+			if _, err := tx.CopyFrom(ctx, pgx.Identifier{"books"},
+				[]string{"uuid", "title", "description", "author", "isbn", "price", "created_at", "updated_at"},
+				pgx.CopyFromRows(created),
+			); err != nil {
+				return err
+			}
+		}
+		if batch.Len() > 0 {
+			if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+				return err
+			}
+		}
+		if err := copyOutbox(ctx, tx, events); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, audit...)
+	})
+}
+
+var batchAuditActions = map[models.BookBatchOpType]string{
+	models.BatchOpCreate: models.AuditActionCreate,
+	models.BatchOpUpdate: models.AuditActionUpdate,
+	models.BatchOpDelete: models.AuditActionDelete,
+}
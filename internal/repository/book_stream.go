@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"book-store-api/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// bookColumnSQL сопоставляет колонке выгрузки выражение SELECT и поле книги для Scan.
+var bookColumnSQL = map[string]struct {
+	expr string
+	dest func(b *models.Book) any
+}{
+	"id":          {"uuid", func(b *models.Book) any { return &b.ID }},
+	"title":       {"title", func(b *models.Book) any { return &b.Title }},
+	"author":      {"author", func(b *models.Book) any { return &b.Author }},
+	"description": {"COALESCE(description, '')", func(b *models.Book) any { return &b.Description }},
+	"isbn":        {"isbn", func(b *models.Book) any { return &b.ISBN }},
+	"price":       {"price", func(b *models.Book) any { return &b.Price }},
+	"created_at":  {"created_at", func(b *models.Book) any { return &b.CreatedAt }},
+	"updated_at":  {"updated_at", func(b *models.Book) any { return &b.UpdatedAt }},
+	// только для ответов с выбранными полями, см. models.CoverColumns
+	"cover_version": {"cover_version", func(b *models.Book) any { return &b.Cover.Version }},
+	"cover_width":   {"cover_width", func(b *models.Book) any { return &b.Cover.Width }},
+	"cover_height":  {"cover_height", func(b *models.Book) any { return &b.Cover.Height }},
+}
+
+// StreamBooks читает книги серверным курсором по fetchSize строк и отдаёт их fn по одной,
+// так что память не зависит от размера каталога. Заполняются только поля columns.
+// Ошибка fn прерывает чтение и возвращается как есть.
+func (r *BookRepository) StreamBooks(ctx context.Context, filter models.BookFilter, columns []string, fetchSize int, fn func(models.Book) error) error {
+	exprs, err := bookColumnExprs(columns)
+	if err != nil {
+		return err
+	}
+	where, args := bookFilterSQL(filter)
+
+	// курсор живёт только внутри транзакции; read only не держит блокировок на запись
+	return pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx,
+			`DECLARE books_export NO SCROLL CURSOR FOR SELECT `+strings.Join(exprs, ", ")+` FROM books`+where+
+				` ORDER BY created_at, uuid`,
+			args...,
+		); err != nil {
+			return err
+		}
+
+		fetch := `FETCH FORWARD ` + strconv.Itoa(fetchSize) + ` FROM books_export`
+		var book models.Book
+		dest := bookColumnDests(&book, columns)
+		for {
+			rows, err := tx.Query(ctx, fetch)
+			if err != nil {
+				return err
+			}
+			n := 0
+			for rows.Next() {
+				n++
+				book = models.Book{}
+				if err := rows.Scan(dest...); err != nil {
+					rows.Close()
+					return err
+				}
+				if err := fn(book); err != nil {
+					rows.Close()
+					return err
+				}
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+			if n < fetchSize {
+				return nil
+			}
+		}
+	})
+}
+
+// SelectBooks читает книги по фильтру, заполняя только поля columns: ответы
+// с выбранными полями не тянут из базы длинные описания.
+func (r *BookRepository) SelectBooks(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error) {
+	exprs, err := bookColumnExprs(columns)
+	if err != nil {
+		return nil, err
+	}
+	where, args := bookFilterSQL(filter)
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+strings.Join(exprs, ", ")+` FROM books`+where+` ORDER BY created_at, uuid`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		books []models.Book
+		book  models.Book
+	)
+	dest := bookColumnDests(&book, columns)
+	for rows.Next() {
+		book = models.Book{}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+	return books, rows.Err()
+}
+
+func bookColumnExprs(columns []string) ([]string, error) {
+	exprs := make([]string, 0, len(columns))
+	for _, c := range columns {
+		col, ok := bookColumnSQL[c]
+		if !ok {
+			return nil, fmt.Errorf("unknown book column %q", c)
+		}
+		exprs = append(exprs, col.expr)
+	}
+	return exprs, nil
+}
+
+func bookColumnDests(book *models.Book, columns []string) []any {
+	dest := make([]any, len(columns))
+	for i, c := range columns {
+		dest[i] = bookColumnSQL[c].dest(book)
+	}
+	return dest
+}
+
+func bookFilterSQL(f models.BookFilter) (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if len(f.IDs) > 0 {
+		add("uuid = ANY($%d)", f.IDs)
+	}
+	if f.Author != "" {
+		add("author = $%d", f.Author)
+	}
+	if f.ISBN != "" {
+		add("isbn = $%d", f.ISBN)
+	}
+	if f.MinPrice != nil {
+		add("price >= $%d", *f.MinPrice)
+	}
+	if f.MaxPrice != nil {
+		add("price <= $%d", *f.MaxPrice)
+	}
+	if f.UpdatedSince != nil {
+		add("updated_at >= $%d", *f.UpdatedSince)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const customerColumns = `uuid, email, password_hash, full_name, phone, email_verified, created_at, updated_at`
+
+type CustomerRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCustomerRepository(pool *pgxpool.Pool) *CustomerRepository {
+	return &CustomerRepository{pool: pool}
+}
+
+func (r *CustomerRepository) Create(ctx context.Context, c models.Customer) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO customers (uuid, email, password_hash, full_name, phone, email_verified, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())`,
+		c.ID, c.Email, c.PasswordHash, c.FullName, c.Phone, c.EmailVerified,
+	)
+	if isUniqueViolation(err) {
+		return ErrAlreadyExists
+	}
+	return err
+}
+
+func (r *CustomerRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Customer, error) {
+	return r.getOne(ctx, `SELECT `+customerColumns+` FROM customers WHERE uuid=$1`, id)
+}
+
+func (r *CustomerRepository) GetByEmail(ctx context.Context, email string) (models.Customer, error) {
+	return r.getOne(ctx, `SELECT `+customerColumns+` FROM customers WHERE email=$1`, email)
+}
+
+func (r *CustomerRepository) UpdateProfile(ctx context.Context, p models.ProfileParams) error {
+	commandTag, err := r.pool.Exec(ctx,
+		`UPDATE customers SET full_name=$1, phone=$2, updated_at=NOW() WHERE uuid=$3`,
+		p.FullName, p.Phone, p.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *CustomerRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	commandTag, err := r.pool.Exec(ctx,
+		`UPDATE customers SET password_hash=$1, updated_at=NOW() WHERE uuid=$2`, passwordHash, id,
+	)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *CustomerRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	commandTag, err := r.pool.Exec(ctx,
+		`UPDATE customers SET email_verified=TRUE, updated_at=NOW() WHERE uuid=$1`, id,
+	)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *CustomerRepository) SaveToken(ctx context.Context, t models.CustomerToken) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO customer_tokens (token_hash, customer_uuid, purpose, expires_at) VALUES ($1, $2, $3, $4)`,
+		t.Hash, t.CustomerID, string(t.Purpose), t.ExpiresAt,
+	)
+	return err
+}
+
+// ConsumeToken помечает токен использованным; просроченный или уже использованный токен не найдётся.
+func (r *CustomerRepository) ConsumeToken(ctx context.Context, hash string, purpose models.TokenPurpose) (models.CustomerToken, error) {
+	t := models.CustomerToken{Hash: hash, Purpose: purpose}
+	err := r.pool.QueryRow(ctx,
+		`UPDATE customer_tokens SET used_at=NOW()
+		 WHERE token_hash=$1 AND purpose=$2 AND used_at IS NULL AND expires_at > NOW()
+		 RETURNING customer_uuid, expires_at`,
+		hash, string(purpose),
+	).Scan(&t.CustomerID, &t.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CustomerToken{}, ErrNotFound
+	}
+	if err != nil {
+		return models.CustomerToken{}, err
+	}
+	return t, nil
+}
+
+func (r *CustomerRepository) ListAddresses(ctx context.Context, customerID uuid.UUID) ([]models.Address, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT uuid, customer_uuid, label, recipient, line1, line2, city, postal_code, country, is_default, created_at, updated_at
+		 FROM customer_addresses WHERE customer_uuid=$1 ORDER BY created_at`, customerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addresses := []models.Address{}
+	for rows.Next() {
+		var a models.Address
+		if err := rows.Scan(&a.ID, &a.CustomerID, &a.Label, &a.Recipient, &a.Line1, &a.Line2, &a.City, &a.PostalCode, &a.Country, &a.IsDefault, &a.CreatedAt, &a.UpdatedAt); err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, a)
+	}
+	return addresses, rows.Err()
+}
+
+func (r *CustomerRepository) CreateAddress(ctx context.Context, a models.Address) error {
+	return r.saveAddress(ctx, a, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO customer_addresses (uuid, customer_uuid, label, recipient, line1, line2, city, postal_code, country, is_default, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())`,
+			a.ID, a.CustomerID, a.Label, a.Recipient, a.Line1, a.Line2, a.City, a.PostalCode, a.Country, a.IsDefault,
+		)
+		return err
+	})
+}
+
+func (r *CustomerRepository) UpdateAddress(ctx context.Context, a models.Address) error {
+	return r.saveAddress(ctx, a, func(tx pgx.Tx) error {
+		commandTag, err := tx.Exec(ctx,
+			`UPDATE customer_addresses SET label=$1, recipient=$2, line1=$3, line2=$4, city=$5, postal_code=$6, country=$7, is_default=$8, updated_at=NOW()
+			 WHERE uuid=$9 AND customer_uuid=$10`,
+			a.Label, a.Recipient, a.Line1, a.Line2, a.City, a.PostalCode, a.Country, a.IsDefault, a.ID, a.CustomerID,
+		)
+		if err != nil {
+			return err
+		}
+		if commandTag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (r *CustomerRepository) DeleteAddress(ctx context.Context, customerID, id uuid.UUID) error {
+	commandTag, err := r.pool.Exec(ctx,
+		`DELETE FROM customer_addresses WHERE uuid=$1 AND customer_uuid=$2`, id, customerID,
+	)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// saveAddress снимает флаг по умолчанию с остальных адресов: такой адрес у покупателя только один.
+func (r *CustomerRepository) saveAddress(ctx context.Context, a models.Address, write func(tx pgx.Tx) error) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if a.IsDefault {
+		if _, err := tx.Exec(ctx,
+			`UPDATE customer_addresses SET is_default=FALSE WHERE customer_uuid=$1 AND uuid<>$2`, a.CustomerID, a.ID,
+		); err != nil {
+			return err
+		}
+	}
+	if err := write(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *CustomerRepository) getOne(ctx context.Context, query string, arg any) (models.Customer, error) {
+	var c models.Customer
+	err := r.pool.QueryRow(ctx, query, arg).
+		Scan(&c.ID, &c.Email, &c.PasswordHash, &c.FullName, &c.Phone, &c.EmailVerified, &c.CreatedAt, &c.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Customer{}, ErrNotFound
+	}
+	if err != nil {
+		return models.Customer{}, err
+	}
+	return c, nil
+}
@@ -1,5 +1,21 @@
 package repository
 
-import "errors"
+import (
+	"errors"
 
-var ErrNotFound = errors.New("not found")
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrConflict — строку изменили после того, как вызывающий её прочитал
+	ErrConflict = errors.New("changed concurrently")
+)
+
+const uniqueViolationCode = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
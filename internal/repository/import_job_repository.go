@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const importJobColumns = `uuid, status, format, file_name, options::text, created_by_type, created_by,
+	total_rows, processed_rows, created, updated, deleted, failed, row_errors::text, COALESCE(error, ''),
+	created_at, started_at, finished_at`
+
+type ImportJobRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImportJobRepository(pool *pgxpool.Pool) *ImportJobRepository {
+	return &ImportJobRepository{pool: pool}
+}
+
+func (r *ImportJobRepository) Create(ctx context.Context, job models.ImportJob, file []byte) error {
+	options, err := json.Marshal(job.Options)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO import_jobs (uuid, status, format, file_name, options, file, created_by_type, created_by, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		job.ID, job.Status, job.Format, job.FileName, options, file, job.CreatedByType, job.CreatedBy, job.CreatedAt,
+	)
+	return err
+}
+
+func (r *ImportJobRepository) Get(ctx context.Context, id uuid.UUID) (models.ImportJob, error) {
+	job, err := scanImportJob(r.pool.QueryRow(ctx,
+		`SELECT `+importJobColumns+` FROM import_jobs WHERE uuid=$1`, id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ImportJob{}, ErrNotFound
+	}
+	return job, err
+}
+
+// ClaimNext берёт самую старую задачу: ожидающую или брошенную воркером, чей lease истёк.
+func (r *ImportJobRepository) ClaimNext(ctx context.Context, lease time.Duration) (*models.ImportTask, error) {
+	var task models.ImportTask
+	job, err := scanImportJob(r.pool.QueryRow(ctx,
+		`WITH next AS (
+		   SELECT id FROM import_jobs
+		   WHERE status = 'pending' OR (status = 'running' AND lease_until < NOW())
+		   ORDER BY created_at
+		   LIMIT 1
+		   FOR UPDATE SKIP LOCKED
+		 )
+		 UPDATE import_jobs j SET status='running', lease_until = NOW() + $1::interval, started_at = COALESCE(started_at, NOW())
+		 FROM next WHERE j.id = next.id
+		 RETURNING `+importJobColumns+`, file`,
+		lease,
+	), &task.File)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	task.Job = job
+	return &task, nil
+}
+
+func (r *ImportJobRepository) SaveProgress(ctx context.Context, job models.ImportJob, lease time.Duration) error {
+	rowErrors, err := json.Marshal(job.RowErrors)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx,
+		`UPDATE import_jobs SET total_rows=$2, processed_rows=$3, created=$4, updated=$5, deleted=$6, failed=$7, row_errors=$8,
+		   lease_until = NOW() + $9::interval
+		 WHERE uuid=$1`,
+		job.ID, job.TotalRows, job.ProcessedRows, job.Created, job.Updated, job.Deleted, job.Failed, rowErrors, lease,
+	)
+	return err
+}
+
+// Finish фиксирует итог и удаляет файл: после завершения он больше не нужен.
+func (r *ImportJobRepository) Finish(ctx context.Context, job models.ImportJob) error {
+	rowErrors, err := json.Marshal(job.RowErrors)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx,
+		`UPDATE import_jobs SET status=$2, total_rows=$3, processed_rows=$4, created=$5, updated=$6, deleted=$7, failed=$8,
+		   row_errors=$9, error=NULLIF($10, ''), finished_at=$11, file=NULL, lease_until=NULL
+		 WHERE uuid=$1`,
+		job.ID, job.Status, job.TotalRows, job.ProcessedRows, job.Created, job.Updated, job.Deleted, job.Failed,
+		rowErrors, job.Error, job.FinishedAt,
+	)
+	return err
+}
+
+// scanImportJob дописывает extra к колонкам importJobColumns.
+func scanImportJob(row pgx.Row, extra ...any) (models.ImportJob, error) {
+	var (
+		j                  models.ImportJob
+		options, rowErrors string
+	)
+	dest := []any{&j.ID, &j.Status, &j.Format, &j.FileName, &options, &j.CreatedByType, &j.CreatedBy,
+		&j.TotalRows, &j.ProcessedRows, &j.Created, &j.Updated, &j.Deleted, &j.Failed, &rowErrors, &j.Error,
+		&j.CreatedAt, &j.StartedAt, &j.FinishedAt}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return models.ImportJob{}, err
+	}
+	if err := json.Unmarshal([]byte(options), &j.Options); err != nil {
+		return models.ImportJob{}, err
+	}
+	if err := json.Unmarshal([]byte(rowErrors), &j.RowErrors); err != nil {
+		return models.ImportJob{}, err
+	}
+	return j, nil
+}
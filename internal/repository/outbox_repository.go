@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"book-store-api/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{pool: pool}
+}
+
+func insertOutbox(ctx context.Context, tx pgx.Tx, events ...models.DomainEvent) error {
+	for _, e := range events {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload, occurred_at)
+			 VALUES ($1, $2, $3, $4, $5, $6)`,
+			e.ID, e.AggregateType, e.AggregateID, e.Type, string(e.Payload), e.OccurredAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyOutbox — insertOutbox для пакетов: одна команда COPY вместо INSERT на событие.
+func copyOutbox(ctx context.Context, tx pgx.Tx, events []models.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	rows := make([][]any, 0, len(events))
+	for _, e := range events {
+		rows = append(rows, []any{e.ID, e.AggregateType, e.AggregateID, e.Type, string(e.Payload), e.OccurredAt})
+	}
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"outbox"},
+		[]string{"event_id", "aggregate_type", "aggregate_id", "event_type", "payload", "occurred_at"},
+		pgx.CopyFromRows(rows),
+	)
+	return err
+}
+
+// Process забирает готовые к отправке сообщения и передаёт их publish в транзакции,
+// которая держит блокировки строк. Берётся только самое раннее сообщение каждого
+// агрегата, поэтому следующие события агрегата ждут, пока оно не уйдёт.
+// Отправленные удаляются, упавшие откладываются по policy, исчерпавшие попытки
+// переносятся в outbox_dead_letters.
+func (r *OutboxRepository) Process(ctx context.Context, limit int, policy models.RetryPolicy, publish func(context.Context, models.OutboxMessage) error) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx,
+		`SELECT o.id, o.event_id, o.aggregate_type, o.aggregate_id, o.event_type, o.payload::text, o.occurred_at, o.attempts, COALESCE(o.last_error, '')
+		 FROM outbox o
+		 WHERE o.next_attempt_at <= NOW()
+		   AND NOT EXISTS (
+		     SELECT 1 FROM outbox p
+		     WHERE p.aggregate_type = o.aggregate_type AND p.aggregate_id = o.aggregate_id AND p.id < o.id
+		   )
+		 ORDER BY o.id
+		 LIMIT $1
+		 FOR UPDATE SKIP LOCKED`, limit,
+	)
+	if err != nil {
+		return 0, err
+	}
+	messages, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.OutboxMessage, error) {
+		var (
+			m       models.OutboxMessage
+			payload string
+		)
+		err := row.Scan(&m.ID, &m.Event.ID, &m.Event.AggregateType, &m.Event.AggregateID, &m.Event.Type,
+			&payload, &m.Event.OccurredAt, &m.Attempts, &m.LastError)
+		m.Event.Payload = []byte(payload)
+		return m, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range messages {
+		pubErr := publish(ctx, m)
+		switch {
+		case pubErr == nil:
+			_, err = tx.Exec(ctx, `DELETE FROM outbox WHERE id=$1`, m.ID)
+		case policy.Exhausted(m.Attempts + 1):
+			err = moveToDeadLetter(ctx, tx, m.ID, pubErr.Error())
+		default:
+			_, err = tx.Exec(ctx,
+				`UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id=$1`,
+				m.ID, pubErr.Error(), time.Now().Add(policy.Backoff(m.Attempts+1)),
+			)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(messages), tx.Commit(ctx)
+}
+
+func moveToDeadLetter(ctx context.Context, tx pgx.Tx, id int64, lastErr string) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO outbox_dead_letters (id, event_id, aggregate_type, aggregate_id, event_type, payload, occurred_at, attempts, last_error)
+		 SELECT id, event_id, aggregate_type, aggregate_id, event_type, payload, occurred_at, attempts + 1, $2
+		 FROM outbox WHERE id=$1`, id, lastErr,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM outbox WHERE id=$1`, id)
+	return err
+}
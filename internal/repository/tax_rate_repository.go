@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TaxRateRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTaxRateRepository(pool *pgxpool.Pool) *TaxRateRepository {
+	return &TaxRateRepository{pool: pool}
+}
+
+func (r *TaxRateRepository) GetRates(ctx context.Context, country string, format models.ProductFormat) ([]models.TaxRate, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT country, format, rate_bp, effective_from FROM tax_rates WHERE country=$1 AND format=$2 ORDER BY effective_from`,
+		country, string(format),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []models.TaxRate
+	for rows.Next() {
+		var (
+			rate   models.TaxRate
+			format string
+		)
+		if err := rows.Scan(&rate.Country, &format, &rate.RateBP, &rate.EffectiveFrom); err != nil {
+			return nil, err
+		}
+		rate.Format = models.ProductFormat(format)
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
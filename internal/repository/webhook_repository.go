@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	webhookSubscriptionColumns = `uuid, url, event_types, secret, active, failures, disabled_at, created_at, updated_at`
+	webhookDeliveryColumns     = `uuid, subscription_id, event_id, event_type, payload::text, status, attempts, next_attempt_at,
+		COALESCE(last_status_code, 0), COALESCE(last_error, ''), last_attempt_at, delivered_at, redelivery_of, created_at`
+)
+
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool: pool}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, s models.WebhookSubscription) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO webhook_subscriptions (uuid, url, event_types, secret, active, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $6)`,
+		s.ID, s.URL, s.EventTypes, s.Secret, s.Active, s.CreatedAt,
+	)
+	return err
+}
+
+// UpdateSubscription при включении подписки сбрасывает счётчик ошибок.
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, s models.WebhookSubscription) error {
+	commandTag, err := r.pool.Exec(ctx,
+		`UPDATE webhook_subscriptions SET url=$2, event_types=$3, secret=$4, active=$5,
+		   failures = CASE WHEN $5 THEN 0 ELSE failures END,
+		   disabled_at = CASE WHEN $5 THEN NULL ELSE disabled_at END,
+		   updated_at=NOW()
+		 WHERE uuid=$1`,
+		s.ID, s.URL, s.EventTypes, s.Secret, s.Active,
+	)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	commandTag, err := r.pool.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE uuid=$1`, id)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (models.WebhookSubscription, error) {
+	s, err := scanWebhookSubscription(r.pool.QueryRow(ctx,
+		`SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions WHERE uuid=$1`, id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.WebhookSubscription{}, ErrNotFound
+	}
+	return s, err
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return r.querySubscriptions(ctx, `SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions ORDER BY created_at`)
+}
+
+func (r *WebhookRepository) ListActiveSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return r.querySubscriptions(ctx, `SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions WHERE active ORDER BY created_at`)
+}
+
+func (r *WebhookRepository) querySubscriptions(ctx context.Context, query string) ([]models.WebhookSubscription, error) {
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := []models.WebhookSubscription{}
+	for rows.Next() {
+		s, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// EnqueueDeliveries идемпотентна: повтор того же события из outbox не создаёт дублей.
+func (r *WebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	batch := &pgx.Batch{}
+	for _, d := range deliveries {
+		batch.Queue(
+			`INSERT INTO webhook_deliveries (uuid, subscription_id, event_id, event_type, payload, redelivery_of, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, NOW())
+			 ON CONFLICT (subscription_id, event_id) WHERE redelivery_of IS NULL DO NOTHING`,
+			d.ID, d.SubscriptionID, d.EventID, d.EventType, string(d.Payload), d.RedeliveryOf,
+		)
+	}
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (models.WebhookDelivery, error) {
+	d, err := scanWebhookDelivery(r.pool.QueryRow(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE uuid=$1`, id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.WebhookDelivery{}, ErrNotFound
+	}
+	return d, err
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]models.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE subscription_id=$1 ORDER BY id DESC LIMIT $2`,
+		subscriptionID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// ClaimDue берёт доставки в работу, сдвигая next_attempt_at на lease: HTTP-запрос
+// идёт вне транзакции, а если воркер упадёт, доставка вернётся в очередь после lease.
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookJob, error) {
+	rows, err := r.pool.Query(ctx,
+		`WITH due AS (
+		   SELECT d.id FROM webhook_deliveries d
+		   JOIN webhook_subscriptions s ON s.uuid = d.subscription_id
+		   WHERE d.status = 'pending' AND d.next_attempt_at <= NOW() AND s.active
+		   ORDER BY d.next_attempt_at
+		   LIMIT $1
+		   FOR UPDATE OF d SKIP LOCKED
+		 )
+		 UPDATE webhook_deliveries d SET next_attempt_at = NOW() + $2::interval
+		 FROM due, webhook_subscriptions s
+		 WHERE d.id = due.id AND s.uuid = d.subscription_id
+		 RETURNING d.uuid, d.subscription_id, d.event_id, d.event_type, d.payload::text, d.attempts, s.url, s.secret`,
+		limit, lease,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.WebhookJob, error) {
+		var (
+			j       models.WebhookJob
+			payload string
+		)
+		err := row.Scan(&j.Delivery.ID, &j.Delivery.SubscriptionID, &j.Delivery.EventID, &j.Delivery.EventType,
+			&payload, &j.Delivery.Attempts, &j.URL, &j.Secret)
+		j.Delivery.Payload = []byte(payload)
+		j.Delivery.Status = models.DeliveryPending
+		return j, err
+	})
+}
+
+// RecordAttempt сохраняет результат попытки и обновляет счётчик ошибок подписки.
+// nextAttempt == nil означает, что попытки исчерпаны. Возвращает true, если подписка
+// была отключена этой попыткой.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, a models.WebhookAttempt, nextAttempt *time.Time, disableAfter int) (bool, error) {
+	var disabled bool
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if a.Succeeded() {
+			if _, err := tx.Exec(ctx,
+				`UPDATE webhook_deliveries SET status='succeeded', attempts=attempts+1, last_status_code=$2, last_error=NULL,
+				   last_attempt_at=$3, delivered_at=$3
+				 WHERE uuid=$1`, a.DeliveryID, a.StatusCode, a.At,
+			); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `UPDATE webhook_subscriptions SET failures=0 WHERE uuid=$1`, a.SubscriptionID)
+			return err
+		}
+
+		status, next := models.DeliveryPending, a.At
+		if nextAttempt == nil {
+			status = models.DeliveryFailed
+		} else {
+			next = *nextAttempt
+		}
+		if _, err := tx.Exec(ctx,
+			`UPDATE webhook_deliveries SET status=$2, attempts=attempts+1, last_status_code=NULLIF($3, 0), last_error=$4,
+			   last_attempt_at=$5, next_attempt_at=$6
+			 WHERE uuid=$1`, a.DeliveryID, status, a.StatusCode, a.Err, a.At, next,
+		); err != nil {
+			return err
+		}
+
+		return tx.QueryRow(ctx,
+			`UPDATE webhook_subscriptions SET failures = failures + 1,
+			   active = active AND failures + 1 < $2,
+			   disabled_at = CASE WHEN active AND failures + 1 >= $2 THEN NOW() ELSE disabled_at END
+			 WHERE uuid=$1
+			 RETURNING disabled_at IS NOT NULL AND NOT active AND failures = $2`,
+			a.SubscriptionID, disableAfter,
+		).Scan(&disabled)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// подписку удалили, пока шла доставка
+		return false, nil
+	}
+	return disabled, err
+}
+
+func scanWebhookSubscription(row pgx.Row) (models.WebhookSubscription, error) {
+	var s models.WebhookSubscription
+	err := row.Scan(&s.ID, &s.URL, &s.EventTypes, &s.Secret, &s.Active, &s.Failures, &s.DisabledAt, &s.CreatedAt, &s.UpdatedAt)
+	return s, err
+}
+
+func scanWebhookDelivery(row pgx.Row) (models.WebhookDelivery, error) {
+	var (
+		d       models.WebhookDelivery
+		payload string
+	)
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.LastStatusCode, &d.LastError, &d.LastAttemptAt, &d.DeliveredAt, &d.RedeliveryOf, &d.CreatedAt)
+	d.Payload = []byte(payload)
+	return d, err
+}
@@ -0,0 +1,46 @@
+// Package reqctx переносит метаданные HTTP-запроса в usecase-слой через context.
+package reqctx
+
+import "context"
+
+type contextKey string
+
+const (
+	requestIDKey contextKey = "requestID"
+	clientIPKey  contextKey = "clientIP"
+	actorKey     contextKey = "actor"
+)
+
+// Actor — автор изменения для журнала аудита. Его кладёт аудитор usecase, а репозиторий
+// пишет событие в той же транзакции, что и само изменение; без Actor аудита нет.
+type Actor struct {
+	ID   string
+	Type string
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
+
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey, a)
+}
+
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	a, ok := ctx.Value(actorKey).(Actor)
+	return a, ok
+}
@@ -0,0 +1,47 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func (s *Service) Authenticate(ctx context.Context, raw string) (auth.Principal, error) {
+	prefix, ok := parsePrefix(raw)
+	if !ok {
+		return auth.Principal{}, usecase.ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return auth.Principal{}, usecase.ErrInvalidAPIKey
+		}
+		s.logger.Error("db error", "get api key err", err)
+		return auth.Principal{}, usecase.ErrDbInfrastructure
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hashSecret(raw))) != 1 {
+		return auth.Principal{}, usecase.ErrInvalidAPIKey
+	}
+	now := s.now()
+	if !key.Active(now) {
+		return auth.Principal{}, usecase.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			s.logger.Error("db error", "touch api key err", err)
+		}
+	}
+
+	return auth.Principal{
+		Type:    auth.PrincipalAPIKey,
+		Subject: key.ID.String(),
+		Scopes:  key.Scopes,
+	}, nil
+}
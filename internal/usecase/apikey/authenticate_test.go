@@ -0,0 +1,86 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func TestService_Authenticate(t *testing.T) {
+	raw, prefix, hash, err := generateSecret()
+	require.NoError(t, err)
+
+	key := models.APIKey{ID: uuid.New(), Prefix: prefix, Hash: hash, Scopes: []string{models.ScopeCatalogWrite}}
+	touched := 0
+	repo := &RepositoryMock{
+		GetByPrefixFunc: func(ctx context.Context, p string) (models.APIKey, error) {
+			if p != key.Prefix {
+				return models.APIKey{}, repository.ErrNotFound
+			}
+			return key, nil
+		},
+		TouchLastUsedFunc: func(ctx context.Context, id uuid.UUID, at time.Time) error {
+			touched++
+			return nil
+		},
+	}
+	svc := newTestService(repo)
+	ctx := context.Background()
+
+	t.Run("valid key", func(t *testing.T) {
+		principal, err := svc.Authenticate(ctx, raw)
+		require.NoError(t, err)
+		assert.Equal(t, auth.PrincipalAPIKey, principal.Type)
+		assert.Equal(t, key.ID.String(), principal.Subject)
+		assert.True(t, principal.HasAnyScope(models.ScopeCatalogWrite))
+		assert.Equal(t, 1, touched)
+	})
+
+	t.Run("recently used key is not touched again", func(t *testing.T) {
+		recently := testNow.Add(-10 * time.Second)
+		key.LastUsedAt = &recently
+		defer func() { key.LastUsedAt = nil }()
+
+		touched = 0
+		_, err := svc.Authenticate(ctx, raw)
+		require.NoError(t, err)
+		assert.Zero(t, touched)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		_, err := svc.Authenticate(ctx, "bsk_"+prefix+"_forged")
+		assert.ErrorIs(t, err, usecase.ErrInvalidAPIKey)
+	})
+
+	t.Run("malformed key", func(t *testing.T) {
+		_, err := svc.Authenticate(ctx, "not-a-key")
+		assert.ErrorIs(t, err, usecase.ErrInvalidAPIKey)
+	})
+
+	t.Run("revoked after grace period", func(t *testing.T) {
+		revoked := testNow.Add(-time.Second)
+		key.RevokedAt = &revoked
+		defer func() { key.RevokedAt = nil }()
+
+		_, err := svc.Authenticate(ctx, raw)
+		assert.ErrorIs(t, err, usecase.ErrInvalidAPIKey)
+	})
+
+	t.Run("revoked within grace period", func(t *testing.T) {
+		revoked := testNow.Add(time.Minute)
+		key.RevokedAt = &revoked
+		defer func() { key.RevokedAt = nil }()
+
+		_, err := svc.Authenticate(ctx, raw)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, k models.APIKey) error
+	List(ctx context.Context) ([]models.APIKey, error)
+	GetByID(ctx context.Context, id uuid.UUID) (models.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (models.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	// Rotate отзывает oldID с момента revokeAt и создаёт k атомарно
+	Rotate(ctx context.Context, oldID uuid.UUID, revokeAt time.Time, k models.APIKey) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
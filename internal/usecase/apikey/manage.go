@@ -0,0 +1,98 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) Create(ctx context.Context, params models.APIKeyParams) (models.IssuedAPIKey, error) {
+	issued, err := s.issue(params)
+	if err != nil {
+		return models.IssuedAPIKey{}, err
+	}
+
+	if err := s.repo.Create(ctx, issued.Key); err != nil {
+		s.logger.Error("db error", "create api key err", err)
+		return models.IssuedAPIKey{}, usecase.ErrDbInfrastructure
+	}
+
+	return issued, nil
+}
+
+// issue генерирует секрет и собирает ключ, не сохраняя его.
+func (s *Service) issue(params models.APIKeyParams) (models.IssuedAPIKey, error) {
+	raw, prefix, hash, err := generateSecret()
+	if err != nil {
+		return models.IssuedAPIKey{}, err
+	}
+	params.ID = uuid.New()
+	params.Prefix = prefix
+	params.Hash = hash
+
+	key, err := models.NewAPIKey(params, s.now())
+	if err != nil {
+		return models.IssuedAPIKey{}, err
+	}
+	return models.IssuedAPIKey{Key: key, Secret: raw}, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]models.APIKey, error) {
+	keys, err := s.repo.List(ctx)
+	if err != nil {
+		s.logger.Error("db error", "list api keys err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+	return keys, nil
+}
+
+func (s *Service) Revoke(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Revoke(ctx, id, s.now()); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		s.logger.Error("db error", "revoke api key err", err)
+		return usecase.ErrDbInfrastructure
+	}
+	return nil
+}
+
+// Rotate выпускает новый ключ с теми же именем, scope и сроком действия.
+// Старый ключ продолжает работать grace-период, чтобы интеграция успела переключиться.
+// Новый ключ и отзыв старого сохраняются вместе: при ошибке не остаётся ключа без выданного секрета.
+func (s *Service) Rotate(ctx context.Context, id uuid.UUID) (models.IssuedAPIKey, error) {
+	old, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.IssuedAPIKey{}, err
+		}
+		s.logger.Error("db error", "get api key err", err)
+		return models.IssuedAPIKey{}, usecase.ErrDbInfrastructure
+	}
+	if !old.Active(s.now()) {
+		return models.IssuedAPIKey{}, usecase.ErrAPIKeyInactive
+	}
+
+	issued, err := s.issue(models.APIKeyParams{
+		Name:      old.Name,
+		Scopes:    old.Scopes,
+		ExpiresAt: old.ExpiresAt,
+	})
+	if err != nil {
+		return models.IssuedAPIKey{}, err
+	}
+
+	if err := s.repo.Rotate(ctx, id, s.now().Add(s.grace), issued.Key); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.IssuedAPIKey{}, err
+		}
+		s.logger.Error("db error", "rotate api key err", err)
+		return models.IssuedAPIKey{}, usecase.ErrDbInfrastructure
+	}
+	return issued, nil
+}
@@ -0,0 +1,108 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+var testNow = time.Date(2025, 10, 22, 12, 0, 0, 0, time.UTC)
+
+func newTestService(repo *RepositoryMock) *Service {
+	svc := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, time.Hour)
+	svc.now = func() time.Time { return testNow }
+	return svc
+}
+
+func TestService_Create(t *testing.T) {
+	var stored models.APIKey
+	repo := &RepositoryMock{
+		CreateFunc: func(ctx context.Context, k models.APIKey) error {
+			stored = k
+			return nil
+		},
+	}
+	svc := newTestService(repo)
+
+	issued, err := svc.Create(context.Background(), models.APIKeyParams{
+		Name:   "warehouse",
+		Scopes: []string{models.ScopeStockWrite},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(issued.Secret, "bsk_"+stored.Prefix+"_"))
+	assert.NotContains(t, stored.Hash, issued.Secret)
+	assert.Equal(t, hashSecret(issued.Secret), stored.Hash)
+	assert.Equal(t, testNow, stored.CreatedAt)
+
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := svc.Create(context.Background(), models.APIKeyParams{Name: "erp", Scopes: []string{"orders:write"}})
+		assert.ErrorIs(t, err, models.ErrDomainValidation)
+	})
+}
+
+func TestService_Rotate(t *testing.T) {
+	expires := testNow.Add(24 * time.Hour)
+	old := models.APIKey{ID: uuid.New(), Name: "erp", Scopes: []string{models.ScopeCatalogWrite}, ExpiresAt: &expires}
+
+	var created models.APIKey
+	var revokedAt time.Time
+	repo := &RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (models.APIKey, error) {
+			if id != old.ID {
+				return models.APIKey{}, repository.ErrNotFound
+			}
+			return old, nil
+		},
+		RotateFunc: func(ctx context.Context, oldID uuid.UUID, revokeAt time.Time, k models.APIKey) error {
+			revokedAt = revokeAt
+			created = k
+			return nil
+		},
+	}
+	svc := newTestService(repo)
+
+	issued, err := svc.Rotate(context.Background(), old.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, old.ID, issued.Key.ID)
+	assert.Equal(t, issued.Key, created)
+	assert.NotEmpty(t, issued.Secret)
+	assert.Equal(t, old.Name, created.Name)
+	assert.Equal(t, old.Scopes, created.Scopes)
+	assert.Equal(t, old.ExpiresAt, created.ExpiresAt)
+	assert.Equal(t, testNow.Add(time.Hour), revokedAt)
+	assert.Equal(t, old.ID, repo.RotateCalls()[0].OldID)
+
+	t.Run("rotation fails", func(t *testing.T) {
+		repo.RotateFunc = func(ctx context.Context, oldID uuid.UUID, revokeAt time.Time, k models.APIKey) error {
+			return errors.New("connection reset")
+		}
+		_, err := svc.Rotate(context.Background(), old.ID)
+		assert.ErrorIs(t, err, usecase.ErrDbInfrastructure)
+		assert.Empty(t, repo.CreateCalls(), "new key is never stored outside the rotation transaction")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := svc.Rotate(context.Background(), uuid.New())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		revoked := testNow.Add(-time.Minute)
+		old.RevokedAt = &revoked
+		_, err := svc.Rotate(context.Background(), old.ID)
+		assert.ErrorIs(t, err, usecase.ErrAPIKeyInactive)
+	})
+}
@@ -0,0 +1,27 @@
+package apikey
+
+import (
+	"log/slog"
+	"time"
+
+	"book-store-api/internal/usecase/apikey/interfaces"
+)
+
+// Не пишем last_used_at в БД на каждый запрос интеграции
+const lastUsedResolution = time.Minute
+
+type Service struct {
+	logger *slog.Logger
+	repo   interfaces.Repository
+	grace  time.Duration
+	now    func() time.Time
+}
+
+func NewService(logger *slog.Logger, repo interfaces.Repository, rotationGrace time.Duration) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo,
+		grace:  rotationGrace,
+		now:    time.Now,
+	}
+}
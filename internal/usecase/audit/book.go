@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/reqctx"
+	"book-store-api/internal/usecase/audit/interfaces"
+)
+
+// BookAuditor — декоратор над usecase книг, включающий аудит записи: он кладёт в контекст
+// автора изменения, а событие с состояниями до и после пишет репозиторий в транзакции
+// самого изменения — вместе с outbox. Методы перечислены явно, без встраивания: новая
+// операция в delivery.Usecase не соберётся в app, пока её не добавят сюда.
+type BookAuditor struct {
+	next interfaces.BookUsecase
+}
+
+func NewBookAuditor(next interfaces.BookUsecase) *BookAuditor {
+	return &BookAuditor{next: next}
+}
+
+func (a *BookAuditor) Create(ctx context.Context, bookInfo models.BookParams) (string, error) {
+	return a.next.Create(withActor(ctx), bookInfo)
+}
+
+func (a *BookAuditor) Update(ctx context.Context, bookInfo models.BookParams) error {
+	return a.next.Update(withActor(ctx), bookInfo)
+}
+
+func (a *BookAuditor) DeleteBook(ctx context.Context, id string) error {
+	return a.next.DeleteBook(withActor(ctx), id)
+}
+
+// Merge пишется одним событием merge на удалённый дубликат: before — дубликат,
+// after — каноничная книга. Дельта-выгрузки считают такую книгу удалённой.
+func (a *BookAuditor) Merge(ctx context.Context, canonicalID, duplicateID string) (*models.Book, error) {
+	return a.next.Merge(withActor(ctx), canonicalID, duplicateID)
+}
+
+// Batch пишет событие на каждое применённое изменение; откаченный пакет не аудируется.
+func (a *BookAuditor) Batch(ctx context.Context, mode models.BookBatchMode, ops []models.BookBatchOp) (models.BookBatchResult, error) {
+	return a.next.Batch(withActor(ctx), mode, ops)
+}
+
+func (a *BookAuditor) GetAll(ctx context.Context) ([]models.Book, error) {
+	return a.next.GetAll(ctx)
+}
+
+func (a *BookAuditor) GetByID(ctx context.Context, id string) (*models.Book, error) {
+	return a.next.GetByID(ctx, id)
+}
+
+func (a *BookAuditor) List(ctx context.Context, after *models.BookCursor, size int) (models.BookPage, error) {
+	return a.next.List(ctx, after, size)
+}
+
+// withActor помечает запрос для аудита: автором становится principal, а без него — anonymous.
+func withActor(ctx context.Context) context.Context {
+	actor := reqctx.Actor{ID: "anonymous", Type: "anonymous"}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		actor = reqctx.Actor{ID: principal.Subject, Type: string(principal.Type)}
+	}
+	return reqctx.WithActor(ctx, actor)
+}
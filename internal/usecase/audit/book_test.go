@@ -0,0 +1,122 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/reqctx"
+)
+
+func requestContext() context.Context {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{Type: auth.PrincipalUser, Subject: "staff-1", Roles: []string{auth.RoleAdmin}})
+	ctx = reqctx.WithRequestID(ctx, "req-1")
+	return reqctx.WithClientIP(ctx, "10.0.0.1")
+}
+
+// Событие пишет репозиторий в транзакции изменения; аудитор отвечает за автора в контексте.
+func TestBookAuditor_AttributesWrites(t *testing.T) {
+	var actors []reqctx.Actor
+	seen := func(ctx context.Context) {
+		actor, ok := reqctx.ActorFrom(ctx)
+		require.True(t, ok)
+		actors = append(actors, actor)
+	}
+	next := &BookUsecaseMock{
+		CreateFunc: func(ctx context.Context, p models.BookParams) (string, error) {
+			seen(ctx)
+			return "id", nil
+		},
+		UpdateFunc: func(ctx context.Context, p models.BookParams) error {
+			seen(ctx)
+			return nil
+		},
+		DeleteBookFunc: func(ctx context.Context, id string) error {
+			seen(ctx)
+			return repository.ErrNotFound
+		},
+		MergeFunc: func(ctx context.Context, canonicalID, duplicateID string) (*models.Book, error) {
+			seen(ctx)
+			return &models.Book{}, nil
+		},
+		BatchFunc: func(ctx context.Context, mode models.BookBatchMode, ops []models.BookBatchOp) (models.BookBatchResult, error) {
+			seen(ctx)
+			return models.BookBatchResult{Committed: true}, nil
+		},
+	}
+	auditor := NewBookAuditor(next)
+	ctx := requestContext()
+
+	_, err := auditor.Create(ctx, models.BookParams{})
+	require.NoError(t, err)
+	require.NoError(t, auditor.Update(ctx, models.BookParams{}))
+	assert.ErrorIs(t, auditor.DeleteBook(ctx, uuid.NewString()), repository.ErrNotFound, "errors pass through unchanged")
+	_, err = auditor.Merge(ctx, uuid.NewString(), uuid.NewString())
+	require.NoError(t, err)
+	_, err = auditor.Batch(ctx, models.BatchAtomic, nil)
+	require.NoError(t, err)
+
+	require.Len(t, actors, 5)
+	for _, actor := range actors {
+		assert.Equal(t, reqctx.Actor{ID: "staff-1", Type: "user"}, actor)
+	}
+
+	_, err = auditor.Create(context.Background(), models.BookParams{})
+	require.NoError(t, err)
+	assert.Equal(t, reqctx.Actor{ID: "anonymous", Type: "anonymous"}, actors[5])
+}
+
+func TestBookAuditor_ReadsAreNotAttributed(t *testing.T) {
+	next := &BookUsecaseMock{
+		GetByIDFunc: func(ctx context.Context, id string) (*models.Book, error) {
+			_, ok := reqctx.ActorFrom(ctx)
+			assert.False(t, ok)
+			return &models.Book{}, nil
+		},
+	}
+	_, err := NewBookAuditor(next).GetByID(requestContext(), uuid.NewString())
+	require.NoError(t, err)
+}
+
+func TestService_Verify(t *testing.T) {
+	chain := make([]models.AuditEvent, 0, 2500)
+	prev := ""
+	for i := range 2500 {
+		e := models.AuditEvent{ID: int64(i + 1), Actor: "staff-1", Action: models.AuditActionUpdate, PrevHash: prev}
+		e.Hash = e.ComputeHash()
+		prev = e.Hash
+		chain = append(chain, e)
+	}
+	repo := &RepositoryMock{
+		ChainFunc: func(ctx context.Context, afterID int64, limit int) ([]models.AuditEvent, error) {
+			start := min(int(afterID), len(chain))
+			end := min(start+limit, len(chain))
+			return chain[start:end], nil
+		},
+	}
+	svc := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), repo)
+
+	res, err := svc.Verify(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.AuditVerification{Valid: true, Checked: 2500}, res)
+
+	chain[1700].Actor = "intruder"
+	res, err = svc.Verify(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.AuditVerification{Valid: false, Checked: 1700, BrokenAt: 1701}, res)
+
+	repo.ChainFunc = func(ctx context.Context, afterID int64, limit int) ([]models.AuditEvent, error) {
+		return nil, errors.New("connection refused")
+	}
+	_, err = svc.Verify(context.Background())
+	assert.Error(t, err)
+}
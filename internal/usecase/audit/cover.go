@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase/audit/interfaces"
+)
+
+// CoverAuditor включает аудит смены обложки так же, как BookAuditor для полей книги:
+// событие update с версиями обложки до и после пишет репозиторий.
+type CoverAuditor struct {
+	next interfaces.CoverUsecase
+}
+
+func NewCoverAuditor(next interfaces.CoverUsecase) *CoverAuditor {
+	return &CoverAuditor{next: next}
+}
+
+func (a *CoverAuditor) Upload(ctx context.Context, id string, data []byte) (models.BookCover, error) {
+	return a.next.Upload(withActor(ctx), id, data)
+}
+
+func (a *CoverAuditor) Remove(ctx context.Context, id string) error {
+	return a.next.Remove(withActor(ctx), id)
+}
+
+func (a *CoverAuditor) Open(ctx context.Context, id, version, file string) (models.Blob, error) {
+	return a.next.Open(ctx, id, version, file)
+}
@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/reqctx"
+)
+
+func TestCoverAuditor(t *testing.T) {
+	var actors []reqctx.Actor
+	next := &CoverUsecaseMock{
+		UploadFunc: func(ctx context.Context, id string, data []byte) (models.BookCover, error) {
+			actor, _ := reqctx.ActorFrom(ctx)
+			actors = append(actors, actor)
+			return models.BookCover{Version: "v1"}, nil
+		},
+		RemoveFunc: func(ctx context.Context, id string) error {
+			actor, _ := reqctx.ActorFrom(ctx)
+			actors = append(actors, actor)
+			return nil
+		},
+		OpenFunc: func(ctx context.Context, id, version, file string) (models.Blob, error) {
+			_, ok := reqctx.ActorFrom(ctx)
+			assert.False(t, ok, "reads are not attributed")
+			return models.Blob{}, nil
+		},
+	}
+	auditor := NewCoverAuditor(next)
+	id := uuid.NewString()
+
+	cover, err := auditor.Upload(requestContext(), id, []byte("png"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", cover.Version)
+	require.NoError(t, auditor.Remove(requestContext(), id))
+	_, err = auditor.Open(requestContext(), id, "v1", "small.webp")
+	require.NoError(t, err)
+
+	assert.Equal(t, []reqctx.Actor{{ID: "staff-1", Type: "user"}, {ID: "staff-1", Type: "user"}}, actors)
+}
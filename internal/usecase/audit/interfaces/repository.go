@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"book-store-api/internal/models"
+)
+
+type Repository interface {
+	List(ctx context.Context, f models.AuditFilter) ([]models.AuditEvent, error)
+	Chain(ctx context.Context, afterID int64, limit int) ([]models.AuditEvent, error)
+	DeletedBooks(ctx context.Context, since time.Time) ([]models.DeletedBook, error)
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+)
+
+// BookUsecase повторяет delivery.Usecase, delivery.BookBatchUsecase и слияние дубликатов — это usecase, который оборачивает аудитор.
+type BookUsecase interface {
+	Create(ctx context.Context, bookInfo models.BookParams) (string, error)
+	DeleteBook(ctx context.Context, id string) error
+	GetAll(ctx context.Context) ([]models.Book, error)
+	Update(ctx context.Context, bookInfo models.BookParams) error
+	GetByID(ctx context.Context, id string) (*models.Book, error)
+	List(ctx context.Context, after *models.BookCursor, size int) (models.BookPage, error)
+	Batch(ctx context.Context, mode models.BookBatchMode, ops []models.BookBatchOp) (models.BookBatchResult, error)
+	Merge(ctx context.Context, canonicalID, duplicateID string) (*models.Book, error)
+}
+
+// CoverUsecase повторяет delivery.CoverUsecase.
+type CoverUsecase interface {
+	Upload(ctx context.Context, id string, data []byte) (models.BookCover, error)
+	Remove(ctx context.Context, id string) error
+	Open(ctx context.Context, id, version, file string) (models.Blob, error)
+}
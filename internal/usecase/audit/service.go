@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+	"book-store-api/internal/usecase/audit/interfaces"
+)
+
+const verifyBatchSize = 1000
+
+type Service struct {
+	logger *slog.Logger
+	repo   interfaces.Repository
+}
+
+func NewService(logger *slog.Logger, repo interfaces.Repository) *Service {
+	return &Service{logger: logger, repo: repo}
+}
+
+func (s *Service) List(ctx context.Context, f models.AuditFilter) ([]models.AuditEvent, error) {
+	f.Limit = models.NormalizeAuditLimit(f.Limit)
+
+	events, err := s.repo.List(ctx, f)
+	if err != nil {
+		s.logger.Error("db error", "list audit events err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+	return events, nil
+}
+
+// DeletedBooks — книги, удалённые начиная с since; по ним дельта-выгрузки сообщают об удалении.
+func (s *Service) DeletedBooks(ctx context.Context, since time.Time) ([]models.DeletedBook, error) {
+	deleted, err := s.repo.DeletedBooks(ctx, since)
+	if err != nil {
+		s.logger.Error("db error", "list deleted books err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+	return deleted, nil
+}
+
+// Verify проходит всю цепочку от первой записи и находит первое расхождение.
+func (s *Service) Verify(ctx context.Context) (models.AuditVerification, error) {
+	var (
+		res      = models.AuditVerification{Valid: true}
+		prevHash string
+		afterID  int64
+	)
+	for {
+		events, err := s.repo.Chain(ctx, afterID, verifyBatchSize)
+		if err != nil {
+			s.logger.Error("db error", "read audit chain err", err)
+			return models.AuditVerification{}, usecase.ErrDbInfrastructure
+		}
+		if len(events) == 0 {
+			return res, nil
+		}
+
+		last, brokenAt, ok := models.VerifyAuditChain(prevHash, events)
+		if !ok {
+			for _, e := range events {
+				if e.ID == brokenAt {
+					break
+				}
+				res.Checked++
+			}
+			res.Valid = false
+			res.BrokenAt = brokenAt
+			return res, nil
+		}
+		res.Checked += len(events)
+		prevHash = last
+		afterID = events[len(events)-1].ID
+	}
+}
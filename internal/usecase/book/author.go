@@ -0,0 +1,33 @@
+package book
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+)
+
+// BooksByAuthors — пакетная выборка для GraphQL: по limit первых книг каждого автора.
+// Авторы без книг в ответе отсутствуют.
+func (s *Service) BooksByAuthors(ctx context.Context, authors []string, limit int) (map[string][]models.Book, error) {
+	books, err := s.repository.ListByAuthors(ctx, authors, models.NormalizeBookPageSize(limit))
+	if err != nil {
+		s.logger.Error("db error", "ListByAuthors err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+
+	byAuthor := make(map[string][]models.Book, len(authors))
+	for _, b := range books {
+		byAuthor[b.Author] = append(byAuthor[b.Author], b)
+	}
+	return byAuthor, nil
+}
+
+func (s *Service) CountByAuthors(ctx context.Context, authors []string) (map[string]int, error) {
+	counts, err := s.repository.CountByAuthors(ctx, authors)
+	if err != nil {
+		s.logger.Error("db error", "CountByAuthors err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+	return counts, nil
+}
@@ -0,0 +1,49 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+)
+
+func TestService_BooksByAuthors(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := &RepositoryMock{}
+	svc := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), mockRepo, &CacheMock{}, &BlobStorageMock{})
+
+	t.Run("groups by author", func(t *testing.T) {
+		books := []models.Book{
+			{ID: uuid.New(), Author: "Le Guin"},
+			{ID: uuid.New(), Author: "Le Guin"},
+			{ID: uuid.New(), Author: "Lem"},
+		}
+		mockRepo.ListByAuthorsFunc = func(ctx context.Context, authors []string, perAuthor int) ([]models.Book, error) {
+			assert.Equal(t, []string{"Le Guin", "Lem", "Nobody"}, authors)
+			assert.Equal(t, 3, perAuthor)
+			return books, nil
+		}
+
+		got, err := svc.BooksByAuthors(ctx, []string{"Le Guin", "Lem", "Nobody"}, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, books[:2], got["Le Guin"])
+		assert.Equal(t, books[2:], got["Lem"])
+		assert.NotContains(t, got, "Nobody")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.ListByAuthorsFunc = func(ctx context.Context, authors []string, perAuthor int) ([]models.Book, error) {
+			return nil, errors.New("db error")
+		}
+
+		_, err := svc.BooksByAuthors(ctx, []string{"Lem"}, 3)
+		assert.Equal(t, usecase.ErrDbInfrastructure, err)
+	})
+}
@@ -0,0 +1,157 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// errBatchRejected откатывает транзакцию атомарного пакета с ошибками элементов.
+var errBatchRejected = errors.New("batch rejected")
+
+// Batch применяет пакет операций одной транзакцией. В атомарном режиме ошибка
+// любого элемента отменяет весь пакет, в режиме best_effort такие элементы пропускаются.
+// Ошибка возвращается только для невалидного пакета и сбоев инфраструктуры;
+// результат каждого элемента — в BookBatchResult.Items.
+func (s *Service) Batch(ctx context.Context, mode models.BookBatchMode, ops []models.BookBatchOp) (models.BookBatchResult, error) {
+	if err := models.ValidateBookBatch(mode, ops); err != nil {
+		return models.BookBatchResult{}, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	items := make([]models.BookBatchItem, len(ops))
+	books := make([]models.Book, len(ops))
+	seen := make(map[uuid.UUID]bool, len(ops))
+	var ids []uuid.UUID
+	for i, op := range ops {
+		items[i] = models.BookBatchItem{Index: i, Op: op.Op, ID: op.ID}
+		if op.Op == models.BatchOpCreate {
+			op.Book.ID = uuid.New()
+			items[i].ID = op.Book.ID
+		}
+
+		book, err := models.ValidateBookBatchOp(op)
+		if err == nil && op.Op != models.BatchOpCreate && seen[op.ID] {
+			err = fmt.Errorf("%w: book %s appears more than once in the batch", models.ErrDomainValidation, op.ID)
+		}
+		if err != nil {
+			items[i].Status, items[i].Err = models.BatchItemInvalid, err
+			continue
+		}
+		books[i] = book
+		if op.Op != models.BatchOpCreate {
+			seen[op.ID] = true
+			ids = append(ids, op.ID)
+		}
+	}
+
+	var changes []models.BookChange
+	err := s.repository.ApplyBatch(ctx, ids, func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error) {
+		changes = changes[:0]
+		for i := range items {
+			if items[i].Status == models.BatchItemInvalid {
+				continue
+			}
+			change, ok := planChange(items[i].Op, books[i], existing, now)
+			if !ok {
+				items[i].Status, items[i].Err = models.BatchItemNotFound, fmt.Errorf("book %s not found", items[i].ID)
+				continue
+			}
+			changes = append(changes, change)
+		}
+		if mode == models.BatchAtomic && hasFailures(items) {
+			return nil, errBatchRejected
+		}
+		return changes, nil
+	})
+
+	switch {
+	case errors.Is(err, errBatchRejected):
+		for i := range items {
+			if items[i].Status == "" {
+				items[i].Status = models.BatchItemRolledBack
+			}
+		}
+		return models.BookBatchResult{Items: items}, nil
+	case err != nil:
+		s.logger.Error("db error", "ApplyBatch err", err)
+		return models.BookBatchResult{}, usecase.ErrDbInfrastructure
+	}
+
+	for i := range items {
+		if items[i].Status == "" {
+			items[i].Status = appliedStatus(items[i].Op)
+		}
+	}
+	s.refreshCache(ctx, changes)
+
+	return models.BookBatchResult{Committed: true, Items: items, Changes: changes}, nil
+}
+
+func planChange(op models.BookBatchOpType, book models.Book, existing map[uuid.UUID]models.Book, now time.Time) (models.BookChange, bool) {
+	if op == models.BatchOpCreate {
+		book.CreatedAt, book.UpdatedAt = now, now
+		return models.BookChange{Op: op, After: &book}, true
+	}
+
+	old, ok := existing[book.ID]
+	if !ok {
+		return models.BookChange{}, false
+	}
+	if op == models.BatchOpDelete {
+		return models.BookChange{Op: op, Before: &old}, true
+	}
+	book.CreatedAt, book.UpdatedAt = old.CreatedAt, now
+	book.Cover = old.Cover
+	return models.BookChange{Op: op, Before: &old, After: &book}, true
+}
+
+func hasFailures(items []models.BookBatchItem) bool {
+	for _, item := range items {
+		if item.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+func appliedStatus(op models.BookBatchOpType) models.BookBatchStatus {
+	switch op {
+	case models.BatchOpCreate:
+		return models.BatchItemCreated
+	case models.BatchOpUpdate:
+		return models.BatchItemUpdated
+	default:
+		return models.BatchItemDeleted
+	}
+}
+
+// refreshCache обновляет кэш пачкой: ошибки кэша не отменяют записанный пакет.
+func (s *Service) refreshCache(ctx context.Context, changes []models.BookChange) {
+	set := make(map[string]any)
+	var del []string
+	for _, c := range changes {
+		if c.After != nil {
+			set[c.After.ID.String()] = *c.After
+		} else {
+			del = append(del, c.Before.ID.String())
+		}
+	}
+
+	if len(set) > 0 {
+		if err := s.cache.SetMany(ctx, set); err != nil {
+			s.logger.Error("cache error", "SetMany err", err)
+		}
+	}
+	if len(del) > 0 {
+		if err := s.cache.DeleteMany(ctx, del); err != nil {
+			s.logger.Error("cache error", "DeleteMany err", err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+)
+
+func TestService_Batch(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	stored := models.Book{ID: uuid.New(), Title: "Old", Author: "A", ISBN: "1", Price: 100}
+	gone := models.Book{ID: uuid.New(), Title: "Gone", Author: "A", ISBN: "2", Price: 100}
+	valid := models.BookParams{Title: "New", Author: "B", ISBN: "3", Price: 200}
+
+	newRepo := func(applied *[]models.BookChange) *RepositoryMock {
+		return &RepositoryMock{
+			ApplyBatchFunc: func(ctx context.Context, ids []uuid.UUID, plan func(map[uuid.UUID]models.Book) ([]models.BookChange, error)) error {
+				changes, err := plan(map[uuid.UUID]models.Book{stored.ID: stored, gone.ID: gone})
+				if err != nil {
+					return err
+				}
+				*applied = changes
+				return nil
+			},
+		}
+	}
+	ops := func(missing uuid.UUID) []models.BookBatchOp {
+		return []models.BookBatchOp{
+			{Op: models.BatchOpCreate, Book: valid},
+			{Op: models.BatchOpCreate, Book: models.BookParams{Title: "no author"}},
+			{Op: models.BatchOpUpdate, ID: stored.ID, Book: valid},
+			{Op: models.BatchOpUpdate, ID: missing, Book: valid},
+			{Op: models.BatchOpDelete, ID: gone.ID},
+		}
+	}
+	statuses := func(items []models.BookBatchItem) []models.BookBatchStatus {
+		var got []models.BookBatchStatus
+		for _, item := range items {
+			got = append(got, item.Status)
+		}
+		return got
+	}
+
+	t.Run("best effort skips failed items", func(t *testing.T) {
+		var applied []models.BookChange
+		cacheMock := &CacheMock{
+			SetManyFunc:    func(ctx context.Context, values map[string]any) error { return nil },
+			DeleteManyFunc: func(ctx context.Context, keys []string) error { return nil },
+		}
+		svc := NewService(logger, newRepo(&applied), cacheMock, &BlobStorageMock{})
+
+		res, err := svc.Batch(ctx, models.BatchBestEffort, ops(uuid.New()))
+		require.NoError(t, err)
+		assert.True(t, res.Committed)
+		assert.Equal(t, []models.BookBatchStatus{
+			models.BatchItemCreated, models.BatchItemInvalid, models.BatchItemUpdated,
+			models.BatchItemNotFound, models.BatchItemDeleted,
+		}, statuses(res.Items))
+		assert.ErrorIs(t, res.Items[1].Err, models.ErrDomainValidation)
+		assert.NotEqual(t, uuid.Nil, res.Items[0].ID, "created book gets an id")
+
+		require.Len(t, applied, 3)
+		update := applied[1]
+		assert.Equal(t, stored.Price, update.Before.Price)
+		assert.Equal(t, valid.Price, update.After.Price)
+		assert.Equal(t, stored.CreatedAt, update.After.CreatedAt)
+
+		require.Len(t, cacheMock.SetManyCalls(), 1)
+		assert.Len(t, cacheMock.SetManyCalls()[0].Values, 2)
+		require.Len(t, cacheMock.DeleteManyCalls(), 1)
+		assert.Equal(t, []string{gone.ID.String()}, cacheMock.DeleteManyCalls()[0].Keys)
+	})
+
+	t.Run("atomic rolls back on item failure", func(t *testing.T) {
+		var applied []models.BookChange
+		cacheMock := &CacheMock{}
+		svc := NewService(logger, newRepo(&applied), cacheMock, &BlobStorageMock{})
+
+		res, err := svc.Batch(ctx, models.BatchAtomic, ops(uuid.New()))
+		require.NoError(t, err)
+		assert.False(t, res.Committed)
+		assert.Equal(t, []models.BookBatchStatus{
+			models.BatchItemRolledBack, models.BatchItemInvalid, models.BatchItemRolledBack,
+			models.BatchItemNotFound, models.BatchItemRolledBack,
+		}, statuses(res.Items))
+		assert.Empty(t, applied)
+		assert.Empty(t, res.Changes)
+	})
+
+	t.Run("duplicate id is invalid", func(t *testing.T) {
+		var applied []models.BookChange
+		svc := NewService(logger, newRepo(&applied), &CacheMock{
+			SetManyFunc: func(ctx context.Context, values map[string]any) error { return nil },
+		}, &BlobStorageMock{})
+
+		res, err := svc.Batch(ctx, models.BatchBestEffort, []models.BookBatchOp{
+			{Op: models.BatchOpUpdate, ID: stored.ID, Book: valid},
+			{Op: models.BatchOpUpdate, ID: stored.ID, Book: valid},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []models.BookBatchStatus{models.BatchItemUpdated, models.BatchItemInvalid}, statuses(res.Items))
+	})
+
+	t.Run("invalid envelope", func(t *testing.T) {
+		svc := NewService(logger, &RepositoryMock{}, &CacheMock{}, &BlobStorageMock{})
+
+		_, err := svc.Batch(ctx, "sometimes", nil)
+		var verr *models.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Len(t, verr.Fields, 2)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		svc := NewService(logger, &RepositoryMock{
+			ApplyBatchFunc: func(ctx context.Context, ids []uuid.UUID, plan func(map[uuid.UUID]models.Book) ([]models.BookChange, error)) error {
+				return errors.New("connection reset")
+			},
+		}, &CacheMock{}, &BlobStorageMock{})
+
+		_, err := svc.Batch(ctx, models.BatchAtomic, ops(uuid.New()))
+		assert.Equal(t, usecase.ErrDbInfrastructure, err)
+	})
+}
@@ -0,0 +1,29 @@
+package book
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+)
+
+// exportFetchSize — строк за один FETCH курсора выгрузки.
+const exportFetchSize = 1000
+
+// Export отдаёт книги в emit по мере чтения из базы. Ошибка emit (например, клиент
+// отключился) прерывает выгрузку и возвращается без изменений.
+func (s *Service) Export(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error {
+	var emitErr error
+	err := s.repository.StreamBooks(ctx, exp.Filter, exp.Columns, exportFetchSize, func(b models.Book) error {
+		emitErr = emit(b)
+		return emitErr
+	})
+	if emitErr != nil {
+		return emitErr
+	}
+	if err != nil {
+		s.logger.Error("db error", "StreamBooks err", err)
+		return usecase.ErrDbInfrastructure
+	}
+	return nil
+}
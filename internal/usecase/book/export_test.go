@@ -0,0 +1,64 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+)
+
+func TestService_Export(t *testing.T) {
+	ctx := context.Background()
+	books := []models.Book{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	mockRepo := &RepositoryMock{
+		StreamBooksFunc: func(ctx context.Context, filter models.BookFilter, columns []string, fetchSize int, fn func(models.Book) error) error {
+			for _, b := range books {
+				if err := fn(b); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	svc := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), mockRepo, &CacheMock{}, &BlobStorageMock{})
+	exp := models.BookExport{Format: models.ExportCSV, Columns: []string{"id"}, Filter: models.BookFilter{Author: "Lem"}}
+
+	t.Run("streams every book", func(t *testing.T) {
+		var got []models.Book
+		err := svc.Export(ctx, exp, func(b models.Book) error {
+			got = append(got, b)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, books, got)
+		call := mockRepo.StreamBooksCalls()[0]
+		assert.Equal(t, "Lem", call.Filter.Author)
+		assert.Equal(t, []string{"id"}, call.Columns)
+	})
+
+	t.Run("emit error stops the stream", func(t *testing.T) {
+		clientGone := errors.New("broken pipe")
+		calls := 0
+		err := svc.Export(ctx, exp, func(b models.Book) error {
+			calls++
+			return clientGone
+		})
+		assert.Equal(t, clientGone, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.StreamBooksFunc = func(ctx context.Context, filter models.BookFilter, columns []string, fetchSize int, fn func(models.Book) error) error {
+			return errors.New("db error")
+		}
+		err := svc.Export(ctx, exp, func(b models.Book) error { return nil })
+		assert.Equal(t, usecase.ErrDbInfrastructure, err)
+	})
+}
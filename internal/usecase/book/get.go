@@ -18,6 +18,24 @@ func (s *Service) GetAll(ctx context.Context) ([]models.Book, error) {
 	return books, nil
 }
 
+// List отдаёт страницу книг после курсора; лишняя запись показывает, есть ли следующая страница.
+func (s *Service) List(ctx context.Context, after *models.BookCursor, size int) (models.BookPage, error) {
+	size = models.NormalizeBookPageSize(size)
+	books, err := s.repository.ListPage(ctx, after, size+1)
+	if err != nil {
+		s.logger.Error("db error", "ListPage err", err)
+		return models.BookPage{}, usecase.ErrDbInfrastructure
+	}
+
+	page := models.BookPage{Books: books}
+	if len(books) > size {
+		page.Books = books[:size]
+		next := models.CursorOf(page.Books[size-1])
+		page.Next = &next
+	}
+	return page, nil
+}
+
 func (s *Service) GetByID(ctx context.Context, id string) (*models.Book, error) {
 	cached, err := s.cache.Get(ctx, id)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -21,7 +22,7 @@ func TestGetAll(t *testing.T) {
 	mockRepo := &RepositoryMock{}
 	cacheMock := &CacheMock{}
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	svc := NewService(logger, mockRepo, cacheMock)
+	svc := NewService(logger, mockRepo, cacheMock, &BlobStorageMock{})
 
 	t.Run("success", func(t *testing.T) {
 		expectedBooks := []models.Book{
@@ -158,3 +159,51 @@ func testGetByIDRepoError(t *testing.T) {
 		true,
 	)
 }
+
+func TestService_List(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 10, 26, 9, 0, 0, 0, time.UTC)
+	books := []models.Book{
+		{ID: uuid.New(), Title: "A", CreatedAt: base},
+		{ID: uuid.New(), Title: "B", CreatedAt: base.Add(time.Minute)},
+		{ID: uuid.New(), Title: "C", CreatedAt: base.Add(2 * time.Minute)},
+	}
+	mockRepo := &RepositoryMock{}
+	svc := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), mockRepo, &CacheMock{}, &BlobStorageMock{})
+
+	t.Run("has next page", func(t *testing.T) {
+		mockRepo.ListPageFunc = func(ctx context.Context, after *models.BookCursor, limit int) ([]models.Book, error) {
+			assert.Equal(t, 3, limit, "one extra row to detect the next page")
+			return books, nil
+		}
+
+		page, err := svc.List(ctx, nil, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, books[:2], page.Books)
+		if assert.NotNil(t, page.Next) {
+			assert.Equal(t, models.CursorOf(books[1]), *page.Next)
+		}
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		after := models.CursorOf(books[1])
+		mockRepo.ListPageFunc = func(ctx context.Context, got *models.BookCursor, limit int) ([]models.Book, error) {
+			assert.Equal(t, &after, got)
+			return books[2:], nil
+		}
+
+		page, err := svc.List(ctx, &after, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, books[2:], page.Books)
+		assert.Nil(t, page.Next)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.ListPageFunc = func(ctx context.Context, after *models.BookCursor, limit int) ([]models.Book, error) {
+			return nil, errors.New("db error")
+		}
+
+		_, err := svc.List(ctx, nil, 0)
+		assert.Equal(t, usecase.ErrDbInfrastructure, err)
+	})
+}
@@ -6,4 +6,6 @@ type Cache interface {
 	Set(ctx context.Context, key string, value interface{}) error
 	Get(ctx context.Context, key string) (interface{}, error)
 	Delete(ctx context.Context, key string) error
+	SetMany(ctx context.Context, values map[string]any) error
+	DeleteMany(ctx context.Context, keys []string) error
 }
@@ -4,13 +4,23 @@ import (
 	"context"
 
 	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
 )
 
 type Repository interface {
 	Create(ctx context.Context, book models.Book) error
 	GetAll(ctx context.Context) ([]models.Book, error)
 	GetById(ctx context.Context, id string) (models.Book, error)
-	Update(ctx context.Context, book models.Book) error
+	Update(ctx context.Context, book *models.Book) error
 	Delete(ctx context.Context, id string) error
+	Merge(ctx context.Context, canonicalID, duplicateID string) (canonical, duplicate models.Book, err error)
 	GetAllWithLimit(ctx context.Context, limit int) ([]models.Book, error)
+	ListPage(ctx context.Context, after *models.BookCursor, limit int) ([]models.Book, error)
+	ListByAuthors(ctx context.Context, authors []string, perAuthor int) ([]models.Book, error)
+	CountByAuthors(ctx context.Context, authors []string) (map[string]int, error)
+	ListByISBNs(ctx context.Context, isbns []string) ([]models.Book, error)
+	StreamBooks(ctx context.Context, filter models.BookFilter, columns []string, fetchSize int, fn func(models.Book) error) error
+	SelectBooks(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error)
+	ApplyBatch(ctx context.Context, ids []uuid.UUID, plan func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error)) error
 }
@@ -0,0 +1,9 @@
+package interfaces
+
+import "context"
+
+// BlobStorage — хранилище миниатюр обложек; книге нужно только удалять их вместе с ней.
+// Delete для отсутствующего ключа возвращает nil.
+type BlobStorage interface {
+	Delete(ctx context.Context, key string) error
+}
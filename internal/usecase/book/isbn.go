@@ -0,0 +1,23 @@
+package book
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+)
+
+// BooksByISBN — поиск для импорта с upsert: ключ — ISBN, отсутствующие в каталоге не попадают в ответ.
+func (s *Service) BooksByISBN(ctx context.Context, isbns []string) (map[string]models.Book, error) {
+	books, err := s.repository.ListByISBNs(ctx, isbns)
+	if err != nil {
+		s.logger.Error("db error", "ListByISBNs err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+
+	byISBN := make(map[string]models.Book, len(books))
+	for _, b := range books {
+		byISBN[b.ISBN] = b
+	}
+	return byISBN, nil
+}
@@ -0,0 +1,42 @@
+package book
+
+import (
+	"context"
+	"errors"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+// Merge оставляет каноничную книгу и удаляет дубликат одной транзакцией. Ссылок на книги
+// (склад, строки заказов, отзывы) в схеме пока нет, переназначать нечего; когда появятся,
+// перенос должен идти в той же транзакции, что и удаление дубликата. Миниатюры обложки
+// дубликата удаляются после коммита, как прежняя версия при смене обложки.
+func (s *Service) Merge(ctx context.Context, canonicalID, duplicateID string) (*models.Book, error) {
+	if canonicalID == duplicateID {
+		verr := &models.ValidationError{}
+		verr.Add("duplicate_id", models.FieldInvalid, "duplicate_id must differ from canonical_id")
+		return nil, verr.Err()
+	}
+
+	canonical, duplicate, err := s.repository.Merge(ctx, canonicalID, duplicateID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		s.logger.Error("db error", "merge books err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+
+	if err := s.cache.Delete(ctx, duplicateID); err != nil {
+		s.logger.Error("cache delete error", "merge books err", err)
+	}
+	// ошибки только логируются: дубликат уже удалён
+	for _, img := range duplicate.Cover.Images(duplicate.ID) {
+		if err := s.storage.Delete(ctx, img.Path); err != nil {
+			s.logger.Error("storage error", "Delete err", err, "key", img.Path)
+		}
+	}
+	return &canonical, nil
+}
@@ -0,0 +1,84 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func TestService_Merge(t *testing.T) {
+	ctx := context.Background()
+	canonical := models.Book{ID: uuid.New(), Title: "Солярис", Author: "Станислав Лем",
+		Cover: models.BookCover{Version: "aaaaaaaaaaaaaaaa", Width: 400, Height: 600}}
+	dupID := uuid.New()
+	duplicate := dupID.String()
+	dupCover := models.BookCover{Version: "00112233aabbccdd", Width: 400, Height: 600}
+	books := map[string]models.Book{canonical.ID.String(): canonical, duplicate: {ID: dupID, Title: "Солярис", Cover: dupCover}}
+
+	repo := &RepositoryMock{
+		MergeFunc: func(ctx context.Context, canonicalID, duplicateID string) (models.Book, models.Book, error) {
+			switch {
+			case canonicalID == "broken":
+				return models.Book{}, models.Book{}, errors.New("connection reset")
+			case books[canonicalID] == (models.Book{}):
+				return models.Book{}, models.Book{}, repository.ErrNotFound
+			case books[duplicateID] == (models.Book{}):
+				return models.Book{}, models.Book{}, repository.ErrNotFound
+			}
+			dup := books[duplicateID]
+			delete(books, duplicateID)
+			return books[canonicalID], dup, nil
+		},
+	}
+	cache := &CacheMock{
+		DeleteFunc: func(ctx context.Context, key string) error { return nil },
+	}
+	storage := &BlobStorageMock{DeleteFunc: func(ctx context.Context, key string) error {
+		if strings.HasSuffix(key, ".webp") {
+			return errors.New("bucket is unavailable")
+		}
+		return nil
+	}}
+	svc := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, cache, storage)
+
+	got, err := svc.Merge(ctx, canonical.ID.String(), duplicate)
+	require.NoError(t, err, "storage errors do not fail a committed merge")
+	assert.Equal(t, canonical, *got)
+	assert.NotContains(t, books, duplicate)
+	require.Len(t, cache.DeleteCalls(), 1)
+	assert.Equal(t, duplicate, cache.DeleteCalls()[0].Key, "merged duplicate is evicted from the cache")
+
+	var removed, want []string
+	for _, c := range storage.DeleteCalls() {
+		removed = append(removed, c.Key)
+	}
+	for _, img := range dupCover.Images(dupID) {
+		want = append(want, img.Path)
+	}
+	assert.Equal(t, want, removed, "every thumbnail of the duplicate is removed, the canonical cover is kept")
+
+	_, err = svc.Merge(ctx, canonical.ID.String(), duplicate)
+	assert.ErrorIs(t, err, repository.ErrNotFound, "duplicate is already gone")
+	_, err = svc.Merge(ctx, uuid.NewString(), canonical.ID.String())
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Contains(t, books, canonical.ID.String(), "nothing is deleted without the canonical book")
+
+	_, err = svc.Merge(ctx, "broken", duplicate)
+	assert.ErrorIs(t, err, usecase.ErrDbInfrastructure)
+	assert.Len(t, storage.DeleteCalls(), len(want), "failed merges leave the blobs alone")
+
+	_, err = svc.Merge(ctx, duplicate, duplicate)
+	assert.ErrorIs(t, err, models.ErrDomainValidation)
+	assert.Len(t, repo.MergeCalls(), 4, "identical ids never reach the repository")
+}
@@ -4,10 +4,9 @@
 package book
 
 import (
+	"book-store-api/internal/usecase/book/interfaces"
 	"context"
 	"sync"
-
-	"book-store-api/internal/usecase/book/interfaces"
 )
 
 // Ensure, that CacheMock does implement Cache.
@@ -23,12 +22,18 @@ var _ interfaces.Cache = &CacheMock{}
 //			DeleteFunc: func(ctx context.Context, key string) error {
 //				panic("mock out the Delete method")
 //			},
+//			DeleteManyFunc: func(ctx context.Context, keys []string) error {
+//				panic("mock out the DeleteMany method")
+//			},
 //			GetFunc: func(ctx context.Context, key string) (interface{}, error) {
 //				panic("mock out the Get method")
 //			},
 //			SetFunc: func(ctx context.Context, key string, value interface{}) error {
 //				panic("mock out the Set method")
 //			},
+//			SetManyFunc: func(ctx context.Context, values map[string]any) error {
+//				panic("mock out the SetMany method")
+//			},
 //		}
 //
 //		// use mockedCache in code that requires Cache
@@ -39,12 +44,18 @@ type CacheMock struct {
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, key string) error
 
+	// DeleteManyFunc mocks the DeleteMany method.
+	DeleteManyFunc func(ctx context.Context, keys []string) error
+
 	// GetFunc mocks the Get method.
 	GetFunc func(ctx context.Context, key string) (interface{}, error)
 
 	// SetFunc mocks the Set method.
 	SetFunc func(ctx context.Context, key string, value interface{}) error
 
+	// SetManyFunc mocks the SetMany method.
+	SetManyFunc func(ctx context.Context, values map[string]any) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Delete holds details about calls to the Delete method.
@@ -54,6 +65,13 @@ type CacheMock struct {
 			// Key is the key argument value.
 			Key string
 		}
+		// DeleteMany holds details about calls to the DeleteMany method.
+		DeleteMany []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Keys is the keys argument value.
+			Keys []string
+		}
 		// Get holds details about calls to the Get method.
 		Get []struct {
 			// Ctx is the ctx argument value.
@@ -70,10 +88,19 @@ type CacheMock struct {
 			// Value is the value argument value.
 			Value interface{}
 		}
+		// SetMany holds details about calls to the SetMany method.
+		SetMany []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Values is the values argument value.
+			Values map[string]any
+		}
 	}
-	lockDelete sync.RWMutex
-	lockGet    sync.RWMutex
-	lockSet    sync.RWMutex
+	lockDelete     sync.RWMutex
+	lockDeleteMany sync.RWMutex
+	lockGet        sync.RWMutex
+	lockSet        sync.RWMutex
+	lockSetMany    sync.RWMutex
 }
 
 // Delete calls DeleteFunc.
@@ -112,6 +139,42 @@ func (mock *CacheMock) DeleteCalls() []struct {
 	return calls
 }
 
+// DeleteMany calls DeleteManyFunc.
+func (mock *CacheMock) DeleteMany(ctx context.Context, keys []string) error {
+	if mock.DeleteManyFunc == nil {
+		panic("CacheMock.DeleteManyFunc: method is nil but Cache.DeleteMany was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Keys []string
+	}{
+		Ctx:  ctx,
+		Keys: keys,
+	}
+	mock.lockDeleteMany.Lock()
+	mock.calls.DeleteMany = append(mock.calls.DeleteMany, callInfo)
+	mock.lockDeleteMany.Unlock()
+	return mock.DeleteManyFunc(ctx, keys)
+}
+
+// DeleteManyCalls gets all the calls that were made to DeleteMany.
+// Check the length with:
+//
+//	len(mockedCache.DeleteManyCalls())
+func (mock *CacheMock) DeleteManyCalls() []struct {
+	Ctx  context.Context
+	Keys []string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Keys []string
+	}
+	mock.lockDeleteMany.RLock()
+	calls = mock.calls.DeleteMany
+	mock.lockDeleteMany.RUnlock()
+	return calls
+}
+
 // Get calls GetFunc.
 func (mock *CacheMock) Get(ctx context.Context, key string) (interface{}, error) {
 	if mock.GetFunc == nil {
@@ -187,3 +250,39 @@ func (mock *CacheMock) SetCalls() []struct {
 	mock.lockSet.RUnlock()
 	return calls
 }
+
+// SetMany calls SetManyFunc.
+func (mock *CacheMock) SetMany(ctx context.Context, values map[string]any) error {
+	if mock.SetManyFunc == nil {
+		panic("CacheMock.SetManyFunc: method is nil but Cache.SetMany was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Values map[string]any
+	}{
+		Ctx:    ctx,
+		Values: values,
+	}
+	mock.lockSetMany.Lock()
+	mock.calls.SetMany = append(mock.calls.SetMany, callInfo)
+	mock.lockSetMany.Unlock()
+	return mock.SetManyFunc(ctx, values)
+}
+
+// SetManyCalls gets all the calls that were made to SetMany.
+// Check the length with:
+//
+//	len(mockedCache.SetManyCalls())
+func (mock *CacheMock) SetManyCalls() []struct {
+	Ctx    context.Context
+	Values map[string]any
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Values map[string]any
+	}
+	mock.lockSetMany.RLock()
+	calls = mock.calls.SetMany
+	mock.lockSetMany.RUnlock()
+	return calls
+}
@@ -7,6 +7,7 @@ import (
 	"book-store-api/internal/models"
 	"book-store-api/internal/usecase/book/interfaces"
 	"context"
+	"github.com/google/uuid"
 	"sync"
 )
 
@@ -20,6 +21,12 @@ var _ interfaces.Repository = &RepositoryMock{}
 //
 //		// make and configure a mocked Repository
 //		mockedRepository := &RepositoryMock{
+//			ApplyBatchFunc: func(ctx context.Context, ids []uuid.UUID, plan func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error)) error {
+//				panic("mock out the ApplyBatch method")
+//			},
+//			CountByAuthorsFunc: func(ctx context.Context, authors []string) (map[string]int, error) {
+//				panic("mock out the CountByAuthors method")
+//			},
 //			CreateFunc: func(ctx context.Context, book models.Book) error {
 //				panic("mock out the Create method")
 //			},
@@ -35,7 +42,25 @@ var _ interfaces.Repository = &RepositoryMock{}
 //			GetByIdFunc: func(ctx context.Context, id string) (models.Book, error) {
 //				panic("mock out the GetById method")
 //			},
-//			UpdateFunc: func(ctx context.Context, book models.Book) error {
+//			ListByAuthorsFunc: func(ctx context.Context, authors []string, perAuthor int) ([]models.Book, error) {
+//				panic("mock out the ListByAuthors method")
+//			},
+//			ListByISBNsFunc: func(ctx context.Context, isbns []string) ([]models.Book, error) {
+//				panic("mock out the ListByISBNs method")
+//			},
+//			ListPageFunc: func(ctx context.Context, after *models.BookCursor, limit int) ([]models.Book, error) {
+//				panic("mock out the ListPage method")
+//			},
+//			MergeFunc: func(ctx context.Context, canonicalID string, duplicateID string) (models.Book, models.Book, error) {
+//				panic("mock out the Merge method")
+//			},
+//			SelectBooksFunc: func(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error) {
+//				panic("mock out the SelectBooks method")
+//			},
+//			StreamBooksFunc: func(ctx context.Context, filter models.BookFilter, columns []string, fetchSize int, fn func(models.Book) error) error {
+//				panic("mock out the StreamBooks method")
+//			},
+//			UpdateFunc: func(ctx context.Context, book *models.Book) error {
 //				panic("mock out the Update method")
 //			},
 //		}
@@ -45,6 +70,12 @@ var _ interfaces.Repository = &RepositoryMock{}
 //
 //	}
 type RepositoryMock struct {
+	// ApplyBatchFunc mocks the ApplyBatch method.
+	ApplyBatchFunc func(ctx context.Context, ids []uuid.UUID, plan func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error)) error
+
+	// CountByAuthorsFunc mocks the CountByAuthors method.
+	CountByAuthorsFunc func(ctx context.Context, authors []string) (map[string]int, error)
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, book models.Book) error
 
@@ -60,11 +91,45 @@ type RepositoryMock struct {
 	// GetByIdFunc mocks the GetById method.
 	GetByIdFunc func(ctx context.Context, id string) (models.Book, error)
 
+	// ListByAuthorsFunc mocks the ListByAuthors method.
+	ListByAuthorsFunc func(ctx context.Context, authors []string, perAuthor int) ([]models.Book, error)
+
+	// ListByISBNsFunc mocks the ListByISBNs method.
+	ListByISBNsFunc func(ctx context.Context, isbns []string) ([]models.Book, error)
+
+	// ListPageFunc mocks the ListPage method.
+	ListPageFunc func(ctx context.Context, after *models.BookCursor, limit int) ([]models.Book, error)
+
+	// MergeFunc mocks the Merge method.
+	MergeFunc func(ctx context.Context, canonicalID string, duplicateID string) (models.Book, models.Book, error)
+
+	// SelectBooksFunc mocks the SelectBooks method.
+	SelectBooksFunc func(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error)
+
+	// StreamBooksFunc mocks the StreamBooks method.
+	StreamBooksFunc func(ctx context.Context, filter models.BookFilter, columns []string, fetchSize int, fn func(models.Book) error) error
+
 	// UpdateFunc mocks the Update method.
-	UpdateFunc func(ctx context.Context, book models.Book) error
+	UpdateFunc func(ctx context.Context, book *models.Book) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ApplyBatch holds details about calls to the ApplyBatch method.
+		ApplyBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []uuid.UUID
+			// Plan is the plan argument value.
+			Plan func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error)
+		}
+		// CountByAuthors holds details about calls to the CountByAuthors method.
+		CountByAuthors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Authors is the authors argument value.
+			Authors []string
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Ctx is the ctx argument value.
@@ -98,22 +163,162 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// ListByAuthors holds details about calls to the ListByAuthors method.
+		ListByAuthors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Authors is the authors argument value.
+			Authors []string
+			// PerAuthor is the perAuthor argument value.
+			PerAuthor int
+		}
+		// ListByISBNs holds details about calls to the ListByISBNs method.
+		ListByISBNs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Isbns is the isbns argument value.
+			Isbns []string
+		}
+		// ListPage holds details about calls to the ListPage method.
+		ListPage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// After is the after argument value.
+			After *models.BookCursor
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// Merge holds details about calls to the Merge method.
+		Merge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CanonicalID is the canonicalID argument value.
+			CanonicalID string
+			// DuplicateID is the duplicateID argument value.
+			DuplicateID string
+		}
+		// SelectBooks holds details about calls to the SelectBooks method.
+		SelectBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.BookFilter
+			// Columns is the columns argument value.
+			Columns []string
+		}
+		// StreamBooks holds details about calls to the StreamBooks method.
+		StreamBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.BookFilter
+			// Columns is the columns argument value.
+			Columns []string
+			// FetchSize is the fetchSize argument value.
+			FetchSize int
+			// Fn is the fn argument value.
+			Fn func(models.Book) error
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Book is the book argument value.
-			Book models.Book
+			Book *models.Book
 		}
 	}
+	lockApplyBatch      sync.RWMutex
+	lockCountByAuthors  sync.RWMutex
 	lockCreate          sync.RWMutex
 	lockDelete          sync.RWMutex
 	lockGetAll          sync.RWMutex
 	lockGetAllWithLimit sync.RWMutex
 	lockGetById         sync.RWMutex
+	lockListByAuthors   sync.RWMutex
+	lockListByISBNs     sync.RWMutex
+	lockListPage        sync.RWMutex
+	lockMerge           sync.RWMutex
+	lockSelectBooks     sync.RWMutex
+	lockStreamBooks     sync.RWMutex
 	lockUpdate          sync.RWMutex
 }
 
+// ApplyBatch calls ApplyBatchFunc.
+func (mock *RepositoryMock) ApplyBatch(ctx context.Context, ids []uuid.UUID, plan func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error)) error {
+	if mock.ApplyBatchFunc == nil {
+		panic("RepositoryMock.ApplyBatchFunc: method is nil but Repository.ApplyBatch was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Ids  []uuid.UUID
+		Plan func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error)
+	}{
+		Ctx:  ctx,
+		Ids:  ids,
+		Plan: plan,
+	}
+	mock.lockApplyBatch.Lock()
+	mock.calls.ApplyBatch = append(mock.calls.ApplyBatch, callInfo)
+	mock.lockApplyBatch.Unlock()
+	return mock.ApplyBatchFunc(ctx, ids, plan)
+}
+
+// ApplyBatchCalls gets all the calls that were made to ApplyBatch.
+// Check the length with:
+//
+//	len(mockedRepository.ApplyBatchCalls())
+func (mock *RepositoryMock) ApplyBatchCalls() []struct {
+	Ctx  context.Context
+	Ids  []uuid.UUID
+	Plan func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error)
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Ids  []uuid.UUID
+		Plan func(existing map[uuid.UUID]models.Book) ([]models.BookChange, error)
+	}
+	mock.lockApplyBatch.RLock()
+	calls = mock.calls.ApplyBatch
+	mock.lockApplyBatch.RUnlock()
+	return calls
+}
+
+// CountByAuthors calls CountByAuthorsFunc.
+func (mock *RepositoryMock) CountByAuthors(ctx context.Context, authors []string) (map[string]int, error) {
+	if mock.CountByAuthorsFunc == nil {
+		panic("RepositoryMock.CountByAuthorsFunc: method is nil but Repository.CountByAuthors was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Authors []string
+	}{
+		Ctx:     ctx,
+		Authors: authors,
+	}
+	mock.lockCountByAuthors.Lock()
+	mock.calls.CountByAuthors = append(mock.calls.CountByAuthors, callInfo)
+	mock.lockCountByAuthors.Unlock()
+	return mock.CountByAuthorsFunc(ctx, authors)
+}
+
+// CountByAuthorsCalls gets all the calls that were made to CountByAuthors.
+// Check the length with:
+//
+//	len(mockedRepository.CountByAuthorsCalls())
+func (mock *RepositoryMock) CountByAuthorsCalls() []struct {
+	Ctx     context.Context
+	Authors []string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Authors []string
+	}
+	mock.lockCountByAuthors.RLock()
+	calls = mock.calls.CountByAuthors
+	mock.lockCountByAuthors.RUnlock()
+	return calls
+}
+
 // Create calls CreateFunc.
 func (mock *RepositoryMock) Create(ctx context.Context, book models.Book) error {
 	if mock.CreateFunc == nil {
@@ -290,14 +495,258 @@ func (mock *RepositoryMock) GetByIdCalls() []struct {
 	return calls
 }
 
+// ListByAuthors calls ListByAuthorsFunc.
+func (mock *RepositoryMock) ListByAuthors(ctx context.Context, authors []string, perAuthor int) ([]models.Book, error) {
+	if mock.ListByAuthorsFunc == nil {
+		panic("RepositoryMock.ListByAuthorsFunc: method is nil but Repository.ListByAuthors was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Authors   []string
+		PerAuthor int
+	}{
+		Ctx:       ctx,
+		Authors:   authors,
+		PerAuthor: perAuthor,
+	}
+	mock.lockListByAuthors.Lock()
+	mock.calls.ListByAuthors = append(mock.calls.ListByAuthors, callInfo)
+	mock.lockListByAuthors.Unlock()
+	return mock.ListByAuthorsFunc(ctx, authors, perAuthor)
+}
+
+// ListByAuthorsCalls gets all the calls that were made to ListByAuthors.
+// Check the length with:
+//
+//	len(mockedRepository.ListByAuthorsCalls())
+func (mock *RepositoryMock) ListByAuthorsCalls() []struct {
+	Ctx       context.Context
+	Authors   []string
+	PerAuthor int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Authors   []string
+		PerAuthor int
+	}
+	mock.lockListByAuthors.RLock()
+	calls = mock.calls.ListByAuthors
+	mock.lockListByAuthors.RUnlock()
+	return calls
+}
+
+// ListByISBNs calls ListByISBNsFunc.
+func (mock *RepositoryMock) ListByISBNs(ctx context.Context, isbns []string) ([]models.Book, error) {
+	if mock.ListByISBNsFunc == nil {
+		panic("RepositoryMock.ListByISBNsFunc: method is nil but Repository.ListByISBNs was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Isbns []string
+	}{
+		Ctx:   ctx,
+		Isbns: isbns,
+	}
+	mock.lockListByISBNs.Lock()
+	mock.calls.ListByISBNs = append(mock.calls.ListByISBNs, callInfo)
+	mock.lockListByISBNs.Unlock()
+	return mock.ListByISBNsFunc(ctx, isbns)
+}
+
+// ListByISBNsCalls gets all the calls that were made to ListByISBNs.
+// Check the length with:
+//
+//	len(mockedRepository.ListByISBNsCalls())
+func (mock *RepositoryMock) ListByISBNsCalls() []struct {
+	Ctx   context.Context
+	Isbns []string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Isbns []string
+	}
+	mock.lockListByISBNs.RLock()
+	calls = mock.calls.ListByISBNs
+	mock.lockListByISBNs.RUnlock()
+	return calls
+}
+
+// ListPage calls ListPageFunc.
+func (mock *RepositoryMock) ListPage(ctx context.Context, after *models.BookCursor, limit int) ([]models.Book, error) {
+	if mock.ListPageFunc == nil {
+		panic("RepositoryMock.ListPageFunc: method is nil but Repository.ListPage was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		After *models.BookCursor
+		Limit int
+	}{
+		Ctx:   ctx,
+		After: after,
+		Limit: limit,
+	}
+	mock.lockListPage.Lock()
+	mock.calls.ListPage = append(mock.calls.ListPage, callInfo)
+	mock.lockListPage.Unlock()
+	return mock.ListPageFunc(ctx, after, limit)
+}
+
+// ListPageCalls gets all the calls that were made to ListPage.
+// Check the length with:
+//
+//	len(mockedRepository.ListPageCalls())
+func (mock *RepositoryMock) ListPageCalls() []struct {
+	Ctx   context.Context
+	After *models.BookCursor
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		After *models.BookCursor
+		Limit int
+	}
+	mock.lockListPage.RLock()
+	calls = mock.calls.ListPage
+	mock.lockListPage.RUnlock()
+	return calls
+}
+
+// Merge calls MergeFunc.
+func (mock *RepositoryMock) Merge(ctx context.Context, canonicalID string, duplicateID string) (models.Book, models.Book, error) {
+	if mock.MergeFunc == nil {
+		panic("RepositoryMock.MergeFunc: method is nil but Repository.Merge was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		CanonicalID string
+		DuplicateID string
+	}{
+		Ctx:         ctx,
+		CanonicalID: canonicalID,
+		DuplicateID: duplicateID,
+	}
+	mock.lockMerge.Lock()
+	mock.calls.Merge = append(mock.calls.Merge, callInfo)
+	mock.lockMerge.Unlock()
+	return mock.MergeFunc(ctx, canonicalID, duplicateID)
+}
+
+// MergeCalls gets all the calls that were made to Merge.
+// Check the length with:
+//
+//	len(mockedRepository.MergeCalls())
+func (mock *RepositoryMock) MergeCalls() []struct {
+	Ctx         context.Context
+	CanonicalID string
+	DuplicateID string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		CanonicalID string
+		DuplicateID string
+	}
+	mock.lockMerge.RLock()
+	calls = mock.calls.Merge
+	mock.lockMerge.RUnlock()
+	return calls
+}
+
+// SelectBooks calls SelectBooksFunc.
+func (mock *RepositoryMock) SelectBooks(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error) {
+	if mock.SelectBooksFunc == nil {
+		panic("RepositoryMock.SelectBooksFunc: method is nil but Repository.SelectBooks was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Filter  models.BookFilter
+		Columns []string
+	}{
+		Ctx:     ctx,
+		Filter:  filter,
+		Columns: columns,
+	}
+	mock.lockSelectBooks.Lock()
+	mock.calls.SelectBooks = append(mock.calls.SelectBooks, callInfo)
+	mock.lockSelectBooks.Unlock()
+	return mock.SelectBooksFunc(ctx, filter, columns)
+}
+
+// SelectBooksCalls gets all the calls that were made to SelectBooks.
+// Check the length with:
+//
+//	len(mockedRepository.SelectBooksCalls())
+func (mock *RepositoryMock) SelectBooksCalls() []struct {
+	Ctx     context.Context
+	Filter  models.BookFilter
+	Columns []string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Filter  models.BookFilter
+		Columns []string
+	}
+	mock.lockSelectBooks.RLock()
+	calls = mock.calls.SelectBooks
+	mock.lockSelectBooks.RUnlock()
+	return calls
+}
+
+// StreamBooks calls StreamBooksFunc.
+func (mock *RepositoryMock) StreamBooks(ctx context.Context, filter models.BookFilter, columns []string, fetchSize int, fn func(models.Book) error) error {
+	if mock.StreamBooksFunc == nil {
+		panic("RepositoryMock.StreamBooksFunc: method is nil but Repository.StreamBooks was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Filter    models.BookFilter
+		Columns   []string
+		FetchSize int
+		Fn        func(models.Book) error
+	}{
+		Ctx:       ctx,
+		Filter:    filter,
+		Columns:   columns,
+		FetchSize: fetchSize,
+		Fn:        fn,
+	}
+	mock.lockStreamBooks.Lock()
+	mock.calls.StreamBooks = append(mock.calls.StreamBooks, callInfo)
+	mock.lockStreamBooks.Unlock()
+	return mock.StreamBooksFunc(ctx, filter, columns, fetchSize, fn)
+}
+
+// StreamBooksCalls gets all the calls that were made to StreamBooks.
+// Check the length with:
+//
+//	len(mockedRepository.StreamBooksCalls())
+func (mock *RepositoryMock) StreamBooksCalls() []struct {
+	Ctx       context.Context
+	Filter    models.BookFilter
+	Columns   []string
+	FetchSize int
+	Fn        func(models.Book) error
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Filter    models.BookFilter
+		Columns   []string
+		FetchSize int
+		Fn        func(models.Book) error
+	}
+	mock.lockStreamBooks.RLock()
+	calls = mock.calls.StreamBooks
+	mock.lockStreamBooks.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
-func (mock *RepositoryMock) Update(ctx context.Context, book models.Book) error {
+func (mock *RepositoryMock) Update(ctx context.Context, book *models.Book) error {
 	if mock.UpdateFunc == nil {
 		panic("RepositoryMock.UpdateFunc: method is nil but Repository.Update was just called")
 	}
 	callInfo := struct {
 		Ctx  context.Context
-		Book models.Book
+		Book *models.Book
 	}{
 		Ctx:  ctx,
 		Book: book,
@@ -314,11 +763,11 @@ func (mock *RepositoryMock) Update(ctx context.Context, book models.Book) error
 //	len(mockedRepository.UpdateCalls())
 func (mock *RepositoryMock) UpdateCalls() []struct {
 	Ctx  context.Context
-	Book models.Book
+	Book *models.Book
 } {
 	var calls []struct {
 		Ctx  context.Context
-		Book models.Book
+		Book *models.Book
 	}
 	mock.lockUpdate.RLock()
 	calls = mock.calls.Update
@@ -0,0 +1,107 @@
+package book
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// Query отдаёт каталог с полями sel; связи подгружаются одним запросом на весь список.
+func (s *Service) Query(ctx context.Context, sel models.BookSelection) ([]models.Book, models.BookRelations, error) {
+	books, err := s.repository.SelectBooks(ctx, models.BookFilter{}, sel.Columns())
+	if err != nil {
+		s.logger.Error("db error", "SelectBooks err", err)
+		return nil, models.BookRelations{}, usecase.ErrDbInfrastructure
+	}
+	rel, err := s.relations(ctx, books, sel)
+	if err != nil {
+		return nil, models.BookRelations{}, err
+	}
+	return books, rel, nil
+}
+
+// QueryByID — то же для одной книги. Кэш GetByID хранит книги целиком, поэтому не используется.
+func (s *Service) QueryByID(ctx context.Context, id string, sel models.BookSelection) (*models.Book, models.BookRelations, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, models.BookRelations{}, repository.ErrNotFound
+	}
+	books, err := s.repository.SelectBooks(ctx, models.BookFilter{IDs: []uuid.UUID{uid}}, sel.Columns())
+	if err != nil {
+		s.logger.Error("db error", "SelectBooks err", err)
+		return nil, models.BookRelations{}, usecase.ErrDbInfrastructure
+	}
+	if len(books) == 0 {
+		return nil, models.BookRelations{}, repository.ErrNotFound
+	}
+	rel, err := s.relations(ctx, books, sel)
+	if err != nil {
+		return nil, models.BookRelations{}, err
+	}
+	return &books[0], rel, nil
+}
+
+func (s *Service) relations(ctx context.Context, books []models.Book, sel models.BookSelection) (models.BookRelations, error) {
+	var rel models.BookRelations
+	if !sel.Includes(models.IncludeAuthors) {
+		return rel, nil
+	}
+
+	seen := make(map[string]bool)
+	var authors []string
+	for _, b := range books {
+		if b.Author != "" && !seen[b.Author] {
+			seen[b.Author] = true
+			authors = append(authors, b.Author)
+		}
+	}
+	rel.Authors = make(map[string]models.AuthorSummary, len(authors))
+	if len(authors) == 0 {
+		return rel, nil
+	}
+	counts, err := s.CountByAuthors(ctx, authors)
+	if err != nil {
+		return models.BookRelations{}, err
+	}
+	for _, a := range authors {
+		rel.Authors[a] = models.AuthorSummary{Name: a, BookCount: counts[a]}
+	}
+	return rel, nil
+}
+
+// GetByIDs отдаёт книги в порядке ids, повторы сохраняются. Неизвестные id —
+// ошибка валидации поля ids со всеми такими id.
+func (s *Service) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]models.Book, error) {
+	books, err := s.repository.SelectBooks(ctx, models.BookFilter{IDs: ids}, models.BookColumns)
+	if err != nil {
+		s.logger.Error("db error", "SelectBooks err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+	byID := make(map[uuid.UUID]models.Book, len(books))
+	for _, b := range books {
+		byID[b.ID] = b
+	}
+
+	out := make([]models.Book, 0, len(ids))
+	verr := &models.ValidationError{}
+	reported := make(map[uuid.UUID]bool)
+	for _, id := range ids {
+		b, ok := byID[id]
+		if !ok {
+			if !reported[id] {
+				reported[id] = true
+				verr.Addf("ids", models.FieldUnknown, "book %s not found", id)
+			}
+			continue
+		}
+		out = append(out, b)
+	}
+	if err := verr.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,113 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func TestService_Query(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := &RepositoryMock{}
+	svc := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), mockRepo, &CacheMock{}, &BlobStorageMock{})
+
+	books := []models.Book{
+		{ID: uuid.New(), Title: "Солярис", Author: "Лем"},
+		{ID: uuid.New(), Title: "Эдем", Author: "Лем"},
+		{ID: uuid.New(), Title: "Аноним"},
+	}
+	mockRepo.SelectBooksFunc = func(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error) {
+		return books, nil
+	}
+	mockRepo.CountByAuthorsFunc = func(ctx context.Context, authors []string) (map[string]int, error) {
+		return map[string]int{"Лем": 7}, nil
+	}
+
+	t.Run("selects only needed columns", func(t *testing.T) {
+		sel, err := models.NewBookSelection(models.BookSelectionParams{Fields: "title", Include: "authors"})
+		require.NoError(t, err)
+
+		got, rel, err := svc.Query(ctx, sel)
+		require.NoError(t, err)
+		assert.Equal(t, books, got)
+		assert.Equal(t, []string{"id", "title", "author"}, mockRepo.SelectBooksCalls()[0].Columns)
+		assert.Equal(t, map[string]models.AuthorSummary{"Лем": {Name: "Лем", BookCount: 7}}, rel.Authors)
+		require.Len(t, mockRepo.CountByAuthorsCalls(), 1)
+		assert.Equal(t, []string{"Лем"}, mockRepo.CountByAuthorsCalls()[0].Authors, "authors are counted once per name")
+	})
+
+	t.Run("no relations requested", func(t *testing.T) {
+		calls := len(mockRepo.CountByAuthorsCalls())
+		sel, err := models.NewBookSelection(models.BookSelectionParams{Fields: "price"})
+		require.NoError(t, err)
+
+		_, rel, err := svc.Query(ctx, sel)
+		require.NoError(t, err)
+		assert.Nil(t, rel.Authors)
+		assert.Len(t, mockRepo.CountByAuthorsCalls(), calls)
+	})
+
+	t.Run("by id", func(t *testing.T) {
+		sel, err := models.NewBookSelection(models.BookSelectionParams{Fields: "title"})
+		require.NoError(t, err)
+
+		got, _, err := svc.QueryByID(ctx, books[0].ID.String(), sel)
+		require.NoError(t, err)
+		assert.Equal(t, books[0], *got)
+		filter := mockRepo.SelectBooksCalls()[len(mockRepo.SelectBooksCalls())-1].Filter
+		assert.Equal(t, []uuid.UUID{books[0].ID}, filter.IDs)
+
+		mockRepo.SelectBooksFunc = func(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error) {
+			return nil, nil
+		}
+		_, _, err = svc.QueryByID(ctx, uuid.NewString(), sel)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.SelectBooksFunc = func(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error) {
+			return nil, errors.New("db error")
+		}
+
+		_, _, err := svc.Query(ctx, models.BookSelection{})
+		assert.Equal(t, usecase.ErrDbInfrastructure, err)
+	})
+}
+
+func TestService_GetByIDs(t *testing.T) {
+	ctx := context.Background()
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+	mockRepo := &RepositoryMock{SelectBooksFunc: func(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error) {
+		return []models.Book{{ID: first, Title: "Солярис"}, {ID: second, Title: "Эдем"}}, nil
+	}}
+	svc := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), mockRepo, &CacheMock{}, &BlobStorageMock{})
+
+	got, err := svc.GetByIDs(ctx, []uuid.UUID{second, first, second})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, []string{"Эдем", "Солярис", "Эдем"}, []string{got[0].Title, got[1].Title, got[2].Title}, "request order and repeats are kept")
+	assert.Equal(t, models.BookColumns, mockRepo.SelectBooksCalls()[0].Columns)
+
+	_, err = svc.GetByIDs(ctx, []uuid.UUID{first, missing, missing})
+	var verr *models.ValidationError
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr.Fields, 1)
+	assert.Equal(t, models.FieldUnknown, verr.Fields[0].Code)
+	assert.Contains(t, verr.Fields[0].Message, missing.String())
+
+	mockRepo.SelectBooksFunc = func(ctx context.Context, filter models.BookFilter, columns []string) ([]models.Book, error) {
+		return nil, errors.New("connection refused")
+	}
+	_, err = svc.GetByIDs(ctx, []uuid.UUID{first})
+	assert.ErrorIs(t, err, usecase.ErrDbInfrastructure)
+}
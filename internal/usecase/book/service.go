@@ -10,12 +10,14 @@ type Service struct {
 	logger     *slog.Logger
 	repository interfaces.Repository
 	cache      interfaces.Cache
+	storage    interfaces.BlobStorage
 }
 
-func NewService(logger *slog.Logger, repo interfaces.Repository, cache interfaces.Cache) *Service {
+func NewService(logger *slog.Logger, repo interfaces.Repository, cache interfaces.Cache, storage interfaces.BlobStorage) *Service {
 	return &Service{
 		logger:     logger,
 		repository: repo,
 		cache:      cache,
+		storage:    storage,
 	}
 }
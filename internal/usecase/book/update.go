@@ -14,9 +14,9 @@ func (s *Service) Update(ctx context.Context, bookInfo models.BookParams) error
 	if err != nil {
 		return err
 	}
-	err = s.repository.Update(ctx, book)
+	err = s.repository.Update(ctx, &book)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrConflict) {
 			return err
 		}
 		s.logger.Error("db error", "update error", err)
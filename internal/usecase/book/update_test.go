@@ -27,7 +27,7 @@ func TestService_Update(t *testing.T) {
 		cacheSetCalled := false
 
 		mockRepo := &RepositoryMock{
-			UpdateFunc: func(ctx context.Context, b models.Book) error {
+			UpdateFunc: func(ctx context.Context, b *models.Book) error {
 				return nil
 			},
 		}
@@ -39,7 +39,7 @@ func TestService_Update(t *testing.T) {
 		}
 
 		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-		svc := NewService(logger, mockRepo, mockCache)
+		svc := NewService(logger, mockRepo, mockCache, &BlobStorageMock{})
 
 		err := svc.Update(ctx, validBookParams)
 		assert.NoError(t, err)
@@ -50,7 +50,7 @@ func TestService_Update(t *testing.T) {
 		mockRepo := &RepositoryMock{}
 		mockCache := &CacheMock{}
 		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-		svc := NewService(logger, mockRepo, mockCache)
+		svc := NewService(logger, mockRepo, mockCache, &BlobStorageMock{})
 
 		invalidBook := models.BookParams{Title: ""}
 		err := svc.Update(ctx, invalidBook)
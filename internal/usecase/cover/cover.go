@@ -0,0 +1,149 @@
+package cover
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"book-store-api/internal/imaging"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// Upload заменяет обложку книги: проверяет изображение, пишет миниатюры всех размеров
+// и форматов под новой версией и только потом переключает книгу на неё.
+// Миниатюры прежней версии удаляются после переключения.
+func (s *Service) Upload(ctx context.Context, id string, data []byte) (models.BookCover, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return models.BookCover{}, repository.ErrNotFound
+	}
+
+	width, height, err := imaging.DecodeConfig(data)
+	if errors.Is(err, imaging.ErrUnsupportedFormat) {
+		return models.BookCover{}, usecase.ErrUnsupportedImage
+	}
+	if err != nil {
+		return models.BookCover{}, invalidImage(err)
+	}
+	if err := models.ValidateCoverSize(width, height); err != nil {
+		return models.BookCover{}, err
+	}
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return models.BookCover{}, invalidImage(err)
+	}
+
+	cover := models.BookCover{Version: s.newVersion(), Width: width, Height: height}
+	var written []string
+	for _, size := range models.CoverSizes {
+		w, h := cover.Scaled(size.Width)
+		thumb := imaging.Resize(img, w, h)
+		for _, format := range models.CoverFormats {
+			var buf bytes.Buffer
+			if err := imaging.Encode(&buf, thumb, format); err != nil {
+				s.removeKeys(ctx, written)
+				s.logger.Error("image encode error", "format", format, "err", err)
+				return models.BookCover{}, err
+			}
+			key := models.CoverPath(uid, cover.Version, size.Name, format)
+			if err := s.storage.Put(ctx, key, buf.Bytes(), models.CoverContentType(format)); err != nil {
+				s.removeKeys(ctx, written)
+				s.logger.Error("storage error", "Put err", err)
+				return models.BookCover{}, usecase.ErrBlobStorage
+			}
+			written = append(written, key)
+		}
+	}
+
+	old, err := s.repository.SetCover(ctx, uid, cover)
+	if err != nil {
+		s.removeKeys(ctx, written)
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.BookCover{}, err
+		}
+		s.logger.Error("db error", "SetCover err", err)
+		return models.BookCover{}, usecase.ErrDbInfrastructure
+	}
+	s.afterChange(ctx, uid, old)
+	return cover, nil
+}
+
+// Remove убирает обложку; у книги без обложки ничего не меняется.
+func (s *Service) Remove(ctx context.Context, id string) error {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return repository.ErrNotFound
+	}
+	old, err := s.repository.SetCover(ctx, uid, models.BookCover{})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		s.logger.Error("db error", "SetCover err", err)
+		return usecase.ErrDbInfrastructure
+	}
+	s.afterChange(ctx, uid, old)
+	return nil
+}
+
+// Open отдаёт миниатюру по частям её URL. Тело закрывает вызывающий. Отдаётся только текущая
+// обложка существующей книги: миниатюры удалённых и слитых книг, как и прежних версий,
+// могли остаться в хранилище.
+func (s *Service) Open(ctx context.Context, id, version, file string) (models.Blob, error) {
+	key, ok := models.ParseCoverPath(id, version, file)
+	if !ok {
+		return models.Blob{}, repository.ErrNotFound
+	}
+	book, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.Blob{}, err
+		}
+		s.logger.Error("db error", "GetById err", err)
+		return models.Blob{}, usecase.ErrDbInfrastructure
+	}
+	if book.Cover.Version != version {
+		return models.Blob{}, repository.ErrNotFound
+	}
+
+	blob, err := s.storage.Get(ctx, key)
+	if errors.Is(err, models.ErrBlobNotFound) {
+		return models.Blob{}, repository.ErrNotFound
+	}
+	if err != nil {
+		s.logger.Error("storage error", "Get err", err)
+		return models.Blob{}, usecase.ErrBlobStorage
+	}
+	return blob, nil
+}
+
+// afterChange сбрасывает книгу в кэше и удаляет миниатюры прежней версии.
+// Ошибки только логируются: обложка уже сменена.
+func (s *Service) afterChange(ctx context.Context, id uuid.UUID, old models.BookCover) {
+	if err := s.cache.Delete(ctx, id.String()); err != nil {
+		s.logger.Error("cache delete error", "err", err)
+	}
+	var keys []string
+	for _, img := range old.Images(id) {
+		keys = append(keys, img.Path)
+	}
+	s.removeKeys(ctx, keys)
+}
+
+func (s *Service) removeKeys(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Error("storage error", "Delete err", err, "key", key)
+		}
+	}
+}
+
+func invalidImage(err error) error {
+	var verr models.ValidationError
+	verr.Addf("file", models.FieldInvalid, "image cannot be decoded: %v", err)
+	return verr.Err()
+}
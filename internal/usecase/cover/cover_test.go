@@ -0,0 +1,240 @@
+package cover
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+const testVersion = "00112233aabbccdd"
+
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 0xC0
+	}
+	img.SetNRGBA(0, 0, color.NRGBA{A: 0})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// memStorage — BlobStorageMock поверх map.
+func memStorage() (*BlobStorageMock, map[string][]byte) {
+	var mu sync.Mutex
+	blobs := map[string][]byte{}
+	return &BlobStorageMock{
+		PutFunc: func(ctx context.Context, key string, data []byte, contentType string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			blobs[key] = data
+			return nil
+		},
+		GetFunc: func(ctx context.Context, key string) (models.Blob, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			data, ok := blobs[key]
+			if !ok {
+				return models.Blob{}, models.ErrBlobNotFound
+			}
+			return models.Blob{Body: io.NopCloser(bytes.NewReader(data)), Size: int64(len(data))}, nil
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			delete(blobs, key)
+			return nil
+		},
+	}, blobs
+}
+
+func newTestService(repo *RepositoryMock, storage *BlobStorageMock) (*Service, *CacheMock) {
+	cache := &CacheMock{DeleteFunc: func(ctx context.Context, key string) error { return nil }}
+	svc := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), repo, storage, cache)
+	svc.newVersion = func() string { return testVersion }
+	return svc, cache
+}
+
+func keys(blobs map[string][]byte) []string {
+	out := make([]string, 0, len(blobs))
+	for k := range blobs {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestService_Upload(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	old := models.BookCover{Version: "ffffffffffffffff", Width: 500, Height: 700}
+
+	storage, blobs := memStorage()
+	for _, img := range old.Images(id) {
+		blobs[img.Path] = []byte("old")
+	}
+	repo := &RepositoryMock{SetCoverFunc: func(ctx context.Context, bookID uuid.UUID, cover models.BookCover) (models.BookCover, error) {
+		return old, nil
+	}}
+	svc, cache := newTestService(repo, storage)
+
+	cover, err := svc.Upload(ctx, id.String(), pngImage(t, 1000, 1500))
+	require.NoError(t, err)
+	assert.Equal(t, models.BookCover{Version: testVersion, Width: 1000, Height: 1500}, cover)
+	assert.Equal(t, cover, repo.SetCoverCalls()[0].Cover)
+
+	prefix := "covers/" + id.String() + "/" + testVersion + "/"
+	assert.Equal(t, []string{
+		prefix + "large.jpg", prefix + "large.webp",
+		prefix + "medium.jpg", prefix + "medium.webp",
+		prefix + "small.jpg", prefix + "small.webp",
+	}, keys(blobs), "old version is removed")
+	assert.Equal(t, "image/webp", storage.PutCalls()[1].ContentType)
+
+	large, err := jpeg.Decode(bytes.NewReader(blobs[prefix+"large.jpg"]))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 800, 1200), large.Bounds())
+	assert.Equal(t, id.String(), cache.DeleteCalls()[0].Key)
+}
+
+func TestService_Upload_Rejected(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.NewString()
+
+	storage, blobs := memStorage()
+	repo := &RepositoryMock{}
+	svc, _ := newTestService(repo, storage)
+
+	_, err := svc.Upload(ctx, id, []byte("GIF89a not really"))
+	assert.ErrorIs(t, err, usecase.ErrUnsupportedImage)
+
+	_, err = svc.Upload(ctx, id, pngImage(t, 60, 300))
+	var verr *models.ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, models.FieldTooShort, verr.Fields[0].Code)
+
+	truncated := pngImage(t, 200, 200)
+	_, err = svc.Upload(ctx, id, truncated[:len(truncated)/2])
+	assert.ErrorIs(t, err, models.ErrDomainValidation)
+
+	_, err = svc.Upload(ctx, "not-a-uuid", pngImage(t, 200, 200))
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	assert.Empty(t, storage.PutCalls())
+	assert.Empty(t, repo.SetCoverCalls())
+	assert.Empty(t, blobs)
+}
+
+func TestService_Upload_CleansUpOnFailure(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("book not found", func(t *testing.T) {
+		storage, blobs := memStorage()
+		repo := &RepositoryMock{SetCoverFunc: func(ctx context.Context, id uuid.UUID, cover models.BookCover) (models.BookCover, error) {
+			return models.BookCover{}, repository.ErrNotFound
+		}}
+		svc, cache := newTestService(repo, storage)
+
+		_, err := svc.Upload(ctx, uuid.NewString(), pngImage(t, 200, 300))
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		assert.Empty(t, blobs)
+		assert.Empty(t, cache.DeleteCalls())
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		storage, blobs := memStorage()
+		put := storage.PutFunc
+		storage.PutFunc = func(ctx context.Context, key string, data []byte, contentType string) error {
+			if strings.HasSuffix(key, "medium.webp") {
+				return errors.New("connection reset")
+			}
+			return put(ctx, key, data, contentType)
+		}
+		repo := &RepositoryMock{}
+		svc, _ := newTestService(repo, storage)
+
+		_, err := svc.Upload(ctx, uuid.NewString(), pngImage(t, 200, 300))
+		assert.Equal(t, usecase.ErrBlobStorage, err)
+		assert.Empty(t, blobs, "partially written version is removed")
+		assert.Empty(t, repo.SetCoverCalls())
+	})
+}
+
+func TestService_Remove(t *testing.T) {
+	id := uuid.New()
+	old := models.BookCover{Version: testVersion, Width: 100, Height: 100}
+	storage, _ := memStorage()
+	repo := &RepositoryMock{SetCoverFunc: func(ctx context.Context, bookID uuid.UUID, cover models.BookCover) (models.BookCover, error) {
+		return old, nil
+	}}
+	svc, _ := newTestService(repo, storage)
+
+	require.NoError(t, svc.Remove(context.Background(), id.String()))
+	assert.True(t, repo.SetCoverCalls()[0].Cover.Empty())
+	assert.Len(t, storage.DeleteCalls(), len(models.CoverSizes)*len(models.CoverFormats))
+}
+
+func TestService_Open(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.NewString()
+	storage, blobs := memStorage()
+	books := map[string]models.Book{id: {Cover: models.BookCover{Version: testVersion}}}
+	repo := &RepositoryMock{GetByIdFunc: func(ctx context.Context, id string) (models.Book, error) {
+		if id == "00000000-0000-0000-0000-000000000001" {
+			return models.Book{}, errors.New("connection reset")
+		}
+		b, ok := books[id]
+		if !ok {
+			return models.Book{}, repository.ErrNotFound
+		}
+		return b, nil
+	}}
+	svc, _ := newTestService(repo, storage)
+	blobs["covers/"+id+"/"+testVersion+"/small.webp"] = []byte("webp")
+	blobs["covers/"+id+"/ffeeddccbbaa9988/small.webp"] = []byte("old")
+
+	blob, err := svc.Open(ctx, id, testVersion, "small.webp")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), blob.Size)
+
+	_, err = svc.Open(ctx, id, "ffeeddccbbaa9988", "small.webp")
+	assert.ErrorIs(t, err, repository.ErrNotFound, "previous version is not served")
+
+	delete(books, id)
+	_, err = svc.Open(ctx, id, testVersion, "small.webp")
+	assert.ErrorIs(t, err, repository.ErrNotFound, "thumbnails of a deleted book are not served")
+
+	_, err = svc.Open(ctx, "00000000-0000-0000-0000-000000000001", testVersion, "small.webp")
+	assert.ErrorIs(t, err, usecase.ErrDbInfrastructure)
+	books[id] = models.Book{Cover: models.BookCover{Version: testVersion}}
+
+	_, err = svc.Open(ctx, id, testVersion, "large.webp")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	for _, file := range []string{"small.png", "huge.jpg", "../small.jpg", "small.jpeg"} {
+		_, err = svc.Open(ctx, id, testVersion, file)
+		assert.ErrorIs(t, err, repository.ErrNotFound, file)
+	}
+	_, err = svc.Open(ctx, id, "..", "small.jpg")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Len(t, storage.GetCalls(), 2, "malformed paths and stale covers do not reach the storage")
+}
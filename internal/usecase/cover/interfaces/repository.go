@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	GetById(ctx context.Context, id string) (models.Book, error)
+	SetCover(ctx context.Context, id uuid.UUID, cover models.BookCover) (models.BookCover, error)
+}
+
+// Cache — кэш книг; после смены обложки запись книги сбрасывается.
+type Cache interface {
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+)
+
+// BlobStorage хранит миниатюры обложек по ключу вида covers/{id}/{version}/{size}.{ext}.
+// Get для отсутствующего ключа возвращает models.ErrBlobNotFound, Delete — nil.
+type BlobStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (models.Blob, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,33 @@
+package cover
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+
+	"book-store-api/internal/usecase/cover/interfaces"
+)
+
+type Service struct {
+	logger     *slog.Logger
+	repository interfaces.Repository
+	storage    interfaces.BlobStorage
+	cache      interfaces.Cache
+	newVersion func() string
+}
+
+func NewService(logger *slog.Logger, repo interfaces.Repository, storage interfaces.BlobStorage, cache interfaces.Cache) *Service {
+	return &Service{
+		logger:     logger,
+		repository: repo,
+		storage:    storage,
+		cache:      cache,
+		newVersion: randomVersion,
+	}
+}
+
+func randomVersion() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,62 @@
+package customer
+
+import (
+	"context"
+	"errors"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) ListAddresses(ctx context.Context, customerID uuid.UUID) ([]models.Address, error) {
+	addresses, err := s.repo.ListAddresses(ctx, customerID)
+	if err != nil {
+		s.logger.Error("db error", "list addresses err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+	return addresses, nil
+}
+
+func (s *Service) AddAddress(ctx context.Context, params models.AddressParams) (string, error) {
+	params.ID = uuid.New()
+	address, err := models.NewAddress(params)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.repo.CreateAddress(ctx, address); err != nil {
+		s.logger.Error("db error", "create address err", err)
+		return "", usecase.ErrDbInfrastructure
+	}
+	return address.ID.String(), nil
+}
+
+func (s *Service) UpdateAddress(ctx context.Context, params models.AddressParams) error {
+	address, err := models.NewAddress(params)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateAddress(ctx, address); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		s.logger.Error("db error", "update address err", err)
+		return usecase.ErrDbInfrastructure
+	}
+	return nil
+}
+
+func (s *Service) DeleteAddress(ctx context.Context, customerID, id uuid.UUID) error {
+	if err := s.repo.DeleteAddress(ctx, customerID, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		s.logger.Error("db error", "delete address err", err)
+		return usecase.ErrDbInfrastructure
+	}
+	return nil
+}
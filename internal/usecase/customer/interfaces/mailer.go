@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+)
+
+type Mailer interface {
+	Send(ctx context.Context, email models.Email) error
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, c models.Customer) error
+	GetByID(ctx context.Context, id uuid.UUID) (models.Customer, error)
+	GetByEmail(ctx context.Context, email string) (models.Customer, error)
+	UpdateProfile(ctx context.Context, p models.ProfileParams) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, id uuid.UUID) error
+	SaveToken(ctx context.Context, t models.CustomerToken) error
+	ConsumeToken(ctx context.Context, hash string, purpose models.TokenPurpose) (models.CustomerToken, error)
+	ListAddresses(ctx context.Context, customerID uuid.UUID) ([]models.Address, error)
+	CreateAddress(ctx context.Context, a models.Address) error
+	UpdateAddress(ctx context.Context, a models.Address) error
+	DeleteAddress(ctx context.Context, customerID, id uuid.UUID) error
+}
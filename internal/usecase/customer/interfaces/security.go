@@ -0,0 +1,12 @@
+package interfaces
+
+import "time"
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) error
+}
+
+type TokenIssuer interface {
+	Issue(subject string, roles []string) (string, time.Time, error)
+}
@@ -0,0 +1,38 @@
+package customer
+
+import (
+	"context"
+	"errors"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func (s *Service) Login(ctx context.Context, email, password string) (models.AccessToken, error) {
+	c, err := s.repo.GetByEmail(ctx, models.NormalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			_ = s.hasher.Compare(s.dummyHash(), password)
+			return models.AccessToken{}, usecase.ErrInvalidCredentials
+		}
+		s.logger.Error("db error", "login err", err)
+		return models.AccessToken{}, usecase.ErrDbInfrastructure
+	}
+
+	if err := s.hasher.Compare(c.PasswordHash, password); err != nil {
+		return models.AccessToken{}, usecase.ErrInvalidCredentials
+	}
+	if !c.EmailVerified {
+		return models.AccessToken{}, usecase.ErrEmailNotVerified
+	}
+
+	token, expiresAt, err := s.issuer.Issue(c.ID.String(), []string{auth.RoleCustomer})
+	if err != nil {
+		s.logger.Error("token issue error", "err", err)
+		return models.AccessToken{}, err
+	}
+
+	return models.AccessToken{Token: token, ExpiresAt: expiresAt}, nil
+}
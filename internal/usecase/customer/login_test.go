@@ -0,0 +1,59 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func TestService_Login(t *testing.T) {
+	ctx := context.Background()
+	verified := models.Customer{ID: uuid.New(), Email: "reader@example.com", PasswordHash: "hashed:long-enough", EmailVerified: true}
+	unverified := models.Customer{ID: uuid.New(), Email: "new@example.com", PasswordHash: "hashed:long-enough"}
+
+	repo := &RepositoryMock{
+		GetByEmailFunc: func(ctx context.Context, email string) (models.Customer, error) {
+			switch email {
+			case verified.Email:
+				return verified, nil
+			case unverified.Email:
+				return unverified, nil
+			default:
+				return models.Customer{}, repository.ErrNotFound
+			}
+		},
+	}
+	svc := newTestService(repo, &MailerMock{})
+
+	t.Run("success", func(t *testing.T) {
+		token, err := svc.Login(ctx, " Reader@example.com", "long-enough")
+		assert.NoError(t, err)
+		assert.Equal(t, "token-for-"+verified.ID.String(), token.Token)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := svc.Login(ctx, verified.Email, "wrong-password")
+		assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		hasher := svc.hasher.(*PasswordHasherMock)
+		compared := len(hasher.CompareCalls())
+		_, err := svc.Login(ctx, "nobody@example.com", "long-enough")
+		assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		require.Len(t, hasher.CompareCalls(), compared+1, "password is still compared to keep timing equal")
+		assert.Equal(t, "hashed:login-timing-dummy-password", hasher.CompareCalls()[compared].Hash)
+	})
+
+	t.Run("email not verified", func(t *testing.T) {
+		_, err := svc.Login(ctx, unverified.Email, "long-enough")
+		assert.ErrorIs(t, err, usecase.ErrEmailNotVerified)
+	})
+}
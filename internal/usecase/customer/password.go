@@ -0,0 +1,67 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+// RequestPasswordReset не сообщает, существует ли аккаунт с таким email.
+func (s *Service) RequestPasswordReset(ctx context.Context, email string) error {
+	c, err := s.repo.GetByEmail(ctx, models.NormalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		s.logger.Error("db error", "password reset err", err)
+		return usecase.ErrDbInfrastructure
+	}
+
+	token, err := s.issueOneTimeToken(ctx, c.ID, models.TokenPasswordReset, resetTokenTTL)
+	if err != nil {
+		s.logger.Error("db error", "reset token err", err)
+		return usecase.ErrDbInfrastructure
+	}
+
+	err = s.mailer.Send(ctx, models.Email{
+		To:      c.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello, %s!\n\nTo set a new password open the link below. It is valid for one hour.\n%s/reset-password?token=%s\n",
+			c.FullName, s.publicURL, token),
+	})
+	if err != nil {
+		s.logger.Error("password reset email error", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (s *Service) ResetPassword(ctx context.Context, token, password string) error {
+	if err := models.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	t, err := s.repo.ConsumeToken(ctx, hashToken(token), models.TokenPasswordReset)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return usecase.ErrInvalidToken
+		}
+		s.logger.Error("db error", "consume token err", err)
+		return usecase.ErrDbInfrastructure
+	}
+
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		s.logger.Error("password hash error", "err", err)
+		return err
+	}
+	if err := s.repo.UpdatePassword(ctx, t.CustomerID, hash); err != nil {
+		s.logger.Error("db error", "update password err", err)
+		return usecase.ErrDbInfrastructure
+	}
+	return nil
+}
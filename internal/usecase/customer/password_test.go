@@ -0,0 +1,56 @@
+package customer
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func TestService_PasswordReset(t *testing.T) {
+	ctx := context.Background()
+	c := models.Customer{ID: uuid.New(), Email: "reader@example.com", FullName: "Reader"}
+	tokens := map[string]models.CustomerToken{}
+
+	repo := &RepositoryMock{
+		GetByEmailFunc: func(ctx context.Context, email string) (models.Customer, error) {
+			if email != c.Email {
+				return models.Customer{}, repository.ErrNotFound
+			}
+			return c, nil
+		},
+		SaveTokenFunc: func(ctx context.Context, t models.CustomerToken) error {
+			tokens[t.Hash] = t
+			return nil
+		},
+		ConsumeTokenFunc: func(ctx context.Context, hash string, purpose models.TokenPurpose) (models.CustomerToken, error) {
+			t, ok := tokens[hash]
+			if !ok || t.Purpose != purpose {
+				return models.CustomerToken{}, repository.ErrNotFound
+			}
+			delete(tokens, hash)
+			return t, nil
+		},
+		UpdatePasswordFunc: func(ctx context.Context, id uuid.UUID, passwordHash string) error { return nil },
+	}
+	mailer := &MailerMock{SendFunc: func(ctx context.Context, email models.Email) error { return nil }}
+	svc := newTestService(repo, mailer)
+
+	assert.NoError(t, svc.RequestPasswordReset(ctx, "nobody@example.com"))
+	assert.Empty(t, mailer.SendCalls(), "unknown email must not trigger a message")
+
+	assert.NoError(t, svc.RequestPasswordReset(ctx, c.Email))
+	assert.Len(t, mailer.SendCalls(), 1)
+	token := regexp.MustCompile(`token=(\S+)`).FindStringSubmatch(mailer.SendCalls()[0].Email.Body)[1]
+
+	assert.ErrorIs(t, svc.ResetPassword(ctx, token, "short"), models.ErrDomainValidation)
+	assert.NoError(t, svc.ResetPassword(ctx, token, "new-password"))
+	assert.Equal(t, "hashed:new-password", repo.UpdatePasswordCalls()[0].PasswordHash)
+	assert.ErrorIs(t, svc.ResetPassword(ctx, token, "new-password"), usecase.ErrInvalidToken)
+}
@@ -0,0 +1,40 @@
+package customer
+
+import (
+	"context"
+	"errors"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) GetProfile(ctx context.Context, id uuid.UUID) (models.Customer, error) {
+	c, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.Customer{}, err
+		}
+		s.logger.Error("db error", "get profile err", err)
+		return models.Customer{}, usecase.ErrDbInfrastructure
+	}
+	return c, nil
+}
+
+func (s *Service) UpdateProfile(ctx context.Context, params models.ProfileParams) error {
+	params, err := models.NewProfile(params)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateProfile(ctx, params); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		s.logger.Error("db error", "update profile err", err)
+		return usecase.ErrDbInfrastructure
+	}
+	return nil
+}
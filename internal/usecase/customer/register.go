@@ -0,0 +1,117 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// Register не сообщает, занят ли email: владельцу существующего аккаунта уходит письмо
+// о попытке регистрации, а вызывающий получает тот же ответ, что и при успехе.
+func (s *Service) Register(ctx context.Context, params models.RegistrationParams) error {
+	params, err := models.NewRegistration(params)
+	if err != nil {
+		return err
+	}
+
+	hash, err := s.hasher.Hash(params.Password)
+	if err != nil {
+		s.logger.Error("password hash error", "err", err)
+		return err
+	}
+
+	c := models.Customer{
+		ID:           uuid.New(),
+		Email:        params.Email,
+		PasswordHash: hash,
+		FullName:     params.FullName,
+		Phone:        params.Phone,
+	}
+	if err := s.repo.Create(ctx, c); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			if err := s.sendAccountExists(ctx, c.Email); err != nil {
+				s.logger.Error("account exists email error", "err", err)
+			}
+			return nil
+		}
+		s.logger.Error("db error", "register customer err", err)
+		return usecase.ErrDbInfrastructure
+	}
+
+	// Письмо можно запросить повторно, поэтому ошибка отправки не отменяет регистрацию
+	if err := s.sendVerification(ctx, c); err != nil {
+		s.logger.Error("verification email error", "err", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ResendVerification(ctx context.Context, email string) error {
+	c, err := s.repo.GetByEmail(ctx, models.NormalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		s.logger.Error("db error", "resend verification err", err)
+		return usecase.ErrDbInfrastructure
+	}
+	if c.EmailVerified {
+		return nil
+	}
+
+	return s.sendVerification(ctx, c)
+}
+
+func (s *Service) VerifyEmail(ctx context.Context, token string) error {
+	t, err := s.repo.ConsumeToken(ctx, hashToken(token), models.TokenEmailVerification)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return usecase.ErrInvalidToken
+		}
+		s.logger.Error("db error", "consume token err", err)
+		return usecase.ErrDbInfrastructure
+	}
+
+	if err := s.repo.MarkEmailVerified(ctx, t.CustomerID); err != nil {
+		s.logger.Error("db error", "verify email err", err)
+		return usecase.ErrDbInfrastructure
+	}
+	return nil
+}
+
+func (s *Service) sendVerification(ctx context.Context, c models.Customer) error {
+	token, err := s.issueOneTimeToken(ctx, c.ID, models.TokenEmailVerification, verificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, models.Email{
+		To:      c.Email,
+		Subject: "Confirm your email",
+		Body: fmt.Sprintf("Hello, %s!\n\nConfirm your email address by opening the link below:\n%s/verify-email?token=%s\n",
+			c.FullName, s.publicURL, token),
+	})
+}
+
+// sendAccountExists предупреждает владельца email о повторной регистрации. Имя берётся
+// из аккаунта, а не из запроса, чтобы чужой текст не попадал в письмо.
+func (s *Service) sendAccountExists(ctx context.Context, email string) error {
+	c, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, models.Email{
+		To:      c.Email,
+		Subject: "You already have an account",
+		Body: fmt.Sprintf("Hello, %s!\n\nSomeone tried to register a new account with this email address, but you already have one.\n"+
+			"If it was you, sign in at %s or request a password reset there. Otherwise you can ignore this email.\n",
+			c.FullName, s.publicURL),
+	})
+}
@@ -0,0 +1,129 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func newTestService(repo *RepositoryMock, mailer *MailerMock) *Service {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	hasher := &PasswordHasherMock{
+		HashFunc: func(password string) (string, error) {
+			return "hashed:" + password, nil
+		},
+		CompareFunc: func(hash, password string) error {
+			if hash != "hashed:"+password {
+				return usecase.ErrInvalidCredentials
+			}
+			return nil
+		},
+	}
+	issuer := &TokenIssuerMock{
+		IssueFunc: func(subject string, roles []string) (string, time.Time, error) {
+			return "token-for-" + subject, time.Now().Add(time.Hour), nil
+		},
+	}
+	return NewService(logger, repo, mailer, hasher, issuer, "https://shop.example")
+}
+
+func TestService_Register(t *testing.T) {
+	ctx := context.Background()
+	params := models.RegistrationParams{Email: "Reader@Example.com", Password: "long-enough", FullName: "Reader"}
+
+	t.Run("registers and sends verification email", func(t *testing.T) {
+		var saved models.Customer
+		var token models.CustomerToken
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, c models.Customer) error {
+				saved = c
+				return nil
+			},
+			SaveTokenFunc: func(ctx context.Context, t models.CustomerToken) error {
+				token = t
+				return nil
+			},
+		}
+		mailer := &MailerMock{SendFunc: func(ctx context.Context, email models.Email) error { return nil }}
+		svc := newTestService(repo, mailer)
+
+		err := svc.Register(ctx, params)
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, saved.ID)
+		assert.Equal(t, "reader@example.com", saved.Email)
+		assert.Equal(t, "hashed:long-enough", saved.PasswordHash)
+		assert.Equal(t, models.TokenEmailVerification, token.Purpose)
+
+		sent := mailer.SendCalls()
+		assert.Len(t, sent, 1)
+		assert.Equal(t, "reader@example.com", sent[0].Email.To)
+		assert.True(t, strings.Contains(sent[0].Email.Body, "https://shop.example/verify-email?token="))
+		// в БД хранится только хэш токена из письма
+		assert.False(t, strings.Contains(sent[0].Email.Body, token.Hash))
+	})
+
+	t.Run("duplicate email looks like a registration", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, c models.Customer) error { return repository.ErrAlreadyExists },
+			GetByEmailFunc: func(ctx context.Context, email string) (models.Customer, error) {
+				return models.Customer{ID: uuid.New(), Email: email, FullName: "Owner"}, nil
+			},
+		}
+		mailer := &MailerMock{SendFunc: func(ctx context.Context, email models.Email) error { return nil }}
+		svc := newTestService(repo, mailer)
+
+		err := svc.Register(ctx, models.RegistrationParams{Email: "Reader@Example.com", Password: "long-enough", FullName: "<a href=evil>"})
+		assert.NoError(t, err, "taken email must not be distinguishable")
+		assert.Empty(t, repo.SaveTokenCalls(), "no verification token for someone else's account")
+
+		sent := mailer.SendCalls()
+		assert.Len(t, sent, 1)
+		assert.Equal(t, "reader@example.com", sent[0].Email.To)
+		assert.Equal(t, "You already have an account", sent[0].Email.Subject)
+		assert.True(t, strings.Contains(sent[0].Email.Body, "Hello, Owner!"))
+		assert.False(t, strings.Contains(sent[0].Email.Body, "evil"), "name comes from the existing account")
+
+		mailer.SendFunc = func(ctx context.Context, email models.Email) error { return errors.New("smtp is down") }
+		assert.NoError(t, svc.Register(ctx, params), "mail errors are not reported either")
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		svc := newTestService(&RepositoryMock{}, &MailerMock{})
+
+		err := svc.Register(ctx, models.RegistrationParams{Email: "bad"})
+		assert.ErrorIs(t, err, models.ErrDomainValidation)
+	})
+}
+
+func TestService_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	customerID := uuid.New()
+	raw, hash, err := newOneTimeToken()
+	assert.NoError(t, err)
+
+	repo := &RepositoryMock{
+		ConsumeTokenFunc: func(ctx context.Context, h string, purpose models.TokenPurpose) (models.CustomerToken, error) {
+			if h != hash || purpose != models.TokenEmailVerification {
+				return models.CustomerToken{}, repository.ErrNotFound
+			}
+			return models.CustomerToken{Hash: h, CustomerID: customerID, Purpose: purpose}, nil
+		},
+		MarkEmailVerifiedFunc: func(ctx context.Context, id uuid.UUID) error { return nil },
+	}
+	svc := newTestService(repo, &MailerMock{})
+
+	assert.NoError(t, svc.VerifyEmail(ctx, raw))
+	assert.Equal(t, customerID, repo.MarkEmailVerifiedCalls()[0].ID)
+	assert.ErrorIs(t, svc.VerifyEmail(ctx, "unknown"), usecase.ErrInvalidToken)
+}
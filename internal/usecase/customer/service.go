@@ -0,0 +1,44 @@
+package customer
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"book-store-api/internal/usecase/customer/interfaces"
+)
+
+const (
+	verificationTokenTTL = 48 * time.Hour
+	resetTokenTTL        = time.Hour
+)
+
+type Service struct {
+	logger    *slog.Logger
+	repo      interfaces.Repository
+	mailer    interfaces.Mailer
+	hasher    interfaces.PasswordHasher
+	issuer    interfaces.TokenIssuer
+	publicURL string
+	// dummyHash сравнивается с паролем для неизвестного email, чтобы по времени ответа
+	// нельзя было узнать, зарегистрирован ли адрес; стоимость та же, что у настоящих хэшей
+	dummyHash func() string
+}
+
+func NewService(logger *slog.Logger, repo interfaces.Repository, mailer interfaces.Mailer, hasher interfaces.PasswordHasher, issuer interfaces.TokenIssuer, publicURL string) *Service {
+	return &Service{
+		logger:    logger,
+		repo:      repo,
+		mailer:    mailer,
+		hasher:    hasher,
+		issuer:    issuer,
+		publicURL: publicURL,
+		dummyHash: sync.OnceValue(func() string {
+			hash, err := hasher.Hash("login-timing-dummy-password")
+			if err != nil {
+				logger.Error("dummy password hash error", "err", err)
+			}
+			return hash
+		}),
+	}
+}
@@ -0,0 +1,46 @@
+package customer
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// newOneTimeToken возвращает токен для письма и его хэш для хранения в БД.
+func newOneTimeToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	raw := base64.RawURLEncoding.EncodeToString(buf)
+	return raw, hashToken(raw), nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Service) issueOneTimeToken(ctx context.Context, customerID uuid.UUID, purpose models.TokenPurpose, ttl time.Duration) (string, error) {
+	raw, hash, err := newOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+	err = s.repo.SaveToken(ctx, models.CustomerToken{
+		Hash:       hash,
+		CustomerID: customerID,
+		Purpose:    purpose,
+		ExpiresAt:  time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return raw, nil
+}
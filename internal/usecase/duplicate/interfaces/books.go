@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+)
+
+// Catalog читает книги пар свежими: между проходами их могли изменить или удалить.
+type Catalog interface {
+	Export(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error
+}
+
+// Merger — слияние с записью в журнал аудита.
+type Merger interface {
+	Merge(ctx context.Context, canonicalID, duplicateID string) (*models.Book, error)
+}
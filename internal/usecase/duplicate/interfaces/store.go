@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+)
+
+type Store interface {
+	// Latest возвращает nil, если прохода ещё не было.
+	Latest(ctx context.Context) (*models.DuplicateScan, error)
+}
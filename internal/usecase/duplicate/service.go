@@ -0,0 +1,94 @@
+package duplicate
+
+import (
+	"context"
+	"log/slog"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+	"book-store-api/internal/usecase/duplicate/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	logger  *slog.Logger
+	store   interfaces.Store
+	catalog interfaces.Catalog
+	merger  interfaces.Merger
+}
+
+func NewService(logger *slog.Logger, store interfaces.Store, catalog interfaces.Catalog, merger interfaces.Merger) *Service {
+	return &Service{logger: logger, store: store, catalog: catalog, merger: merger}
+}
+
+// Candidates отдаёт до limit пар последнего прохода с оценкой не ниже minScore.
+// Пары, где книги уже нет (например, после слияния), пропускаются.
+func (s *Service) Candidates(ctx context.Context, minScore float64, limit int) (models.DuplicateReport, error) {
+	limit = models.NormalizeDuplicateLimit(limit)
+	report := models.DuplicateReport{Candidates: []models.DuplicateCandidate{}}
+
+	scan, err := s.store.Latest(ctx)
+	if err != nil {
+		s.logger.Error("duplicate store error", "err", err)
+		return models.DuplicateReport{}, usecase.ErrDbInfrastructure
+	}
+	if scan == nil {
+		return report, nil
+	}
+	report.ScannedAt = scan.ScannedAt
+
+	var pairs []models.DuplicatePair
+	for _, p := range scan.Pairs {
+		if p.Score >= minScore {
+			pairs = append(pairs, p)
+		}
+	}
+	// книги читаются порциями по limit пар, пока выдача не наберётся
+	for len(pairs) > 0 && len(report.Candidates) < limit {
+		chunk := pairs[:min(limit, len(pairs))]
+		pairs = pairs[len(chunk):]
+
+		books, err := s.books(ctx, chunk)
+		if err != nil {
+			return models.DuplicateReport{}, err
+		}
+		for _, p := range chunk {
+			a, okA := books[p.BookIDs[0]]
+			b, okB := books[p.BookIDs[1]]
+			if !okA || !okB {
+				continue
+			}
+			report.Candidates = append(report.Candidates, models.DuplicateCandidate{Pair: p, Books: [2]models.Book{a, b}})
+			if len(report.Candidates) == limit {
+				break
+			}
+		}
+	}
+	return report, nil
+}
+
+func (s *Service) books(ctx context.Context, pairs []models.DuplicatePair) (map[uuid.UUID]models.Book, error) {
+	ids := make([]uuid.UUID, 0, 2*len(pairs))
+	for _, p := range pairs {
+		ids = append(ids, p.BookIDs[:]...)
+	}
+	books := make(map[uuid.UUID]models.Book, len(ids))
+	err := s.catalog.Export(ctx, models.BookExport{Columns: models.BookColumns, Filter: models.BookFilter{IDs: ids}}, func(b models.Book) error {
+		books[b.ID] = b
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// Merge оставляет canonicalID и удаляет duplicateID.
+func (s *Service) Merge(ctx context.Context, canonicalID, duplicateID string) (models.Book, error) {
+	canonical, err := s.merger.Merge(ctx, canonicalID, duplicateID)
+	if err != nil {
+		return models.Book{}, err
+	}
+	return *canonical, nil
+}
@@ -0,0 +1,98 @@
+package duplicate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func catalogOf(books ...models.Book) *CatalogMock {
+	return &CatalogMock{ExportFunc: func(ctx context.Context, exp models.BookExport, emit func(models.Book) error) error {
+		for _, b := range books {
+			if slices.Contains(exp.Filter.IDs, b.ID) {
+				if err := emit(b); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}}
+}
+
+func newTestService(store *StoreMock, catalog *CatalogMock, merger *MergerMock) *Service {
+	return NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), store, catalog, merger)
+}
+
+func TestService_Candidates(t *testing.T) {
+	ctx := context.Background()
+	var books []models.Book
+	for range 5 {
+		books = append(books, models.Book{ID: uuid.New(), Title: "Солярис"})
+	}
+	pair := func(a, b int, score float64) models.DuplicatePair {
+		return models.DuplicatePair{BookIDs: [2]uuid.UUID{books[a].ID, books[b].ID}, Score: score}
+	}
+	scannedAt := time.Date(2025, 11, 3, 4, 0, 0, 0, time.UTC)
+	store := &StoreMock{LatestFunc: func(ctx context.Context) (*models.DuplicateScan, error) {
+		return &models.DuplicateScan{ScannedAt: scannedAt, Books: 6, Pairs: []models.DuplicatePair{
+			pair(0, 1, 0.95),
+			pair(0, 5, 0.9),
+			pair(2, 3, 0.8),
+			pair(3, 4, 0.65),
+		}}, nil
+	}}
+	books = append(books, models.Book{ID: uuid.New()}) // книга 5 удалена после прохода
+	catalog := catalogOf(books[:5]...)
+	svc := newTestService(store, catalog, &MergerMock{})
+
+	report, err := svc.Candidates(ctx, 0.7, 2)
+	require.NoError(t, err)
+	assert.Equal(t, scannedAt, report.ScannedAt)
+	require.Len(t, report.Candidates, 2)
+	assert.Equal(t, [2]models.Book{books[0], books[1]}, report.Candidates[0].Books)
+	assert.Equal(t, 0.8, report.Candidates[1].Pair.Score, "pair with a deleted book is skipped")
+	assert.Len(t, catalog.ExportCalls(), 2)
+
+	report, err = svc.Candidates(ctx, 0.9, 0)
+	require.NoError(t, err)
+	assert.Len(t, report.Candidates, 1)
+
+	store.LatestFunc = func(ctx context.Context) (*models.DuplicateScan, error) { return nil, nil }
+	report, err = svc.Candidates(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.True(t, report.ScannedAt.IsZero())
+	assert.NotNil(t, report.Candidates)
+
+	store.LatestFunc = func(ctx context.Context) (*models.DuplicateScan, error) { return nil, errors.New("redis down") }
+	_, err = svc.Candidates(ctx, 0, 10)
+	assert.Equal(t, usecase.ErrDbInfrastructure, err)
+}
+
+func TestService_Merge(t *testing.T) {
+	canonical := models.Book{ID: uuid.New(), Title: "Солярис"}
+	merger := &MergerMock{MergeFunc: func(ctx context.Context, canonicalID, duplicateID string) (*models.Book, error) {
+		if canonicalID != canonical.ID.String() {
+			return nil, repository.ErrNotFound
+		}
+		return &canonical, nil
+	}}
+	svc := newTestService(&StoreMock{}, &CatalogMock{}, merger)
+
+	got, err := svc.Merge(context.Background(), canonical.ID.String(), uuid.NewString())
+	require.NoError(t, err)
+	assert.Equal(t, canonical, got)
+	_, err = svc.Merge(context.Background(), uuid.NewString(), canonical.ID.String())
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
@@ -0,0 +1,145 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+)
+
+// Lookup ищет метаданные по ISBN в любой записи. Ответы источника, включая
+// «не найдено», кэшируются; недоступный кэш только замедляет поиск.
+func (s *Service) Lookup(ctx context.Context, isbn string) (models.BookMetadata, error) {
+	code, err := models.ISBN13(isbn)
+	if err != nil {
+		verr := &models.ValidationError{}
+		verr.Add("isbn", models.FieldInvalid, "isbn is invalid")
+		return models.BookMetadata{}, verr
+	}
+
+	cached, err := s.cache.Get(ctx, code)
+	if err != nil {
+		s.logger.Warn("cache error", "Get err", err)
+	}
+	if cached != nil {
+		if !cached.Found {
+			return models.BookMetadata{}, models.ErrMetadataNotFound
+		}
+		return cached.Metadata, nil
+	}
+
+	meta, err := s.source.Lookup(ctx, code)
+	switch {
+	case errors.Is(err, models.ErrMetadataNotFound):
+		s.store(ctx, code, models.MetadataLookup{}, s.cfg.MissTTL)
+		return models.BookMetadata{}, err
+	case err != nil:
+		s.logger.Error("metadata source error", "Lookup err", err, "isbn", code)
+		return models.BookMetadata{}, usecase.ErrMetadataSource
+	}
+	s.store(ctx, code, models.MetadataLookup{Found: true, Metadata: meta}, s.cfg.CacheTTL)
+	return meta, nil
+}
+
+func (s *Service) store(ctx context.Context, isbn string, lookup models.MetadataLookup, ttl time.Duration) {
+	if err := s.cache.Set(ctx, isbn, lookup, ttl); err != nil {
+		s.logger.Warn("cache error", "Set err", err)
+	}
+}
+
+// Suggest сравнивает книгу с метаданными её ISBN и предлагает значения полей;
+// сама книга не меняется.
+func (s *Service) Suggest(ctx context.Context, id string) (models.BookEnrichment, error) {
+	book, err := s.books.GetByID(ctx, id)
+	if err != nil {
+		return models.BookEnrichment{}, err
+	}
+	meta, err := s.lookupBook(ctx, *book)
+	if err != nil {
+		return models.BookEnrichment{}, err
+	}
+	return models.BookEnrichment{
+		BookID:      book.ID,
+		Metadata:    meta,
+		Suggestions: models.Suggest(*book, meta),
+	}, nil
+}
+
+// Accept применяет к книге только перечисленные предложения. Предложения
+// пересчитываются по текущей книге: поле, которое уже совпадает с источником
+// или для которого источнику нечего предложить, — ошибка валидации.
+// Картинка скачивается заранее, а текстовые поля записываются до загрузки обложки,
+// чтобы не оставлять книгу с новой обложкой и отклонённым названием. Запись идёт,
+// только если книга не менялась после чтения: иначе она вернула бы чужие правки,
+// и Accept отвечает repository.ErrConflict.
+func (s *Service) Accept(ctx context.Context, id string, fields []string) (models.Book, error) {
+	fields, err := models.ParseSuggestFields(fields)
+	if err != nil {
+		return models.Book{}, err
+	}
+	book, err := s.books.GetByID(ctx, id)
+	if err != nil {
+		return models.Book{}, err
+	}
+	meta, err := s.lookupBook(ctx, *book)
+	if err != nil {
+		return models.Book{}, err
+	}
+
+	suggestions := models.Suggest(*book, meta)
+	verr := &models.ValidationError{}
+	for _, f := range fields {
+		if !slices.ContainsFunc(suggestions, func(s models.FieldSuggestion) bool { return s.Field == f }) {
+			verr.Addf("fields", models.FieldInvalid, "no suggestion for %s", f)
+		}
+	}
+	if err := verr.Err(); err != nil {
+		return models.Book{}, err
+	}
+
+	// params.UpdatedAt — условие для Update: строка та же, что была прочитана
+	params := models.ApplySuggestions(*book, suggestions, fields)
+	text := slices.ContainsFunc(fields, func(f string) bool { return f != models.SuggestCover })
+	if text {
+		if _, err := models.NewBook(params); err != nil {
+			return models.Book{}, err
+		}
+	}
+	cover := slices.Contains(fields, models.SuggestCover)
+	var image []byte
+	if cover {
+		image, err = s.source.FetchImage(ctx, meta.CoverURL, s.cfg.MaxImageSize)
+		if err != nil {
+			s.logger.Error("metadata source error", "FetchImage err", err, "url", meta.CoverURL)
+			return models.Book{}, usecase.ErrMetadataSource
+		}
+	}
+	if text {
+		if err := s.books.Update(ctx, params); err != nil {
+			return models.Book{}, err
+		}
+	}
+	if cover {
+		if _, err := s.covers.Upload(ctx, id, image); err != nil {
+			return models.Book{}, err
+		}
+	}
+
+	updated, err := s.books.GetByID(ctx, id)
+	if err != nil {
+		return models.Book{}, err
+	}
+	return *updated, nil
+}
+
+func (s *Service) lookupBook(ctx context.Context, book models.Book) (models.BookMetadata, error) {
+	if _, err := models.ISBN13(book.ISBN); err != nil {
+		verr := &models.ValidationError{}
+		verr.Add("isbn", models.FieldInvalid, "book has no valid isbn")
+		return models.BookMetadata{}, verr
+	}
+	return s.Lookup(ctx, book.ISBN)
+}
@@ -0,0 +1,251 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+const testISBN = "9785171183660"
+
+var testMetadata = models.BookMetadata{
+	Source:      "googlebooks",
+	ISBN:        testISBN,
+	Title:       "Пикник на обочине",
+	Author:      "Аркадий Стругацкий, Борис Стругацкий",
+	Description: "Зона посещения.",
+	PageCount:   256,
+	CoverURL:    "https://covers.example/9785171183660.jpg",
+}
+
+// memCache — CacheMock поверх map.
+func memCache() *CacheMock {
+	var mu sync.Mutex
+	entries := map[string]models.MetadataLookup{}
+	return &CacheMock{
+		GetFunc: func(ctx context.Context, isbn string) (*models.MetadataLookup, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if e, ok := entries[isbn]; ok {
+				return &e, nil
+			}
+			return nil, nil
+		},
+		SetFunc: func(ctx context.Context, isbn string, lookup models.MetadataLookup, ttl time.Duration) error {
+			mu.Lock()
+			defer mu.Unlock()
+			entries[isbn] = lookup
+			return nil
+		},
+	}
+}
+
+func sourceOf(known ...models.BookMetadata) *SourceMock {
+	return &SourceMock{
+		LookupFunc: func(ctx context.Context, isbn string) (models.BookMetadata, error) {
+			for _, m := range known {
+				if m.ISBN == isbn {
+					return m, nil
+				}
+			}
+			return models.BookMetadata{}, models.ErrMetadataNotFound
+		},
+		FetchImageFunc: func(ctx context.Context, url string, maxSize int64) ([]byte, error) {
+			return []byte("jpeg"), nil
+		},
+	}
+}
+
+// booksOf — BooksMock над одной книгой, Update меняет её.
+func booksOf(book *models.Book) *BooksMock {
+	return &BooksMock{
+		GetByIDFunc: func(ctx context.Context, id string) (*models.Book, error) {
+			if id != book.ID.String() {
+				return nil, repository.ErrNotFound
+			}
+			b := *book
+			return &b, nil
+		},
+		UpdateFunc: func(ctx context.Context, params models.BookParams) error {
+			*book = models.Book(params)
+			return nil
+		},
+	}
+}
+
+func newTestService(source *SourceMock, cache *CacheMock, books *BooksMock, covers *CoversMock) *Service {
+	return NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), source, cache, books, covers, Config{
+		CacheTTL:     24 * time.Hour,
+		MissTTL:      time.Hour,
+		MaxImageSize: 1 << 20,
+	})
+}
+
+func TestService_Lookup(t *testing.T) {
+	ctx := context.Background()
+	source := sourceOf(testMetadata)
+	cache := memCache()
+	svc := newTestService(source, cache, &BooksMock{}, &CoversMock{})
+
+	m, err := svc.Lookup(ctx, "5-17-118366-X")
+	require.NoError(t, err)
+	assert.Equal(t, testMetadata, m)
+	_, err = svc.Lookup(ctx, "978-5-17-118366-0")
+	require.NoError(t, err)
+	assert.Len(t, source.LookupCalls(), 1, "second lookup is served from cache")
+	assert.Equal(t, 24*time.Hour, cache.SetCalls()[0].TTL)
+
+	_, err = svc.Lookup(ctx, "978-0-306-40615-7")
+	assert.ErrorIs(t, err, models.ErrMetadataNotFound)
+	_, err = svc.Lookup(ctx, "978-0-306-40615-7")
+	assert.ErrorIs(t, err, models.ErrMetadataNotFound)
+	assert.Len(t, source.LookupCalls(), 2, "misses are cached too")
+	assert.Equal(t, time.Hour, cache.SetCalls()[1].TTL)
+
+	_, err = svc.Lookup(ctx, "978-0-306-40615-8")
+	assert.ErrorIs(t, err, models.ErrDomainValidation)
+	assert.Len(t, source.LookupCalls(), 2)
+}
+
+func TestService_Lookup_Failures(t *testing.T) {
+	ctx := context.Background()
+	source := sourceOf(testMetadata)
+	source.LookupFunc = func(ctx context.Context, isbn string) (models.BookMetadata, error) {
+		return models.BookMetadata{}, errors.New("volumes endpoint returned 429")
+	}
+	cache := memCache()
+	svc := newTestService(source, cache, &BooksMock{}, &CoversMock{})
+
+	_, err := svc.Lookup(ctx, testISBN)
+	assert.Equal(t, usecase.ErrMetadataSource, err)
+	assert.Empty(t, cache.SetCalls(), "source errors are not cached")
+
+	cache.GetFunc = func(ctx context.Context, isbn string) (*models.MetadataLookup, error) {
+		return nil, errors.New("redis: connection refused")
+	}
+	source.LookupFunc = sourceOf(testMetadata).LookupFunc
+	m, err := svc.Lookup(ctx, testISBN)
+	require.NoError(t, err, "unavailable cache falls through to the source")
+	assert.Equal(t, testMetadata.Title, m.Title)
+}
+
+func TestService_Suggest(t *testing.T) {
+	book := &models.Book{ID: uuid.New(), Title: "Пикник на обочине", Author: "Стругацкие", ISBN: testISBN}
+	svc := newTestService(sourceOf(testMetadata), memCache(), booksOf(book), &CoversMock{})
+
+	got, err := svc.Suggest(context.Background(), book.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, book.ID, got.BookID)
+	assert.Equal(t, 256, got.Metadata.PageCount)
+	assert.Equal(t, []models.FieldSuggestion{
+		{Field: models.SuggestAuthor, Current: "Стругацкие", Suggested: testMetadata.Author},
+		{Field: models.SuggestDescription, Suggested: testMetadata.Description},
+		{Field: models.SuggestCover, Suggested: testMetadata.CoverURL},
+	}, got.Suggestions, "matching title is not suggested")
+
+	book.ISBN = "n/a"
+	_, err = svc.Suggest(context.Background(), book.ID.String())
+	assert.ErrorIs(t, err, models.ErrDomainValidation)
+	_, err = svc.Suggest(context.Background(), uuid.NewString())
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestService_Accept(t *testing.T) {
+	ctx := context.Background()
+	book := &models.Book{ID: uuid.New(), Title: "Пикник", Author: "Стругацкие", Description: "Своё описание", ISBN: testISBN, Price: 500}
+	books := booksOf(book)
+	covers := &CoversMock{UploadFunc: func(ctx context.Context, id string, data []byte) (models.BookCover, error) {
+		book.Cover = models.BookCover{Version: "00112233aabbccdd", Width: 400, Height: 600}
+		return book.Cover, nil
+	}}
+	source := sourceOf(testMetadata)
+	svc := newTestService(source, memCache(), books, covers)
+
+	got, err := svc.Accept(ctx, book.ID.String(), []string{"title", "cover", "title"})
+	require.NoError(t, err)
+	assert.Equal(t, testMetadata.Title, got.Title)
+	assert.Equal(t, "Своё описание", got.Description, "fields not accepted are kept")
+	assert.Equal(t, "Стругацкие", got.Author)
+	assert.Equal(t, 500, got.Price)
+	assert.False(t, got.Cover.Empty())
+	require.Len(t, covers.UploadCalls(), 1)
+	assert.Equal(t, "jpeg", string(covers.UploadCalls()[0].Data))
+	assert.Equal(t, testMetadata.CoverURL, source.FetchImageCalls()[0].URL)
+	assert.Equal(t, int64(1<<20), source.FetchImageCalls()[0].MaxSize)
+
+	_, err = svc.Accept(ctx, book.ID.String(), []string{"title"})
+	var verr *models.ValidationError
+	require.True(t, errors.As(err, &verr), "title already matches the source")
+	assert.Equal(t, "no suggestion for title", verr.Fields[0].Message)
+
+	_, err = svc.Accept(ctx, book.ID.String(), []string{"price"})
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, models.FieldUnknown, verr.Fields[0].Code)
+	assert.Len(t, books.UpdateCalls(), 1)
+}
+
+func TestService_Accept_ValidatesBeforeCover(t *testing.T) {
+	ctx := context.Background()
+	long := testMetadata
+	long.Title = strings.Repeat("Очень длинное название ", 50)
+	book := &models.Book{ID: uuid.New(), Title: "Пикник", Author: "Стругацкие", ISBN: testISBN, Price: 500}
+	books := booksOf(book)
+	covers := &CoversMock{}
+	svc := newTestService(sourceOf(long), memCache(), books, covers)
+
+	_, err := svc.Accept(ctx, book.ID.String(), []string{"cover", "title"})
+	assert.ErrorIs(t, err, models.ErrDomainValidation)
+	assert.Empty(t, covers.UploadCalls())
+	assert.Empty(t, books.UpdateCalls())
+
+	source := sourceOf(testMetadata)
+	source.FetchImageFunc = func(ctx context.Context, url string, maxSize int64) ([]byte, error) {
+		return nil, errors.New("image request returned 404")
+	}
+	svc = newTestService(source, memCache(), books, covers)
+	_, err = svc.Accept(ctx, book.ID.String(), []string{"cover", "author"})
+	assert.Equal(t, usecase.ErrMetadataSource, err)
+	assert.Empty(t, books.UpdateCalls(), "text is not saved when the cover fails")
+}
+
+func TestService_Accept_TextBeforeCover(t *testing.T) {
+	ctx := context.Background()
+	book := &models.Book{ID: uuid.New(), Title: "Пикник", Author: "Стругацкие", ISBN: testISBN, Price: 500,
+		UpdatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)}
+	var order []string
+	books := booksOf(book)
+	update := books.UpdateFunc
+	books.UpdateFunc = func(ctx context.Context, params models.BookParams) error {
+		order = append(order, "update")
+		return update(ctx, params)
+	}
+	covers := &CoversMock{UploadFunc: func(ctx context.Context, id string, data []byte) (models.BookCover, error) {
+		order = append(order, "cover")
+		return models.BookCover{Version: "00112233aabbccdd"}, nil
+	}}
+	svc := newTestService(sourceOf(testMetadata), memCache(), books, covers)
+
+	_, err := svc.Accept(ctx, book.ID.String(), []string{"cover", "title"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"update", "cover"}, order)
+	assert.Equal(t, time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), books.UpdateCalls()[0].BookInfo.UpdatedAt,
+		"update is conditional on the version that was read")
+
+	books.UpdateFunc = func(ctx context.Context, params models.BookParams) error { return repository.ErrConflict }
+	_, err = svc.Accept(ctx, book.ID.String(), []string{"cover", "author"})
+	assert.ErrorIs(t, err, repository.ErrConflict)
+	assert.Len(t, covers.UploadCalls(), 1, "no cover for a book changed concurrently")
+}
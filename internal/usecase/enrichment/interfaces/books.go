@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+)
+
+// Books — каталог; изменения идут через него, чтобы попасть в аудит и события.
+type Books interface {
+	GetByID(ctx context.Context, id string) (*models.Book, error)
+	Update(ctx context.Context, bookInfo models.BookParams) error
+}
+
+type Covers interface {
+	Upload(ctx context.Context, id string, data []byte) (models.BookCover, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"book-store-api/internal/models"
+)
+
+type Cache interface {
+	Get(ctx context.Context, isbn string) (*models.MetadataLookup, error)
+	Set(ctx context.Context, isbn string, lookup models.MetadataLookup, ttl time.Duration) error
+}
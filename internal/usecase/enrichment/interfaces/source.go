@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+)
+
+// Source — внешний библиографический источник. Lookup возвращает
+// models.ErrMetadataNotFound, если книги у источника нет.
+type Source interface {
+	Lookup(ctx context.Context, isbn string) (models.BookMetadata, error)
+	FetchImage(ctx context.Context, url string, maxSize int64) ([]byte, error)
+}
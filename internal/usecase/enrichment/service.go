@@ -0,0 +1,37 @@
+package enrichment
+
+import (
+	"log/slog"
+	"time"
+
+	"book-store-api/internal/usecase/enrichment/interfaces"
+)
+
+type Config struct {
+	// CacheTTL — сколько хранится найденная книга, MissTTL — ответ «не найдено»:
+	// его стоит перепроверять чаще, источник пополняется
+	CacheTTL time.Duration
+	MissTTL  time.Duration
+	// MaxImageSize — предел размера скачиваемой обложки в байтах
+	MaxImageSize int64
+}
+
+type Service struct {
+	logger *slog.Logger
+	source interfaces.Source
+	cache  interfaces.Cache
+	books  interfaces.Books
+	covers interfaces.Covers
+	cfg    Config
+}
+
+func NewService(logger *slog.Logger, source interfaces.Source, cache interfaces.Cache, books interfaces.Books, covers interfaces.Covers, cfg Config) *Service {
+	return &Service{
+		logger: logger,
+		source: source,
+		cache:  cache,
+		books:  books,
+		covers: covers,
+		cfg:    cfg,
+	}
+}
@@ -3,6 +3,17 @@ package usecase
 import "errors"
 
 var (
-	ErrDbInfrastructure error = errors.New("database infrastructure error")
-	ErrCache            error = errors.New("cache error")
+	ErrDbInfrastructure   error = errors.New("database infrastructure error")
+	ErrCache              error = errors.New("cache error")
+	ErrInvalidCredentials error = errors.New("invalid email or password")
+	ErrEmailNotVerified   error = errors.New("email is not verified")
+	ErrInvalidToken       error = errors.New("invalid or expired token")
+	ErrInvalidAPIKey      error = errors.New("invalid api key")
+	ErrAPIKeyInactive     error = errors.New("api key is revoked or expired")
+	ErrInvalidLoginState  error = errors.New("unknown or expired login state")
+	ErrNoStaffRole        error = errors.New("identity has no staff role")
+	ErrIdentityProvider   error = errors.New("identity provider error")
+	ErrUnsupportedImage   error = errors.New("unsupported image type")
+	ErrBlobStorage        error = errors.New("blob storage error")
+	ErrMetadataSource     error = errors.New("metadata source error")
 )
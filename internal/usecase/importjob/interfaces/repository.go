@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, job models.ImportJob, file []byte) error
+	Get(ctx context.Context, id uuid.UUID) (models.ImportJob, error)
+}
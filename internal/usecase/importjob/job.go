@@ -0,0 +1,45 @@
+package importjob
+
+import (
+	"context"
+	"errors"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// Create ставит файл в очередь; строки проверяются воркером, здесь — только формат и сопоставление колонок.
+// Книги пишутся от имени загрузившего, поэтому он сохраняется в задаче.
+func (s *Service) Create(ctx context.Context, params models.ImportJobParams) (models.ImportJob, error) {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		params.CreatedByType, params.CreatedBy = string(principal.Type), principal.Subject
+	}
+
+	job, err := models.NewImportJob(params)
+	if err != nil {
+		return models.ImportJob{}, err
+	}
+	job.CreatedAt = s.now().UTC()
+
+	if err := s.repo.Create(ctx, job, params.File); err != nil {
+		s.logger.Error("db error", "create import job err", err)
+		return models.ImportJob{}, usecase.ErrDbInfrastructure
+	}
+	return job, nil
+}
+
+func (s *Service) Get(ctx context.Context, id uuid.UUID) (models.ImportJob, error) {
+	job, err := s.repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ImportJob{}, err
+		}
+		s.logger.Error("db error", "get import job err", err)
+		return models.ImportJob{}, usecase.ErrDbInfrastructure
+	}
+	return job, nil
+}
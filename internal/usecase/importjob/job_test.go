@@ -0,0 +1,75 @@
+package importjob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func newTestService(repo *RepositoryMock) *Service {
+	return NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), repo)
+}
+
+func TestService_Create(t *testing.T) {
+	var stored models.ImportJob
+	repo := &RepositoryMock{
+		CreateFunc: func(ctx context.Context, job models.ImportJob, file []byte) error {
+			stored = job
+			return nil
+		},
+	}
+	svc := newTestService(repo)
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{Type: auth.PrincipalAPIKey, Subject: "erp"})
+
+	job, err := svc.Create(ctx, models.ImportJobParams{
+		FileName: "Supplier.CSV",
+		File:     []byte("title,author,isbn,price\n"),
+		Options:  models.ImportOptions{Mapping: map[string]string{"isbn": "EAN"}, UpsertByISBN: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ImportCSV, job.Format, "format is taken from the file extension")
+	assert.Equal(t, models.ImportPending, job.Status)
+	assert.Equal(t, "erp", job.CreatedBy)
+	assert.Equal(t, string(auth.PrincipalAPIKey), job.CreatedByType)
+	assert.Equal(t, job, stored)
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := svc.Create(ctx, models.ImportJobParams{
+			FileName: "books.pdf",
+			Options:  models.ImportOptions{Mapping: map[string]string{"pages": "Pages"}},
+		})
+		var verr *models.ValidationError
+		require.ErrorAs(t, err, &verr)
+		require.Len(t, verr.Fields, 3)
+		assert.Equal(t, "format", verr.Fields[0].Field)
+		assert.Equal(t, "file", verr.Fields[1].Field)
+		assert.Equal(t, "mapping.pages", verr.Fields[2].Field)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		repo.CreateFunc = func(ctx context.Context, job models.ImportJob, file []byte) error { return errors.New("boom") }
+		_, err := svc.Create(ctx, models.ImportJobParams{Format: models.ImportXLSX, File: []byte{1}})
+		assert.ErrorIs(t, err, usecase.ErrDbInfrastructure)
+	})
+}
+
+func TestService_Get(t *testing.T) {
+	repo := &RepositoryMock{
+		GetFunc: func(ctx context.Context, id uuid.UUID) (models.ImportJob, error) {
+			return models.ImportJob{}, repository.ErrNotFound
+		},
+	}
+	_, err := newTestService(repo).Get(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"book-store-api/internal/models"
+)
+
+type StateStore interface {
+	Save(ctx context.Context, state string, s models.StaffLoginState, ttl time.Duration) error
+	// Take возвращает состояние и удаляет его, чтобы callback нельзя было повторить
+	Take(ctx context.Context, state string) (models.StaffLoginState, error)
+}
+
+type TokenIssuer interface {
+	Issue(subject string, roles []string) (string, time.Time, error)
+}
@@ -0,0 +1,78 @@
+package staff
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+)
+
+// BeginLogin готовит state, nonce и PKCE verifier и возвращает адрес авторизации на IdP
+// вместе со state, чтобы вызывающий привязал его к браузеру.
+func (s *Service) BeginLogin(ctx context.Context) (models.StaffLoginRedirect, error) {
+	state, err := randomString(32)
+	if err != nil {
+		return models.StaffLoginRedirect{}, err
+	}
+	nonce, err := randomString(32)
+	if err != nil {
+		return models.StaffLoginRedirect{}, err
+	}
+	verifier, err := randomString(32)
+	if err != nil {
+		return models.StaffLoginRedirect{}, err
+	}
+
+	now := time.Now()
+	loginState := models.StaffLoginState{Nonce: nonce, CodeVerifier: verifier, CreatedAt: now}
+	if err := s.states.Save(ctx, state, loginState, loginStateTTL); err != nil {
+		s.logger.Error("cache error", "save login state err", err)
+		return models.StaffLoginRedirect{}, usecase.ErrCache
+	}
+
+	url, err := s.provider.AuthCodeURL(ctx, state, nonce, codeChallenge(verifier))
+	if err != nil {
+		s.logger.Error("identity provider error", "discovery err", err)
+		return models.StaffLoginRedirect{}, usecase.ErrIdentityProvider
+	}
+	return models.StaffLoginRedirect{AuthURL: url, State: state, ExpiresAt: now.Add(loginStateTTL)}, nil
+}
+
+// CompleteLogin обменивает code на ID token и выпускает собственный access-токен с ролями по группам.
+func (s *Service) CompleteLogin(ctx context.Context, state, code string) (models.AccessToken, error) {
+	loginState, err := s.states.Take(ctx, state)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidLoginState) {
+			return models.AccessToken{}, err
+		}
+		s.logger.Error("cache error", "take login state err", err)
+		return models.AccessToken{}, usecase.ErrCache
+	}
+
+	identity, err := s.provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) {
+			s.logger.Warn("id token rejected", "err", err)
+			return models.AccessToken{}, usecase.ErrInvalidToken
+		}
+		s.logger.Error("identity provider error", "exchange err", err)
+		return models.AccessToken{}, usecase.ErrIdentityProvider
+	}
+
+	roles := auth.MapRoles(identity.Groups, s.roleMapping)
+	if len(roles) == 0 {
+		return models.AccessToken{}, usecase.ErrNoStaffRole
+	}
+
+	token, expiresAt, err := s.issuer.Issue(identity.Subject, roles)
+	if err != nil {
+		s.logger.Error("token issue error", "err", err)
+		return models.AccessToken{}, err
+	}
+
+	s.logger.Info("staff login", "subject", identity.Subject, "email", identity.Email, "roles", roles)
+	return models.AccessToken{Token: token, ExpiresAt: expiresAt}, nil
+}
@@ -0,0 +1,124 @@
+package staff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/auth"
+	"book-store-api/internal/models"
+	"book-store-api/internal/usecase"
+)
+
+type memoryStates map[string]models.StaffLoginState
+
+func (m memoryStates) mock() *StateStoreMock {
+	return &StateStoreMock{
+		SaveFunc: func(ctx context.Context, state string, s models.StaffLoginState, ttl time.Duration) error {
+			m[state] = s
+			return nil
+		},
+		TakeFunc: func(ctx context.Context, state string) (models.StaffLoginState, error) {
+			s, ok := m[state]
+			if !ok {
+				return models.StaffLoginState{}, usecase.ErrInvalidLoginState
+			}
+			delete(m, state)
+			return s, nil
+		},
+	}
+}
+
+func newTestService(provider *IdentityProviderMock, states *StateStoreMock) *Service {
+	issuer := &TokenIssuerMock{
+		IssueFunc: func(subject string, roles []string) (string, time.Time, error) {
+			return fmt.Sprintf("%s:%v", subject, roles), time.Now().Add(time.Hour), nil
+		},
+	}
+	mapping := map[string]string{
+		"it-admins":    auth.RoleAdmin,
+		"catalog-team": auth.RoleCatalogEditor,
+		"editors":      auth.RoleCatalogEditor,
+	}
+	return NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), provider, states, issuer, mapping)
+}
+
+func TestService_Login(t *testing.T) {
+	ctx := context.Background()
+	states := memoryStates{}
+	groups := []string{"catalog-team", "editors", "marketing"}
+
+	provider := &IdentityProviderMock{
+		AuthCodeURLFunc: func(ctx context.Context, state, nonce, challenge string) (string, error) {
+			return "https://idp.example/authorize?" + url.Values{"state": {state}, "code_challenge": {challenge}}.Encode(), nil
+		},
+		ExchangeFunc: func(ctx context.Context, code, verifier, nonce string) (models.StaffIdentity, error) {
+			if code != "good-code" {
+				return models.StaffIdentity{}, fmt.Errorf("%w: invalid_grant", auth.ErrInvalidToken)
+			}
+			return models.StaffIdentity{Subject: "staff-1", Groups: groups}, nil
+		},
+	}
+	svc := newTestService(provider, states.mock())
+
+	begin := func(t *testing.T) (string, models.StaffLoginState) {
+		t.Helper()
+		redirect, err := svc.BeginLogin(ctx)
+		require.NoError(t, err)
+		u, err := url.Parse(redirect.AuthURL)
+		require.NoError(t, err)
+		state := u.Query().Get("state")
+		require.Contains(t, states, state)
+		assert.Equal(t, state, redirect.State)
+		assert.WithinDuration(t, time.Now().Add(loginStateTTL), redirect.ExpiresAt, time.Minute)
+		assert.Equal(t, codeChallenge(states[state].CodeVerifier), u.Query().Get("code_challenge"))
+		return state, states[state]
+	}
+
+	t.Run("maps groups to roles", func(t *testing.T) {
+		state, _ := begin(t)
+
+		token, err := svc.CompleteLogin(ctx, state, "good-code")
+		require.NoError(t, err)
+		assert.Equal(t, "staff-1:[catalog-editor]", token.Token)
+		assert.NotContains(t, states, state, "state must be single use")
+	})
+
+	t.Run("verifier and nonce come from state", func(t *testing.T) {
+		state, saved := begin(t)
+
+		_, err := svc.CompleteLogin(ctx, state, "good-code")
+		require.NoError(t, err)
+		last := provider.ExchangeCalls()[len(provider.ExchangeCalls())-1]
+		assert.Equal(t, saved.CodeVerifier, last.CodeVerifier)
+		assert.Equal(t, saved.Nonce, last.Nonce)
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		_, err := svc.CompleteLogin(ctx, "forged", "good-code")
+		assert.ErrorIs(t, err, usecase.ErrInvalidLoginState)
+	})
+
+	t.Run("rejected code", func(t *testing.T) {
+		state, _ := begin(t)
+
+		_, err := svc.CompleteLogin(ctx, state, "bad-code")
+		assert.ErrorIs(t, err, usecase.ErrInvalidToken)
+	})
+
+	t.Run("no staff groups", func(t *testing.T) {
+		groups = []string{"marketing"}
+		defer func() { groups = []string{"catalog-team"} }()
+		state, _ := begin(t)
+
+		_, err := svc.CompleteLogin(ctx, state, "good-code")
+		assert.ErrorIs(t, err, usecase.ErrNoStaffRole)
+	})
+}
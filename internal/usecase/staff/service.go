@@ -0,0 +1,29 @@
+package staff
+
+import (
+	"log/slog"
+	"time"
+
+	"book-store-api/internal/usecase/staff/interfaces"
+)
+
+const loginStateTTL = 10 * time.Minute
+
+type Service struct {
+	logger   *slog.Logger
+	provider interfaces.IdentityProvider
+	states   interfaces.StateStore
+	issuer   interfaces.TokenIssuer
+	// roleMapping сопоставляет группу IdP с ролью сервиса
+	roleMapping map[string]string
+}
+
+func NewService(logger *slog.Logger, provider interfaces.IdentityProvider, states interfaces.StateStore, issuer interfaces.TokenIssuer, roleMapping map[string]string) *Service {
+	return &Service{
+		logger:      logger,
+		provider:    provider,
+		states:      states,
+		issuer:      issuer,
+		roleMapping: roleMapping,
+	}
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+)
+
+type RateRepository interface {
+	GetRates(ctx context.Context, country string, format models.ProductFormat) ([]models.TaxRate, error)
+}
+
+type BookRepository interface {
+	GetById(ctx context.Context, id string) (models.Book, error)
+}
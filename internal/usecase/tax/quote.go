@@ -0,0 +1,68 @@
+package tax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+// Quote считает net/tax/gross по каждой строке корзины или заказа и итоги по ним.
+func (s *Service) Quote(ctx context.Context, params models.TaxQuoteParams) (models.TaxQuote, error) {
+	if params.Date.IsZero() {
+		params.Date = time.Now().UTC()
+	}
+	params, err := models.NewTaxQuoteParams(params)
+	if err != nil {
+		return models.TaxQuote{}, err
+	}
+
+	lines := make([]models.TaxLine, 0, len(params.Lines))
+	for _, l := range params.Lines {
+		line, err := s.quoteLine(ctx, params.Country, params.Date, l)
+		if err != nil {
+			return models.TaxQuote{}, err
+		}
+		lines = append(lines, line)
+	}
+
+	return models.NewTaxQuote(params.Country, params.Date, s.pricesIncludeTax, lines), nil
+}
+
+func (s *Service) quoteLine(ctx context.Context, country string, date time.Time, l models.TaxLineParams) (models.TaxLine, error) {
+	book, err := s.books.GetById(ctx, l.BookID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.TaxLine{}, err
+		}
+		s.logger.Error("db error", "quote book err", err)
+		return models.TaxLine{}, usecase.ErrDbInfrastructure
+	}
+
+	rates, err := s.rates.GetRates(ctx, country, l.Format)
+	if err != nil {
+		s.logger.Error("db error", "get tax rates err", err)
+		return models.TaxLine{}, usecase.ErrDbInfrastructure
+	}
+	rate, ok := models.EffectiveRate(rates, date)
+	if !ok {
+		return models.TaxLine{}, fmt.Errorf("%w: %s/%s on %s", models.ErrTaxRateNotFound, country, l.Format, date.Format(time.DateOnly))
+	}
+
+	net, tax, gross := models.CalculateTax(book.Price, l.Quantity, rate.RateBP, s.pricesIncludeTax)
+
+	return models.TaxLine{
+		BookID:    book.ID,
+		Format:    l.Format,
+		Quantity:  l.Quantity,
+		UnitPrice: book.Price,
+		RateBP:    rate.RateBP,
+		Net:       net,
+		Tax:       tax,
+		Gross:     gross,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package tax
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func TestService_Quote(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	book := models.Book{ID: uuid.New(), Title: "Book", Price: 1200}
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	books := &BookRepositoryMock{
+		GetByIdFunc: func(ctx context.Context, id string) (models.Book, error) {
+			if id != book.ID.String() {
+				return models.Book{}, repository.ErrNotFound
+			}
+			return book, nil
+		},
+	}
+	rates := &RateRepositoryMock{
+		GetRatesFunc: func(ctx context.Context, country string, format models.ProductFormat) ([]models.TaxRate, error) {
+			if country != "DE" {
+				return nil, nil
+			}
+			rate := 700
+			if format == models.FormatPrint {
+				rate = 1900
+			}
+			return []models.TaxRate{{Country: country, Format: format, RateBP: rate, EffectiveFrom: date.AddDate(-1, 0, 0)}}, nil
+		},
+	}
+
+	params := models.TaxQuoteParams{
+		Country: "DE",
+		Date:    date,
+		Lines: []models.TaxLineParams{
+			{BookID: book.ID, Format: models.FormatPrint, Quantity: 2},
+			{BookID: book.ID, Format: models.FormatEbook, Quantity: 1},
+		},
+	}
+
+	t.Run("tax exclusive prices", func(t *testing.T) {
+		svc := NewService(logger, rates, books, false)
+		quote, err := svc.Quote(ctx, params)
+		assert.NoError(t, err)
+		assert.Len(t, quote.Lines, 2)
+		assert.Equal(t, 2400, quote.Lines[0].Net)
+		assert.Equal(t, 456, quote.Lines[0].Tax)
+		assert.Equal(t, 84, quote.Lines[1].Tax)
+		assert.Equal(t, 3600, quote.Net)
+		assert.Equal(t, 540, quote.Tax)
+		assert.Equal(t, 4140, quote.Gross)
+	})
+
+	t.Run("tax inclusive prices", func(t *testing.T) {
+		svc := NewService(logger, rates, books, true)
+		quote, err := svc.Quote(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, 3600, quote.Gross)
+		assert.Equal(t, quote.Gross, quote.Net+quote.Tax)
+		assert.True(t, quote.PricesIncludeTax)
+	})
+
+	t.Run("no rate for country", func(t *testing.T) {
+		svc := NewService(logger, rates, books, true)
+		p := params
+		p.Country = "FR"
+		_, err := svc.Quote(ctx, p)
+		assert.ErrorIs(t, err, models.ErrTaxRateNotFound)
+	})
+
+	t.Run("unknown book", func(t *testing.T) {
+		svc := NewService(logger, rates, books, true)
+		p := params
+		p.Lines = []models.TaxLineParams{{BookID: uuid.New(), Format: models.FormatPrint, Quantity: 1}}
+		_, err := svc.Quote(ctx, p)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("rates repository error", func(t *testing.T) {
+		failing := &RateRepositoryMock{
+			GetRatesFunc: func(ctx context.Context, country string, format models.ProductFormat) ([]models.TaxRate, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		svc := NewService(logger, failing, books, true)
+		_, err := svc.Quote(ctx, params)
+		assert.Equal(t, usecase.ErrDbInfrastructure, err)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		svc := NewService(logger, rates, books, true)
+		_, err := svc.Quote(ctx, models.TaxQuoteParams{Country: "DE"})
+		assert.ErrorIs(t, err, models.ErrDomainValidation)
+	})
+}
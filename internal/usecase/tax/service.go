@@ -0,0 +1,23 @@
+package tax
+
+import (
+	"log/slog"
+
+	"book-store-api/internal/usecase/tax/interfaces"
+)
+
+type Service struct {
+	logger           *slog.Logger
+	rates            interfaces.RateRepository
+	books            interfaces.BookRepository
+	pricesIncludeTax bool
+}
+
+func NewService(logger *slog.Logger, rates interfaces.RateRepository, books interfaces.BookRepository, pricesIncludeTax bool) *Service {
+	return &Service{
+		logger:           logger,
+		rates:            rates,
+		books:            books,
+		pricesIncludeTax: pricesIncludeTax,
+	}
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]models.WebhookDelivery, error) {
+	if _, err := s.Get(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, subscriptionID, models.NormalizeDeliveryLimit(limit))
+	if err != nil {
+		s.logger.Error("db error", "list webhook deliveries err", err)
+		return nil, usecase.ErrDbInfrastructure
+	}
+	return deliveries, nil
+}
+
+// Redeliver ставит в очередь новую доставку того же события; исходная запись журнала не меняется.
+func (s *Service) Redeliver(ctx context.Context, deliveryID uuid.UUID) (models.WebhookDelivery, error) {
+	orig, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.WebhookDelivery{}, err
+		}
+		s.logger.Error("db error", "get webhook delivery err", err)
+		return models.WebhookDelivery{}, usecase.ErrDbInfrastructure
+	}
+
+	d := models.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: orig.SubscriptionID,
+		EventID:        orig.EventID,
+		EventType:      orig.EventType,
+		Payload:        orig.Payload,
+		Status:         models.DeliveryPending,
+		RedeliveryOf:   &orig.ID,
+	}
+	if err := s.repo.EnqueueDeliveries(ctx, []models.WebhookDelivery{d}); err != nil {
+		s.logger.Error("db error", "enqueue webhook redelivery err", err)
+		return models.WebhookDelivery{}, usecase.ErrDbInfrastructure
+	}
+	return d, nil
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+)
+
+func TestService_Redeliver(t *testing.T) {
+	orig := models.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: uuid.New(),
+		EventID:        uuid.New(),
+		EventType:      models.EventBookUpdated,
+		Payload:        json.RawMessage(`{"id":"1"}`),
+		Status:         models.DeliveryFailed,
+		Attempts:       10,
+	}
+	var enqueued []models.WebhookDelivery
+	repo := &RepositoryMock{
+		GetDeliveryFunc: func(ctx context.Context, id uuid.UUID) (models.WebhookDelivery, error) {
+			if id != orig.ID {
+				return models.WebhookDelivery{}, repository.ErrNotFound
+			}
+			return orig, nil
+		},
+		EnqueueDeliveriesFunc: func(ctx context.Context, d []models.WebhookDelivery) error {
+			enqueued = d
+			return nil
+		},
+	}
+	svc := newTestService(repo)
+
+	d, err := svc.Redeliver(context.Background(), orig.ID)
+	require.NoError(t, err)
+	require.Len(t, enqueued, 1)
+	assert.Equal(t, d, enqueued[0])
+	assert.NotEqual(t, orig.ID, d.ID)
+	require.NotNil(t, d.RedeliveryOf)
+	assert.Equal(t, orig.ID, *d.RedeliveryOf)
+	assert.Equal(t, orig.EventID, d.EventID)
+	assert.Equal(t, orig.Payload, d.Payload)
+	assert.Zero(t, d.Attempts)
+
+	_, err = svc.Redeliver(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestService_ListDeliveries(t *testing.T) {
+	subID := uuid.New()
+	var gotLimit int
+	repo := &RepositoryMock{
+		GetSubscriptionFunc: func(ctx context.Context, id uuid.UUID) (models.WebhookSubscription, error) {
+			if id != subID {
+				return models.WebhookSubscription{}, repository.ErrNotFound
+			}
+			return models.WebhookSubscription{ID: subID}, nil
+		},
+		ListDeliveriesFunc: func(ctx context.Context, id uuid.UUID, limit int) ([]models.WebhookDelivery, error) {
+			gotLimit = limit
+			return []models.WebhookDelivery{}, nil
+		},
+	}
+	svc := newTestService(repo)
+
+	_, err := svc.ListDeliveries(context.Background(), subID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 50, gotLimit)
+
+	_, err = svc.ListDeliveries(context.Background(), subID, 10_000)
+	require.NoError(t, err)
+	assert.Equal(t, 200, gotLimit)
+
+	_, err = svc.ListDeliveries(context.Background(), uuid.New(), 10)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
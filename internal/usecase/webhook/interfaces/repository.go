@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	CreateSubscription(ctx context.Context, s models.WebhookSubscription) error
+	UpdateSubscription(ctx context.Context, s models.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	EnqueueDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]models.WebhookDelivery, error)
+}
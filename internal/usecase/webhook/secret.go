@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+const secretPrefix = "whsec_"
+
+// generateSecret выдаёт секрет подписи, если партнёр не прислал свой.
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"log/slog"
+	"time"
+
+	"book-store-api/internal/usecase/webhook/interfaces"
+)
+
+type Service struct {
+	logger *slog.Logger
+	repo   interfaces.Repository
+	now    func() time.Time
+}
+
+func NewService(logger *slog.Logger, repo interfaces.Repository) *Service {
+	return &Service{
+		logger: logger,
+		repo:   repo,
+		now:    time.Now,
+	}
+}
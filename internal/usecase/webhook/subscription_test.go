@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+	"book-store-api/internal/repository"
+	"book-store-api/internal/usecase"
+)
+
+func newTestService(repo *RepositoryMock) *Service {
+	return NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), repo)
+}
+
+func TestService_Create(t *testing.T) {
+	var stored models.WebhookSubscription
+	repo := &RepositoryMock{
+		CreateSubscriptionFunc: func(ctx context.Context, s models.WebhookSubscription) error {
+			stored = s
+			return nil
+		},
+	}
+	svc := newTestService(repo)
+
+	sub, err := svc.Create(context.Background(), models.WebhookSubscriptionParams{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{models.EventPriceChanged},
+		Active:     true,
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sub.Secret, secretPrefix), "secret is generated")
+	assert.Equal(t, sub, stored)
+	assert.NotEqual(t, uuid.Nil, sub.ID)
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := svc.Create(context.Background(), models.WebhookSubscriptionParams{URL: "ftp://x", EventTypes: []string{models.WebhookAllEvents}})
+		assert.ErrorIs(t, err, models.ErrDomainValidation)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		repo.CreateSubscriptionFunc = func(ctx context.Context, s models.WebhookSubscription) error { return errors.New("boom") }
+		_, err := svc.Create(context.Background(), models.WebhookSubscriptionParams{
+			URL: "https://partner.example.com/hooks", EventTypes: []string{models.WebhookAllEvents},
+		})
+		assert.ErrorIs(t, err, usecase.ErrDbInfrastructure)
+	})
+}
+
+func TestService_UpdateKeepsSecret(t *testing.T) {
+	current := models.WebhookSubscription{ID: uuid.New(), Secret: "existing-secret-0123456789"}
+	var updated models.WebhookSubscription
+	repo := &RepositoryMock{
+		GetSubscriptionFunc: func(ctx context.Context, id uuid.UUID) (models.WebhookSubscription, error) {
+			if id != current.ID {
+				return models.WebhookSubscription{}, repository.ErrNotFound
+			}
+			return current, nil
+		},
+		UpdateSubscriptionFunc: func(ctx context.Context, s models.WebhookSubscription) error {
+			updated = s
+			return nil
+		},
+	}
+	svc := newTestService(repo)
+
+	err := svc.Update(context.Background(), models.WebhookSubscriptionParams{
+		ID: current.ID, URL: "https://partner.example.com/v2", EventTypes: []string{models.EventBookDeleted}, Active: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, current.Secret, updated.Secret)
+	assert.Equal(t, "https://partner.example.com/v2", updated.URL)
+
+	err = svc.Update(context.Background(), models.WebhookSubscriptionParams{
+		ID: uuid.New(), URL: "https://partner.example.com/v2", EventTypes: []string{models.EventBookDeleted},
+	})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
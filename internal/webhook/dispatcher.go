@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"context"
+
+	"book-store-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type SubscriptionSource interface {
+	ListActiveSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	EnqueueDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+}
+
+// Dispatcher — outbox.Publisher, который раскладывает событие по подходящим подпискам.
+// Сама отправка идёт из Worker, чтобы медленный получатель не тормозил outbox.
+type Dispatcher struct {
+	source SubscriptionSource
+}
+
+func NewDispatcher(source SubscriptionSource) *Dispatcher {
+	return &Dispatcher{source: source}
+}
+
+func (d *Dispatcher) Publish(ctx context.Context, e models.DomainEvent) error {
+	subs, err := d.source.ListActiveSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []models.WebhookDelivery
+	for _, s := range subs {
+		if !s.Matches(e.Type) {
+			continue
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: s.ID,
+			EventID:        e.ID,
+			EventType:      e.Type,
+			Payload:        e.Envelope(),
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return d.source.EnqueueDeliveries(ctx, deliveries)
+}
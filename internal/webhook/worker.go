@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"book-store-api/internal/models"
+)
+
+type Store interface {
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookJob, error)
+	RecordAttempt(ctx context.Context, a models.WebhookAttempt, nextAttempt *time.Time, disableAfter int) (bool, error)
+}
+
+type WorkerConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Concurrency  int
+	Timeout      time.Duration
+	Retry        models.RetryPolicy
+	// DisableAfter — сколько неудачных попыток подряд отключают подписку
+	DisableAfter int
+}
+
+type Worker struct {
+	store  Store
+	client *http.Client
+	cfg    WorkerConfig
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+func NewWorker(store Store, client *http.Client, cfg WorkerConfig, logger *slog.Logger) *Worker {
+	if client == nil {
+		client = &http.Client{Timeout: cfg.Timeout}
+	}
+	return &Worker{store: store, client: client, cfg: cfg, logger: logger, now: time.Now}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		// lease с запасом покрывает таймаут запроса
+		jobs, err := w.store.ClaimDue(ctx, w.cfg.BatchSize, 2*w.cfg.Timeout)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Error("webhook claim error", "err", err)
+			}
+			return
+		}
+
+		sem := make(chan struct{}, max(w.cfg.Concurrency, 1))
+		var wg sync.WaitGroup
+		for _, job := range jobs {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				w.deliver(ctx, job)
+			}()
+		}
+		wg.Wait()
+
+		if len(jobs) < w.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+func (w *Worker) deliver(ctx context.Context, job models.WebhookJob) {
+	attempt := w.send(ctx, job)
+
+	var next *time.Time
+	attempts := job.Delivery.Attempts + 1
+	if !attempt.Succeeded() && !w.cfg.Retry.Exhausted(attempts) {
+		at := attempt.At.Add(w.cfg.Retry.Backoff(attempts))
+		next = &at
+	}
+
+	// результат пишем даже после отмены ctx, иначе успешная доставка уйдёт повторно
+	disabled, err := w.store.RecordAttempt(context.WithoutCancel(ctx), attempt, next, w.cfg.DisableAfter)
+	if err != nil {
+		w.logger.Error("webhook record error", "delivery_id", job.Delivery.ID, "err", err)
+		return
+	}
+
+	if !attempt.Succeeded() {
+		w.logger.Warn("webhook delivery failed",
+			"delivery_id", job.Delivery.ID, "url", job.URL, "attempt", attempts,
+			"status", attempt.StatusCode, "err", attempt.Err, "gave_up", next == nil)
+	}
+	if disabled {
+		w.logger.Warn("webhook subscription disabled after repeated failures", "subscription_id", job.Delivery.SubscriptionID)
+	}
+}
+
+func (w *Worker) send(ctx context.Context, job models.WebhookJob) models.WebhookAttempt {
+	now := w.now()
+	attempt := models.WebhookAttempt{DeliveryID: job.Delivery.ID, SubscriptionID: job.Delivery.SubscriptionID, At: now}
+
+	reqCtx, cancel := context.WithTimeout(ctx, w.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, job.URL, bytes.NewReader(job.Delivery.Payload))
+	if err != nil {
+		attempt.Err = err.Error()
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "book-store-api-webhooks/1.0")
+	req.Header.Set(HeaderEvent, job.Delivery.EventType)
+	req.Header.Set(HeaderEventID, job.Delivery.EventID.String())
+	req.Header.Set(HeaderDelivery, job.Delivery.ID.String())
+	req.Header.Set(HeaderSignature, Sign(job.Secret, job.Delivery.Payload, now))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		attempt.Err = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	attempt.StatusCode = resp.StatusCode
+	if !attempt.Succeeded() {
+		attempt.Err = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	return attempt
+}
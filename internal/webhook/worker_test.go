@@ -0,0 +1,207 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"book-store-api/internal/models"
+)
+
+const testSecret = "partner-secret-0123456789"
+
+// receiver — локальный получатель вебхуков, отвечающий статусами из очереди.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+
+	status := http.StatusNoContent
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+type recorded struct {
+	attempt  models.WebhookAttempt
+	next     *time.Time
+	disabled bool
+}
+
+func newTestWorker(t *testing.T, jobs []models.WebhookJob, failures *int) (*Worker, *[]recorded) {
+	t.Helper()
+	var results []recorded
+	var mu sync.Mutex
+	store := &StoreMock{
+		ClaimDueFunc: func(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookJob, error) {
+			claimed := jobs
+			jobs = nil
+			return claimed, nil
+		},
+		RecordAttemptFunc: func(ctx context.Context, a models.WebhookAttempt, next *time.Time, disableAfter int) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			disabled := false
+			if a.Succeeded() {
+				*failures = 0
+			} else {
+				*failures++
+				disabled = *failures == disableAfter
+			}
+			results = append(results, recorded{attempt: a, next: next, disabled: disabled})
+			return disabled, nil
+		},
+	}
+	cfg := WorkerConfig{
+		PollInterval: time.Hour,
+		BatchSize:    10,
+		Concurrency:  2,
+		Timeout:      time.Second,
+		Retry:        models.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour},
+		DisableAfter: 2,
+	}
+	w := NewWorker(store, nil, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	w.now = func() time.Time { return time.Date(2025, 10, 25, 12, 0, 0, 0, time.UTC) }
+	return w, &results
+}
+
+func job(url string, attempts int) models.WebhookJob {
+	e := models.DomainEvent{ID: uuid.New(), Type: models.EventPriceChanged, Payload: []byte(`{"old_price":100,"new_price":120}`)}
+	return models.WebhookJob{
+		URL:    url,
+		Secret: testSecret,
+		Delivery: models.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: uuid.New(),
+			EventID:        e.ID,
+			EventType:      e.Type,
+			Payload:        e.Envelope(),
+			Attempts:       attempts,
+		},
+	}
+}
+
+func TestWorker_SignedDelivery(t *testing.T) {
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	j := job(srv.URL, 0)
+	failures := 0
+	w, results := newTestWorker(t, []models.WebhookJob{j}, &failures)
+	w.now = time.Now
+
+	w.drain(context.Background())
+
+	require.Len(t, rc.requests, 1)
+	req := rc.requests[0]
+	assert.Equal(t, models.EventPriceChanged, req.Header.Get(HeaderEvent))
+	assert.Equal(t, j.Delivery.ID.String(), req.Header.Get(HeaderDelivery))
+	assert.NoError(t, Verify(testSecret, req.Header.Get(HeaderSignature), rc.bodies[0], time.Now(), 5*time.Minute))
+	assert.ErrorIs(t, Verify("other-secret-0123456789", req.Header.Get(HeaderSignature), rc.bodies[0], time.Now(), 5*time.Minute), ErrInvalidSignature)
+	assert.JSONEq(t, string(j.Delivery.Payload), string(rc.bodies[0]))
+
+	require.Len(t, *results, 1)
+	assert.True(t, (*results)[0].attempt.Succeeded())
+	assert.Equal(t, http.StatusNoContent, (*results)[0].attempt.StatusCode)
+}
+
+func TestWorker_RetriesWithBackoff(t *testing.T) {
+	rc := &receiver{statuses: []int{http.StatusServiceUnavailable}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	failures := 0
+	w, results := newTestWorker(t, []models.WebhookJob{job(srv.URL, 1)}, &failures)
+	w.drain(context.Background())
+
+	require.Len(t, *results, 1)
+	r := (*results)[0]
+	assert.False(t, r.attempt.Succeeded())
+	assert.Equal(t, http.StatusServiceUnavailable, r.attempt.StatusCode)
+	require.NotNil(t, r.next)
+	assert.Equal(t, 2*time.Minute, r.next.Sub(r.attempt.At), "second attempt waits 2x base delay")
+}
+
+func TestWorker_GivesUpAndDisablesSubscription(t *testing.T) {
+	rc := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	failures := 0
+	w, results := newTestWorker(t, []models.WebhookJob{job(srv.URL, 2)}, &failures)
+	w.cfg.Concurrency = 1
+	w.drain(context.Background())
+	require.Len(t, *results, 1)
+	assert.Nil(t, (*results)[0].next, "attempts exhausted")
+	assert.False(t, (*results)[0].disabled)
+
+	w.store.(*StoreMock).ClaimDueFunc = func(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookJob, error) {
+		if len(*results) > 1 {
+			return nil, nil
+		}
+		return []models.WebhookJob{job(srv.URL, 0)}, nil
+	}
+	w.drain(context.Background())
+	require.Len(t, *results, 2)
+	assert.True(t, (*results)[1].disabled)
+}
+
+func TestWorker_UnreachableReceiver(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	failures := 0
+	w, results := newTestWorker(t, []models.WebhookJob{job(url, 0)}, &failures)
+	w.drain(context.Background())
+
+	require.Len(t, *results, 1)
+	assert.Zero(t, (*results)[0].attempt.StatusCode)
+	assert.NotEmpty(t, (*results)[0].attempt.Err)
+	assert.NotNil(t, (*results)[0].next)
+}
+
+func TestDispatcher_FansOutToMatchingSubscriptions(t *testing.T) {
+	priceSub := models.WebhookSubscription{ID: uuid.New(), EventTypes: []string{models.EventPriceChanged}, Active: true}
+	allSub := models.WebhookSubscription{ID: uuid.New(), EventTypes: []string{models.WebhookAllEvents}, Active: true}
+	var enqueued []models.WebhookDelivery
+
+	source := &SubscriptionSourceMock{
+		ListActiveSubscriptionsFunc: func(ctx context.Context) ([]models.WebhookSubscription, error) {
+			return []models.WebhookSubscription{priceSub, allSub}, nil
+		},
+		EnqueueDeliveriesFunc: func(ctx context.Context, d []models.WebhookDelivery) error {
+			enqueued = append(enqueued, d...)
+			return nil
+		},
+	}
+	d := NewDispatcher(source)
+
+	require.NoError(t, d.Publish(context.Background(), models.DomainEvent{ID: uuid.New(), Type: models.EventBookCreated, Payload: []byte(`{}`)}))
+	require.Len(t, enqueued, 1)
+	assert.Equal(t, allSub.ID, enqueued[0].SubscriptionID)
+
+	enqueued = nil
+	require.NoError(t, d.Publish(context.Background(), models.DomainEvent{ID: uuid.New(), Type: models.EventPriceChanged, Payload: []byte(`{}`)}))
+	assert.Len(t, enqueued, 2)
+}
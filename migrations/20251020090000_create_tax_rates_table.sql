@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tax_rates (
+                       id SERIAL PRIMARY KEY,
+                       country CHAR(2) NOT NULL,
+                       format TEXT NOT NULL,
+                       rate_bp INT NOT NULL CHECK (rate_bp >= 0),
+                       effective_from DATE NOT NULL,
+                       created_at TIMESTAMPTZ DEFAULT NOW(),
+                       UNIQUE (country, format, effective_from)
+);
+
+INSERT INTO tax_rates (country, format, rate_bp, effective_from) VALUES
+    ('RU', 'print', 1000, '2004-01-01'),
+    ('RU', 'ebook', 2000, '2019-01-01'),
+    ('RU', 'audiobook', 2000, '2019-01-01'),
+    ('DE', 'print', 700, '2007-01-01'),
+    ('DE', 'ebook', 700, '2019-12-18'),
+    ('DE', 'audiobook', 700, '2019-12-18');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE tax_rates;
+-- +goose StatementEnd
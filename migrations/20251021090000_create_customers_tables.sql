@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE customers (
+                       id SERIAL PRIMARY KEY,
+                       uuid UUID NOT NULL UNIQUE,
+                       email TEXT NOT NULL UNIQUE,
+                       password_hash TEXT NOT NULL,
+                       full_name TEXT NOT NULL,
+                       phone TEXT NOT NULL DEFAULT '',
+                       email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+                       created_at TIMESTAMPTZ DEFAULT NOW(),
+                       updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE customer_addresses (
+                       id SERIAL PRIMARY KEY,
+                       uuid UUID NOT NULL UNIQUE,
+                       customer_uuid UUID NOT NULL REFERENCES customers (uuid) ON DELETE CASCADE,
+                       label TEXT NOT NULL DEFAULT '',
+                       recipient TEXT NOT NULL,
+                       line1 TEXT NOT NULL,
+                       line2 TEXT NOT NULL DEFAULT '',
+                       city TEXT NOT NULL,
+                       postal_code TEXT NOT NULL DEFAULT '',
+                       country CHAR(2) NOT NULL,
+                       is_default BOOLEAN NOT NULL DEFAULT FALSE,
+                       created_at TIMESTAMPTZ DEFAULT NOW(),
+                       updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX customer_addresses_customer_idx ON customer_addresses (customer_uuid);
+
+CREATE TABLE customer_tokens (
+                       token_hash TEXT PRIMARY KEY,
+                       customer_uuid UUID NOT NULL REFERENCES customers (uuid) ON DELETE CASCADE,
+                       purpose TEXT NOT NULL,
+                       expires_at TIMESTAMPTZ NOT NULL,
+                       used_at TIMESTAMPTZ,
+                       created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE customer_tokens;
+DROP TABLE customer_addresses;
+DROP TABLE customers;
+-- +goose StatementEnd
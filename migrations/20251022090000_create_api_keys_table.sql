@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE api_keys (
+                       id SERIAL PRIMARY KEY,
+                       uuid UUID NOT NULL UNIQUE,
+                       name TEXT NOT NULL,
+                       prefix TEXT NOT NULL UNIQUE,
+                       key_hash TEXT NOT NULL,
+                       scopes TEXT[] NOT NULL,
+                       expires_at TIMESTAMPTZ,
+                       last_used_at TIMESTAMPTZ,
+                       revoked_at TIMESTAMPTZ,
+                       created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE api_keys;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- before/after хранятся как JSON, а не JSONB: хэш считается по исходному тексту снимка
+CREATE TABLE audit_events (
+                       id BIGSERIAL PRIMARY KEY,
+                       actor TEXT NOT NULL,
+                       actor_type TEXT NOT NULL,
+                       request_id TEXT NOT NULL,
+                       ip TEXT NOT NULL,
+                       entity TEXT NOT NULL,
+                       entity_id TEXT NOT NULL,
+                       action TEXT NOT NULL,
+                       before_state JSON,
+                       after_state JSON,
+                       occurred_at TIMESTAMPTZ NOT NULL,
+                       prev_hash TEXT NOT NULL,
+                       hash TEXT NOT NULL UNIQUE
+);
+
+CREATE INDEX audit_events_entity_idx ON audit_events (entity, entity_id);
+CREATE INDEX audit_events_actor_idx ON audit_events (actor);
+CREATE INDEX audit_events_occurred_at_idx ON audit_events (occurred_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_events;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE outbox (
+                       id BIGSERIAL PRIMARY KEY,
+                       event_id UUID NOT NULL UNIQUE,
+                       aggregate_type TEXT NOT NULL,
+                       aggregate_id TEXT NOT NULL,
+                       event_type TEXT NOT NULL,
+                       payload JSONB NOT NULL,
+                       occurred_at TIMESTAMPTZ NOT NULL,
+                       attempts INT NOT NULL DEFAULT 0,
+                       next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                       last_error TEXT
+);
+
+CREATE INDEX outbox_aggregate_idx ON outbox (aggregate_type, aggregate_id, id);
+CREATE INDEX outbox_next_attempt_idx ON outbox (next_attempt_at);
+
+CREATE TABLE outbox_dead_letters (
+                       id BIGINT PRIMARY KEY,
+                       event_id UUID NOT NULL UNIQUE,
+                       aggregate_type TEXT NOT NULL,
+                       aggregate_id TEXT NOT NULL,
+                       event_type TEXT NOT NULL,
+                       payload JSONB NOT NULL,
+                       occurred_at TIMESTAMPTZ NOT NULL,
+                       attempts INT NOT NULL,
+                       last_error TEXT,
+                       failed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE outbox_dead_letters;
+DROP TABLE outbox;
+-- +goose StatementEnd
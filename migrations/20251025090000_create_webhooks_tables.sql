@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE webhook_subscriptions (
+                       id SERIAL PRIMARY KEY,
+                       uuid UUID NOT NULL UNIQUE,
+                       url TEXT NOT NULL,
+                       event_types TEXT[] NOT NULL,
+                       secret TEXT NOT NULL,
+                       active BOOLEAN NOT NULL DEFAULT TRUE,
+                       failures INT NOT NULL DEFAULT 0,
+                       disabled_at TIMESTAMPTZ,
+                       created_at TIMESTAMPTZ DEFAULT NOW(),
+                       updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE webhook_deliveries (
+                       id SERIAL PRIMARY KEY,
+                       uuid UUID NOT NULL UNIQUE,
+                       subscription_id UUID NOT NULL REFERENCES webhook_subscriptions (uuid) ON DELETE CASCADE,
+                       event_id UUID NOT NULL,
+                       event_type TEXT NOT NULL,
+                       payload JSONB NOT NULL,
+                       status TEXT NOT NULL DEFAULT 'pending',
+                       attempts INT NOT NULL DEFAULT 0,
+                       next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                       last_status_code INT,
+                       last_error TEXT,
+                       last_attempt_at TIMESTAMPTZ,
+                       delivered_at TIMESTAMPTZ,
+                       redelivery_of UUID,
+                       created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- повторная публикация из outbox не должна создавать вторую доставку
+CREATE UNIQUE INDEX webhook_deliveries_event_idx ON webhook_deliveries (subscription_id, event_id) WHERE redelivery_of IS NULL;
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_deliveries;
+DROP TABLE webhook_subscriptions;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX books_created_at_uuid_idx ON books (created_at, uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX books_created_at_uuid_idx;
+-- +goose StatementEnd
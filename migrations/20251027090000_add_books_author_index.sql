@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX books_author_created_at_idx ON books (author, created_at, uuid);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX books_author_created_at_idx;
+-- +goose StatementEnd
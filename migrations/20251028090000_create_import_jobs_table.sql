@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE import_jobs (
+                       id SERIAL PRIMARY KEY,
+                       uuid UUID NOT NULL UNIQUE,
+                       status TEXT NOT NULL DEFAULT 'pending',
+                       format TEXT NOT NULL,
+                       file_name TEXT NOT NULL DEFAULT '',
+                       options JSONB NOT NULL,
+                       file BYTEA,
+                       created_by_type TEXT NOT NULL DEFAULT '',
+                       created_by TEXT NOT NULL DEFAULT '',
+                       total_rows INT NOT NULL DEFAULT 0,
+                       processed_rows INT NOT NULL DEFAULT 0,
+                       created INT NOT NULL DEFAULT 0,
+                       updated INT NOT NULL DEFAULT 0,
+                       failed INT NOT NULL DEFAULT 0,
+                       row_errors JSONB NOT NULL DEFAULT '[]',
+                       error TEXT,
+                       lease_until TIMESTAMPTZ,
+                       created_at TIMESTAMPTZ DEFAULT NOW(),
+                       started_at TIMESTAMPTZ,
+                       finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX import_jobs_queue_idx ON import_jobs (created_at) WHERE status IN ('pending', 'running');
+
+-- upsert по ISBN при импорте
+CREATE INDEX books_isbn_idx ON books (isbn);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX books_isbn_idx;
+DROP TABLE import_jobs;
+-- +goose StatementEnd
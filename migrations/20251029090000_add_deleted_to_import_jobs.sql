@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- удаления из ONIX (NotificationType 05)
+ALTER TABLE import_jobs ADD COLUMN deleted INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE import_jobs DROP COLUMN deleted;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- обложка: версия задаёт путь миниатюр в хранилище, пустая — обложки нет
+ALTER TABLE books
+    ADD COLUMN cover_version TEXT NOT NULL DEFAULT '',
+    ADD COLUMN cover_width INT NOT NULL DEFAULT 0,
+    ADD COLUMN cover_height INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE books DROP COLUMN cover_version, DROP COLUMN cover_width, DROP COLUMN cover_height;
+-- +goose StatementEnd